@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
@@ -24,6 +26,7 @@ var (
 	errNoBalanceSource       = errors.New("at least one balance source must be set")
 	errBalanceParsingAborted = errors.New("balance parsing aborted")
 	errInvalidAddress        = errors.New("invalid address encountered")
+	errInvalidVestingPeriod  = errors.New("invalid vesting period")
 )
 
 type balancesAddCfg struct {
@@ -32,6 +35,12 @@ type balancesAddCfg struct {
 	balanceSheet  string
 	singleEntries commands.StringArr
 	parseExport   string
+
+	vestingType     string
+	vestingStart    int64
+	vestingEnd      int64
+	vestingPeriods  commands.StringArr
+	vestingByHeight bool
 }
 
 // newBalancesAddCmd creates the genesis balances add subcommand
@@ -73,6 +82,41 @@ func (c *balancesAddCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"the path to the transaction export containing a list of transactions (JSONL)",
 	)
+
+	fs.StringVar(
+		&c.vestingType,
+		"vesting-type",
+		"",
+		"lock the added balances with a vesting schedule: continuous, delayed or periodic",
+	)
+
+	fs.Int64Var(
+		&c.vestingStart,
+		"vesting-start",
+		0,
+		"the vesting start, as a unix timestamp (or a block height with -vesting-by-height)",
+	)
+
+	fs.Int64Var(
+		&c.vestingEnd,
+		"vesting-end",
+		0,
+		"the vesting end of continuous and delayed schedules, as a unix timestamp (or a block height with -vesting-by-height)",
+	)
+
+	fs.Var(
+		&c.vestingPeriods,
+		"vesting-period",
+		"a period of a periodic vesting schedule, in the format <length>=<amount>"+ugnot.Denom+
+			", the length being in seconds (or blocks with -vesting-by-height); the periods amounts must sum up to each balance",
+	)
+
+	fs.BoolVar(
+		&c.vestingByHeight,
+		"vesting-by-height",
+		false,
+		"the vesting schedule is expressed in block heights rather than in time",
+	)
 }
 
 func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) error {
@@ -135,6 +179,15 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		finalBalances.LeftMerge(balances)
 	}
 
+	// Lock the added balances if a vesting schedule is set
+	var vesting []gnoland.VestingSchedule
+	if cfg.vestingType != "" {
+		var err error
+		if vesting, err = cfg.vestingSchedules(finalBalances); err != nil {
+			return err
+		}
+	}
+
 	// Initialize genesis app state if it is not initialized already
 	if genesis.AppState == nil {
 		genesis.AppState = gnoland.GnoGenesisState{}
@@ -142,6 +195,7 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 
 	// Construct the initial genesis balance sheet
 	state := genesis.AppState.(gnoland.GnoGenesisState)
+	state.Vesting = mergeVestingSchedules(vesting, state.Vesting)
 	genesisBalances, err := mapGenesisBalancesFromState(state)
 	if err != nil {
 		return err
@@ -171,9 +225,79 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		len(finalBalances),
 	)
 
+	if len(vesting) != 0 {
+		io.Printfln(
+			"%d balances locked with a %s vesting schedule",
+			len(vesting),
+			cfg.vestingType,
+		)
+	}
+
 	return nil
 }
 
+// vestingSchedules returns the vesting schedules locking the given balances,
+// as configured by the vesting flags
+func (c *balancesAddCfg) vestingSchedules(balances gnoland.Balances) ([]gnoland.VestingSchedule, error) {
+	periods := make([]gnoland.VestingPeriod, 0, len(c.vestingPeriods))
+	for _, entry := range c.vestingPeriods {
+		parts := strings.Split(strings.TrimSpace(entry), "=") // <length>=<amount>
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: malformed entry %q", errInvalidVestingPeriod, entry)
+		}
+
+		length, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid length %q, %w", errInvalidVestingPeriod, parts[0], err)
+		}
+
+		amount, err := std.ParseCoins(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid amount %q, %w", errInvalidVestingPeriod, parts[1], err)
+		}
+
+		periods = append(periods, gnoland.VestingPeriod{Length: length, Amount: amount})
+	}
+
+	schedules := make([]gnoland.VestingSchedule, 0, len(balances))
+	for _, balance := range balances {
+		schedule := gnoland.VestingSchedule{
+			Address:  balance.Address,
+			Type:     c.vestingType,
+			Amount:   balance.Amount,
+			ByHeight: c.vestingByHeight,
+			Start:    c.vestingStart,
+			End:      c.vestingEnd,
+			Periods:  periods,
+		}
+
+		if err := schedule.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid vesting schedule for %s, %w", balance.Address, err)
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// mergeVestingSchedules left-merges the two vesting schedule lists,
+// by account address
+func mergeVestingSchedules(to, from []gnoland.VestingSchedule) []gnoland.VestingSchedule {
+	present := make(map[types.Address]struct{}, len(to))
+	for _, schedule := range to {
+		present[schedule.Address] = struct{}{}
+	}
+
+	for _, schedule := range from {
+		if _, ok := present[schedule.Address]; !ok {
+			to = append(to, schedule)
+		}
+	}
+
+	return to
+}
+
 // getBalancesFromTransactions constructs a balance map based on MsgSend messages.
 // This way of determining the final balance sheet is not valid, since it doesn't take into
 // account different message types (ex. MsgCall) that can initialize accounts with some balance values.
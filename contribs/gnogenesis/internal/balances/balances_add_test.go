@@ -142,6 +142,92 @@ func TestGenesis_Balances_Add(t *testing.T) {
 		}
 	})
 
+	t.Run("vesting balances from entries", func(t *testing.T) {
+		t.Parallel()
+
+		dummyKey := common.GetDummyKeys(t, 1)[0]
+
+		tempGenesis, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		genesis := common.GetDefaultGenesis()
+		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
+
+		// Create the command
+		cmd := NewBalancesCmd(commands.NewTestIO())
+		args := []string{
+			"add",
+			"--genesis-path",
+			tempGenesis.Name(),
+			"--single",
+			dummyKey.Address().String() + "=100ugnot",
+			"--vesting-type",
+			gnoland.VestingPeriodic,
+			"--vesting-by-height",
+			"--vesting-start",
+			"10",
+			"--vesting-period",
+			"100=40ugnot",
+			"--vesting-period",
+			"100=60ugnot",
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		require.NoError(t, cmdErr)
+
+		// Validate the genesis was updated
+		genesis, loadErr := types.GenesisDocFromFile(tempGenesis.Name())
+		require.NoError(t, loadErr)
+
+		state, ok := genesis.AppState.(gnoland.GnoGenesisState)
+		require.True(t, ok)
+
+		require.Len(t, state.Balances, 1)
+		require.Len(t, state.Vesting, 1)
+
+		schedule := state.Vesting[0]
+		assert.Equal(t, dummyKey.Address(), schedule.Address)
+		assert.Equal(t, gnoland.VestingPeriodic, schedule.Type)
+		assert.Equal(t, "100ugnot", schedule.Amount.String())
+		assert.True(t, schedule.ByHeight)
+		assert.Equal(t, int64(10), schedule.Start)
+		require.Len(t, schedule.Periods, 2)
+		assert.Equal(t, "40ugnot", schedule.Periods[0].Amount.String())
+	})
+
+	t.Run("invalid vesting schedule", func(t *testing.T) {
+		t.Parallel()
+
+		dummyKey := common.GetDummyKeys(t, 1)[0]
+
+		tempGenesis, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		genesis := common.GetDefaultGenesis()
+		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
+
+		// Create the command
+		cmd := NewBalancesCmd(commands.NewTestIO())
+		args := []string{
+			"add",
+			"--genesis-path",
+			tempGenesis.Name(),
+			"--single",
+			dummyKey.Address().String() + "=100ugnot",
+			"--vesting-type",
+			gnoland.VestingContinuous,
+			"--vesting-start",
+			"20",
+			"--vesting-end",
+			"10",
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, gnoland.ErrVestingInvalidEnd)
+	})
+
 	t.Run("balances from sheet", func(t *testing.T) {
 		t.Parallel()
 
@@ -80,11 +80,19 @@ func execBalancesRemove(cfg *balancesRemoveCfg, io commands.IO) error {
 		return errBalanceNotFound
 	}
 
-	// Drop the account pre-mine
+	// Drop the account pre-mine, and its vesting schedule
 	delete(genesisBalances, address)
 
+	vesting := state.Vesting[:0]
+	for _, schedule := range state.Vesting {
+		if schedule.Address != address {
+			vesting = append(vesting, schedule)
+		}
+	}
+
 	// Save the balances
 	state.Balances = genesisBalances.List()
+	state.Vesting = vesting
 	genesis.AppState = state
 
 	// Save the updated genesis
@@ -8,6 +8,7 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/mock"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
@@ -190,6 +191,38 @@ func TestGenesis_Verify(t *testing.T) {
 		require.Error(t, cmdErr)
 	})
 
+	t.Run("vesting exceeds balance", func(t *testing.T) {
+		t.Parallel()
+
+		tempFile, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		g := getValidTestGenesis()
+		addr := crypto.AddressFromPreimage([]byte("vesting"))
+
+		state := gnoland.DefaultGenState()
+		state.Balances = []gnoland.Balance{
+			{Address: addr, Amount: std.NewCoins(std.NewCoin("ugnot", 10))},
+		}
+		state.Vesting = []gnoland.VestingSchedule{
+			{Address: addr, Type: gnoland.VestingDelayed, Amount: std.NewCoins(std.NewCoin("ugnot", 11)), End: 100},
+		}
+		g.AppState = state
+
+		require.NoError(t, g.SaveAs(tempFile.Name()))
+
+		// Create the command
+		cmd := NewVerifyCmd(commands.NewTestIO())
+		args := []string{
+			"--genesis-path",
+			tempFile.Name(),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		require.ErrorIs(t, cmdErr, gnoland.ErrVestingExceedsBalance)
+	})
+
 	t.Run("valid genesis", func(t *testing.T) {
 		t.Parallel()
 
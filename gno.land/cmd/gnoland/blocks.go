@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+// newBlocksCmd creates the blocks root command
+func newBlocksCmd(io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "blocks",
+			ShortUsage: "blocks <subcommand> [flags]",
+			ShortHelp:  "block archives manipulation suite",
+			LongHelp: "block archives manipulation suite, for exporting the blocks of a node to a compressed, " +
+				"checksummed archive, and executing them on another node without the p2p network",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(
+		newBlocksExportCmd(io),
+		newBlocksImportCmd(io),
+	)
+
+	return cmd
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/archive"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+type blocksExportCfg struct {
+	dataDir    string
+	outputFile string
+	from       int64
+	to         int64
+}
+
+// newBlocksExportCmd creates the blocks export command
+func newBlocksExportCmd(io commands.IO) *commands.Command {
+	cfg := &blocksExportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "export",
+			ShortUsage: "blocks export [flags]",
+			ShortHelp:  "exports a range of blocks of a stopped node to an archive",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execBlocksExport(cfg, io)
+		},
+	)
+}
+
+func (c *blocksExportCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.StringVar(
+		&c.outputFile,
+		"output",
+		"blocks.archive",
+		"the path to the exported archive",
+	)
+
+	fs.Int64Var(
+		&c.from,
+		"from",
+		0,
+		"the first exported height, 0 for the lowest stored one",
+	)
+
+	fs.Int64Var(
+		&c.to,
+		"to",
+		0,
+		"the last exported height, 0 for the latest one",
+	)
+}
+
+func execBlocksExport(c *blocksExportCfg, io commands.IO) error {
+	// Get the absolute path to the node's data directory
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	// Load the configuration
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the block store database, %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	from, to := c.from, c.to
+	if from == 0 {
+		from = blockStore.Base()
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+
+	file, err := os.Create(c.outputFile)
+	if err != nil {
+		return fmt.Errorf("unable to create the archive, %w", err)
+	}
+
+	if err := archive.Export(file, blockStore, from, to); err != nil {
+		file.Close()
+		os.Remove(c.outputFile)
+
+		return fmt.Errorf("unable to export the blocks, %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("unable to save the archive, %w", err)
+	}
+
+	io.Printfln("Exported the blocks %d to %d to %q", from, to, c.outputFile)
+
+	return nil
+}
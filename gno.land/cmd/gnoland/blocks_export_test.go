@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/archive"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prepareBlocksNode initializes the node directory with a block store of
+// the given number of blocks
+func prepareBlocksNode(t *testing.T, nodeDir string, height int64) {
+	t.Helper()
+
+	prepareNodeRPC(t, nodeDir)
+
+	db, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, filepath.Join(nodeDir, config.DefaultDBDir))
+	require.NoError(t, err)
+	defer db.Close()
+
+	var (
+		blockStore = store.NewBlockStore(db)
+		lastCommit = new(bft.Commit)
+	)
+
+	// The commits are not signed, they are not verified on export
+	for h := int64(1); h <= height; h++ {
+		block := bft.MakeBlock(h, []bft.Tx{[]byte{byte(h)}}, lastCommit)
+		block.ChainID = "blocks"
+		parts := block.MakePartSet(bft.BlockPartSizeBytes)
+
+		lastCommit = bft.NewCommit(bft.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}, nil)
+		blockStore.SaveBlock(block, parts, lastCommit)
+	}
+}
+
+func TestBlocksExport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodeDir    = t.TempDir()
+		outputPath = filepath.Join(nodeDir, "blocks.archive")
+	)
+
+	prepareBlocksNode(t, nodeDir, 3)
+
+	mockOut := new(bytes.Buffer)
+	io := commands.NewTestIO()
+	io.SetOut(commands.WriteNopCloser(mockOut))
+
+	args := []string{
+		"blocks",
+		"export",
+		"--data-dir",
+		nodeDir,
+		"--output",
+		outputPath,
+		"--to",
+		"2",
+	}
+
+	require.NoError(t, newRootCmd(io).ParseAndRun(context.Background(), args))
+	assert.Contains(t, mockOut.String(), "Exported the blocks 1 to 2")
+
+	file, err := os.Open(outputPath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	ar, err := archive.NewReader(file)
+	require.NoError(t, err)
+	defer ar.Close()
+
+	assert.Equal(t, archive.Header{
+		Format:      archive.CurrentFormat,
+		ChainID:     "blocks",
+		StartHeight: 1,
+		EndHeight:   2,
+	}, ar.Header())
+}
+
+func TestBlocksExport_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uninitialized node", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"blocks", "export", "--data-dir", t.TempDir()},
+		)
+		assert.ErrorContains(t, err, tryConfigInit)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			nodeDir    = t.TempDir()
+			outputPath = filepath.Join(nodeDir, "blocks.archive")
+		)
+
+		prepareBlocksNode(t, nodeDir, 2)
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"blocks", "export", "--data-dir", nodeDir, "--output", outputPath, "--to", "3"},
+		)
+		assert.ErrorIs(t, err, archive.ErrInvalidRange)
+
+		// The partial archive is removed
+		assert.NoFileExists(t, outputPath)
+	})
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/node"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"go.uber.org/zap/zapcore"
+)
+
+type blocksImportCfg struct {
+	dataDir                    string
+	genesisFile                string
+	skipFailingGenesisTxs      bool
+	skipGenesisSigVerification bool
+
+	logLevel  string
+	logFormat string
+}
+
+// newBlocksImportCmd creates the blocks import command
+func newBlocksImportCmd(io commands.IO) *commands.Command {
+	cfg := &blocksImportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "import",
+			ShortUsage: "blocks import [flags] <archive>",
+			ShortHelp:  "executes the blocks of an archive on a stopped node",
+			LongHelp: "Executes the blocks of an archive on a stopped node, as if they were synced from its peers. " +
+				"The archive checksum is verified before any block is executed, " +
+				"and the commit of each block is verified with the validators of the node state. " +
+				"The archive must start at or below the next height of the node, the blocks it already has are skipped",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execBlocksImport(cfg, args, io)
+		},
+	)
+}
+
+func (c *blocksImportCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.StringVar(
+		&c.genesisFile,
+		"genesis",
+		"genesis.json",
+		"the path to the genesis.json, used if the node was never started",
+	)
+
+	fs.BoolVar(
+		&c.skipFailingGenesisTxs,
+		"skip-failing-genesis-txs",
+		false,
+		"don't panic when replaying invalid genesis txs",
+	)
+
+	fs.BoolVar(
+		&c.skipGenesisSigVerification,
+		"skip-genesis-sig-verification",
+		false,
+		"don't panic when replaying invalidly signed genesis txs",
+	)
+
+	fs.StringVar(
+		&c.logLevel,
+		"log-level",
+		zapcore.InfoLevel.String(),
+		"log level for the import",
+	)
+
+	fs.StringVar(
+		&c.logFormat,
+		"log-format",
+		log.ConsoleFormat.String(),
+		"log format for the import",
+	)
+}
+
+func execBlocksImport(c *blocksImportCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	// Get the absolute path to the node's data directory
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	// Load the configuration
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to open the archive, %w", err)
+	}
+	defer file.Close()
+
+	// Initialize the logger
+	zapLogger, err := initializeLogger(io.Out(), c.logLevel, c.logFormat)
+	if err != nil {
+		return fmt.Errorf("unable to initialize zap logger, %w", err)
+	}
+
+	defer func() {
+		// Sync the logger before exiting
+		_ = zapLogger.Sync()
+	}()
+
+	logger := log.ZapLoggerToSlog(zapLogger)
+	evsw := events.NewEventSwitch()
+
+	app, err := newApp(
+		nodeDir,
+		cfg,
+		gnoland.GenesisAppConfig{
+			SkipFailingTxs:      c.skipFailingGenesisTxs,
+			SkipSigVerification: c.skipGenesisSigVerification,
+		},
+		evsw,
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create the Gnoland app, %w", err)
+	}
+
+	state, err := node.ImportArchive(
+		cfg,
+		file,
+		proxy.NewLocalClientCreator(app),
+		node.DefaultGenesisDocProviderFunc(c.genesisFile),
+		node.DefaultDBProvider,
+		evsw,
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to import the blocks, %w", err)
+	}
+
+	io.Printfln("Imported the blocks up to height %d, with app hash %X", state.LastBlockHeight, state.AppHash)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlocksImport_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing archive", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"blocks", "import", "--data-dir", t.TempDir()},
+		)
+		assert.ErrorIs(t, err, flag.ErrHelp)
+	})
+
+	t.Run("uninitialized node", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"blocks", "import", "--data-dir", t.TempDir(), "blocks.archive"},
+		)
+		assert.ErrorContains(t, err, tryConfigInit)
+	})
+
+	t.Run("archive not found", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		prepareNodeRPC(t, nodeDir)
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"blocks", "import", "--data-dir", nodeDir, filepath.Join(nodeDir, "blocks.archive")},
+		)
+		assert.ErrorContains(t, err, "unable to open the archive")
+	})
+}
@@ -193,6 +193,14 @@ func TestConfig_Get_Base(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"min retain blocks fetched",
+			"min_retain_blocks",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.MinRetainBlocks, unmarshalJSONCommon[int64](t, value))
+			},
+			false,
+		},
 		{
 			"validator key fetched",
 			"priv_validator_key_file",
@@ -809,6 +817,30 @@ func TestConfig_Get_RPC(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"max subscription clients",
+			"rpc.max_subscription_clients",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.MaxSubscriptionClients, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
+		{
+			"max subscriptions per client",
+			"rpc.max_subscriptions_per_client",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.MaxSubscriptionsPerClient, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
+		{
+			"subscription buffer size",
+			"rpc.subscription_buffer_size",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.SubscriptionBufferSize, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
 		{
 			"TLS cert file",
 			"rpc.tls_cert_file",
@@ -922,6 +954,87 @@ func TestConfig_Get_Mempool(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"type",
+			"mempool.type",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.Mempool.Type, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
+		{
+			"type, raw",
+			"mempool.type",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.Mempool.Type, escapeNewline(value))
+			},
+			true,
+		},
+		{
+			"price bump",
+			"mempool.price_bump",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.Mempool.PriceBump, unmarshalJSONCommon[int64](t, value))
+			},
+			false,
+		},
+	}
+
+	verifyGetTestTableCommon(t, testTable)
+}
+
+func TestConfig_Get_StateSync(t *testing.T) {
+	t.Parallel()
+
+	testTable := []testGetCase{
+		{
+			"enable flag",
+			"statesync.enable",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.StateSync.Enable, unmarshalJSONCommon[bool](t, value))
+			},
+			false,
+		},
+		{
+			"trust height",
+			"statesync.trust_height",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.StateSync.TrustHeight, unmarshalJSONCommon[int64](t, value))
+			},
+			false,
+		},
+		{
+			"trust hash",
+			"statesync.trust_hash",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.StateSync.TrustHash, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
+		{
+			"discovery time",
+			"statesync.discovery_time",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(
+					t,
+					loadedCfg.StateSync.DiscoveryTime,
+					unmarshalJSONCommon[time.Duration](t, value),
+				)
+			},
+			false,
+		},
+		{
+			"chunk request timeout",
+			"statesync.chunk_request_timeout",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(
+					t,
+					loadedCfg.StateSync.ChunkRequestTimeout,
+					unmarshalJSONCommon[time.Duration](t, value),
+				)
+			},
+			false,
+		},
 	}
 
 	verifyGetTestTableCommon(t, testTable)
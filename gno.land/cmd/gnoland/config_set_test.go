@@ -184,6 +184,16 @@ func TestConfig_Set_Base(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.DBPath)
 			},
 		},
+		{
+			"min retain blocks updated",
+			[]string{
+				"min_retain_blocks",
+				"1000",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.MinRetainBlocks))
+			},
+		},
 		{
 			"validator key updated",
 			[]string{
@@ -707,6 +717,36 @@ func TestConfig_Set_RPC(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxHeaderBytes))
 			},
 		},
+		{
+			"max subscription clients updated",
+			[]string{
+				"rpc.max_subscription_clients",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionClients))
+			},
+		},
+		{
+			"max subscriptions per client updated",
+			[]string{
+				"rpc.max_subscriptions_per_client",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionsPerClient))
+			},
+		},
+		{
+			"subscription buffer size updated",
+			[]string{
+				"rpc.subscription_buffer_size",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.SubscriptionBufferSize))
+			},
+		},
 		{
 			"TLS cert file updated",
 			[]string{
@@ -812,6 +852,75 @@ func TestConfig_Set_Mempool(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.CacheSize))
 			},
 		},
+		{
+			"type updated",
+			[]string{
+				"mempool.type",
+				"priority",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
+		{
+			"price bump updated",
+			[]string{
+				"mempool.price_bump",
+				"25",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.PriceBump))
+			},
+		},
+	}
+
+	verifySetTestTableCommon(t, testTable)
+}
+
+func TestConfig_Set_StateSync(t *testing.T) {
+	t.Parallel()
+
+	testTable := []testSetCase{
+		{
+			"trust height updated",
+			[]string{
+				"statesync.trust_height",
+				"100",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.StateSync.TrustHeight))
+			},
+		},
+		{
+			"trust hash updated",
+			[]string{
+				"statesync.trust_hash",
+				"AB12CD34",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.StateSync.TrustHash)
+			},
+		},
+		{
+			"discovery time updated",
+			[]string{
+				"statesync.discovery_time",
+				"30s",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.StateSync.DiscoveryTime.String())
+			},
+		},
+		{
+			"chunk request timeout updated",
+			[]string{
+				"statesync.chunk_request_timeout",
+				"5s",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.StateSync.ChunkRequestTimeout.String())
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var errMissingCreator = errors.New("missing creator address")
+
+type exportCfg struct {
+	dataDir           string
+	genesisFile       string
+	outputFile        string
+	creator           string
+	height            int64
+	includeRealmState bool
+}
+
+// newExportCmd creates the export command
+func newExportCmd(io commands.IO) *commands.Command {
+	cfg := &exportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "export",
+			ShortUsage: "export [flags]",
+			ShortHelp:  "exports the chain state to a new genesis.json",
+			LongHelp: "Exports the state of a stopped node, at a height, to a new genesis.json. " +
+				"The genesis has the balances, sequences and session keys of the accounts, the module params, " +
+				"and txs adding every package in the order they were added, which are not signed: " +
+				"the exported chain must be started with --skip-genesis-sig-verification. " +
+				"The storage deposits and scheduled calls of the realms are exported with their state; " +
+				"without it, the deposits are refunded to their depositors",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execExport(cfg, io)
+		},
+	)
+}
+
+func (c *exportCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.StringVar(
+		&c.genesisFile,
+		"genesis",
+		"genesis.json",
+		"the path to the node's genesis.json, the base of the exported one",
+	)
+
+	fs.StringVar(
+		&c.outputFile,
+		"output",
+		"genesis-export.json",
+		"the path to the exported genesis.json",
+	)
+
+	fs.StringVar(
+		&c.creator,
+		"creator",
+		"",
+		"the address of the creator of the exported packages (required)",
+	)
+
+	fs.Int64Var(
+		&c.height,
+		"height",
+		0,
+		"the height of the exported state, 0 for the latest one",
+	)
+
+	fs.BoolVar(
+		&c.includeRealmState,
+		"include-realm-state",
+		false,
+		"export the state of the realms, with their storage deposits and scheduled calls, instead of having them initialized again",
+	)
+}
+
+func execExport(c *exportCfg, io commands.IO) error {
+	if c.creator == "" {
+		return errMissingCreator
+	}
+
+	creator, err := crypto.AddressFromBech32(c.creator)
+	if err != nil {
+		return fmt.Errorf("invalid creator address, %w", err)
+	}
+
+	// Get the absolute path to the node's data directory
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	// Load the configuration
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	genesis, err := bft.GenesisDocFromFile(c.genesisFile)
+	if err != nil {
+		return fmt.Errorf("unable to load genesis, %w", err)
+	}
+
+	// Export the application state
+	appDB, err := dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(nodeDir, config.DefaultDBDir))
+	if err != nil {
+		return fmt.Errorf("unable to open the application database, %w", err)
+	}
+	defer appDB.Close()
+
+	state, height, err := gnoland.ExportGenesisState(appDB, gnoland.ExportOptions{
+		Height:            c.height,
+		Creator:           creator,
+		IncludeRealmState: c.includeRealmState,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to export the application state, %w", err)
+	}
+
+	// The validators of the new chain are those of the next height
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the state database, %w", err)
+	}
+	defer stateDB.Close()
+
+	vals, err := sm.LoadValidators(stateDB, height+1)
+	if err != nil {
+		return fmt.Errorf("unable to load the validators at height %d, %w", height+1, err)
+	}
+
+	// Keep the names of the genesis validators
+	names := make(map[crypto.Address]string, len(genesis.Validators))
+	for _, val := range genesis.Validators {
+		names[val.Address] = val.Name
+	}
+
+	genesis.GenesisTime = time.Now()
+	genesis.AppState = state
+	genesis.Validators = make([]bft.GenesisValidator, 0, vals.Size())
+
+	for _, val := range vals.Validators {
+		genesis.Validators = append(genesis.Validators, bft.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower,
+			Name:    names[val.Address],
+		})
+	}
+
+	if err := genesis.SaveAs(c.outputFile); err != nil {
+		return fmt.Errorf("unable to save the exported genesis, %w", err)
+	}
+
+	io.Printfln(
+		"Exported the state at height %d to %q: %d accounts, %d packages, %d realm states",
+		height,
+		c.outputFile,
+		len(state.Accounts),
+		len(state.Txs),
+		len(state.Realms),
+	)
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prepareExportNode initializes the node directory with a chain at height 1,
+// with a realm, and returns the path to its genesis.json
+func prepareExportNode(t *testing.T, nodeDir string, creator crypto.Address) string {
+	t.Helper()
+
+	prepareNodeRPC(t, nodeDir)
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	genesis := &bft.GenesisDoc{
+		GenesisTime: time.Now(),
+		ChainID:     "export",
+		ConsensusParams: abci.ConsensusParams{
+			Block: &abci.BlockParams{
+				MaxTxBytes:   1_000_000,
+				MaxDataBytes: 2_000_000,
+				MaxGas:       3_000_000_000,
+				TimeIotaMS:   100,
+			},
+		},
+		Validators: []bft.GenesisValidator{
+			{
+				Address: pubKey.Address(),
+				PubKey:  pubKey,
+				Power:   10,
+				Name:    "validator",
+			},
+		},
+	}
+
+	appState := gnoland.DefaultGenState()
+	appState.Balances = []gnoland.Balance{{Address: creator, Amount: std.NewCoins(std.NewCoin("ugnot", 1e10))}}
+	appState.Txs = []gnoland.TxWithMetadata{
+		{
+			Tx: std.Tx{
+				Msgs: []std.Msg{vm.NewMsgAddPackage(creator, "gno.land/r/demo/export", []*std.MemFile{
+					{Name: "export.gno", Body: "package export; var Value = 42"},
+				})},
+				Fee:        std.NewFee(1e6, std.NewCoin("ugnot", 1e6)),
+				Signatures: []std.Signature{{}},
+			},
+		},
+	}
+	genesis.AppState = appState
+
+	genesisPath := filepath.Join(nodeDir, "genesis.json")
+	require.NoError(t, genesis.SaveAs(genesisPath))
+
+	// Run the genesis block in the application
+	appDB, err := dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(nodeDir, config.DefaultDBDir))
+	require.NoError(t, err)
+
+	app, err := gnoland.NewAppWithOptions(gnoland.TestAppOptions(appDB))
+	require.NoError(t, err)
+
+	resp := app.InitChain(abci.RequestInitChain{
+		ChainID:         genesis.ChainID,
+		ConsensusParams: &genesis.ConsensusParams,
+		AppState:        appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+	app.Commit()
+	appDB.Close()
+
+	// Save the validators in the node state
+	state, err := sm.MakeGenesisState(genesis)
+	require.NoError(t, err)
+	state.LastBlockHeight = 1
+
+	stateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, filepath.Join(nodeDir, config.DefaultDBDir))
+	require.NoError(t, err)
+	sm.BootstrapState(stateDB, state)
+	stateDB.Close()
+
+	return genesisPath
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodeDir    = t.TempDir()
+		outputPath = filepath.Join(nodeDir, "exported.json")
+		creator    = ed25519.GenPrivKey().PubKey().Address()
+	)
+
+	genesisPath := prepareExportNode(t, nodeDir, creator)
+
+	mockOut := new(bytes.Buffer)
+	io := commands.NewTestIO()
+	io.SetOut(commands.WriteNopCloser(mockOut))
+
+	args := []string{
+		"export",
+		"--data-dir",
+		nodeDir,
+		"--genesis",
+		genesisPath,
+		"--output",
+		outputPath,
+		"--creator",
+		creator.String(),
+		"--include-realm-state",
+	}
+
+	require.NoError(t, newRootCmd(io).ParseAndRun(context.Background(), args))
+	assert.Contains(t, mockOut.String(), "Exported the state at height 1")
+
+	exported, err := bft.GenesisDocFromFile(outputPath)
+	require.NoError(t, err)
+
+	original, err := bft.GenesisDocFromFile(genesisPath)
+	require.NoError(t, err)
+
+	// The chain parameters are kept, with the validators of the next height
+	assert.Equal(t, original.ChainID, exported.ChainID)
+	assert.Equal(t, original.ConsensusParams, exported.ConsensusParams)
+	assert.Equal(t, original.Validators, exported.Validators)
+
+	state, ok := exported.AppState.(gnoland.GnoGenesisState)
+	require.True(t, ok)
+	require.Len(t, state.Txs, 1)
+	assert.Equal(t, "gno.land/r/demo/export", state.Txs[0].Tx.Msgs[0].(vm.MsgAddPackage).Package.Path)
+	require.Len(t, state.Realms, 1)
+	assert.NotEmpty(t, state.Realms[0].Objects)
+}
+
+func TestExport_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing creator", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"export", "--data-dir", t.TempDir()},
+		)
+		assert.ErrorIs(t, err, errMissingCreator)
+	})
+
+	t.Run("invalid creator", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"export", "--data-dir", t.TempDir(), "--creator", "invalid"},
+		)
+		assert.ErrorContains(t, err, "invalid creator address")
+	})
+
+	t.Run("uninitialized node", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{
+				"export",
+				"--data-dir",
+				t.TempDir(),
+				"--creator",
+				ed25519.GenPrivKey().PubKey().Address().String(),
+			},
+		)
+		assert.ErrorContains(t, err, tryConfigInit)
+	})
+}
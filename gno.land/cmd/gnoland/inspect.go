@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/gnolang/gno/gno.land/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/node"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"go.uber.org/zap/zapcore"
+)
+
+type inspectCfg struct {
+	dataDir       string
+	listenAddress string
+
+	logLevel  string
+	logFormat string
+}
+
+// newInspectCmd creates the inspect command
+func newInspectCmd(io commands.IO) *commands.Command {
+	cfg := &inspectCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "inspect",
+			ShortUsage: "inspect [flags]",
+			ShortHelp:  "serves a read-only RPC over the databases of a stopped node",
+			LongHelp: "Starts a read-only RPC server over the block store and the state of a stopped node, " +
+				"without starting the application nor joining the consensus. " +
+				"Only the routes reading the blocks, the transactions, the validators and the consensus params are served",
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execInspect(ctx, cfg, io)
+		},
+	)
+}
+
+func (c *inspectCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.StringVar(
+		&c.listenAddress,
+		"listen",
+		"",
+		"the RPC listen address, the one of the node's config if empty",
+	)
+
+	fs.StringVar(
+		&c.logLevel,
+		"log-level",
+		zapcore.InfoLevel.String(),
+		"log level for the inspect server",
+	)
+
+	fs.StringVar(
+		&c.logFormat,
+		"log-format",
+		log.ConsoleFormat.String(),
+		"log format for the inspect server",
+	)
+}
+
+func execInspect(ctx context.Context, c *inspectCfg, io commands.IO) error {
+	// Get the absolute path to the node's data directory
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	// Load the configuration
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	if c.listenAddress != "" {
+		cfg.RPC.ListenAddress = c.listenAddress
+	}
+
+	// Initialize the logger
+	zapLogger, err := initializeLogger(io.Out(), c.logLevel, c.logFormat)
+	if err != nil {
+		return fmt.Errorf("unable to initialize zap logger, %w", err)
+	}
+
+	defer func() {
+		// Sync the logger before exiting
+		_ = zapLogger.Sync()
+	}()
+
+	inspector, err := node.NewInspector(cfg, node.DefaultDBProvider, log.ZapLoggerToSlog(zapLogger))
+	if err != nil {
+		return fmt.Errorf("unable to inspect the node, %w", err)
+	}
+
+	if err := inspector.Start(); err != nil {
+		return fmt.Errorf("unable to start the inspect server, %w", err)
+	}
+
+	io.Printfln("Serving the node databases on %s", cfg.RPC.ListenAddress)
+
+	// Set up the wait context
+	inspectCtx, _ := signal.NotifyContext(
+		ctx,
+		os.Interrupt,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	)
+
+	// Wait for the exit signal
+	<-inspectCtx.Done()
+
+	if !inspector.IsRunning() {
+		return nil
+	}
+
+	// Stop the inspect server
+	if err := inspector.Stop(); err != nil {
+		return fmt.Errorf("unable to gracefully stop the inspect server, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uninitialized node", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"inspect", "--data-dir", t.TempDir()},
+		)
+		assert.ErrorContains(t, err, tryConfigInit)
+	})
+
+	t.Run("node never started", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		prepareNodeRPC(t, nodeDir)
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"inspect", "--data-dir", nodeDir},
+		)
+		assert.ErrorContains(t, err, "the node was never started")
+	})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/node"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type reindexCfg struct {
+	dataDir string
+	from    int64
+	to      int64
+}
+
+// newReindexCmd creates the reindex command
+func newReindexCmd(io commands.IO) *commands.Command {
+	cfg := &reindexCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "reindex",
+			ShortUsage: "reindex [flags]",
+			ShortHelp:  "rebuilds the transaction and block index of a stopped node from its block store",
+			LongHelp: "Stores the transactions of the executed blocks of a stopped node, their results, and " +
+				"the BeginBlock and EndBlock events in the transaction event store of its config, e.g. to build " +
+				"the indexes of the kv event store after it is enabled, or upgraded. The events already stored for " +
+				"the blocks are deleted first. The blocks must not be pruned",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execReindex(cfg, io)
+		},
+	)
+}
+
+func (c *reindexCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.Int64Var(
+		&c.from,
+		"from",
+		0,
+		"the first reindexed height, 0 for the lowest stored one",
+	)
+
+	fs.Int64Var(
+		&c.to,
+		"to",
+		0,
+		"the last reindexed height, 0 for the latest executed one",
+	)
+}
+
+func execReindex(c *reindexCfg, io commands.IO) error {
+	// Get the absolute path to the node's data directory
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	// Load the configuration
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	count, err := node.ReindexEvents(cfg, node.DefaultDBProvider, c.from, c.to)
+	if err != nil {
+		return fmt.Errorf("unable to reindex the events, %w", err)
+	}
+
+	io.Printfln("Reindexed %d transactions", count)
+
+	return nil
+}
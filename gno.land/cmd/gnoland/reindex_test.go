@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindex(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uninitialized node", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"reindex", "--data-dir", t.TempDir()},
+		)
+		assert.ErrorContains(t, err, tryConfigInit)
+	})
+
+	t.Run("event store disabled", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		prepareNodeRPC(t, nodeDir)
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"reindex", "--data-dir", nodeDir},
+		)
+		assert.ErrorContains(t, err, "the transaction event store is not enabled")
+	})
+
+	t.Run("no executed blocks", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		prepareNodeRPC(t, nodeDir)
+
+		require.NoError(t, newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"config", "set", "--config-path", constructConfigPath(nodeDir), "tx_event_store.event_store_type", "kv"},
+		))
+
+		mockOut := new(bytes.Buffer)
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(mockOut))
+
+		require.NoError(t, newRootCmd(io).ParseAndRun(
+			context.Background(),
+			[]string{"reindex", "--data-dir", nodeDir},
+		))
+		assert.Contains(t, mockOut.String(), "Reindexed 0 transactions")
+	})
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+type rollbackCfg struct {
+	dataDir string
+}
+
+// newRollbackCmd creates the rollback command
+func newRollbackCmd(io commands.IO) *commands.Command {
+	cfg := &rollbackCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "rollback",
+			ShortUsage: "rollback [flags]",
+			ShortHelp:  "reverts the state of a stopped node by one height",
+			LongHelp: "Reverts the block store, the consensus state and the application state of a stopped node " +
+				"by one height, so the latest block is executed again once the node is restarted. " +
+				"If the latest block was saved but not executed, only that block is deleted, " +
+				"unless the application committed it: the node then recovers the block on restart, and nothing is reverted. " +
+				"The application must keep the previous height, which requires pruning_keep_recent to be at least 1, " +
+				"as it is by default",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execRollback(cfg, io)
+		},
+	)
+}
+
+func (c *rollbackCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+}
+
+func execRollback(c *rollbackCfg, io commands.IO) error {
+	// Get the absolute path to the node's data directory
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	// Load the configuration
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the block store database, %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the state database, %w", err)
+	}
+	defer stateDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	state := sm.LoadState(stateDB)
+	if state.IsEmpty() {
+		return fmt.Errorf("no state found in %q", cfg.DBDir())
+	}
+
+	appDB, err := dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(nodeDir, config.DefaultDBDir))
+	if err != nil {
+		return fmt.Errorf("unable to open the application database, %w", err)
+	}
+	defer appDB.Close()
+
+	// A block which was saved but not executed is only deleted
+	height := state.LastBlockHeight - 1
+	if blockStore.Height() == state.LastBlockHeight+1 {
+		appHeight, err := gnoland.AppStateHeight(appDB)
+		if err != nil {
+			return fmt.Errorf("unable to load the application state, %w", err)
+		}
+
+		// The handshake saves the state of a block the application committed
+		if appHeight == blockStore.Height() {
+			io.Printfln(
+				"The application committed block %d, whose state is saved on restart: nothing to roll back",
+				appHeight,
+			)
+
+			return nil
+		}
+
+		height = state.LastBlockHeight
+	}
+
+	// The application is reverted first: if the consensus state can't be
+	// reverted, the latest block is replayed in the application on restart
+	appHash, err := gnoland.RollbackAppState(appDB, height)
+	if err != nil {
+		return fmt.Errorf("unable to roll back the application state, %w", err)
+	}
+
+	rollbackHeight, stateAppHash, err := sm.Rollback(blockStore, stateDB)
+	if err != nil {
+		return fmt.Errorf("unable to roll back the consensus state, %w", err)
+	}
+
+	if !bytes.Equal(appHash, stateAppHash) {
+		io.ErrPrintfln(
+			"WARN: the application hash %X does not match the consensus state app hash %X",
+			appHash,
+			stateAppHash,
+		)
+	}
+
+	io.Printfln("Rolled back the state to height %d, with app hash %X", rollbackHeight, stateAppHash)
+
+	return nil
+}
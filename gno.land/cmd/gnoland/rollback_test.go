@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/privval"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uninitialized node", func(t *testing.T) {
+		t.Parallel()
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"rollback", "--data-dir", t.TempDir()},
+		)
+		assert.ErrorContains(t, err, tryConfigInit)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		prepareNodeRPC(t, nodeDir)
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"rollback", "--data-dir", nodeDir},
+		)
+		assert.ErrorContains(t, err, "no state found")
+	})
+
+	t.Run("first block", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		prepareExportNode(t, nodeDir, ed25519.GenPrivKey().PubKey().Address())
+
+		err := newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"rollback", "--data-dir", nodeDir},
+		)
+		assert.ErrorContains(t, err, "unable to roll back the application state")
+	})
+}
+
+func TestRollback_CommittedBlock(t *testing.T) {
+	t.Parallel()
+
+	nodeDir := t.TempDir()
+	prepareExportNode(t, nodeDir, ed25519.GenPrivKey().PubKey().Address())
+	dbDir := filepath.Join(nodeDir, config.DefaultDBDir)
+
+	// The application committed the first block, but the node state was
+	// not saved
+	stateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, dbDir)
+	require.NoError(t, err)
+	state := sm.LoadState(stateDB)
+	state.LastBlockHeight = 0
+	sm.SaveState(stateDB, state)
+	stateDB.Close()
+
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, dbDir)
+	require.NoError(t, err)
+	block := bft.MakeBlock(1, nil, new(bft.Commit))
+	parts := block.MakePartSet(bft.BlockPartSizeBytes)
+	store.NewBlockStore(blockStoreDB).SaveBlock(block, parts, new(bft.Commit))
+	blockStoreDB.Close()
+
+	mockOut := new(bytes.Buffer)
+	io := commands.NewTestIO()
+	io.SetOut(commands.WriteNopCloser(mockOut))
+
+	require.NoError(t, newRootCmd(io).ParseAndRun(
+		context.Background(),
+		[]string{"rollback", "--data-dir", nodeDir},
+	))
+	assert.Contains(t, mockOut.String(), "nothing to roll back")
+
+	// The block is kept, for the handshake to save its state
+	blockStoreDB, err = dbm.NewDB("blockstore", dbm.GoLevelDBBackend, dbDir)
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	assert.Equal(t, int64(1), store.NewBlockStore(blockStoreDB).Height())
+}
+
+// nodeProcessEnv is set to run the gnoland command in the test binary, as
+// a node process
+const nodeProcessEnv = "GNOLAND_TEST_NODE_PROCESS"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(nodeProcessEnv) != "" {
+		main()
+		return
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestRollback_Node(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nodeDir     = t.TempDir()
+		genesisFile = filepath.Join(nodeDir, "genesis.json")
+	)
+
+	// Serve the RPC on a known free address, to follow the height of the node
+	prepareNodeRPC(t, nodeDir)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	rpcAddr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cmdIO := commands.NewTestIO()
+	cmdIO.SetOut(commands.WriteNopCloser(new(bytes.Buffer)))
+	require.NoError(t, newRootCmd(cmdIO).ParseAndRun(
+		context.Background(),
+		[]string{"config", "set", "--config-path", constructConfigPath(nodeDir), "rpc.laddr", "tcp://" + rpcAddr},
+	))
+	require.NoError(t, newRootCmd(cmdIO).ParseAndRun(
+		context.Background(),
+		[]string{"secrets", "init", "--data-dir", constructSecretsPath(nodeDir)},
+	))
+
+	// The node is the only validator of the chain
+	cfg, err := config.LoadConfig(nodeDir)
+	require.NoError(t, err)
+	pubKey := privval.LoadFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile()).GetPubKey()
+
+	genesis := &bft.GenesisDoc{
+		GenesisTime: time.Now(),
+		ChainID:     "rollback",
+		ConsensusParams: abci.ConsensusParams{
+			Block: &abci.BlockParams{
+				MaxTxBytes:   1_000_000,
+				MaxDataBytes: 2_000_000,
+				MaxGas:       3_000_000_000,
+				TimeIotaMS:   100,
+			},
+		},
+		Validators: []bft.GenesisValidator{
+			{
+				Address: pubKey.Address(),
+				PubKey:  pubKey,
+				Power:   10,
+				Name:    "validator",
+			},
+		},
+		AppState: gnoland.DefaultGenState(),
+	}
+	require.NoError(t, genesis.SaveAs(genesisFile))
+
+	client, err := rpcclient.NewHTTPClient("tcp://" + rpcAddr)
+	require.NoError(t, err)
+
+	// runNode runs a node process until it reaches the height, and stops it
+	runNode := func(height int64) {
+		t.Helper()
+
+		var output bytes.Buffer
+		cmd := exec.Command(os.Args[0], "start", "--data-dir", nodeDir, "--genesis", genesisFile)
+		cmd.Env = append(os.Environ(), nodeProcessEnv+"=1")
+		cmd.Stdout, cmd.Stderr = &output, &output
+		require.NoError(t, cmd.Start())
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancelFn()
+
+		err := retryUntilTimeout(ctx, func() bool {
+			status, err := client.Status()
+			return err != nil || status.SyncInfo.LatestBlockHeight < height
+		})
+
+		require.NoError(t, cmd.Process.Signal(os.Interrupt))
+		require.NoError(t, cmd.Wait(), "node output: %s", output.String())
+		require.NoError(t, err, "node output: %s", output.String())
+	}
+
+	// appHeight returns the height of the application state of the node
+	appHeight := func() int64 {
+		t.Helper()
+
+		appDB, err := dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(nodeDir, config.DefaultDBDir))
+		require.NoError(t, err)
+		defer appDB.Close()
+
+		height, err := gnoland.AppStateHeight(appDB)
+		require.NoError(t, err)
+
+		return height
+	}
+
+	runNode(3)
+	height := appHeight()
+
+	mockOut := new(bytes.Buffer)
+	io := commands.NewTestIO()
+	io.SetOut(commands.WriteNopCloser(mockOut))
+
+	require.NoError(t, newRootCmd(io).ParseAndRun(
+		context.Background(),
+		[]string{"rollback", "--data-dir", nodeDir},
+	))
+	assert.Contains(t, mockOut.String(), fmt.Sprintf("Rolled back the state to height %d", height-1))
+	assert.Equal(t, height-1, appHeight())
+
+	// The only validator signs the deleted block again, which the double
+	// signing protection and the consensus WAL would refuse
+	privval.LoadFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile()).Reset()
+	require.NoError(t, os.RemoveAll(filepath.Dir(cfg.Consensus.WalFile())))
+
+	// The node executes the reverted block again, and keeps up with the chain
+	runNode(height + 1)
+}
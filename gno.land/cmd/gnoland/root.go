@@ -27,6 +27,11 @@ func newRootCmd(io commands.IO) *commands.Command {
 		newStartCmd(io),
 		newSecretsCmd(io),
 		newConfigCmd(io),
+		newExportCmd(io),
+		newRollbackCmd(io),
+		newInspectCmd(io),
+		newBlocksCmd(io),
+		newReindexCmd(io),
 	)
 
 	return cmd
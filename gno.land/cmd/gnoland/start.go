@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -28,6 +29,8 @@ import (
 	osm "github.com/gnolang/gno/tm2/pkg/os"
 
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -239,18 +242,17 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 
 	// Create a top-level shared event switch
 	evsw := events.NewEventSwitch()
-	minGasPrices := cfg.Application.MinGasPrices
 
 	// Create application and node
-	cfg.LocalApp, err = gnoland.NewApp(
+	cfg.LocalApp, err = newApp(
 		nodeDir,
+		cfg,
 		gnoland.GenesisAppConfig{
 			SkipFailingTxs:      c.skipFailingGenesisTxs,
 			SkipSigVerification: c.skipGenesisSigVerification,
 		},
 		evsw,
 		logger,
-		minGasPrices,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create the Gnoland app, %w", err)
@@ -291,6 +293,33 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 	return nil
 }
 
+// newApp creates the gno.land application of the node, with the application
+// options of its config
+func newApp(
+	nodeDir string,
+	cfg *config.Config,
+	genesisCfg gnoland.GenesisAppConfig,
+	evsw events.EventSwitch,
+	logger *slog.Logger,
+) (abci.Application, error) {
+	return gnoland.NewApp(
+		nodeDir,
+		genesisCfg,
+		evsw,
+		logger,
+		cfg.Application.MinGasPrices,
+		snapshots.Options{
+			Interval:   cfg.Application.SnapshotInterval,
+			KeepRecent: cfg.Application.SnapshotKeepRecent,
+		},
+		store.PruningOptions{
+			KeepRecent: cfg.Application.PruningKeepRecent,
+		},
+		cfg.MinRetainBlocks,
+		cfg.Application.CommitBaseStore,
+	)
+}
+
 // lazyInitNodeDir initializes new secrets, and a default configuration
 // in the given node directory, if not present
 func lazyInitNodeDir(io commands.IO, nodeDir string) error {
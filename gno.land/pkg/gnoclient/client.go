@@ -1,6 +1,7 @@
 package gnoclient
 
 import (
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 )
 
@@ -8,6 +9,8 @@ import (
 type Client struct {
 	Signer    Signer           // Signer for transaction authentication
 	RPCClient rpcclient.Client // RPC client for blockchain communication
+
+	LightClient *light.Client // Optional light client, for verified queries
 }
 
 // validateSigner checks that the signer is correctly configured.
@@ -25,3 +28,11 @@ func (c *Client) validateRPCClient() error {
 	}
 	return nil
 }
+
+// validateLightClient checks that the LightClient is correctly configured.
+func (c *Client) validateLightClient() error {
+	if c.LightClient == nil {
+		return ErrMissingLightClient
+	}
+	return nil
+}
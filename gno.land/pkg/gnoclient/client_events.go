@@ -0,0 +1,69 @@
+package gnoclient
+
+import (
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var ErrSubscriptionsUnsupported = errors.New("RPCClient does not support subscriptions")
+
+// Subscribe subscribes to the chain events matching the query, like
+// "tm.event = 'GnoEvent' AND gno.pkg_path = 'gno.land/r/demo/boards'".
+// The events are delivered on the returned channel, which must be drained
+// until it is closed. Subscriptions require a WS RPCClient, see rpcclient.NewWSClient
+func (c *Client) Subscribe(query string) (<-chan ctypes.ResultEvent, error) {
+	events, err := c.eventsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := events.Subscribe(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "subscribe failed")
+	}
+
+	return ch, nil
+}
+
+// Unsubscribe cancels the subscription to the query
+func (c *Client) Unsubscribe(query string) error {
+	events, err := c.eventsClient()
+	if err != nil {
+		return err
+	}
+
+	if err := events.Unsubscribe(query); err != nil {
+		return errors.Wrap(err, "unsubscribe failed")
+	}
+
+	return nil
+}
+
+// UnsubscribeAll cancels all the subscriptions of the client
+func (c *Client) UnsubscribeAll() error {
+	events, err := c.eventsClient()
+	if err != nil {
+		return err
+	}
+
+	if err := events.UnsubscribeAll(); err != nil {
+		return errors.Wrap(err, "unsubscribe failed")
+	}
+
+	return nil
+}
+
+// eventsClient returns the RPCClient, if it supports subscriptions
+func (c *Client) eventsClient() (rpcclient.EventsClient, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	events, ok := c.RPCClient.(rpcclient.EventsClient)
+	if !ok {
+		return nil, ErrSubscriptionsUnsupported
+	}
+
+	return events, nil
+}
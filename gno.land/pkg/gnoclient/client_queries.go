@@ -2,16 +2,23 @@ package gnoclient
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/merkle"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
 )
 
-var ErrInvalidBlockHeight = errors.New("invalid block height provided")
+var (
+	ErrInvalidBlockHeight = errors.New("invalid block height provided")
+	ErrUnprovableQuery    = errors.New("query path is not a store key query")
+)
 
 // QueryCfg contains configuration options for performing ABCI queries.
 type QueryCfg struct {
@@ -37,6 +44,102 @@ func (c *Client) Query(cfg QueryCfg) (*ctypes.ResultABCIQuery, error) {
 	return qres, nil
 }
 
+// QueryVerified performs a store key query with a proof, like
+// ".store/main/key", and verifies the proof against the app hash of a header
+// verified by the light client. If no height is given, the latest height with
+// a verified app hash is queried.
+//
+// The app hash of a height is in the header of the next one, so the queried
+// state is never the latest one of the node. The node must keep the recent
+// states: application.pruning_keep_recent must be at least 1, as it is by
+// default.
+func (c *Client) QueryVerified(cfg QueryCfg) (*ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+	if err := c.validateLightClient(); err != nil {
+		return nil, err
+	}
+
+	storeName, err := parseStoreKeyPath(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// The app hash after the block at a height is in the next header
+	if cfg.Height == 0 {
+		if _, err := c.LightClient.Update(now); err != nil {
+			return nil, fmt.Errorf("unable to update light client: %w", err)
+		}
+
+		trustedHeight := c.LightClient.LastTrustedHeight()
+		if trustedHeight < 2 {
+			return nil, fmt.Errorf("no app hash verified yet, at trusted height %d", trustedHeight)
+		}
+		cfg.Height = trustedHeight - 1
+	}
+
+	cfg.Prove = true
+	qres, err := c.Query(cfg)
+	if err != nil {
+		if qres == nil {
+			return nil, err
+		}
+
+		// The node is unable to prove the state at the height
+		return qres, fmt.Errorf("unable to query height %d, which may be pruned by the node: %w", cfg.Height, err)
+	}
+	if qres.Response.Height != cfg.Height {
+		return nil, fmt.Errorf("query response height %d does not match the queried height %d",
+			qres.Response.Height, cfg.Height)
+	}
+
+	lb, err := c.LightClient.VerifyLightBlockAtHeight(cfg.Height+1, now)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify header: %w", err)
+	}
+
+	if err := verifyQueryProof(qres, storeName, cfg.Data, lb.AppHash); err != nil {
+		return nil, fmt.Errorf("unable to verify proof: %w", err)
+	}
+
+	return qres, nil
+}
+
+// parseStoreKeyPath returns the store name of a store key query path
+func parseStoreKeyPath(path string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 3 || parts[0] != ".store" || parts[1] == "" || parts[2] != "key" {
+		return "", errors.Wrapf(ErrUnprovableQuery, "path %q", path)
+	}
+
+	return parts[1], nil
+}
+
+// verifyQueryProof verifies the proof of the value, or of the absence of the
+// key, in the store
+func verifyQueryProof(qres *ctypes.ResultABCIQuery, storeName string, key, appHash []byte) error {
+	if qres.Response.Proof == nil {
+		return errors.New("missing proof")
+	}
+
+	var (
+		prt     = rootmulti.DefaultProofRuntime()
+		keypath = merkle.KeyPath{}.
+			AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+			AppendKey(key, merkle.KeyEncodingHex).
+			String()
+	)
+
+	if len(qres.Response.Value) == 0 {
+		return prt.VerifyAbsence(qres.Response.Proof, appHash, keypath)
+	}
+
+	return prt.VerifyValue(qres.Response.Proof, appHash, keypath, qres.Response.Value)
+}
+
 // QueryAccount retrieves account information for a given address.
 func (c *Client) QueryAccount(addr crypto.Address) (*std.BaseAccount, *ctypes.ResultABCIQuery, error) {
 	if err := c.validateRPCClient(); err != nil {
@@ -164,6 +267,22 @@ func (c *Client) BlockResult(height int64) (*ctypes.ResultBlockResults, error) {
 	return blockResults, nil
 }
 
+// AccountTxs gets a page of the transactions signed by addr, or sending
+// coins to it, in the order of the chain ("asc") or the newest first ("desc").
+// The node must index the transactions with the kv event store
+func (c *Client) AccountTxs(addr crypto.Address, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, ErrMissingRPCClient
+	}
+
+	txs, err := c.RPCClient.AccountTxs(addr.String(), page, perPage, orderBy)
+	if err != nil {
+		return nil, fmt.Errorf("account txs query failed: %w", err)
+	}
+
+	return txs, nil
+}
+
 // LatestBlockHeight gets the latest block height on the chain
 func (c *Client) LatestBlockHeight() (int64, error) {
 	if err := c.validateRPCClient(); err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -247,8 +248,8 @@ func TestCallErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -290,6 +291,29 @@ func TestCallErrors(t *testing.T) {
 			},
 			expectedError: ErrInvalidGasFee.Error(),
 		},
+		{
+			name: "Negative Timeout",
+			client: Client{
+				Signer:    &mockSigner{},
+				RPCClient: &mockRPCClient{},
+			},
+			cfg: BaseTxCfg{
+				GasWanted:      100000,
+				GasFee:         testGasFee,
+				AccountNumber:  1,
+				SequenceNumber: 1,
+				Memo:           "Test memo",
+				TimeoutHeight:  -1,
+			},
+			msgs: []vm.MsgCall{
+				{
+					Caller:  mockAddress,
+					PkgPath: "gno.land/r/random/path",
+					Func:    "RandomName",
+				},
+			},
+			expectedError: ErrInvalidTimeout.Error(),
+		},
 		{
 			name: "Negative Gas Wanted",
 			client: Client{
@@ -439,8 +463,8 @@ func TestClient_Send_Errors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -815,8 +839,8 @@ func TestRunErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -1014,8 +1038,8 @@ func TestAddPackageErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			cfg: BaseTxCfg{
 				GasWanted:      100000,
@@ -1217,6 +1241,38 @@ func TestBlockResults(t *testing.T) {
 	assert.Equal(t, height, blockResult.Height)
 }
 
+func TestAccountTxs(t *testing.T) {
+	t.Parallel()
+
+	addr := crypto.AddressFromPreimage([]byte("account"))
+	client := &Client{
+		Signer: &mockSigner{},
+		RPCClient: &mockRPCClient{
+			accountTxs: func(address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+				assert.Equal(t, addr.String(), address)
+				assert.Equal(t, 2, page)
+				assert.Equal(t, 10, perPage)
+				assert.Equal(t, "desc", orderBy)
+
+				return &ctypes.ResultTxSearch{
+					Txs:        []*ctypes.ResultTx{{Height: 5}},
+					TotalCount: 11,
+				}, nil
+			},
+		},
+	}
+
+	txs, err := client.AccountTxs(addr, 2, 10, "desc")
+	require.NoError(t, err)
+	assert.Equal(t, 11, txs.TotalCount)
+	require.Len(t, txs.Txs, 1)
+	assert.Equal(t, int64(5), txs.Txs[0].Height)
+
+	client.RPCClient = nil
+	_, err = client.AccountTxs(addr, 1, 10, "")
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+}
+
 func TestLatestBlockHeight(t *testing.T) {
 	t.Parallel()
 
@@ -1252,8 +1308,8 @@ func TestBlockErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			height:        1,
 			expectedError: ErrMissingRPCClient,
@@ -1261,8 +1317,8 @@ func TestBlockErrors(t *testing.T) {
 		{
 			name: "Invalid height",
 			client: Client{
-				&mockSigner{},
-				&mockRPCClient{},
+				Signer:    &mockSigner{},
+				RPCClient: &mockRPCClient{},
 			},
 			height:        0,
 			expectedError: ErrInvalidBlockHeight,
@@ -1293,8 +1349,8 @@ func TestBlockResultErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			height:        1,
 			expectedError: ErrMissingRPCClient,
@@ -1302,8 +1358,8 @@ func TestBlockResultErrors(t *testing.T) {
 		{
 			name: "Invalid height",
 			client: Client{
-				&mockSigner{},
-				&mockRPCClient{},
+				Signer:    &mockSigner{},
+				RPCClient: &mockRPCClient{},
 			},
 			height:        0,
 			expectedError: ErrInvalidBlockHeight,
@@ -1333,8 +1389,8 @@ func TestLatestBlockHeightErrors(t *testing.T) {
 		{
 			name: "Invalid RPCClient",
 			client: Client{
-				&mockSigner{},
-				nil,
+				Signer:    &mockSigner{},
+				RPCClient: nil,
 			},
 			expectedError: ErrMissingRPCClient,
 		},
@@ -1352,6 +1408,141 @@ func TestLatestBlockHeightErrors(t *testing.T) {
 	}
 }
 
+func TestQueryVerifiedErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		client        Client
+		cfg           QueryCfg
+		expectedError error
+	}{
+		{
+			name: "Invalid RPCClient",
+			client: Client{
+				Signer:      &mockSigner{},
+				RPCClient:   nil,
+				LightClient: &light.Client{},
+			},
+			cfg:           QueryCfg{Path: ".store/main/key"},
+			expectedError: ErrMissingRPCClient,
+		},
+		{
+			name: "Invalid LightClient",
+			client: Client{
+				Signer:      &mockSigner{},
+				RPCClient:   &mockRPCClient{},
+				LightClient: nil,
+			},
+			cfg:           QueryCfg{Path: ".store/main/key"},
+			expectedError: ErrMissingLightClient,
+		},
+		{
+			name: "Unprovable query",
+			client: Client{
+				Signer:      &mockSigner{},
+				RPCClient:   &mockRPCClient{},
+				LightClient: &light.Client{},
+			},
+			cfg:           QueryCfg{Path: "auth/accounts/g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"},
+			expectedError: ErrUnprovableQuery,
+		},
+		{
+			name: "Store subspace query",
+			client: Client{
+				Signer:      &mockSigner{},
+				RPCClient:   &mockRPCClient{},
+				LightClient: &light.Client{},
+			},
+			cfg:           QueryCfg{Path: ".store/main/subspace"},
+			expectedError: ErrUnprovableQuery,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := tc.client.QueryVerified(tc.cfg)
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	query := "tm.event = 'GnoEvent' AND gno.pkg_path = 'gno.land/r/demo/boards'"
+
+	events := make(chan ctypes.ResultEvent)
+	close(events)
+
+	client := Client{
+		Signer: &mockSigner{},
+		RPCClient: &mockEventsClient{
+			mockRPCClient: &mockRPCClient{},
+			subscribe: func(q string) (<-chan ctypes.ResultEvent, error) {
+				assert.Equal(t, query, q)
+
+				return events, nil
+			},
+			unsubscribe: func(q string) error {
+				assert.Equal(t, query, q)
+
+				return nil
+			},
+		},
+	}
+
+	ch, err := client.Subscribe(query)
+	require.NoError(t, err)
+	assert.Equal(t, (<-chan ctypes.ResultEvent)(events), ch)
+
+	assert.NoError(t, client.Unsubscribe(query))
+	assert.NoError(t, client.UnsubscribeAll())
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		client        Client
+		expectedError error
+	}{
+		{
+			name: "Invalid RPCClient",
+			client: Client{
+				Signer:    &mockSigner{},
+				RPCClient: nil,
+			},
+			expectedError: ErrMissingRPCClient,
+		},
+		{
+			name: "Unsupported RPCClient",
+			client: Client{
+				Signer:    &mockSigner{},
+				RPCClient: &mockRPCClient{},
+			},
+			expectedError: ErrSubscriptionsUnsupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := tc.client.Subscribe("tm.event = 'NewBlock'")
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, tc.expectedError)
+
+			assert.ErrorIs(t, tc.client.Unsubscribe("tm.event = 'NewBlock'"), tc.expectedError)
+			assert.ErrorIs(t, tc.client.UnsubscribeAll(), tc.expectedError)
+		})
+	}
+}
+
 // The same as client.Call, but test signing separately
 func callSigningSeparately(t *testing.T, client Client, cfg BaseTxCfg, msgs ...vm.MsgCall) (*ctypes.ResultBroadcastTxCommit, error) {
 	t.Helper()
@@ -13,21 +13,25 @@ import (
 )
 
 var (
-	ErrInvalidGasWanted = errors.New("invalid gas wanted")
-	ErrInvalidGasFee    = errors.New("invalid gas fee")
-	ErrMissingSigner    = errors.New("missing Signer")
-	ErrMissingRPCClient = errors.New("missing RPCClient")
+	ErrInvalidGasWanted   = errors.New("invalid gas wanted")
+	ErrInvalidGasFee      = errors.New("invalid gas fee")
+	ErrInvalidTimeout     = errors.New("invalid timeout")
+	ErrMissingSigner      = errors.New("missing Signer")
+	ErrMissingRPCClient   = errors.New("missing RPCClient")
+	ErrMissingLightClient = errors.New("missing LightClient")
 )
 
 const simulatePath = ".app/simulate"
 
 // BaseTxCfg defines the base transaction configuration, shared by all message types
 type BaseTxCfg struct {
-	GasFee         string // Gas fee
-	GasWanted      int64  // Gas wanted
-	AccountNumber  uint64 // Account number
-	SequenceNumber uint64 // Sequence number
-	Memo           string // Memo
+	GasFee           string // Gas fee
+	GasWanted        int64  // Gas wanted
+	AccountNumber    uint64 // Account number
+	SequenceNumber   uint64 // Sequence number
+	Memo             string // Memo
+	TimeoutHeight    int64  // Block height after which the tx is no longer valid, optional
+	TimeoutTimestamp int64  // Unix timestamp after which the tx is no longer valid, optional
 }
 
 // Call executes one or more MsgCall calls on the blockchain
@@ -77,6 +81,9 @@ func NewCallTx(cfg BaseTxCfg, msgs ...vm.MsgCall) (*std.Tx, error) {
 		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
+
+		TimeoutHeight:    cfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.TimeoutTimestamp,
 	}, nil
 }
 
@@ -127,6 +134,9 @@ func NewRunTx(cfg BaseTxCfg, msgs ...vm.MsgRun) (*std.Tx, error) {
 		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
+
+		TimeoutHeight:    cfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.TimeoutTimestamp,
 	}, nil
 }
 
@@ -177,6 +187,9 @@ func NewSendTx(cfg BaseTxCfg, msgs ...bank.MsgSend) (*std.Tx, error) {
 		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
+
+		TimeoutHeight:    cfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.TimeoutTimestamp,
 	}, nil
 }
 
@@ -227,6 +240,9 @@ func NewAddPackageTx(cfg BaseTxCfg, msgs ...vm.MsgAddPackage) (*std.Tx, error) {
 		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
+
+		TimeoutHeight:    cfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.TimeoutTimestamp,
 	}, nil
 }
 
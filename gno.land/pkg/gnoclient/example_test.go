@@ -1,9 +1,13 @@
 package gnoclient_test
 
 import (
+	"time"
+
 	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 )
 
 // Example_withDisk demonstrates how to initialize a gnoclient with a keybase sourced from a directory.
@@ -54,3 +58,34 @@ func Example_readOnly() {
 	}
 	_ = client
 }
+
+// Example_verifiedQueries demonstrates how to initialize a gnoclient verifying the query proofs
+// with a light client, from a header trusted out of band.
+func Example_verifiedQueries() {
+	remote := "127.0.0.1:26657"
+	rpcClient, _ := rpcclient.NewHTTPClient(remote)
+
+	chainID := "dev"
+	lightClient, _ := light.NewClient(
+		chainID,
+		light.TrustOptions{
+			Period: 24 * time.Hour,
+			Height: 1,
+			Hash:   []byte("trusted header hash"),
+		},
+		light.NewProvider(chainID, rpcClient),
+		nil, // witnesses
+		light.NewStore(memdb.NewMemDB()),
+	)
+
+	client := gnoclient.Client{
+		RPCClient:   rpcClient,
+		LightClient: lightClient,
+	}
+
+	// The query must be a store key query, the node must keep the recent states
+	_, _ = client.QueryVerified(gnoclient.QueryCfg{
+		Path: ".store/main/key",
+		Data: []byte("/a/..."),
+	})
+}
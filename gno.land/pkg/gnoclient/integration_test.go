@@ -2,7 +2,9 @@ package gnoclient
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
@@ -10,12 +12,19 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -694,6 +703,211 @@ func Hello(str string) string {
 // MsgCall with Send field populated (single/multiple)
 // MsgRun with Send field populated (single/multiple)
 
+func TestSubscribe_Integration(t *testing.T) {
+	// Setup packages
+	rootdir := gnoenv.RootDir()
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	meta := loadpkgs(t, rootdir, "gno.land/r/n2p5/loci")
+	state := config.Genesis.AppState.(gnoland.GnoGenesisState)
+	state.Txs = append(state.Txs, meta...)
+	config.Genesis.AppState = state
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & WS RPCClient, required by the subscriptions
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewWSClient("ws://" + strings.TrimPrefix(remoteAddr, "tcp://") + "/websocket")
+	require.NoError(t, err)
+	defer rpcClient.Close()
+
+	// Setup Client
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	events, err := client.Subscribe("tm.event = 'GnoEvent' AND gno.pkg_path = 'gno.land/r/n2p5/loci'")
+	require.NoError(t, err)
+
+	// Make Tx config
+	baseCfg := BaseTxCfg{
+		GasFee:         ugnot.ValueString(2100000),
+		GasWanted:      21000000,
+		AccountNumber:  0,
+		SequenceNumber: 0,
+		Memo:           "",
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	// Emit an event
+	res, err := client.Call(baseCfg, vm.MsgCall{
+		Caller:  caller.GetAddress(),
+		PkgPath: "gno.land/r/n2p5/loci",
+		Func:    "Set",
+		Args:    []string{"dmFsdWU="}, // base64 encoded "value"
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, ctypes.EventGno, event.Type)
+		require.IsType(t, bft.EventTx{}, event.Event)
+		assert.Equal(t, res.Height, event.Event.(bft.EventTx).Result.Height)
+
+		gnoEvent, ok := event.GnoEvent.(gnostd.GnoEvent)
+		require.True(t, ok)
+		assert.Equal(t, "SetValue", gnoEvent.Type)
+		assert.Equal(t, "gno.land/r/n2p5/loci", gnoEvent.PkgPath)
+	case <-time.After(10 * time.Second):
+		t.Fatal("event not received")
+	}
+
+	require.NoError(t, client.Unsubscribe("tm.event = 'GnoEvent' AND gno.pkg_path = 'gno.land/r/n2p5/loci'"))
+
+	// The channel is closed once unsubscribed
+	for range events {
+	}
+}
+
+func TestQueryVerified_Integration(t *testing.T) {
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	// The test node commits many blocks per second, keep them all
+	config.PruningOptions = store.PruningOptions{KeepRecent: 100_000}
+	// The block times follow the local clock, so the light client accepts
+	// the headers of the fast test blocks
+	config.Genesis.ConsensusParams.Block.TimeIotaMS = 1
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	// Wait for a few blocks, so the proofs can be verified
+	require.Eventually(t, func() bool {
+		return node.BlockStore().Height() >= 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// Trust the first header
+	commit, err := rpcClient.Commit(&[]int64{1}[0])
+	require.NoError(t, err)
+
+	chainID := config.Genesis.ChainID
+	lightClient, err := light.NewClient(
+		chainID,
+		light.TrustOptions{
+			Period: time.Hour,
+			Height: 1,
+			Hash:   commit.Hash(),
+		},
+		light.NewProvider(chainID, rpcClient),
+		nil,
+		light.NewStore(memdb.NewMemDB()),
+	)
+	require.NoError(t, err)
+
+	client := Client{
+		Signer:      signer,
+		RPCClient:   rpcClient,
+		LightClient: lightClient,
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	// The account is proven to exist
+	qres, err := client.QueryVerified(QueryCfg{
+		Path: ".store/main/key",
+		Data: auth.AddressStoreKey(caller.GetAddress()),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, qres.Response.Value)
+
+	// An unknown account is proven not to exist
+	qres, err = client.QueryVerified(QueryCfg{
+		Path: ".store/main/key",
+		Data: auth.AddressStoreKey(crypto.AddressFromPreimage([]byte("unknown"))),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, qres.Response.Value)
+
+	// A tampered value is rejected
+	client.RPCClient = &tamperingRPCClient{Client: rpcClient}
+
+	_, err = client.QueryVerified(QueryCfg{
+		Path: ".store/main/key",
+		Data: auth.AddressStoreKey(caller.GetAddress()),
+	})
+	assert.ErrorContains(t, err, "unable to verify proof")
+
+	// Only the store key queries can be verified
+	_, err = client.QueryVerified(QueryCfg{Path: "auth/accounts/" + caller.GetAddress().String()})
+	assert.ErrorIs(t, err, ErrUnprovableQuery)
+
+	// The state of a height unknown to the node is not proven
+	client.RPCClient = rpcClient
+
+	_, err = client.QueryVerified(QueryCfg{
+		Path:             ".store/main/key",
+		Data:             auth.AddressStoreKey(caller.GetAddress()),
+		ABCIQueryOptions: rpcclient.ABCIQueryOptions{Height: 100_000},
+	})
+	assert.ErrorContains(t, err, "may be pruned by the node")
+
+	// No app hash is verified with only the first header
+	client.LightClient, err = light.NewClient(
+		chainID,
+		light.TrustOptions{
+			Period: time.Hour,
+			Height: 1,
+			Hash:   commit.Hash(),
+		},
+		firstBlockProvider{light.NewProvider(chainID, rpcClient)},
+		nil,
+		light.NewStore(memdb.NewMemDB()),
+	)
+	require.NoError(t, err)
+
+	_, err = client.QueryVerified(QueryCfg{
+		Path: ".store/main/key",
+		Data: auth.AddressStoreKey(caller.GetAddress()),
+	})
+	assert.ErrorContains(t, err, "no app hash verified yet")
+}
+
+// firstBlockProvider provides the first light block as the latest one
+type firstBlockProvider struct {
+	light.Provider
+}
+
+func (p firstBlockProvider) LightBlock(height int64) (*light.LightBlock, error) {
+	if height == 0 {
+		height = 1
+	}
+
+	return p.Provider.LightBlock(height)
+}
+
+// tamperingRPCClient alters the values of the ABCI queries
+type tamperingRPCClient struct {
+	rpcclient.Client
+}
+
+func (c *tamperingRPCClient) ABCIQueryWithOptions(path string, data []byte, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	qres, err := c.Client.ABCIQueryWithOptions(path, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	qres.Response.Value = append(qres.Response.Value, 0x1)
+
+	return qres, nil
+}
+
 func newInMemorySigner(t *testing.T, chainid string) *SignerFromKeybase {
 	t.Helper()
 
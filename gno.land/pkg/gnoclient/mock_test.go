@@ -106,6 +106,7 @@ type (
 	mockBroadcastTxSync      func(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	mockGenesis              func() (*ctypes.ResultGenesis, error)
 	mockBlockchainInfo       func(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	mockBlockSearch          func(query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
 	mockNetInfo              func() (*ctypes.ResultNetInfo, error)
 	mockDumpConsensusState   func() (*ctypes.ResultDumpConsensusState, error)
 	mockConsensusState       func() (*ctypes.ResultConsensusState, error)
@@ -119,6 +120,8 @@ type (
 	mockUnconfirmedTxs       func(limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	mockNumUnconfirmedTxs    func() (*ctypes.ResultUnconfirmedTxs, error)
 	mockTx                   func(hash []byte) (*ctypes.ResultTx, error)
+	mockTxSearch             func(query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	mockAccountTxs           func(address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
 )
 
 type mockRPCClient struct {
@@ -130,6 +133,7 @@ type mockRPCClient struct {
 	broadcastTxSync      mockBroadcastTxSync
 	genesis              mockGenesis
 	blockchainInfo       mockBlockchainInfo
+	blockSearch          mockBlockSearch
 	netInfo              mockNetInfo
 	dumpConsensusState   mockDumpConsensusState
 	consensusState       mockConsensusState
@@ -143,6 +147,8 @@ type mockRPCClient struct {
 	unconfirmedTxs       mockUnconfirmedTxs
 	numUnconfirmedTxs    mockNumUnconfirmedTxs
 	tx                   mockTx
+	txSearch             mockTxSearch
+	accountTxs           mockAccountTxs
 }
 
 func (m *mockRPCClient) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
@@ -201,6 +207,13 @@ func (m *mockRPCClient) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.Resu
 	return nil, nil
 }
 
+func (m *mockRPCClient) BlockSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	if m.blockSearch != nil {
+		return m.blockSearch(query, page, perPage, orderBy)
+	}
+	return nil, nil
+}
+
 func (m *mockRPCClient) NetInfo() (*ctypes.ResultNetInfo, error) {
 	if m.netInfo != nil {
 		return m.netInfo()
@@ -292,3 +305,58 @@ func (m *mockRPCClient) Tx(hash []byte) (*ctypes.ResultTx, error) {
 
 	return nil, nil
 }
+
+func (m *mockRPCClient) TxSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	if m.txSearch != nil {
+		return m.txSearch(query, page, perPage, orderBy)
+	}
+
+	return nil, nil
+}
+
+func (m *mockRPCClient) AccountTxs(address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	if m.accountTxs != nil {
+		return m.accountTxs(address, page, perPage, orderBy)
+	}
+
+	return nil, nil
+}
+
+type (
+	mockSubscribe      func(query string) (<-chan ctypes.ResultEvent, error)
+	mockUnsubscribe    func(query string) error
+	mockUnsubscribeAll func() error
+)
+
+// mockEventsClient is an RPC client supporting subscriptions
+type mockEventsClient struct {
+	*mockRPCClient
+
+	subscribe      mockSubscribe
+	unsubscribe    mockUnsubscribe
+	unsubscribeAll mockUnsubscribeAll
+}
+
+func (m *mockEventsClient) Subscribe(query string) (<-chan ctypes.ResultEvent, error) {
+	if m.subscribe != nil {
+		return m.subscribe(query)
+	}
+
+	return nil, nil
+}
+
+func (m *mockEventsClient) Unsubscribe(query string) error {
+	if m.unsubscribe != nil {
+		return m.unsubscribe(query)
+	}
+
+	return nil
+}
+
+func (m *mockEventsClient) UnsubscribeAll() error {
+	if m.unsubscribeAll != nil {
+		return m.unsubscribeAll()
+	}
+
+	return nil
+}
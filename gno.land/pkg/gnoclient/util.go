@@ -7,6 +7,9 @@ func (cfg BaseTxCfg) validateBaseTxConfig() error {
 	if cfg.GasFee == "" {
 		return ErrInvalidGasFee
 	}
+	if cfg.TimeoutHeight < 0 || cfg.TimeoutTimestamp < 0 {
+		return ErrInvalidTimeout
+	}
 
 	return nil
 }
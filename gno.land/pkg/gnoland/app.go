@@ -20,12 +20,14 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
-	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 
 	// Only goleveldb is supported for now.
 	_ "github.com/gnolang/gno/tm2/pkg/db/_tags"
@@ -34,13 +36,18 @@ import (
 
 // AppOptions contains the options to create the gno.land ABCI application.
 type AppOptions struct {
-	DB                      dbm.DB             // required
-	Logger                  *slog.Logger       // required
-	EventSwitch             events.EventSwitch // required
-	VMOutput                io.Writer          // optional
-	SkipGenesisVerification bool               // default to verify genesis transactions
-	InitChainerConfig                          // options related to InitChainer
-	MinGasPrices            string             // optional
+	DB                      dbm.DB               // required
+	Logger                  *slog.Logger         // required
+	EventSwitch             events.EventSwitch   // required
+	VMOutput                io.Writer            // optional
+	SkipGenesisVerification bool                 // default to verify genesis transactions
+	InitChainerConfig                            // options related to InitChainer
+	MinGasPrices            string               // optional
+	SnapshotDB              dbm.DB               // optional, to serve state snapshots
+	SnapshotOptions         snapshots.Options    // optional
+	PruningOptions          store.PruningOptions // optional, default to keeping only the latest state
+	MinRetainBlocks         int64                // optional, default to keeping all the blocks
+	CommitBaseStore         bool                 // optional, to commit the base store of a new state to the app hash
 }
 
 // TestAppOptions provides a "ready" default [AppOptions] for use with
@@ -69,6 +76,10 @@ func (c AppOptions) validate() error {
 	case c.EventSwitch == nil:
 		return fmt.Errorf("no event switch provided")
 	}
+	// The entries of a snapshot are verified against the app hash
+	if c.SnapshotDB != nil && c.SnapshotOptions.Interval > 0 && !c.CommitBaseStore {
+		return fmt.Errorf("serving state snapshots requires committing the base store")
+	}
 	return nil
 }
 
@@ -87,20 +98,29 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	if cfg.MinGasPrices != "" {
 		appOpts = append(appOpts, sdk.SetMinGasPrices(cfg.MinGasPrices))
 	}
+	// Without a snapshot DB, snapshots can only be restored
+	appOpts = append(appOpts, sdk.SetSnapshots(cfg.SnapshotDB, cfg.SnapshotOptions))
+	appOpts = append(appOpts, sdk.SetPruningOptions(cfg.PruningOptions))
+	appOpts = append(appOpts, sdk.SetMinRetainBlocks(cfg.MinRetainBlocks))
 	// Create BaseApp.
 	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey, appOpts...)
 	baseApp.SetAppVersion("dev")
 
 	// Set mounts for BaseApp's MultiStore.
+	baseStore, err := baseStoreConstructor(cfg.DB, cfg.CommitBaseStore)
+	if err != nil {
+		return nil, err
+	}
 	baseApp.MountStoreWithDB(mainKey, iavl.StoreConstructor, cfg.DB)
-	baseApp.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, cfg.DB)
+	baseApp.MountStoreWithDB(baseKey, baseStore, cfg.DB)
 
 	// Construct keepers.
 
 	prmk := params.NewParamsKeeper(mainKey)
 	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
-	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
+	bankk := bank.NewBankKeeper(mainKey, acck, prmk.ForModule(bank.ModuleName))
 	gpk := auth.NewGasPriceKeeper(mainKey)
+	azk := authz.NewAuthzKeeper(mainKey)
 	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
 	vmk.Output = cfg.VMOutput
 
@@ -131,10 +151,20 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 			ctx = ctx.WithValue(auth.AuthParamsContextKey{}, acck.GetParams(ctx))
 			// Continue on with default auth ante handler.
 			newCtx, res, abort = authAnteHandler(ctx, tx, simulate)
+			if abort {
+				return
+			}
+			// Check the messages executed on behalf of other accounts.
+			if err := azk.CheckExecs(newCtx, tx); err != nil {
+				return newCtx, sdk.ABCIResultFromError(err), true
+			}
 			return
 		},
 	)
 
+	// Refund the fees paid for the unused gas of transactions.
+	baseApp.SetPostHandler(auth.NewFeeRefundHandler(acck, bankk))
+
 	// Set begin and end transaction hooks.
 	// These are used to create gno transaction stores and commit them when finishing
 	// the tx - in other words, data from a failing transaction won't be persisted
@@ -149,6 +179,11 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 		}
 	})
 
+	// Reload the gno store once the state is restored from a snapshot.
+	baseApp.SetRestoreHook(func(ms store.MultiStore) {
+		vmk.Reinitialize(cfg.Logger, ms)
+	})
+
 	// Set up the event collector
 	c := newCollector[validatorUpdate](
 		cfg.EventSwitch,      // global event switch filled by the node
@@ -168,6 +203,7 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 
 	// Set a handler Route.
 	baseApp.Router().AddRoute("auth", auth.NewHandler(acck, gpk))
+	baseApp.Router().AddRoute("authz", authz.NewHandler(azk, baseApp.Router()))
 	baseApp.Router().AddRoute("bank", bank.NewHandler(bankk))
 	baseApp.Router().AddRoute("params", params.NewHandler(prmk))
 	baseApp.Router().AddRoute("vm", vm.NewHandler(vmk))
@@ -185,6 +221,35 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	return baseApp, nil
 }
 
+// mountedStoresPrefix is the prefix of the stores mounted with the DB of the
+// multistore, in that DB.
+var mountedStoresPrefix = []byte("s/_/")
+
+// baseStoreConstructor returns the constructor of the base store of the
+// application state in db: hashdb.StoreConstructor if its entries are
+// committed to the app hash, or else hashdb.UnhashedStoreConstructor. Both
+// version the entries, so that the state can be rolled back; the unhashed
+// store is compatible with the states created with a dbadapter.Store.
+//
+// commit only applies to a new state: the base store of an existing state
+// is the one it was created with, as changing it changes the app hash.
+func baseStoreConstructor(db dbm.DB, commit bool) (store.CommitStoreConstructor, error) {
+	if store.GetLatestVersion(db) > 0 && committedBaseStore(db) != commit {
+		return nil, fmt.Errorf("commit_base_store is %t for the existing state, and can't be changed", !commit)
+	}
+
+	if commit {
+		return hashdb.StoreConstructor, nil
+	}
+	return hashdb.UnhashedStoreConstructor, nil
+}
+
+// committedBaseStore reports whether the entries of the base store of the
+// application state in db are committed to the app hash.
+func committedBaseStore(db dbm.DB) bool {
+	return hashdb.IsHashed(dbm.NewPrefixDB(db, mountedStoresPrefix))
+}
+
 // GenesisAppConfig wraps the most important
 // genesis params relating to the App
 type GenesisAppConfig struct {
@@ -207,6 +272,10 @@ func NewApp(
 	evsw events.EventSwitch,
 	logger *slog.Logger,
 	minGasPrices string,
+	snapshotOpts snapshots.Options,
+	pruningOpts store.PruningOptions,
+	minRetainBlocks int64,
+	commitBaseStore bool,
 ) (abci.Application, error) {
 	var err error
 
@@ -219,6 +288,10 @@ func NewApp(
 		},
 		MinGasPrices:            minGasPrices,
 		SkipGenesisVerification: genesisCfg.SkipSigVerification,
+		SnapshotOptions:         snapshotOpts,
+		PruningOptions:          pruningOpts,
+		MinRetainBlocks:         minRetainBlocks,
+		CommitBaseStore:         commitBaseStore,
 	}
 	if genesisCfg.SkipFailingTxs {
 		cfg.GenesisTxResultHandler = NoopGenesisTxResultHandler
@@ -230,6 +303,14 @@ func NewApp(
 		return nil, fmt.Errorf("error initializing database %q using path %q: %w", dbm.GoLevelDBBackend, dataRootDir, err)
 	}
 
+	// Get snapshot DB, if snapshots are taken.
+	if snapshotOpts.Interval > 0 {
+		cfg.SnapshotDB, err = dbm.NewDB("snapshots", dbm.GoLevelDBBackend, filepath.Join(dataRootDir, config.DefaultDBDir))
+		if err != nil {
+			return nil, fmt.Errorf("error initializing snapshot database %q using path %q: %w", dbm.GoLevelDBBackend, dataRootDir, err)
+		}
+	}
+
 	return NewAppWithOptions(cfg)
 }
 
@@ -339,6 +420,17 @@ func (cfg InitChainerConfig) loadAppState(ctx sdk.Context, appState any) ([]abci
 			panic(err)
 		}
 	}
+	// Lock genesis balances following their vesting schedules.
+	for _, vs := range state.Vesting {
+		if err := vs.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid vesting schedule for %s: %w", vs.Address, err)
+		}
+		acc, ok := cfg.acck.GetAccount(ctx, vs.Address).(*GnoAccount)
+		if !ok || !acc.GetCoins().IsAllGTE(vs.Amount) {
+			return nil, fmt.Errorf("vesting amount %s of %s exceeds its genesis balance", vs.Amount, vs.Address)
+		}
+		cfg.acck.SetAccount(ctx, vs.NewVestingAccount(acc))
+	}
 	// The account keeper's initial genesis state must be set after genesis
 	// accounts are created in account keeeper with genesis balances
 	cfg.acck.InitGenesis(ctx, state.Auth)
@@ -348,12 +440,20 @@ func (cfg InitChainerConfig) loadAppState(ctx sdk.Context, appState any) ([]abci
 
 	for _, addr := range state.Auth.Params.UnrestrictedAddrs {
 		acc := cfg.acck.GetAccount(ctx, addr)
-		accr := acc.(*GnoAccount)
+		accr := acc.(interface{ SetUnrestricted() }) // *GnoAccount or vesting account
 		accr.SetUnrestricted()
 		cfg.acck.SetAccount(ctx, acc)
 	}
 
-	cfg.vmk.InitGenesis(ctx, state.VM)
+	// Exported states replay their txs without storage deposits, as the
+	// coins, storage deposits and scheduled calls are restored after the txs.
+	exported := len(state.Accounts) > 0 || len(state.Realms) > 0
+	replayVM := state.VM
+	replayVM.StorageDeposits, replayVM.ScheduledCalls = nil, nil
+	if price, err := std.ParseCoin(replayVM.Params.StoragePrice); exported && err == nil {
+		replayVM.Params.StoragePrice = std.NewCoin(price.Denom, 0).String()
+	}
+	cfg.vmk.InitGenesis(ctx, replayVM)
 
 	params := cfg.acck.GetParams(ctx)
 	ctx = ctx.WithValue(auth.AuthParamsContextKey{}, params)
@@ -403,6 +503,39 @@ func (cfg InitChainerConfig) loadAppState(ctx sdk.Context, appState any) ([]abci
 
 		cfg.GenesisTxResultHandler(ctx, stdTx, res)
 	}
+
+	// Restore the exported VM params, accounts and realm states, as they
+	// were before the replayed txs changed them.
+	if exported {
+		cfg.vmk.InitGenesis(ctx, state.VM)
+	}
+	for _, ga := range state.Accounts {
+		if err := cfg.bankk.SetCoins(ctx, ga.Address, ga.Coins); err != nil {
+			return nil, fmt.Errorf("unable to restore the coins of %s: %w", ga.Address, err)
+		}
+		acc := cfg.acck.GetAccount(ctx, ga.Address)
+		if err := acc.SetSequence(ga.Sequence); err != nil {
+			return nil, fmt.Errorf("unable to restore the sequence of %s: %w", ga.Address, err)
+		}
+		if len(ga.SessionKeys) > 0 {
+			skacc, ok := acc.(vm.SessionKeyAccount)
+			if !ok {
+				return nil, fmt.Errorf("unable to restore the session keys of %s", ga.Address)
+			}
+			keys := make([]vm.SessionKey, len(ga.SessionKeys))
+			for i, key := range ga.SessionKeys {
+				key.ExpiresAt += ctx.BlockHeight()
+				keys[i] = key
+			}
+			skacc.SetSessionKeys(keys)
+		}
+		cfg.acck.SetAccount(ctx, acc)
+	}
+	for _, rs := range state.Realms {
+		if err := cfg.vmk.RestoreRealmState(ctx, rs); err != nil {
+			return nil, err
+		}
+	}
 	return txResponses, nil
 }
 
@@ -416,8 +549,8 @@ type endBlockerApp interface {
 }
 
 // EndBlocker defines the logic executed after every block.
-// Currently, it parses events that happened during execution to calculate
-// validator set changes
+// It runs the realm calls scheduled for the block, and parses events that
+// happened during execution to calculate validator set changes
 func EndBlocker(
 	collector *collector[validatorUpdate],
 	acck auth.AccountKeeperI,
@@ -437,10 +570,16 @@ func EndBlocker(
 		if acck != nil && gpk != nil {
 			auth.EndBlocker(ctx, gpk)
 		}
+		// Run the realm calls scheduled for this block
+		var events []abci.Event
+		if vmk != nil {
+			events = vmk.RunScheduledCalls(ctx)
+		}
+
 		// Check if there was a valset change
 		if len(collector.getEvents()) == 0 {
 			// No valset updates
-			return abci.ResponseEndBlock{}
+			return abci.ResponseEndBlock{Events: events}
 		}
 
 		// Run the VM to get the updates from the chain
@@ -452,7 +591,7 @@ func EndBlocker(
 		if err != nil {
 			app.Logger().Error("unable to call VM during EndBlocker", "err", err)
 
-			return abci.ResponseEndBlock{}
+			return abci.ResponseEndBlock{Events: events}
 		}
 
 		// Extract the updates from the VM response
@@ -460,11 +599,12 @@ func EndBlocker(
 		if err != nil {
 			app.Logger().Error("unable to extract updates from response", "err", err)
 
-			return abci.ResponseEndBlock{}
+			return abci.ResponseEndBlock{Events: events}
 		}
 
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: updates,
+			Events:           events,
 		}
 	}
 }
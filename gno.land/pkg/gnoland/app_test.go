@@ -26,6 +26,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -125,6 +126,155 @@ func TestNewAppWithOptions(t *testing.T) {
 	}
 }
 
+func TestNewAppWithOptions_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	opts := TestAppOptions(memdb.NewMemDB())
+	opts.SnapshotDB = memdb.NewMemDB()
+	opts.SnapshotOptions = snapshots.Options{Interval: 1, KeepRecent: 2}
+	opts.CommitBaseStore = true
+
+	app, err := NewAppWithOptions(opts)
+	require.NoError(t, err)
+
+	addr := crypto.AddressFromPreimage([]byte("test1"))
+
+	appState := DefaultGenState()
+	appState.Balances = []Balance{
+		{
+			Address: addr,
+			Amount:  []std.Coin{{Amount: 1e15, Denom: "ugnot"}},
+		},
+	}
+	appState.Txs = []TxWithMetadata{
+		{
+			Tx: std.Tx{
+				Msgs: []std.Msg{vm.NewMsgAddPackage(addr, "gno.land/r/demo", []*std.MemFile{
+					{
+						Name: "demo.gno",
+						Body: "package demo; func Hello() string { return `hello` }",
+					},
+				})},
+				Fee:        std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: 1e6, Denom: "ugnot"}},
+				Signatures: []std.Signature{{}}, // one empty signature
+			},
+		},
+	}
+
+	resp := app.InitChain(abci.RequestInitChain{
+		Time:    time.Now(),
+		ChainID: "dev",
+		ConsensusParams: &abci.ConsensusParams{
+			Block: defaultBlockParams(),
+		},
+		Validators: []abci.ValidatorUpdate{},
+		AppState:   appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+
+	// A snapshot is taken on commit
+	cres := app.Commit()
+
+	lres := app.ListSnapshots(abci.RequestListSnapshots{})
+	require.Nil(t, lres.Error)
+	require.Len(t, lres.Snapshots, 1)
+
+	snapshot := lres.Snapshots[0]
+	assert.Equal(t, int64(1), snapshot.Height)
+
+	// Restore the snapshot into a new app
+	restoredOpts := TestAppOptions(memdb.NewMemDB())
+	restoredOpts.CommitBaseStore = true
+	restored, err := NewAppWithOptions(restoredOpts)
+	require.NoError(t, err)
+
+	ores := restored.OfferSnapshot(abci.RequestOfferSnapshot{
+		Snapshot: snapshot,
+		AppHash:  cres.Data,
+	})
+	require.Equal(t, abci.OfferSnapshotAccept, ores.Result)
+
+	for index := range snapshot.Chunks {
+		chunk := app.LoadSnapshotChunk(abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  index,
+		})
+		require.Nil(t, chunk.Error)
+
+		ares := restored.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{
+			Index: index,
+			Chunk: chunk.Chunk,
+		})
+		require.Equal(t, abci.ApplySnapshotChunkAccept, ares.Result, "chunk %d: %v", index, ares.Error)
+	}
+
+	info := restored.Info(abci.RequestInfo{})
+	assert.Equal(t, int64(1), info.LastBlockHeight)
+	assert.Equal(t, cres.Data, info.LastBlockAppHash)
+
+	// The packages are loaded in the VM of the restored app
+	qres := restored.Query(abci.RequestQuery{
+		Path: "vm/qeval",
+		Data: []byte("gno.land/r/demo.Hello()"),
+	})
+	require.True(t, qres.IsOK(), "Query response: %v", qres)
+	assert.Equal(t, `("hello" string)`, string(qres.Data))
+}
+
+func TestNewAppWithOptions_CommitBaseStore(t *testing.T) {
+	t.Parallel()
+
+	// commitState commits the genesis state of a new app, and returns its
+	// app hash
+	commitState := func(t *testing.T, opts *AppOptions) []byte {
+		t.Helper()
+
+		app, err := NewAppWithOptions(opts)
+		require.NoError(t, err)
+
+		resp := app.InitChain(abci.RequestInitChain{
+			Time:    time.Now(),
+			ChainID: "dev",
+			ConsensusParams: &abci.ConsensusParams{
+				Block: defaultBlockParams(),
+			},
+			Validators: []abci.ValidatorUpdate{},
+			AppState:   DefaultGenState(),
+		})
+		require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+
+		return app.Commit().Data
+	}
+
+	defaultDB, committedDB := memdb.NewMemDB(), memdb.NewMemDB()
+	committedOpts := TestAppOptions(committedDB)
+	committedOpts.CommitBaseStore = true
+
+	// The base store is only part of the app hash when committed
+	defaultHash := commitState(t, TestAppOptions(defaultDB))
+	committedHash := commitState(t, committedOpts)
+	assert.NotEqual(t, defaultHash, committedHash)
+
+	// The base store of an existing state can't change
+	_, err := NewAppWithOptions(committedOpts)
+	require.NoError(t, err)
+	_, err = NewAppWithOptions(TestAppOptions(committedDB))
+	assert.ErrorContains(t, err, "commit_base_store is true for the existing state")
+
+	defaultOpts := TestAppOptions(defaultDB)
+	defaultOpts.CommitBaseStore = true
+	_, err = NewAppWithOptions(defaultOpts)
+	assert.ErrorContains(t, err, "commit_base_store is false for the existing state")
+
+	// Snapshots verify the base store against the app hash
+	opts := TestAppOptions(memdb.NewMemDB())
+	opts.SnapshotDB = memdb.NewMemDB()
+	opts.SnapshotOptions = snapshots.Options{Interval: 1}
+	_, err = NewAppWithOptions(opts)
+	assert.ErrorContains(t, err, "requires committing the base store")
+}
+
 func TestNewAppWithOptions_ErrNoDB(t *testing.T) {
 	t.Parallel()
 
@@ -132,11 +282,42 @@ func TestNewAppWithOptions_ErrNoDB(t *testing.T) {
 	assert.ErrorContains(t, err, "no db provided")
 }
 
+func TestNewAppWithOptions_MinRetainBlocks(t *testing.T) {
+	t.Parallel()
+
+	opts := TestAppOptions(memdb.NewMemDB())
+	opts.MinRetainBlocks = 2
+
+	app, err := NewAppWithOptions(opts)
+	require.NoError(t, err)
+
+	resp := app.InitChain(abci.RequestInitChain{
+		Time:    time.Now(),
+		ChainID: "dev",
+		ConsensusParams: &abci.ConsensusParams{
+			Block: defaultBlockParams(),
+		},
+		Validators: []abci.ValidatorUpdate{},
+		AppState:   DefaultGenState(),
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+
+	// The app requests the node to keep its last 2 blocks
+	for height, expected := range []int64{0, 1, 2} {
+		header := &bft.Header{ChainID: "dev", Height: int64(height + 1)}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.EndBlock(abci.RequestEndBlock{})
+
+		res := app.Commit()
+		assert.Equal(t, expected, res.RetainHeight, "height %d", header.Height)
+	}
+}
+
 func TestNewApp(t *testing.T) {
 	// NewApp should have good defaults and manage to run InitChain.
 	td := t.TempDir()
 
-	app, err := NewApp(td, NewTestGenesisAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), "")
+	app, err := NewApp(td, NewTestGenesisAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), "", snapshots.Options{}, store.PruningOptions{}, 0, false)
 	require.NoError(t, err, "NewApp should be successful")
 
 	resp := app.InitChain(abci.RequestInitChain{
@@ -452,6 +633,57 @@ func TestInitChainer_MetadataTxs(t *testing.T) {
 	}
 }
 
+func TestInitChainer_Vesting(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key     = getDummyKey(t)
+		addr    = key.PubKey().Address()
+		chainID = "test"
+	)
+
+	app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+	require.NoError(t, err)
+
+	// Lock 15 of the 20 genesis gnots until block 100.
+	app.InitChain(abci.RequestInitChain{
+		ChainID: chainID,
+		ConsensusParams: &abci.ConsensusParams{
+			Block: defaultBlockParams(),
+		},
+		AppState: GnoGenesisState{
+			Balances: []Balance{{Address: addr, Amount: std.NewCoins(std.NewCoin("ugnot", 20_000_000))}},
+			Vesting: []VestingSchedule{{
+				Address:  addr,
+				Type:     VestingDelayed,
+				Amount:   std.NewCoins(std.NewCoin("ugnot", 15_000_000)),
+				ByHeight: true,
+				End:      100,
+			}},
+			Auth: auth.DefaultGenesisState(),
+			Bank: bank.DefaultGenesisState(),
+			VM:   vm.DefaultGenesisState(),
+		},
+	})
+
+	// Sending locked coins fails.
+	to := crypto.AddressFromPreimage([]byte("to"))
+	tx := createAndSignTx(t, []std.Msg{bank.NewMsgSend(addr, to, std.NewCoins(std.NewCoin("ugnot", 10_000_000)))}, chainID, key)
+	resp := app.DeliverTx(abci.RequestDeliverTx{Tx: amino.MustMarshal(tx)})
+	require.False(t, resp.IsOK())
+	assert.Contains(t, resp.Log, "insufficient unlocked funds")
+	app.Commit()
+
+	qres := app.Query(abci.RequestQuery{Path: "bank/vesting/" + addr.String()})
+	require.True(t, qres.IsOK(), "query response: %v", qres)
+	var info bank.VestingInfo
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &info))
+	assert.Equal(t, "15000000ugnot", info.OriginalVesting.String())
+	assert.Equal(t, "15000000ugnot", info.Unvested.String())
+	assert.True(t, info.Vested.IsZero())
+	assert.Equal(t, "3000000ugnot", info.Spendable.String()) // minus the fee
+}
+
 func TestEndBlocker(t *testing.T) {
 	t.Parallel()
 
@@ -834,7 +1066,7 @@ func newGasPriceTestApp(t *testing.T) abci.Application {
 	prmk := params.NewParamsKeeper(mainKey)
 	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
 	gpk := auth.NewGasPriceKeeper(mainKey)
-	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
+	bankk := bank.NewBankKeeper(mainKey, acck, prmk.ForModule(bank.ModuleName))
 	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
 	prmk.Register(auth.ModuleName, acck)
 	prmk.Register(bank.ModuleName, bankk)
@@ -954,6 +1186,7 @@ func gnoGenesisState(t *testing.T) GnoGenesisState {
         "max_memo_bytes": "65536",
         "sig_verify_cost_ed25519": "590",
         "sig_verify_cost_secp256k1": "1000",
+        "sig_verify_cost_secp256r1": "1000",
         "target_gas_ratio": "60",
         "tx_sig_limit": "7",
         "tx_size_cost_per_byte": "10",
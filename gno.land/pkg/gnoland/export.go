@@ -0,0 +1,283 @@
+package gnoland
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+// exportDeployFee is the fee of the txs adding the exported packages. Genesis
+// txs are not metered, but a fee can't be zero once encoded in the genesis.
+var exportDeployFee = std.NewFee(50_000, std.NewCoin(ugnot.Denom, 1))
+
+var (
+	ErrEmptyState          = errors.New("the application state is empty")
+	ErrMissingCreator      = errors.New("missing creator of the exported packages")
+	ErrHistoricalRealmData = errors.New("realm states can only be exported at the latest height")
+)
+
+// ExportOptions are the options of [ExportGenesisState].
+type ExportOptions struct {
+	// Height of the exported state; 0 exports the latest one.
+	Height int64
+
+	// Creator of the txs adding the exported packages. The txs are not
+	// signed, so the exported genesis must be loaded without verifying the
+	// genesis signatures.
+	Creator crypto.Address
+
+	// IncludeRealmState exports the persisted state of the realms, which
+	// is restored once their packages are added again, instead of the
+	// state set by their init functions.
+	IncludeRealmState bool
+}
+
+// ExportGenesisState reads the gno.land application state stored in db, and
+// returns it as a genesis state, along with the height it was read at.
+//
+// The genesis state has the balances, vesting schedules, sequences and session
+// keys of the accounts, the params of the auth, bank and vm modules, and txs
+// adding every package in the order they were added, so the imports of a
+// package are always added before it. The storage deposits and scheduled
+// calls of the realms are exported with their state; without it, the
+// deposits are refunded to their depositors.
+func ExportGenesisState(db dbm.DB, opts ExportOptions) (GnoGenesisState, int64, error) {
+	if opts.Creator.IsZero() {
+		return GnoGenesisState{}, 0, ErrMissingCreator
+	}
+
+	mainKey := store.NewStoreKey("main")
+	baseKey := store.NewStoreKey("base")
+
+	baseStore, err := baseStoreConstructor(db, committedBaseStore(db))
+	if err != nil {
+		return GnoGenesisState{}, 0, err
+	}
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, db)
+	ms.MountStoreWithDB(baseKey, baseStore, db)
+
+	if err := ms.LoadLatestVersion(); err != nil {
+		return GnoGenesisState{}, 0, fmt.Errorf("unable to load the latest state, %w", err)
+	}
+
+	latest := ms.LastCommitID().Version
+	if latest == 0 {
+		return GnoGenesisState{}, 0, ErrEmptyState
+	}
+
+	height := latest
+	if opts.Height != 0 && opts.Height != latest {
+		// The base store (objects, types...) is not versioned
+		if opts.IncludeRealmState {
+			return GnoGenesisState{}, 0, ErrHistoricalRealmData
+		}
+
+		if err := ms.LoadVersion(opts.Height); err != nil {
+			return GnoGenesisState{}, 0, fmt.Errorf("unable to load the state at height %d, %w", opts.Height, err)
+		}
+		height = opts.Height
+	}
+
+	// The keepers are only read from
+	prmk := params.NewParamsKeeper(mainKey)
+	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
+	bankk := bank.NewBankKeeper(mainKey, acck, prmk.ForModule(bank.ModuleName))
+	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
+
+	prmk.Register(auth.ModuleName, acck)
+	prmk.Register(bank.ModuleName, bankk)
+	prmk.Register(vm.ModuleName, vmk)
+
+	header := &bft.Header{ChainID: "export", Height: height}
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms.MultiCacheWrap(), header, log.NewNoopLogger())
+
+	state := GnoGenesisState{
+		Balances: []Balance{},
+		Txs:      []TxWithMetadata{},
+		Auth:     acck.ExportGenesis(ctx),
+		Bank:     bankk.ExportGenesis(ctx),
+		VM:       vmk.ExportGenesis(ctx),
+	}
+
+	acck.IterateAccounts(ctx, func(acc std.Account) bool {
+		if !acc.GetCoins().IsZero() {
+			state.Balances = append(state.Balances, Balance{
+				Address: acc.GetAddress(),
+				Amount:  acc.GetCoins(),
+			})
+		}
+
+		if vs, ok := exportVestingSchedule(acc); ok {
+			state.Vesting = append(state.Vesting, vs)
+		}
+
+		state.Accounts = append(state.Accounts, GenesisAccount{
+			Address:     acc.GetAddress(),
+			Coins:       acc.GetCoins(),
+			Sequence:    acc.GetSequence(),
+			SessionKeys: exportSessionKeys(acc, height),
+		})
+
+		return false
+	})
+
+	mpkgs := vmk.ExportPackages(ctx)
+	for _, mpkg := range mpkgs {
+		tx := std.Tx{
+			Fee: exportDeployFee,
+			Msgs: []std.Msg{
+				vm.MsgAddPackage{
+					Creator: opts.Creator,
+					Package: mpkg,
+				},
+			},
+		}
+		tx.Signatures = make([]std.Signature, len(tx.GetSigners()))
+		state.Txs = append(state.Txs, TxWithMetadata{Tx: tx})
+
+		if opts.IncludeRealmState && gno.IsRealmPath(mpkg.Path) {
+			state.Realms = append(state.Realms, vmk.ExportRealmState(ctx, mpkg.Path))
+		}
+	}
+
+	if !opts.IncludeRealmState {
+		// The realms are initialized again, so are their storage and
+		// scheduled calls
+		refundStorageDeposits(&state)
+		state.VM.ScheduledCalls = nil
+	}
+
+	fundExportCreator(&state, opts.Creator, int64(len(mpkgs)))
+
+	return state, height, nil
+}
+
+// exportSessionKeys returns the session keys of acc which can still be used
+// after height, with their expiry height relative to it.
+func exportSessionKeys(acc std.Account, height int64) []vm.SessionKey {
+	skacc, ok := acc.(vm.SessionKeyAccount)
+	if !ok {
+		return nil
+	}
+
+	var keys []vm.SessionKey
+	for _, key := range skacc.GetSessionKeys() {
+		// keys whose spend limit is spent can't sign calls
+		if key.IsExpired(height+1) || key.SpendLimit.IsZero() {
+			continue
+		}
+		key.ExpiresAt -= height
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// refundStorageDeposits moves the exported storage deposits from the deposit
+// addresses of the realms back to the balances of their depositors.
+func refundStorageDeposits(state *GnoGenesisState) {
+	for _, sd := range state.VM.StorageDeposits {
+		depositAddr := gno.DeriveStorageDepositCryptoAddr(sd.RealmPath)
+		updateExportedCoins(state, depositAddr, func(coins std.Coins) std.Coins { return coins.Sub(sd.Deposit) })
+		updateExportedCoins(state, sd.Depositor, func(coins std.Coins) std.Coins { return coins.Add(sd.Deposit) })
+	}
+	state.VM.StorageDeposits = nil
+}
+
+// updateExportedCoins updates the exported balance and account of addr.
+func updateExportedCoins(state *GnoGenesisState, addr crypto.Address, update func(std.Coins) std.Coins) {
+	i := slices.IndexFunc(state.Balances, func(bal Balance) bool { return bal.Address == addr })
+	if i < 0 {
+		state.Balances = append(state.Balances, Balance{Address: addr})
+		i = len(state.Balances) - 1
+	}
+	state.Balances[i].Amount = update(state.Balances[i].Amount)
+	if state.Balances[i].Amount.IsZero() {
+		state.Balances = slices.Delete(state.Balances, i, i+1)
+	}
+
+	i = slices.IndexFunc(state.Accounts, func(acc GenesisAccount) bool { return acc.Address == addr })
+	if i < 0 {
+		state.Accounts = append(state.Accounts, GenesisAccount{Address: addr, Coins: std.Coins{}})
+		i = len(state.Accounts) - 1
+	}
+	state.Accounts[i].Coins = update(state.Accounts[i].Coins)
+}
+
+// fundExportCreator adds the fees of the package txs to the genesis balance
+// of their creator. Its exported coins are restored after the txs, which are
+// replayed without storage deposits.
+func fundExportCreator(state *GnoGenesisState, creator crypto.Address, txs int64) {
+	if txs == 0 {
+		return
+	}
+
+	fees := std.Coins{std.NewCoin(exportDeployFee.GasFee.Denom, exportDeployFee.GasFee.Amount*txs)}
+
+	for i, bal := range state.Balances {
+		if bal.Address == creator {
+			state.Balances[i].Amount = bal.Amount.Add(fees)
+			return
+		}
+	}
+
+	state.Balances = append(state.Balances, Balance{Address: creator, Amount: fees})
+
+	for _, acc := range state.Accounts {
+		if acc.Address == creator {
+			return
+		}
+	}
+
+	// The creator had no account
+	state.Accounts = append(state.Accounts, GenesisAccount{Address: creator, Coins: std.Coins{}})
+}
+
+// exportVestingSchedule returns the vesting schedule of acc, if it is a
+// vesting account.
+func exportVestingSchedule(acc std.Account) (VestingSchedule, bool) {
+	var (
+		base    *BaseVestingAccount
+		vs      VestingSchedule
+		periods []VestingPeriod
+	)
+
+	switch va := acc.(type) {
+	case *ContinuousVestingAccount:
+		base, vs.Type = &va.BaseVestingAccount, VestingContinuous
+	case *DelayedVestingAccount:
+		base, vs.Type = &va.BaseVestingAccount, VestingDelayed
+	case *PeriodicVestingAccount:
+		base, vs.Type, periods = &va.BaseVestingAccount, VestingPeriodic, va.Periods
+	default:
+		return vs, false
+	}
+
+	vs.Address = base.Address
+	vs.Amount = base.OriginalVesting
+	vs.ByHeight = base.ByHeight
+	vs.Start = base.Start
+	vs.Periods = periods
+	if vs.Type != VestingPeriodic {
+		vs.End = base.End
+	}
+
+	return vs, true
+}
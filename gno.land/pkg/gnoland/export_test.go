@@ -0,0 +1,326 @@
+package gnoland
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExportTestChain runs a chain with a counter realm, incremented twice at
+// height 1, when it also schedules an increment at height 101 and a session
+// key expiring at height 50 is added, and returns its db.
+func newExportTestChain(t *testing.T) (dbm.DB, crypto.Address) {
+	t.Helper()
+
+	var (
+		db      = memdb.NewMemDB()
+		key     = getDummyKey(t)
+		addr    = key.PubKey().Address()
+		chainID = "test"
+	)
+
+	opts := TestAppOptions(db)
+	opts.PruningOptions = store.PruningOptions{KeepRecent: 10}
+	app, err := NewAppWithOptions(opts)
+	require.NoError(t, err)
+
+	appState := DefaultGenState()
+	appState.Balances = []Balance{{Address: addr, Amount: std.NewCoins(std.NewCoin("ugnot", 1e10))}}
+	appState.VM.Params.StoragePrice = "100ugnot"
+	appState.Txs = []TxWithMetadata{
+		{Tx: newExportTestAddPkgTx(addr, "gno.land/p/demo/incr", "package incr; func Incr(n int) int { return n + 1 }")},
+		{Tx: newExportTestAddPkgTx(addr, "gno.land/r/demo/counter", `package counter
+
+import (
+	"std"
+
+	"gno.land/p/demo/incr"
+)
+
+var count int
+
+func Inc() int { crossing(); count = incr.Incr(count); return count }
+
+func Later() { crossing(); std.ScheduleCall(std.ChainHeight()+100, 1_000_000, "Inc") }
+
+func Count() int { return count }`)},
+	}
+
+	resp := app.InitChain(abci.RequestInitChain{
+		Time:            time.Now(),
+		ChainID:         chainID,
+		ConsensusParams: &abci.ConsensusParams{Block: defaultBlockParams()},
+		AppState:        appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+
+	tx := createAndSignTx(t, []std.Msg{
+		vm.NewMsgCall(addr, nil, "gno.land/r/demo/counter", "Inc", nil),
+		vm.NewMsgCall(addr, nil, "gno.land/r/demo/counter", "Inc", nil),
+		vm.NewMsgCall(addr, nil, "gno.land/r/demo/counter", "Later", nil),
+		vm.MsgAddSessionKey{Creator: addr, SessionKey: vm.SessionKey{
+			PubKey:       ed25519.GenPrivKey().PubKey(),
+			AllowedPaths: []string{"gno.land/r/demo/counter"},
+			MaxFee:       std.NewCoin("ugnot", 1e6),
+			SpendLimit:   std.NewCoins(std.NewCoin("ugnot", 1e6)),
+			ExpiresAt:    50,
+		}},
+	}, chainID, key)
+	app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: chainID, Height: 1, Time: time.Now()}})
+	dres := app.DeliverTx(abci.RequestDeliverTx{Tx: amino.MustMarshal(tx)})
+	require.True(t, dres.IsOK(), "DeliverTx response: %v", dres)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	return db, addr
+}
+
+func newExportTestAddPkgTx(creator crypto.Address, path, body string) std.Tx {
+	return std.Tx{
+		Msgs: []std.Msg{vm.NewMsgAddPackage(creator, path, []*std.MemFile{
+			{Name: "pkg.gno", Body: body},
+		})},
+		Fee:        std.NewFee(1e6, std.NewCoin("ugnot", 1e6)),
+		Signatures: []std.Signature{{}},
+	}
+}
+
+// initExportedChain starts a new chain from the exported state
+func initExportedChain(t *testing.T, state GnoGenesisState) *sdk.BaseApp {
+	t.Helper()
+
+	app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+	require.NoError(t, err)
+
+	resp := app.InitChain(abci.RequestInitChain{
+		Time:            time.Now(),
+		ChainID:         "exported",
+		ConsensusParams: &abci.ConsensusParams{Block: defaultBlockParams()},
+		AppState:        state,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+	app.Commit()
+
+	return app.(*sdk.BaseApp)
+}
+
+// exportedCoins returns the exported coins of addr.
+func exportedCoins(t *testing.T, state GnoGenesisState, addr crypto.Address) std.Coins {
+	t.Helper()
+
+	for _, acc := range state.Accounts {
+		if acc.Address == addr {
+			return acc.Coins
+		}
+	}
+	require.FailNow(t, "account not exported", addr.String())
+
+	return nil
+}
+
+func queryExportedAccount(t *testing.T, app *sdk.BaseApp, addr crypto.Address) GnoAccount {
+	t.Helper()
+
+	qres := app.Query(abci.RequestQuery{Path: "auth/accounts/" + addr.String()})
+	require.True(t, qres.IsOK(), "Query response: %v", qres)
+	var acc GnoAccount
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &acc))
+
+	return acc
+}
+
+func queryCount(t *testing.T, app *sdk.BaseApp) string {
+	t.Helper()
+
+	qres := app.Query(abci.RequestQuery{
+		Path: "vm/qeval",
+		Data: []byte("gno.land/r/demo/counter.Count()"),
+	})
+	require.True(t, qres.IsOK(), "Query response: %v", qres)
+
+	return string(qres.Data)
+}
+
+func TestExportGenesisState(t *testing.T) {
+	t.Parallel()
+
+	db, addr := newExportTestChain(t)
+
+	t.Run("packages and accounts", func(t *testing.T) {
+		t.Parallel()
+
+		state, height, err := ExportGenesisState(db, ExportOptions{Creator: addr})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), height)
+		assert.Empty(t, state.Realms)
+
+		// The packages are added in order, without the stdlibs
+		require.Len(t, state.Txs, 2)
+		for i, path := range []string{"gno.land/p/demo/incr", "gno.land/r/demo/counter"} {
+			msg := state.Txs[i].Tx.Msgs[0].(vm.MsgAddPackage)
+			assert.Equal(t, path, msg.Package.Path)
+			assert.Equal(t, addr, msg.Creator)
+			assert.Equal(t, exportDeployFee, state.Txs[i].Tx.Fee)
+		}
+
+		var exported *GenesisAccount
+		for i, acc := range state.Accounts {
+			if acc.Address == addr {
+				exported = &state.Accounts[i]
+			}
+		}
+		require.NotNil(t, exported)
+		assert.Equal(t, uint64(1), exported.Sequence)
+
+		// The session key expires 49 blocks after the exported height
+		require.Len(t, exported.SessionKeys, 1)
+		assert.Equal(t, int64(49), exported.SessionKeys[0].ExpiresAt)
+
+		// The realm is initialized again, so the storage deposits are
+		// refunded and the scheduled calls dropped
+		assert.Empty(t, state.VM.StorageDeposits)
+		assert.Empty(t, state.VM.ScheduledCalls)
+
+		withRealms, _, err := ExportGenesisState(db, ExportOptions{Creator: addr, IncludeRealmState: true})
+		require.NoError(t, err)
+		require.NotEmpty(t, withRealms.VM.StorageDeposits)
+		coins := exportedCoins(t, withRealms, addr)
+		for _, sd := range withRealms.VM.StorageDeposits {
+			assert.Equal(t, addr, sd.Depositor)
+			coins = coins.Add(sd.Deposit)
+			for _, bal := range state.Balances {
+				assert.NotEqual(t, gno.DeriveStorageDepositCryptoAddr(sd.RealmPath), bal.Address)
+			}
+		}
+		assert.Equal(t, coins, exported.Coins)
+
+		app := initExportedChain(t, state)
+
+		// The realm is initialized again
+		assert.Equal(t, "(0 int)", queryCount(t, app))
+
+		// The accounts are restored as exported
+		acc := queryExportedAccount(t, app, addr)
+		assert.Equal(t, exported.Sequence, acc.Sequence)
+		assert.Equal(t, exported.Coins, acc.Coins)
+		require.Len(t, acc.SessionKeys, 1)
+		assert.Equal(t, int64(49), acc.SessionKeys[0].ExpiresAt)
+	})
+
+	t.Run("realm state", func(t *testing.T) {
+		t.Parallel()
+
+		state, _, err := ExportGenesisState(db, ExportOptions{Creator: addr, IncludeRealmState: true})
+		require.NoError(t, err)
+		require.Len(t, state.Realms, 1)
+		assert.Equal(t, "gno.land/r/demo/counter", state.Realms[0].Path)
+
+		// The state survives the amino JSON of the genesis
+		var decoded GnoGenesisState
+		require.NoError(t, amino.UnmarshalJSON(amino.MustMarshalJSON(state), &decoded))
+
+		app := initExportedChain(t, decoded)
+		assert.Equal(t, "(2 int)", queryCount(t, app))
+
+		// The storage deposits are restored with the realm state
+		qres := app.Query(abci.RequestQuery{Path: "vm/qstorage", Data: []byte("gno.land/r/demo/counter")})
+		require.True(t, qres.IsOK(), "Query response: %v", qres)
+		var rs vm.RealmStorage
+		require.NoError(t, amino.UnmarshalJSON(qres.Data, &rs))
+		require.Len(t, state.VM.StorageDeposits, 1)
+		assert.Equal(t, state.VM.StorageDeposits[0].Storage, rs.Storage)
+		assert.Equal(t, state.VM.StorageDeposits[0].Deposit, rs.Deposit)
+		assert.Equal(t, exportedCoins(t, state, rs.DepositAddress), rs.Deposit)
+
+		// So is the scheduled call, 100 blocks after the exported height
+		require.Len(t, state.VM.ScheduledCalls, 1)
+		assert.Equal(t, int64(100), state.VM.ScheduledCalls[0].Height)
+		assert.Equal(t, "Inc", state.VM.ScheduledCalls[0].Func)
+		for height := int64(2); height <= 100; height++ {
+			if height == 100 {
+				assert.Equal(t, "(2 int)", queryCount(t, app))
+			}
+			app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: "exported", Height: height, Time: time.Now()}})
+			app.EndBlock(abci.RequestEndBlock{})
+			app.Commit()
+		}
+		assert.Equal(t, "(3 int)", queryCount(t, app))
+	})
+
+	t.Run("storage price", func(t *testing.T) {
+		t.Parallel()
+
+		// The new creator is only funded for the fees of the replayed txs
+		creator := crypto.AddressFromPreimage([]byte("creator"))
+		state, _, err := ExportGenesisState(db, ExportOptions{Creator: creator})
+		require.NoError(t, err)
+		state.VM.Params.StoragePrice = "100ugnot"
+
+		app := initExportedChain(t, state)
+		assert.Equal(t, "(0 int)", queryCount(t, app))
+
+		// The storage price is restored after the txs
+		qres := app.Query(abci.RequestQuery{Path: "params/vm:p:storage_price"})
+		require.True(t, qres.IsOK(), "Query response: %v", qres)
+		assert.Equal(t, `"100ugnot"`, string(qres.Data))
+	})
+
+	t.Run("invalid realm state", func(t *testing.T) {
+		t.Parallel()
+
+		state, _, err := ExportGenesisState(db, ExportOptions{Creator: addr, IncludeRealmState: true})
+		require.NoError(t, err)
+
+		// The realm state may only overwrite its own entries
+		state.Realms[0].Objects = append(state.Realms[0].Objects, vm.StoreEntry{Key: "pkg:gno.land/p/demo/incr"})
+
+		app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+		require.NoError(t, err)
+
+		resp := app.InitChain(abci.RequestInitChain{
+			ChainID:         "exported",
+			ConsensusParams: &abci.ConsensusParams{Block: defaultBlockParams()},
+			AppState:        state,
+		})
+		assert.False(t, resp.IsOK())
+		assert.Contains(t, resp.Error.Error(), "is not under")
+	})
+
+	t.Run("unknown height", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := ExportGenesisState(db, ExportOptions{Creator: addr, Height: 2})
+		assert.Error(t, err)
+
+		_, _, err = ExportGenesisState(db, ExportOptions{Creator: addr, Height: 2, IncludeRealmState: true})
+		assert.ErrorIs(t, err, ErrHistoricalRealmData)
+	})
+
+	t.Run("missing creator", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := ExportGenesisState(db, ExportOptions{})
+		assert.ErrorIs(t, err, ErrMissingCreator)
+	})
+
+	t.Run("empty state", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := ExportGenesisState(memdb.NewMemDB(), ExportOptions{Creator: addr})
+		assert.ErrorIs(t, err, ErrEmptyState)
+	})
+}
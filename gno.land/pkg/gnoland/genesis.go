@@ -103,6 +103,8 @@ func LoadGenesisParamsFile(path string, ggs *GnoGenesisState) error {
 				ggs.VM.Params.ChainDomain = value.(string)
 			case "sysnames_pkgpath":
 				ggs.VM.Params.SysNamesPkgPath = value.(string)
+			case "storage_price":
+				ggs.VM.Params.StoragePrice = value.(string)
 			default:
 				return errors.New("unexpected vm parameter " + name)
 			}
@@ -259,5 +261,79 @@ func ValidateGenState(state GnoGenesisState) error {
 		return fmt.Errorf("unable to validate vm state: %w", err)
 	}
 
+	// Vesting schedules must lock existing balances
+	balances := NewBalances()
+	for _, balance := range state.Balances {
+		balances[balance.Address] = balance
+	}
+	for _, schedule := range state.Vesting {
+		if err := schedule.Verify(); err != nil {
+			return fmt.Errorf("invalid vesting schedule for %s: %w", schedule.Address, err)
+		}
+
+		balance, ok := balances.Get(schedule.Address)
+		if !ok || !balance.Amount.IsAllGTE(schedule.Amount) {
+			return fmt.Errorf("%w: %s of %s", ErrVestingExceedsBalance, schedule.Amount, schedule.Address)
+		}
+	}
+
+	// Exported accounts are restored once, on valid coins
+	accounts := make(map[crypto.Address]struct{}, len(state.Accounts))
+	for _, acc := range state.Accounts {
+		if acc.Address.IsZero() {
+			return errors.New("invalid exported account with an empty address")
+		}
+		if _, ok := accounts[acc.Address]; ok {
+			return fmt.Errorf("duplicate exported account %s", acc.Address)
+		}
+		accounts[acc.Address] = struct{}{}
+
+		if !acc.Coins.IsValid() {
+			return fmt.Errorf("invalid coins %s of exported account %s", acc.Coins, acc.Address)
+		}
+		for _, key := range acc.SessionKeys {
+			if err := key.ValidateBasic(); err != nil {
+				return fmt.Errorf("invalid session key of exported account %s: %w", acc.Address, err)
+			}
+		}
+	}
+
+	// Exported realm states are restored over realms added by the genesis txs
+	added := make(map[string]bool)
+	for _, tx := range state.Txs {
+		for _, msg := range tx.Tx.Msgs {
+			if msg, ok := msg.(vmm.MsgAddPackage); ok && msg.Package != nil {
+				added[msg.Package.Path] = true
+			}
+		}
+	}
+	realms := make(map[string]struct{}, len(state.Realms))
+	for _, rs := range state.Realms {
+		if err := rs.Validate(); err != nil {
+			return fmt.Errorf("unable to validate exported realm state: %w", err)
+		}
+		if _, ok := realms[rs.Path]; ok {
+			return fmt.Errorf("duplicate exported realm state %s", rs.Path)
+		}
+		realms[rs.Path] = struct{}{}
+
+		if !added[rs.Path] {
+			return fmt.Errorf("exported realm %s is not added by the genesis txs", rs.Path)
+		}
+	}
+
+	// The storage deposits and scheduled calls are exported with the state
+	// of their realms
+	for _, sd := range state.VM.StorageDeposits {
+		if _, ok := realms[sd.RealmPath]; !ok {
+			return fmt.Errorf("storage deposit for %s without an exported realm state", sd.RealmPath)
+		}
+	}
+	for _, sc := range state.VM.ScheduledCalls {
+		if _, ok := realms[sc.PkgPath]; !ok {
+			return fmt.Errorf("scheduled call %d of %s without an exported realm state", sc.ID, sc.PkgPath)
+		}
+	}
+
 	return nil
 }
@@ -5,12 +5,45 @@ import (
 	"testing"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGenesis_Verify(t *testing.T) {
+	var (
+		addr     = crypto.AddressFromPreimage([]byte("addr"))
+		realm    = "gno.land/r/demo/realm"
+		addRealm = TxWithMetadata{Tx: std.Tx{Msgs: []std.Msg{
+			vm.NewMsgAddPackage(addr, realm, []*std.MemFile{{Name: "realm.gno", Body: "package realm"}}),
+		}}}
+		withExport = func(accounts []GenesisAccount, realms []vm.RealmState) GnoGenesisState {
+			state := DefaultGenState()
+			state.Txs = []TxWithMetadata{addRealm}
+			state.Accounts = accounts
+			state.Realms = realms
+			return state
+		}
+		withVMExport = func(realms []vm.RealmState, deposits []vm.GenesisStorageDeposit, calls []vm.ScheduledCall) GnoGenesisState {
+			state := withExport(nil, realms)
+			state.VM.StorageDeposits = deposits
+			state.VM.ScheduledCalls = calls
+			return state
+		}
+		deposit = vm.GenesisStorageDeposit{RealmPath: realm, Depositor: addr, Storage: 10, Deposit: std.NewCoins(std.NewCoin("ugnot", 1000))}
+		call    = vm.ScheduledCall{ID: 1, PkgPath: realm, Func: "Tick", Height: 10, GasLimit: 1000}
+		key     = vm.SessionKey{
+			PubKey:       ed25519.GenPrivKey().PubKey(),
+			AllowedPaths: []string{realm},
+			MaxFee:       std.NewCoin("ugnot", 1),
+			SpendLimit:   std.NewCoins(std.NewCoin("ugnot", 1)),
+			ExpiresAt:    10,
+		}
+	)
+
 	tests := []struct {
 		name      string
 		genesis   GnoGenesisState
@@ -48,6 +81,72 @@ func TestGenesis_Verify(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"exported accounts and realms",
+			withExport(
+				[]GenesisAccount{{Address: addr, Coins: std.NewCoins(std.NewCoin("ugnot", 1))}},
+				[]vm.RealmState{{Path: realm}},
+			),
+			false,
+		},
+		{"empty exported account address", withExport([]GenesisAccount{{}}, nil), true},
+		{
+			"duplicate exported account",
+			withExport([]GenesisAccount{{Address: addr}, {Address: addr}}, nil),
+			true,
+		},
+		{
+			"invalid exported account coins",
+			withExport([]GenesisAccount{{Address: addr, Coins: std.Coins{{Denom: "ugnot", Amount: -1}}}}, nil),
+			true,
+		},
+		{"exported package state", withExport(nil, []vm.RealmState{{Path: "gno.land/p/demo/pkg"}}), true},
+		{"exported realm not added", withExport(nil, []vm.RealmState{{Path: "gno.land/r/demo/other"}}), true},
+		{
+			"duplicate exported realm",
+			withExport(nil, []vm.RealmState{{Path: realm}, {Path: realm}}),
+			true,
+		},
+		{
+			"exported realm entry outside the realm",
+			withExport(nil, []vm.RealmState{{Path: realm, Objects: []vm.StoreEntry{{Key: "pkg:" + realm}}}}),
+			true,
+		},
+		{
+			"exported session keys",
+			withExport([]GenesisAccount{{Address: addr, SessionKeys: []vm.SessionKey{key}}}, nil),
+			false,
+		},
+		{
+			"invalid exported session key",
+			withExport([]GenesisAccount{{Address: addr, SessionKeys: []vm.SessionKey{{PubKey: key.PubKey}}}}, nil),
+			true,
+		},
+		{
+			"exported storage deposits and scheduled calls",
+			withVMExport([]vm.RealmState{{Path: realm}}, []vm.GenesisStorageDeposit{deposit}, []vm.ScheduledCall{call}),
+			false,
+		},
+		{
+			"exported storage deposit without realm state",
+			withVMExport(nil, []vm.GenesisStorageDeposit{deposit}, nil),
+			true,
+		},
+		{
+			"exported scheduled call without realm state",
+			withVMExport(nil, nil, []vm.ScheduledCall{call}),
+			true,
+		},
+		{
+			"duplicate exported storage deposit",
+			withVMExport([]vm.RealmState{{Path: realm}}, []vm.GenesisStorageDeposit{deposit, deposit}, nil),
+			true,
+		},
+		{
+			"exported scheduled call without a height or timestamp",
+			withVMExport([]vm.RealmState{{Path: realm}}, nil, []vm.ScheduledCall{{ID: 1, PkgPath: realm, Func: "Tick", GasLimit: 1000}}),
+			true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
@@ -59,6 +60,7 @@ type mockVMKeeper struct {
 	loadStdlibCachedFn          func(sdk.Context, string)
 	makeGnoTransactionStoreFn   func(ctx sdk.Context) sdk.Context
 	commitGnoTransactionStoreFn func(ctx sdk.Context)
+	runScheduledCallsFn         func(ctx sdk.Context) []abci.Event
 }
 
 func (m *mockVMKeeper) AddPackage(ctx sdk.Context, msg vm.MsgAddPackage) error {
@@ -120,6 +122,17 @@ func (m *mockVMKeeper) CommitGnoTransactionStore(ctx sdk.Context) {
 
 func (m *mockVMKeeper) InitGenesis(ctx sdk.Context, gs vm.GenesisState) {}
 
+func (m *mockVMKeeper) RestoreRealmState(ctx sdk.Context, rs vm.RealmState) error {
+	return nil
+}
+
+func (m *mockVMKeeper) RunScheduledCalls(ctx sdk.Context) []abci.Event {
+	if m.runScheduledCallsFn != nil {
+		return m.runScheduledCallsFn(ctx)
+	}
+	return nil
+}
+
 type mockBankKeeper struct{}
 
 func (m *mockBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []bank.Input, outputs []bank.Output) error {
@@ -145,6 +158,9 @@ func (m *mockBankKeeper) AddCoins(ctx sdk.Context, addr crypto.Address, amt std.
 func (m *mockBankKeeper) InitGenesis(ctx sdk.Context, data bank.GenesisState)     {}
 func (m *mockBankKeeper) GetParams(ctx sdk.Context) bank.Params                   { return bank.Params{} }
 func (m *mockBankKeeper) GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins { return nil }
+func (m *mockBankKeeper) SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins {
+	return nil
+}
 func (m *mockBankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error {
 	return nil
 }
@@ -153,6 +169,9 @@ func (m *mockBankKeeper) HasCoins(ctx sdk.Context, addr crypto.Address, amt std.
 	return true
 }
 
+func (m *mockBankKeeper) TotalCoin(ctx sdk.Context, denom string) int64 { return 0 }
+func (m *mockBankKeeper) TotalSupply(ctx sdk.Context) std.Coins         { return nil }
+
 type mockAuthKeeper struct{}
 
 func (m *mockAuthKeeper) NewAccountWithAddress(ctx sdk.Context, addr crypto.Address) std.Account {
@@ -18,6 +18,8 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 type InMemoryNodeConfig struct {
@@ -27,6 +29,10 @@ type InMemoryNodeConfig struct {
 	DB                      db.DB     // will be initialized if nil
 	VMOutput                io.Writer // optional
 	SkipGenesisVerification bool
+	PruningOptions          store.PruningOptions // optional, default to keeping only the latest state
+	SnapshotDB              db.DB                // optional, to serve state snapshots
+	SnapshotOptions         snapshots.Options    // optional
+	CommitBaseStore         bool                 // optional, required to serve state snapshots
 
 	// If StdlibDir not set, then it's filepath.Join(TMConfig.RootDir, "gnovm", "stdlibs")
 	InitChainerConfig
@@ -118,6 +124,11 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 		InitChainerConfig:       cfg.InitChainerConfig,
 		VMOutput:                cfg.VMOutput,
 		SkipGenesisVerification: cfg.SkipGenesisVerification,
+		PruningOptions:          cfg.PruningOptions,
+		SnapshotDB:              cfg.SnapshotDB,
+		SnapshotOptions:         cfg.SnapshotOptions,
+		MinRetainBlocks:         cfg.TMConfig.MinRetainBlocks,
+		CommitBaseStore:         cfg.CommitBaseStore,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing new app: %w", err)
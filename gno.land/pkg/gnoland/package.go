@@ -11,6 +11,13 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 ).WithDependencies().WithTypes(
 	&GnoAccount{}, "Account",
 	GnoGenesisState{}, "GenesisState",
+	GenesisAccount{}, "GenesisAccount",
 	TxWithMetadata{}, "TxWithMetadata",
 	GnoTxMetadata{}, "GnoTxMetadata",
+	BaseVestingAccount{}, "BaseVestingAccount",
+	&ContinuousVestingAccount{}, "ContinuousVestingAccount",
+	&DelayedVestingAccount{}, "DelayedVestingAccount",
+	&PeriodicVestingAccount{}, "PeriodicVestingAccount",
+	VestingPeriod{}, "VestingPeriod",
+	VestingSchedule{}, "VestingSchedule",
 ))
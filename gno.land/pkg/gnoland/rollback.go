@@ -0,0 +1,67 @@
+package gnoland
+
+import (
+	"fmt"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+// AppStateHeight returns the height of the gno.land application state
+// stored in db.
+func AppStateHeight(db dbm.DB) (int64, error) {
+	ms, err := loadLatestAppStore(db)
+	if err != nil {
+		return 0, err
+	}
+	return ms.LastCommitID().Version, nil
+}
+
+// RollbackAppState reverts the gno.land application state stored in db to
+// the given height, and returns the app hash of that height. The state is
+// kept as it is if it already is at that height.
+//
+// The height must not have been pruned. The changes of the base store, which
+// has the VM objects, types and realm states, are only kept since the base
+// store is versioned: older states can't be reverted.
+func RollbackAppState(db dbm.DB, height int64) ([]byte, error) {
+	ms, err := loadLatestAppStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := ms.LastCommitID().Version
+	switch {
+	case latest == 0:
+		return nil, ErrEmptyState
+	case latest < height:
+		return nil, fmt.Errorf("the application state is at height %d, below %d", latest, height)
+	case latest == height:
+		return ms.LastCommitID().Hash, nil
+	}
+
+	if err := ms.RollbackToVersion(height); err != nil {
+		return nil, fmt.Errorf("unable to roll back to height %d, %w", height, err)
+	}
+
+	return ms.LastCommitID().Hash, nil
+}
+
+// loadLatestAppStore loads the latest version of the stores of the gno.land
+// application in db.
+func loadLatestAppStore(db dbm.DB) (store.CommitMultiStore, error) {
+	baseStore, err := baseStoreConstructor(db, committedBaseStore(db))
+	if err != nil {
+		return nil, err
+	}
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(store.NewStoreKey("main"), iavl.StoreConstructor, db)
+	ms.MountStoreWithDB(store.NewStoreKey("base"), baseStore, db)
+
+	if err := ms.LoadLatestVersion(); err != nil {
+		return nil, fmt.Errorf("unable to load the latest state, %w", err)
+	}
+	return ms, nil
+}
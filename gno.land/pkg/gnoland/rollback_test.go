@@ -0,0 +1,91 @@
+package gnoland
+
+import (
+	"testing"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	rollbackMainKey = store.NewStoreKey("main")
+	rollbackBaseKey = store.NewStoreKey("base")
+)
+
+func newRollbackTestStore(t *testing.T, db dbm.DB) store.CommitMultiStore {
+	t.Helper()
+
+	ms := store.NewCommitMultiStore(db)
+	ms.SetStoreOptions(store.StoreOptions{PruningOptions: store.PruneNothing})
+	ms.MountStoreWithDB(rollbackMainKey, iavl.StoreConstructor, db)
+	ms.MountStoreWithDB(rollbackBaseKey, hashdb.UnhashedStoreConstructor, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	return ms
+}
+
+func TestRollbackAppState(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	ms := newRollbackTestStore(t, db)
+
+	commits := make([]store.CommitID, 0, 3)
+	for _, value := range []string{"value1", "value2", "value3"} {
+		ms.GetStore(rollbackMainKey).Set([]byte("key"), []byte(value))
+		ms.GetStore(rollbackBaseKey).Set([]byte("key"), []byte(value))
+		commits = append(commits, ms.Commit())
+	}
+
+	height, err := AppStateHeight(db)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), height)
+
+	// The state is kept at its own height
+	hash, err := RollbackAppState(db, 3)
+	require.NoError(t, err)
+	assert.Equal(t, commits[2].Hash, hash)
+
+	_, err = RollbackAppState(db, 4)
+	assert.ErrorContains(t, err, "below 4")
+
+	// Both stores are reverted
+	hash, err = RollbackAppState(db, 2)
+	require.NoError(t, err)
+	assert.Equal(t, commits[1].Hash, hash)
+
+	ms = newRollbackTestStore(t, db)
+	assert.Equal(t, commits[1], ms.LastCommitID())
+	assert.Equal(t, []byte("value2"), ms.GetStore(rollbackMainKey).Get([]byte("key")))
+	assert.Equal(t, []byte("value2"), ms.GetStore(rollbackBaseKey).Get([]byte("key")))
+
+	// The heights committed before the base store was versioned can't be
+	// reverted
+	legacy := memdb.NewMemDB()
+	ms = store.NewCommitMultiStore(legacy)
+	ms.MountStoreWithDB(rollbackMainKey, iavl.StoreConstructor, legacy)
+	ms.MountStoreWithDB(rollbackBaseKey, dbadapter.StoreConstructor, legacy)
+	require.NoError(t, ms.LoadLatestVersion())
+	ms.Commit()
+	ms.Commit()
+
+	_, err = RollbackAppState(legacy, 1)
+	assert.ErrorContains(t, err, "not versioned")
+
+	height, err = AppStateHeight(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), height)
+}
+
+func TestRollbackAppState_EmptyState(t *testing.T) {
+	t.Parallel()
+
+	_, err := RollbackAppState(memdb.NewMemDB(), 1)
+	assert.ErrorIs(t, err, ErrEmptyState)
+}
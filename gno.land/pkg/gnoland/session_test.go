@@ -0,0 +1,245 @@
+package gnoland
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestSessionKeys(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithValue(auth.AuthParamsContextKey{}, auth.DefaultParams())
+	require.NoError(t, env.acck.SetParams(ctx, auth.DefaultParams()))
+	vmk := vm.NewVMKeeper(nil, nil, env.acck, env.bankk, nil)
+	anteHandler := auth.NewAnteHandler(
+		env.acck, env.bankk, auth.DefaultSigVerificationGasConsumer, auth.AnteOptions{})
+
+	var (
+		priv    = secp256k1.GenPrivKey()
+		addr    = priv.PubKey().Address()
+		session = ed25519.GenPrivKey()
+		realm   = "gno.land/r/demo/game"
+	)
+
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	require.NoError(t, acc.SetCoins(std.NewCoins(std.NewCoin(ugnot.Denom, 10_000_000))))
+	env.acck.SetAccount(ctx, acc)
+
+	// A key which is not registered cannot sign for the account.
+	fee := std.NewFee(100_000, std.NewCoin(ugnot.Denom, 1000))
+	call := func(pkgPath string, send int64) []std.Msg {
+		msg := vm.NewMsgCall(addr, std.NewCoins(std.NewCoin(ugnot.Denom, send)), pkgPath, "Play", nil)
+		msg.MaxDeposit = std.NewCoins(std.NewCoin(ugnot.Denom, 10))
+		return []std.Msg{msg}
+	}
+	signSession := func(msgs []std.Msg) std.Tx {
+		acc := env.acck.GetAccount(ctx, addr)
+		return tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{session},
+			[]uint64{acc.GetAccountNumber()}, []uint64{acc.GetSequence()}, fee)
+	}
+	_, res, abort := anteHandler(ctx, signSession(call(realm, 0)), false)
+	require.True(t, abort)
+	assert.ErrorAs(t, res.Error, &std.UnauthorizedError{})
+
+	// Register the session key.
+	sk := vm.SessionKey{
+		PubKey:       session.PubKey(),
+		AllowedPaths: []string{realm},
+		MaxFee:       std.NewCoin(ugnot.Denom, 1000),
+		SpendLimit:   std.NewCoins(std.NewCoin(ugnot.Denom, 100)),
+		ExpiresAt:    ctx.BlockHeight() + 1,
+	}
+	res = vm.NewHandler(vmk).Process(ctx, vm.MsgAddSessionKey{Creator: addr, SessionKey: sk})
+	require.True(t, res.IsOK(), res.Log)
+
+	// The session key can call the allowed realm, spending from its limit the
+	// sent coins; the storage deposit is charged by the VM keeper, which is
+	// told which session key signed.
+	newCtx, res, abort := anteHandler(ctx, signSession(call(realm, 50)), false)
+	require.False(t, abort, res.Log)
+	assert.Equal(t, map[crypto.Address]crypto.PubKey{addr: session.PubKey()},
+		newCtx.Value(auth.SessionKeysContextKey{}))
+
+	gacc := env.acck.GetAccount(ctx, addr).(*GnoAccount)
+	require.Len(t, gacc.SessionKeys, 1)
+	assert.Equal(t, std.NewCoins(std.NewCoin(ugnot.Denom, 50)), gacc.SessionKeys[0].SpendLimit)
+	assert.Nil(t, gacc.GetPubKey(), "session key must not become the account public key")
+
+	// The limit, which must also cover the max storage deposit, cannot be
+	// exceeded, and other realms or messages are rejected.
+	for _, msgs := range [][]std.Msg{
+		call(realm, 45),
+		call("gno.land/r/demo/bank", 0),
+		{bank.NewMsgSend(addr, addr, std.NewCoins(std.NewCoin(ugnot.Denom, 1)))},
+	} {
+		_, res, abort = anteHandler(ctx, signSession(msgs), false)
+		assert.True(t, abort)
+		assert.False(t, res.IsOK())
+	}
+
+	// The account key is not restricted.
+	tx := tu.NewTestTx(t, ctx.ChainID(), call("gno.land/r/demo/bank", 0), []crypto.PrivKey{priv},
+		[]uint64{gacc.GetAccountNumber()}, []uint64{gacc.GetSequence()}, fee)
+	newCtx, res, abort = anteHandler(ctx, tx, false)
+	require.False(t, abort, res.Log)
+	assert.Nil(t, newCtx.Value(auth.SessionKeysContextKey{}))
+
+	// The session key expires.
+	expiredCtx := ctx.WithBlockHeader(&bft.Header{Height: sk.ExpiresAt + 1, ChainID: ctx.ChainID()})
+	_, res, abort = anteHandler(expiredCtx, signSession(call(realm, 0)), false)
+	assert.True(t, abort)
+	assert.ErrorAs(t, res.Error, &std.UnauthorizedError{})
+
+	// Once revoked, the session key can no longer sign.
+	res = vm.NewHandler(vmk).Process(ctx, vm.MsgRevokeSessionKey{Creator: addr, PubKey: session.PubKey()})
+	require.True(t, res.IsOK(), res.Log)
+	assert.Empty(t, env.acck.GetAccount(ctx, addr).(*GnoAccount).SessionKeys)
+
+	_, res, abort = anteHandler(ctx, signSession(call(realm, 0)), false)
+	assert.True(t, abort)
+	assert.ErrorAs(t, res.Error, &std.UnauthorizedError{})
+
+	res = vm.NewHandler(vmk).Process(ctx, vm.MsgRevokeSessionKey{Creator: addr, PubKey: session.PubKey()})
+	assert.False(t, res.IsOK())
+}
+
+func TestSessionKeys_StorageDeposit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key     = getDummyKey(t)
+		addr    = key.PubKey().Address()
+		session = ed25519.GenPrivKey()
+		chainID = "test"
+		realm   = "gno.land/r/demo/list"
+		limit   = std.NewCoins(std.NewCoin(ugnot.Denom, 1e9))
+	)
+
+	app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+	require.NoError(t, err)
+
+	appState := DefaultGenState()
+	appState.Balances = []Balance{{Address: addr, Amount: std.NewCoins(std.NewCoin(ugnot.Denom, 1e10))}}
+	appState.Txs = []TxWithMetadata{
+		{Tx: newExportTestAddPkgTx(addr, realm, `package list
+
+var items []string
+
+func Add(item string) { crossing(); items = append(items, item) }`)},
+		{Tx: std.Tx{
+			Msgs: []std.Msg{vm.MsgAddSessionKey{Creator: addr, SessionKey: vm.SessionKey{
+				PubKey:       session.PubKey(),
+				AllowedPaths: []string{realm},
+				MaxFee:       std.NewCoin(ugnot.Denom, 2e6),
+				SpendLimit:   limit,
+				ExpiresAt:    100,
+			}}},
+			Fee:        std.NewFee(1e6, std.NewCoin(ugnot.Denom, 1e6)),
+			Signatures: []std.Signature{{}},
+		}},
+	}
+	resp := app.InitChain(abci.RequestInitChain{
+		Time:            time.Now(),
+		ChainID:         chainID,
+		ConsensusParams: &abci.ConsensusParams{Block: defaultBlockParams()},
+		AppState:        appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+
+	// The session key calls the realm with a max deposit well above the
+	// storage deposit of the call.
+	msg := vm.NewMsgCall(addr, nil, realm, "Add", []string{"item"})
+	msg.MaxDeposit = std.NewCoins(std.NewCoin(ugnot.Denom, 5e8))
+	tx := std.Tx{Msgs: []std.Msg{msg}, Fee: std.NewFee(1e7, std.NewCoin(ugnot.Denom, 2e6))}
+	signBytes, err := tx.GetSignBytes(chainID, 0, 0)
+	require.NoError(t, err)
+	sig, err := session.Sign(signBytes)
+	require.NoError(t, err)
+	tx.Signatures = []std.Signature{{PubKey: session.PubKey(), Signature: sig}}
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: chainID, Height: 1, Time: time.Now()}})
+	dres := app.DeliverTx(abci.RequestDeliverTx{Tx: amino.MustMarshal(tx)})
+	require.True(t, dres.IsOK(), "DeliverTx response: %v", dres)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	qres := app.Query(abci.RequestQuery{
+		Path: "bank/balances/" + gno.DeriveStorageDepositCryptoAddr(realm).String(),
+	})
+	require.True(t, qres.IsOK(), "Query response: %v", qres)
+	var deposit std.Coins
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &deposit))
+
+	// Only the deposit actually locked is charged to the spend limit.
+	require.True(t, msg.MaxDeposit.IsAllGT(deposit))
+	qres = app.Query(abci.RequestQuery{Path: "auth/accounts/" + addr.String()})
+	require.True(t, qres.IsOK(), "Query response: %v", qres)
+	var acc GnoAccount
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &acc))
+	require.Len(t, acc.SessionKeys, 1)
+	assert.Equal(t, limit.Sub(deposit), acc.SessionKeys[0].SpendLimit)
+}
+
+func TestAddSessionKey_Limit(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	vmk := vm.NewVMKeeper(nil, nil, env.acck, env.bankk, nil)
+	ctx := env.ctx
+
+	addr := crypto.AddressFromPreimage([]byte("creator"))
+	env.acck.SetAccount(ctx, env.acck.NewAccountWithAddress(ctx, addr))
+
+	newKey := func(expiresAt int64) vm.MsgAddSessionKey {
+		return vm.MsgAddSessionKey{
+			Creator: addr,
+			SessionKey: vm.SessionKey{
+				PubKey:       ed25519.GenPrivKey().PubKey(),
+				AllowedPaths: []string{"gno.land/r/demo/game"},
+				ExpiresAt:    expiresAt,
+			},
+		}
+	}
+
+	// Expired keys don't count towards the limit and are pruned.
+	require.NoError(t, vmk.AddSessionKey(ctx, newKey(5)))
+	ctx = ctx.WithBlockHeader(&bft.Header{Height: 10, ChainID: ctx.ChainID()})
+	err := vmk.AddSessionKey(ctx, newKey(5))
+	assert.ErrorAs(t, err, &std.UnauthorizedError{})
+
+	for range vm.MaxSessionKeys {
+		require.NoError(t, vmk.AddSessionKey(ctx, newKey(20)))
+	}
+	err = vmk.AddSessionKey(ctx, newKey(20))
+	assert.ErrorAs(t, err, &std.UnauthorizedError{})
+
+	// Replacing a registered key is allowed.
+	keys := env.acck.GetAccount(ctx, addr).(*GnoAccount).SessionKeys
+	require.Len(t, keys, vm.MaxSessionKeys)
+	replace := newKey(30)
+	replace.SessionKey.PubKey = keys[0].PubKey
+	require.NoError(t, vmk.AddSessionKey(ctx, replace))
+
+	keys = env.acck.GetAccount(ctx, addr).(*GnoAccount).SessionKeys
+	require.Len(t, keys, vm.MaxSessionKeys)
+	assert.Equal(t, replace.SessionKey, keys[len(keys)-1])
+}
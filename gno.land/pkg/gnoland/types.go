@@ -41,11 +41,15 @@ func (bs BitSet) String() string {
 	return fmt.Sprintf("0x%016X", uint64(bs)) // Show all 64 bits
 }
 
-var _ std.AccountUnrestricter = &GnoAccount{}
+var (
+	_ std.AccountUnrestricter = &GnoAccount{}
+	_ vm.SessionKeyAccount    = &GnoAccount{}
+)
 
 type GnoAccount struct {
 	std.BaseAccount
-	Attributes BitSet `json:"attributes" yaml:"attributes"`
+	Attributes  BitSet          `json:"attributes" yaml:"attributes"`
+	SessionKeys []vm.SessionKey `json:"session_keys,omitempty" yaml:"session_keys,omitempty"`
 }
 
 // validFlags defines the set of all valid flags that can be used with BitSet.
@@ -90,6 +94,35 @@ func (ga *GnoAccount) IsUnrestricted() bool {
 	return ga.hasFlag(flagUnrestricted)
 }
 
+// GetSessionKeys returns the session keys registered on the account.
+func (ga *GnoAccount) GetSessionKeys() []vm.SessionKey {
+	return ga.SessionKeys
+}
+
+// SetSessionKeys replaces the session keys registered on the account.
+func (ga *GnoAccount) SetSessionKeys(keys []vm.SessionKey) {
+	ga.SessionKeys = keys
+}
+
+// UseSessionKey checks that the session key pubKey is registered on the
+// account and allowed to sign tx in the block at height, and records the coins
+// it spends.
+func (ga *GnoAccount) UseSessionKey(pubKey crypto.PubKey, tx std.Tx, height int64) error {
+	for i, key := range ga.SessionKeys {
+		if !key.PubKey.Equals(pubKey) {
+			continue
+		}
+		left, err := key.Accept(tx, height)
+		if err != nil {
+			return err
+		}
+		ga.SessionKeys[i] = left
+		return nil
+	}
+	return std.ErrUnauthorized(
+		fmt.Sprintf("%s is not a session key of %s", pubKey.Address(), ga.Address))
+}
+
 // String implements fmt.Stringer
 func (ga *GnoAccount) String() string {
 	return fmt.Sprintf("%s\n  Attributes:	 %s",
@@ -104,10 +137,26 @@ func ProtoGnoAccount() std.Account {
 
 type GnoGenesisState struct {
 	Balances []Balance         `json:"balances"`
+	Vesting  []VestingSchedule `json:"vesting,omitempty"`
 	Txs      []TxWithMetadata  `json:"txs"`
 	Auth     auth.GenesisState `json:"auth"`
 	Bank     bank.GenesisState `json:"bank"`
 	VM       vm.GenesisState   `json:"vm"`
+
+	// Accounts and Realms are restored once the genesis txs are replayed.
+	// They are set by exports of the chain state (see [ExportGenesisState]).
+	Accounts []GenesisAccount `json:"accounts,omitempty"`
+	Realms   []vm.RealmState  `json:"realms,omitempty"`
+}
+
+// GenesisAccount is the exported state of an account. It is restored after
+// the genesis txs, overriding the coins and sequence they changed. The expiry
+// heights of its session keys are relative to the exported height.
+type GenesisAccount struct {
+	Address     crypto.Address  `json:"address"`
+	Coins       std.Coins       `json:"coins"`
+	Sequence    uint64          `json:"sequence"`
+	SessionKeys []vm.SessionKey `json:"session_keys,omitempty"`
 }
 
 type TxWithMetadata struct {
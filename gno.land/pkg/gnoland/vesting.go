@@ -0,0 +1,223 @@
+package gnoland
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Vesting schedule types.
+const (
+	VestingContinuous = "continuous"
+	VestingDelayed    = "delayed"
+	VestingPeriodic   = "periodic"
+)
+
+var (
+	ErrVestingEmptyAddress   = errors.New("vesting address is empty")
+	ErrVestingInvalidAmount  = errors.New("vesting amount is invalid")
+	ErrVestingInvalidType    = errors.New("vesting type is invalid")
+	ErrVestingInvalidEnd     = errors.New("vesting end must be after its start")
+	ErrVestingInvalidPeriods = errors.New("vesting periods are invalid")
+	ErrVestingExceedsBalance = errors.New("vesting amount exceeds the genesis balance")
+)
+
+// VestingPeriod is a period of a periodic vesting schedule, at the end of
+// which Amount is released.
+type VestingPeriod struct {
+	Length int64     `json:"length" yaml:"length"` // seconds, or blocks
+	Amount std.Coins `json:"amount" yaml:"amount"`
+}
+
+// VestingSchedule describes how the coins of a genesis account are released.
+//
+// Start and End are unix timestamps in seconds, or block heights if ByHeight
+// is set. Continuous schedules release Amount linearly between Start and End,
+// delayed schedules release it all at End, and periodic schedules release the
+// amount of each period at its end, the first one beginning at Start.
+type VestingSchedule struct {
+	Address  crypto.Address  `json:"address"`
+	Type     string          `json:"type"`
+	Amount   std.Coins       `json:"amount"`
+	ByHeight bool            `json:"by_height,omitempty"`
+	Start    int64           `json:"start,omitempty"`
+	End      int64           `json:"end,omitempty"`
+	Periods  []VestingPeriod `json:"periods,omitempty"`
+}
+
+func (vs *VestingSchedule) Verify() error {
+	if vs.Address.IsZero() {
+		return ErrVestingEmptyAddress
+	}
+
+	if vs.Amount.Len() == 0 || !vs.Amount.IsValid() {
+		return fmt.Errorf("%w: %q", ErrVestingInvalidAmount, vs.Amount)
+	}
+
+	switch vs.Type {
+	case VestingContinuous, VestingDelayed:
+		if len(vs.Periods) != 0 {
+			return fmt.Errorf("%w: only periodic schedules have periods", ErrVestingInvalidPeriods)
+		}
+		if vs.End <= vs.Start {
+			return ErrVestingInvalidEnd
+		}
+	case VestingPeriodic:
+		if len(vs.Periods) == 0 {
+			return fmt.Errorf("%w: no periods", ErrVestingInvalidPeriods)
+		}
+		total := std.NewCoins()
+		for _, p := range vs.Periods {
+			if p.Length <= 0 || p.Amount.Len() == 0 || !p.Amount.IsValid() {
+				return fmt.Errorf("%w: %d=%s", ErrVestingInvalidPeriods, p.Length, p.Amount)
+			}
+			total = total.Add(p.Amount)
+		}
+		if total.String() != vs.Amount.String() { // NOTE: Coins.IsEqual panics on mismatching denoms.
+			return fmt.Errorf("%w: periods amount %s != %s", ErrVestingInvalidPeriods, total, vs.Amount)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrVestingInvalidType, vs.Type)
+	}
+
+	return nil
+}
+
+// NewVestingAccount returns acc as a vesting account following the schedule.
+func (vs *VestingSchedule) NewVestingAccount(acc *GnoAccount) std.Account {
+	base := BaseVestingAccount{
+		GnoAccount:      *acc,
+		OriginalVesting: vs.Amount,
+		ByHeight:        vs.ByHeight,
+		Start:           vs.Start,
+		End:             vs.End,
+	}
+
+	switch vs.Type {
+	case VestingContinuous:
+		return &ContinuousVestingAccount{BaseVestingAccount: base}
+	case VestingDelayed:
+		return &DelayedVestingAccount{BaseVestingAccount: base}
+	case VestingPeriodic:
+		base.End = vs.Start
+		for _, p := range vs.Periods {
+			base.End += p.Length
+		}
+		return &PeriodicVestingAccount{BaseVestingAccount: base, Periods: vs.Periods}
+	default:
+		panic(fmt.Sprintf("unknown vesting type %q", vs.Type))
+	}
+}
+
+// BaseVestingAccount is the common part of the vesting accounts.
+type BaseVestingAccount struct {
+	GnoAccount
+	OriginalVesting std.Coins `json:"original_vesting" yaml:"original_vesting"`
+	ByHeight        bool      `json:"by_height" yaml:"by_height"`
+	Start           int64     `json:"start" yaml:"start"`
+	End             int64     `json:"end" yaml:"end"`
+}
+
+// GetOriginalVesting implements std.VestingAccount.
+func (bva *BaseVestingAccount) GetOriginalVesting() std.Coins {
+	return bva.OriginalVesting
+}
+
+// now returns the current time or height, depending on the schedule unit.
+func (bva *BaseVestingAccount) now(height int64, blockTime time.Time) int64 {
+	if bva.ByHeight {
+		return height
+	}
+	return blockTime.Unix()
+}
+
+// String implements fmt.Stringer
+func (bva *BaseVestingAccount) String() string {
+	return fmt.Sprintf("%s\n  OriginalVesting: %s\n  ByHeight:        %t\n  Start:           %d\n  End:             %d",
+		bva.GnoAccount.String(),
+		bva.OriginalVesting,
+		bva.ByHeight,
+		bva.Start,
+		bva.End,
+	)
+}
+
+var (
+	_ std.VestingAccount = &ContinuousVestingAccount{}
+	_ std.VestingAccount = &DelayedVestingAccount{}
+	_ std.VestingAccount = &PeriodicVestingAccount{}
+)
+
+// ContinuousVestingAccount releases its original vesting linearly between
+// Start and End.
+type ContinuousVestingAccount struct {
+	BaseVestingAccount
+}
+
+// GetVestingCoins implements std.VestingAccount.
+func (cva *ContinuousVestingAccount) GetVestingCoins(height int64, blockTime time.Time) std.Coins {
+	now := cva.now(height, blockTime)
+	switch {
+	case now <= cva.Start:
+		return cva.OriginalVesting
+	case now >= cva.End:
+		return std.NewCoins()
+	}
+
+	// unvested = original * (end - now) / (end - start), rounded up.
+	remaining, total := big.NewInt(cva.End-now), big.NewInt(cva.End-cva.Start)
+	unvested := std.NewCoins()
+	for _, coin := range cva.OriginalVesting {
+		amt := new(big.Int).Mul(big.NewInt(coin.Amount), remaining)
+		amt.Add(amt, new(big.Int).Sub(total, big.NewInt(1)))
+		amt.Quo(amt, total)
+		if amt.Sign() > 0 {
+			unvested = append(unvested, std.NewCoin(coin.Denom, amt.Int64()))
+		}
+	}
+	return unvested
+}
+
+// DelayedVestingAccount releases all of its original vesting at End.
+type DelayedVestingAccount struct {
+	BaseVestingAccount
+}
+
+// GetVestingCoins implements std.VestingAccount.
+func (dva *DelayedVestingAccount) GetVestingCoins(height int64, blockTime time.Time) std.Coins {
+	if dva.now(height, blockTime) >= dva.End {
+		return std.NewCoins()
+	}
+	return dva.OriginalVesting
+}
+
+// PeriodicVestingAccount releases the amount of each of its periods at the end
+// of the period. The first period begins at Start.
+type PeriodicVestingAccount struct {
+	BaseVestingAccount
+	Periods []VestingPeriod `json:"periods" yaml:"periods"`
+}
+
+// GetVestingCoins implements std.VestingAccount.
+func (pva *PeriodicVestingAccount) GetVestingCoins(height int64, blockTime time.Time) std.Coins {
+	now := pva.now(height, blockTime)
+	unvested := pva.OriginalVesting
+	end := pva.Start
+	for _, p := range pva.Periods {
+		end += p.Length
+		if now < end {
+			break
+		}
+		unvested = unvested.Sub(p.Amount)
+	}
+	return unvested
+}
+
+// String implements fmt.Stringer
+func (pva *PeriodicVestingAccount) String() string {
+	return fmt.Sprintf("%s\n  Periods:         %v", pva.BaseVestingAccount.String(), pva.Periods)
+}
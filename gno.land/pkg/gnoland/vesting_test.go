@@ -0,0 +1,120 @@
+package gnoland
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingSchedule_Verify(t *testing.T) {
+	t.Parallel()
+
+	addr := crypto.AddressFromPreimage([]byte("test1"))
+	amount := std.MustParseCoins("1000ugnot")
+
+	testTable := []struct {
+		name        string
+		schedule    VestingSchedule
+		expectedErr error
+	}{
+		{
+			"valid continuous",
+			VestingSchedule{Address: addr, Type: VestingContinuous, Amount: amount, Start: 10, End: 20},
+			nil,
+		},
+		{
+			"valid periodic",
+			VestingSchedule{Address: addr, Type: VestingPeriodic, Amount: amount, Periods: []VestingPeriod{
+				{Length: 10, Amount: std.MustParseCoins("400ugnot")},
+				{Length: 10, Amount: std.MustParseCoins("600ugnot")},
+			}},
+			nil,
+		},
+		{
+			"empty address",
+			VestingSchedule{Type: VestingDelayed, Amount: amount, End: 20},
+			ErrVestingEmptyAddress,
+		},
+		{
+			"empty amount",
+			VestingSchedule{Address: addr, Type: VestingDelayed, End: 20},
+			ErrVestingInvalidAmount,
+		},
+		{
+			"unknown type",
+			VestingSchedule{Address: addr, Type: "linear", Amount: amount, End: 20},
+			ErrVestingInvalidType,
+		},
+		{
+			"end before start",
+			VestingSchedule{Address: addr, Type: VestingContinuous, Amount: amount, Start: 20, End: 10},
+			ErrVestingInvalidEnd,
+		},
+		{
+			"periods not matching amount",
+			VestingSchedule{Address: addr, Type: VestingPeriodic, Amount: amount, Periods: []VestingPeriod{
+				{Length: 10, Amount: std.MustParseCoins("400foo")},
+			}},
+			ErrVestingInvalidPeriods,
+		},
+		{
+			"empty period",
+			VestingSchedule{Address: addr, Type: VestingPeriodic, Amount: amount, Periods: []VestingPeriod{
+				{Length: 0, Amount: amount},
+			}},
+			ErrVestingInvalidPeriods,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.schedule.Verify()
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVestingAccounts_GetVestingCoins(t *testing.T) {
+	t.Parallel()
+
+	addr := crypto.AddressFromPreimage([]byte("test1"))
+	amount := std.MustParseCoins("1000ugnot")
+	acc := &GnoAccount{BaseAccount: std.BaseAccount{Address: addr, Coins: amount}}
+	at := func(sec int64) time.Time { return time.Unix(sec, 0) }
+
+	continuous := (&VestingSchedule{Address: addr, Type: VestingContinuous, Amount: amount, Start: 100, End: 200}).
+		NewVestingAccount(acc).(std.VestingAccount)
+	assert.Equal(t, "1000ugnot", continuous.GetVestingCoins(0, at(50)).String())
+	assert.Equal(t, "750ugnot", continuous.GetVestingCoins(0, at(125)).String())
+	assert.Equal(t, "10ugnot", continuous.GetVestingCoins(0, at(199)).String())
+	assert.True(t, continuous.GetVestingCoins(0, at(200)).IsZero())
+
+	delayed := (&VestingSchedule{Address: addr, Type: VestingDelayed, Amount: amount, ByHeight: true, End: 10}).
+		NewVestingAccount(acc).(std.VestingAccount)
+	assert.Equal(t, "1000ugnot", delayed.GetVestingCoins(9, at(1e9)).String())
+	assert.True(t, delayed.GetVestingCoins(10, at(0)).IsZero())
+
+	periodic := (&VestingSchedule{Address: addr, Type: VestingPeriodic, Amount: amount, ByHeight: true, Start: 5, Periods: []VestingPeriod{
+		{Length: 10, Amount: std.MustParseCoins("400ugnot")},
+		{Length: 10, Amount: std.MustParseCoins("600ugnot")},
+	}}).NewVestingAccount(acc).(std.VestingAccount)
+	assert.Equal(t, "1000ugnot", periodic.GetVestingCoins(14, at(0)).String())
+	assert.Equal(t, "600ugnot", periodic.GetVestingCoins(15, at(0)).String())
+	assert.True(t, periodic.GetVestingCoins(25, at(0)).IsZero())
+	assert.Equal(t, int64(25), periodic.(*PeriodicVestingAccount).End)
+
+	// The vesting account keeps the original account.
+	require.Equal(t, addr, continuous.(std.Account).GetAddress())
+	assert.Equal(t, amount, continuous.(std.Account).GetCoins())
+	assert.Equal(t, amount, continuous.GetOriginalVesting())
+}
@@ -8,6 +8,15 @@ type DirData struct {
 	FileCounter int
 	FilesLinks  FilesLinks
 	Mode        ViewMode
+	Metadata    *DirMetadata
+}
+
+// DirMetadata is the optional metadata declared by a package in its gno.mod.
+type DirMetadata struct {
+	Description string
+	License     string
+	Authors     []string
+	Repository  string
 }
 
 type DirLinkType int
@@ -45,13 +54,14 @@ func GetFullLinks(files []string, linkType DirLinkType, pkgPath string) FilesLin
 	return result
 }
 
-func DirectoryView(pkgPath string, files []string, fileCounter int, linkType DirLinkType, mode ViewMode) *View {
+func DirectoryView(pkgPath string, files []string, fileCounter int, linkType DirLinkType, mode ViewMode, metadata *DirMetadata) *View {
 	viewData := DirData{
 		PkgPath:     pkgPath,
 		Files:       files,
 		FilesLinks:  GetFullLinks(files, linkType, pkgPath),
 		FileCounter: fileCounter,
 		Mode:        mode,
+		Metadata:    metadata,
 	}
 	return NewTemplateView(DirectoryViewType, "renderDir", viewData)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/markdown"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSourceView(t *testing.T) {
@@ -201,7 +202,14 @@ func TestDirectoryView(t *testing.T) {
 	linkType := DirLinkTypeSource
 	mode := ViewModePackage
 
-	view := DirectoryView(pkgPath, files, fileCounter, linkType, mode)
+	metadata := &DirMetadata{
+		Description: "An example package",
+		License:     "MIT",
+		Authors:     []string{"alice", "bob"},
+		Repository:  "https://github.com/example/path",
+	}
+
+	view := DirectoryView(pkgPath, files, fileCounter, linkType, mode, metadata)
 
 	assert.NotNil(t, view, "expected view to be non-nil")
 
@@ -215,8 +223,13 @@ func TestDirectoryView(t *testing.T) {
 	assert.Equal(t, len(files), len(dirData.Files), "expected %d files, got %d", len(files), len(dirData.Files))
 	assert.Equal(t, fileCounter, dirData.FileCounter, "expected FileCounter %d, got %d", fileCounter, dirData.FileCounter)
 	assert.Equal(t, mode, dirData.Mode, "expected Mode %v, got %v", mode, dirData.Mode)
+	assert.Equal(t, metadata, dirData.Metadata, "expected Metadata %v, got %v", metadata, dirData.Metadata)
 
-	assert.NoError(t, view.Render(io.Discard))
+	var buf strings.Builder
+	require.NoError(t, view.Render(&buf))
+	assert.Contains(t, buf.String(), "An example package")
+	assert.Contains(t, buf.String(), "alice, bob")
+	assert.Contains(t, buf.String(), `href="https://github.com/example/path"`)
 }
 
 func TestDirLinkType_LinkPrefix(t *testing.T) {
@@ -386,6 +386,7 @@ func (h *WebHandler) GetPathsListView(gnourl *weburl.GnoURL, indexData *componen
 		len(paths),
 		components.DirLinkTypeFile,
 		indexData.Mode,
+		nil,
 	)
 }
 
@@ -418,9 +419,31 @@ func (h *WebHandler) GetDirectoryView(gnourl *weburl.GnoURL, indexData *componen
 		len(files),
 		components.DirLinkTypeSource,
 		indexData.Mode,
+		h.getDirMetadata(pkgPath),
 	)
 }
 
+// getDirMetadata returns the metadata declared by the package, if any.
+// Failing to fetch it is not fatal: the directory is displayed without it.
+func (h *WebHandler) getDirMetadata(pkgPath string) *components.DirMetadata {
+	meta, err := h.Client.Metadata(pkgPath)
+	if err != nil {
+		h.Logger.Warn("unable to fetch package metadata", "path", pkgPath, "error", err)
+		return nil
+	}
+
+	if meta.IsEmpty() {
+		return nil
+	}
+
+	return &components.DirMetadata{
+		Description: meta.Description,
+		License:     meta.License,
+		Authors:     meta.Authors,
+		Repository:  meta.Repository,
+	}
+}
+
 func (h *WebHandler) GetSourceDownload(gnourl *weburl.GnoURL, w http.ResponseWriter, r *http.Request) {
 	pkgPath := gnourl.Path
 
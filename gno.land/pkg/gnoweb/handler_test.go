@@ -12,6 +12,7 @@ import (
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb"
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/stretchr/testify/assert"
@@ -84,6 +85,10 @@ func TestWebHandler_Get(t *testing.T) {
 				Results: []*doc.JSONField{{Name: "", Type: "string"}},
 			},
 		},
+		Metadata: &vm.PackageMetadata{
+			Description: "my mock package",
+			License:     "MIT",
+		},
 	}
 
 	// Create a WebHandlerConfig with the mock web client and markdown renderer
@@ -101,6 +106,7 @@ func TestWebHandler_Get(t *testing.T) {
 
 		// Source page
 		{Path: "/r/mock/path/", Status: http.StatusOK, Contain: "Directory"},
+		{Path: "/r/mock/path/", Status: http.StatusOK, Contains: []string{"my mock package", "MIT"}},
 		{Path: "/r/mock/path/render.gno", Status: http.StatusOK, Contain: "one more time"},
 		{Path: "/r/mock/path/LicEnse", Status: http.StatusOK, Contain: "my super license"},
 		{Path: "/r/mock/path$source&file=render.gno", Status: http.StatusOK, Contain: "one more time"},
@@ -312,6 +318,10 @@ func (c *stubDirectoryClient) Sources(path string) ([]string, error) {
 	return nil, c.sourcesErr
 }
 
+func (c *stubDirectoryClient) Metadata(path string) (*vm.PackageMetadata, error) {
+	return &vm.PackageMetadata{}, nil
+}
+
 func (c *stubDirectoryClient) QueryPaths(prefix string, limit int) ([]string, error) {
 	return c.queryPaths, c.queryPathsErr
 }
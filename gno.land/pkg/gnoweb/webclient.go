@@ -6,6 +6,7 @@ import (
 
 	md "github.com/gnolang/gno/gno.land/pkg/gnoweb/markdown"
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 )
 
@@ -54,4 +55,8 @@ type WebClient interface {
 	// Sources lists all source files available in a specified
 	// package path.
 	Sources(path string) ([]string, error)
+
+	// Metadata retrieves the metadata (description, license, authors,
+	// repository) declared by the package at the specified path.
+	Metadata(path string) (*vm.PackageMetadata, error)
 }
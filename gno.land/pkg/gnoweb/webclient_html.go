@@ -92,6 +92,25 @@ func (s *HTMLWebClient) Doc(pkgPath string) (*doc.JSONDocumentation, error) {
 	return jdoc, nil
 }
 
+// Metadata retrieves the metadata of the package at the given path
+// by querying the RPC client.
+func (s *HTMLWebClient) Metadata(pkgPath string) (*vm.PackageMetadata, error) {
+	const qpath = "vm/qmeta"
+
+	args := fmt.Sprintf("%s/%s", s.domain, strings.Trim(pkgPath, "/"))
+	res, err := s.query(qpath, []byte(args))
+	if err != nil {
+		return nil, fmt.Errorf("unable to query qmeta: %w", err)
+	}
+
+	meta := &vm.PackageMetadata{}
+	if err := amino.UnmarshalJSON(res, meta); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal qmeta: %w", err)
+	}
+
+	return meta, nil
+}
+
 // SourceFile fetches and writes the source file from a given
 // package path and file name to the provided writer. It uses
 // Chroma for syntax highlighting or Raw style source.
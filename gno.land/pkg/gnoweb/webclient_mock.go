@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 )
 
@@ -18,6 +19,7 @@ type MockPackage struct {
 	Domain    string
 	Files     map[string]string // filename -> body
 	Functions []*doc.JSONFunc
+	Metadata  *vm.PackageMetadata // optional
 }
 
 // MockWebClient is a mock implementation of the Client interface.
@@ -82,6 +84,20 @@ func (m *MockWebClient) Doc(path string) (*doc.JSONDocumentation, error) {
 	return &doc.JSONDocumentation{Funcs: pkg.Functions}, nil
 }
 
+// Metadata simulates retrieving the metadata of a package.
+func (m *MockWebClient) Metadata(path string) (*vm.PackageMetadata, error) {
+	pkg, exists := m.Packages[path]
+	if !exists {
+		return nil, ErrClientPathNotFound
+	}
+
+	if pkg.Metadata == nil {
+		return &vm.PackageMetadata{}, nil
+	}
+
+	return pkg.Metadata, nil
+}
+
 // Sources simulates listing all source files in a package.
 func (m *MockWebClient) Sources(path string) ([]string, error) {
 	pkg, exists := m.Packages[path]
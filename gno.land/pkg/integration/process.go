@@ -25,6 +25,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/db/goleveldb"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +38,14 @@ type ProcessNodeConfig struct {
 	RootDir      string                 `json:"rootdir"`
 	Genesis      *MarshalableGenesisDoc `json:"genesis"`
 	TMConfig     *tmcfg.Config          `json:"tm"`
+
+	// SnapshotInterval is the interval of the state snapshots served by the
+	// node, 0 to disable them
+	SnapshotInterval int64 `json:"snapshot_interval"`
+
+	// CommitBaseStore commits the base store to the app hash, as required
+	// to serve state snapshots
+	CommitBaseStore bool `json:"commit_base_store"`
 }
 
 type ProcessConfig struct {
@@ -90,6 +99,11 @@ func RunNode(ctx context.Context, pcfg *ProcessNodeConfig, stdout, stderr io.Wri
 	nodecfg.TMConfig.DBPath = pcfg.DBDir
 	nodecfg.TMConfig = pcfg.TMConfig
 	nodecfg.Genesis = pcfg.Genesis.ToGenesisDoc()
+	nodecfg.CommitBaseStore = pcfg.CommitBaseStore
+	if pcfg.SnapshotInterval > 0 {
+		nodecfg.SnapshotDB = memdb.NewMemDB()
+		nodecfg.SnapshotOptions = snapshots.Options{Interval: pcfg.SnapshotInterval}
+	}
 	nodecfg.Genesis.Validators = []bft.GenesisValidator{
 		{
 			Address: pv.Address(),
@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStateSync bootstraps an in-memory node from the snapshots of a node
+// process. The nodes run in separate processes, as the RPC handlers share
+// their state within a process.
+func TestStateSync(t *testing.T) {
+	const pkgPath = "gno.land/r/demo/statesync"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	gnoRootDir := gnoenv.RootDir()
+
+	// Prepare the genesis of the chain, validated by the node process, with a
+	// realm deployed at genesis
+	validatorKey := ed25519.GenPrivKey()
+	cfg := TestingMinimalNodeConfig(gnoRootDir)
+	cfg.Genesis.AppState = GenerateTestingGenesisState(secp256k1.GenPrivKey(), std.MemPackage{
+		Name: "statesync",
+		Path: pkgPath,
+		Files: []*std.MemFile{
+			{Name: "gno.mod", Body: "module " + pkgPath},
+			{Name: "statesync.gno", Body: "package statesync; func Hello() string { return \"hello\" }"},
+		},
+	})
+	cfg.Genesis.Validators = []bft.GenesisValidator{
+		{
+			Address: validatorKey.PubKey().Address(),
+			PubKey:  validatorKey.PubKey(),
+			Power:   10,
+			Name:    "self",
+		},
+	}
+	// The block times follow the local clock, so the light client of the
+	// syncing node accepts the headers of the fast test blocks
+	cfg.Genesis.ConsensusParams.Block.TimeIotaMS = 1
+	// The snapshots verify the base store against the app hash
+	cfg.CommitBaseStore = true
+
+	// Start the node process serving the snapshots
+	processCfg := TestingMinimalNodeConfig(gnoRootDir)
+	processCfg.TMConfig.P2P.ListenAddress = "tcp://" + freeAddress(t)
+
+	var stdio bytes.Buffer
+	defer func() {
+		t.Log("node output:")
+		t.Log(stdio.String())
+	}()
+
+	nodeA := runTestingNodeProcess(t, ctx, ProcessConfig{
+		Stderr: &stdio, Stdout: &stdio,
+		Node: &ProcessNodeConfig{
+			ValidatorKey:     validatorKey,
+			RootDir:          gnoRootDir,
+			TMConfig:         processCfg.TMConfig,
+			Genesis:          NewMarshalableGenesisDoc(cfg.Genesis),
+			SnapshotInterval: 5,
+			CommitBaseStore:  true,
+		},
+	})
+	defer nodeA.Stop()
+
+	clientA, err := rpcclient.NewHTTPClient(nodeA.Address())
+	require.NoError(t, err)
+
+	// Wait for the first snapshot
+	require.Eventually(t, func() bool {
+		status, err := clientA.Status()
+		return err == nil && status.SyncInfo.LatestBlockHeight >= 5
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// Trust the first header
+	commit, err := clientA.Commit(&[]int64{1}[0])
+	require.NoError(t, err)
+
+	status, err := clientA.Status()
+	require.NoError(t, err)
+
+	// Start the in-memory node, restoring a snapshot of the node process
+	cfg.TMConfig.P2P.PersistentPeers = status.NodeInfo.NetAddress.String()
+	cfg.TMConfig.StateSync.Enable = true
+	cfg.TMConfig.StateSync.RPCServers = []string{nodeA.Address(), nodeA.Address()}
+	cfg.TMConfig.StateSync.TrustHeight = 1
+	cfg.TMConfig.StateSync.TrustHash = hex.EncodeToString(commit.Hash())
+	cfg.TMConfig.StateSync.DiscoveryTime = 500 * time.Millisecond
+
+	nodeB, remoteAddr := TestingInMemoryNode(t, log.NewTestingLogger(t), cfg)
+	defer nodeB.Stop()
+
+	// The node starts from the snapshot, and keeps up with the chain
+	require.Eventually(t, func() bool {
+		base := nodeB.BlockStore().Base()
+		return base > 1 && nodeB.BlockStore().Height() > base
+	}, 40*time.Second, 50*time.Millisecond)
+
+	clientB, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	// The realm is restored
+	qres, err := clientB.ABCIQuery("vm/qeval", []byte(pkgPath+".Hello()"))
+	require.NoError(t, err)
+	require.NoError(t, qres.Response.Error)
+	assert.Equal(t, `("hello" string)`, string(qres.Response.Data))
+
+	// The state of the node matches the state of the node process
+	height := nodeB.BlockStore().Height()
+	block, err := clientA.Block(&height)
+	require.NoError(t, err)
+	assert.Equal(t, block.Block.AppHash, nodeB.BlockStore().LoadBlockMeta(height).Header.AppHash)
+}
+
+// freeAddress returns a local address, with a port free to listen on
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	return ln.Addr().String()
+}
@@ -18,9 +18,10 @@ import (
 type MakeAddPkgCfg struct {
 	RootCfg *client.MakeTxCfg
 
-	PkgPath string
-	PkgDir  string
-	Deposit string
+	PkgPath    string
+	PkgDir     string
+	Deposit    string
+	MaxDeposit string
 }
 
 func NewMakeAddPkgCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
@@ -62,6 +63,13 @@ func (c *MakeAddPkgCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"deposit coins",
 	)
+
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit (no limit if empty)",
+	)
 }
 
 func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
@@ -101,6 +109,12 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 		panic(err)
 	}
 
+	// parse max deposit.
+	maxDeposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		panic(err)
+	}
+
 	// open files in directory as MemPackage.
 	memPkg := gno.MustReadMemPackage(cfg.PkgDir, cfg.PkgPath)
 	if memPkg.IsEmpty() {
@@ -115,15 +129,19 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	}
 	// construct msg & tx and marshal.
 	msg := vm.MsgAddPackage{
-		Creator: creator,
-		Package: memPkg,
-		Deposit: deposit,
+		Creator:    creator,
+		Package:    memPkg,
+		Deposit:    deposit,
+		MaxDeposit: maxDeposit,
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
 		Fee:        std.NewFee(gaswanted, gasfee),
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
+
+		TimeoutHeight:    cfg.RootCfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.RootCfg.TimeoutTimestamp,
 	}
 
 	if cfg.RootCfg.Broadcast {
@@ -16,10 +16,11 @@ import (
 type MakeCallCfg struct {
 	RootCfg *client.MakeTxCfg
 
-	Send     string
-	PkgPath  string
-	FuncName string
-	Args     commands.StringArr
+	Send       string
+	MaxDeposit string
+	PkgPath    string
+	FuncName   string
+	Args       commands.StringArr
 }
 
 func NewMakeCallCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
@@ -48,6 +49,13 @@ func (c *MakeCallCfg) RegisterFlags(fs *flag.FlagSet) {
 		"send amount",
 	)
 
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit (no limit if empty)",
+	)
+
 	fs.StringVar(
 		&c.PkgPath,
 		"pkgpath",
@@ -108,6 +116,12 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 		return errors.Wrap(err, "parsing send coins")
 	}
 
+	// Parse max deposit amount.
+	maxDeposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		return errors.Wrap(err, "parsing max deposit coins")
+	}
+
 	// parse gas wanted & fee.
 	gaswanted := cfg.RootCfg.GasWanted
 	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
@@ -117,17 +131,21 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 
 	// construct msg & tx and marshal.
 	msg := vm.MsgCall{
-		Caller:  caller,
-		Send:    send,
-		PkgPath: cfg.PkgPath,
-		Func:    fnc,
-		Args:    cfg.Args,
+		Caller:     caller,
+		Send:       send,
+		PkgPath:    cfg.PkgPath,
+		Func:       fnc,
+		Args:       cfg.Args,
+		MaxDeposit: maxDeposit,
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
 		Fee:        std.NewFee(gaswanted, gasfee),
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
+
+		TimeoutHeight:    cfg.RootCfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.RootCfg.TimeoutTimestamp,
 	}
 
 	if cfg.RootCfg.Broadcast {
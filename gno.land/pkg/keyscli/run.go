@@ -19,6 +19,8 @@ import (
 
 type MakeRunCfg struct {
 	RootCfg *client.MakeTxCfg
+
+	MaxDeposit string
 }
 
 func NewMakeRunCmd(rootCfg *client.MakeTxCfg, cmdio commands.IO) *commands.Command {
@@ -39,7 +41,14 @@ func NewMakeRunCmd(rootCfg *client.MakeTxCfg, cmdio commands.IO) *commands.Comma
 	)
 }
 
-func (c *MakeRunCfg) RegisterFlags(fs *flag.FlagSet) {}
+func (c *MakeRunCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit (no limit if empty)",
+	)
+}
 
 func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	if len(args) != 2 {
@@ -73,6 +82,12 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 		return errors.Wrap(err, "parsing gas fee coin")
 	}
 
+	// parse max deposit.
+	maxDeposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		return errors.Wrap(err, "parsing max deposit coins")
+	}
+
 	memPkg := &std.MemPackage{}
 	if sourcePath == "-" { // stdin
 		data, err := io.ReadAll(cmdio.In())
@@ -116,14 +131,18 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 
 	// construct msg & tx and marshal.
 	msg := vm.MsgRun{
-		Caller:  caller,
-		Package: memPkg,
+		Caller:     caller,
+		Package:    memPkg,
+		MaxDeposit: maxDeposit,
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
 		Fee:        std.NewFee(gaswanted, gasfee),
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
+
+		TimeoutHeight:    cfg.RootCfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.RootCfg.TimeoutTimestamp,
 	}
 
 	if cfg.RootCfg.Broadcast {
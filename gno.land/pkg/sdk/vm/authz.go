@@ -0,0 +1,62 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var _ authz.Authorization = CallAuthorization{}
+
+// CallAuthorization authorizes calls to the realm PkgPath, restricted to the
+// function Func if set. The coins sent with the calls and their storage
+// deposits are taken from the granter, up to a total of SpendLimit. Calls must
+// cap their storage deposit with MaxDeposit, which is counted against
+// SpendLimit along with the sent coins.
+type CallAuthorization struct {
+	PkgPath    string    `json:"pkg_path" yaml:"pkg_path"`
+	Func       string    `json:"func" yaml:"func"`
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// MsgType implements authz.Authorization.
+func (ca CallAuthorization) MsgType() string {
+	return auth.MsgTypeURL(MsgCall{})
+}
+
+// ValidateBasic implements authz.Authorization.
+func (ca CallAuthorization) ValidateBasic() error {
+	if ca.PkgPath == "" {
+		return ErrInvalidPkgPath("missing package path")
+	}
+	if !ca.SpendLimit.IsValid() {
+		return std.ErrInvalidCoins(ca.SpendLimit.String())
+	}
+	return nil
+}
+
+// Accept implements authz.Authorization.
+func (ca CallAuthorization) Accept(ctx sdk.Context, msg std.Msg) (authz.Authorization, bool, error) {
+	call, ok := msg.(MsgCall)
+	if !ok {
+		return ca, false, std.ErrUnauthorized(fmt.Sprintf("call authorization does not allow %T", msg))
+	}
+	if call.PkgPath != ca.PkgPath || (ca.Func != "" && call.Func != ca.Func) {
+		return ca, false, std.ErrUnauthorized(
+			fmt.Sprintf("call authorization does not allow calling %s.%s", call.PkgPath, call.Func))
+	}
+	// an empty MaxDeposit does not cap the storage deposit.
+	if call.MaxDeposit.IsZero() {
+		return ca, false, std.ErrUnauthorized("call authorization requires a max deposit")
+	}
+	spent := call.Send.Add(call.MaxDeposit)
+	if !ca.SpendLimit.IsAllGTE(spent) {
+		return ca, false, std.ErrInsufficientFunds(
+			fmt.Sprintf("call authorization spend limit exceeded; %s < %s", ca.SpendLimit, spent))
+	}
+	ca.SpendLimit = ca.SpendLimit.Sub(spent)
+	return ca, false, nil
+}
@@ -0,0 +1,63 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallAuthorization_Accept(t *testing.T) {
+	t.Parallel()
+
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	ca := CallAuthorization{
+		PkgPath:    "gno.land/r/demo/treasury",
+		Func:       "Pay",
+		SpendLimit: std.MustParseCoins("100ugnot"),
+	}
+	require.NoError(t, ca.ValidateBasic())
+	assert.Equal(t, "vm/exec", ca.MsgType())
+
+	call := func(pkgPath, fn string, send string) MsgCall {
+		msg := NewMsgCall(caller, std.MustParseCoins(send), pkgPath, fn, nil)
+		msg.MaxDeposit = std.MustParseCoins("10ugnot")
+		return msg
+	}
+
+	left, remove, err := ca.Accept(sdk.Context{}, call("gno.land/r/demo/treasury", "Pay", ""))
+	require.NoError(t, err)
+	assert.False(t, remove)
+	assert.Equal(t, "90ugnot", left.(CallAuthorization).SpendLimit.String())
+
+	left, _, err = ca.Accept(sdk.Context{}, call("gno.land/r/demo/treasury", "Pay", "60ugnot"))
+	require.NoError(t, err)
+	assert.Equal(t, "30ugnot", left.(CallAuthorization).SpendLimit.String())
+
+	_, _, err = left.Accept(sdk.Context{}, call("gno.land/r/demo/treasury", "Pay", "30ugnot"))
+	assert.ErrorAs(t, err, &std.InsufficientFundsError{})
+
+	// The storage deposit must be capped.
+	uncapped := call("gno.land/r/demo/treasury", "Pay", "")
+	uncapped.MaxDeposit = nil
+	_, _, err = ca.Accept(sdk.Context{}, uncapped)
+	assert.ErrorAs(t, err, &std.UnauthorizedError{})
+
+	_, _, err = ca.Accept(sdk.Context{}, call("gno.land/r/demo/treasury", "Withdraw", ""))
+	assert.ErrorAs(t, err, &std.UnauthorizedError{})
+
+	_, _, err = ca.Accept(sdk.Context{}, call("gno.land/r/demo/other", "Pay", ""))
+	assert.Error(t, err)
+
+	_, _, err = ca.Accept(sdk.Context{}, bank.NewMsgSend(caller, caller, std.MustParseCoins("1ugnot")))
+	assert.Error(t, err)
+
+	// Without Func, any function of the realm can be called.
+	ca.Func = ""
+	_, _, err = ca.Accept(sdk.Context{}, call("gno.land/r/demo/treasury", "Withdraw", ""))
+	assert.NoError(t, err)
+}
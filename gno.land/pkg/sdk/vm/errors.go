@@ -15,24 +15,32 @@ func (abciError) AssertABCIError() {}
 // declare all script errors.
 // NOTE: these are meant to be used in conjunction with pkgs/errors.
 type (
-	InvalidPkgPathError   struct{ abciError }
-	NoRenderDeclError     struct{ abciError }
-	PkgExistError         struct{ abciError }
-	InvalidStmtError      struct{ abciError }
-	InvalidExprError      struct{ abciError }
-	UnauthorizedUserError struct{ abciError }
-	TypeCheckError        struct {
+	InvalidPkgPathError      struct{ abciError }
+	NoRenderDeclError        struct{ abciError }
+	PkgExistError            struct{ abciError }
+	InvalidStmtError         struct{ abciError }
+	InvalidExprError         struct{ abciError }
+	UnauthorizedUserError    struct{ abciError }
+	InvalidObjectIDError     struct{ abciError }
+	ObjectNotFoundError      struct{ abciError }
+	InsufficientDepositError struct{ abciError }
+	InvalidPkgMetadataError  struct{ abciError }
+	TypeCheckError           struct {
 		abciError
 		Errors []string `json:"errors"`
 	}
 )
 
-func (e InvalidPkgPathError) Error() string   { return "invalid package path" }
-func (e NoRenderDeclError) Error() string     { return "render function not declared" }
-func (e PkgExistError) Error() string         { return "package already exists" }
-func (e InvalidStmtError) Error() string      { return "invalid statement" }
-func (e InvalidExprError) Error() string      { return "invalid expression" }
-func (e UnauthorizedUserError) Error() string { return "unauthorized user" }
+func (e InvalidPkgPathError) Error() string      { return "invalid package path" }
+func (e NoRenderDeclError) Error() string        { return "render function not declared" }
+func (e PkgExistError) Error() string            { return "package already exists" }
+func (e InvalidStmtError) Error() string         { return "invalid statement" }
+func (e InvalidExprError) Error() string         { return "invalid expression" }
+func (e UnauthorizedUserError) Error() string    { return "unauthorized user" }
+func (e InvalidObjectIDError) Error() string     { return "invalid object id" }
+func (e ObjectNotFoundError) Error() string      { return "object not found" }
+func (e InsufficientDepositError) Error() string { return "insufficient storage deposit" }
+func (e InvalidPkgMetadataError) Error() string  { return "invalid package metadata" }
 func (e TypeCheckError) Error() string {
 	var bld strings.Builder
 	bld.WriteString("invalid gno package; type check errors:\n")
@@ -60,6 +68,22 @@ func ErrInvalidExpr(msg string) error {
 	return errors.Wrap(InvalidExprError{}, msg)
 }
 
+func ErrInvalidObjectID(msg string) error {
+	return errors.Wrap(InvalidObjectIDError{}, msg)
+}
+
+func ErrObjectNotFound(msg string) error {
+	return errors.Wrap(ObjectNotFoundError{}, msg)
+}
+
+func ErrInsufficientDeposit(msg string) error {
+	return errors.Wrap(InsufficientDepositError{}, msg)
+}
+
+func ErrInvalidPkgMetadata(msg string) error {
+	return errors.Wrap(InvalidPkgMetadataError{}, msg)
+}
+
 func ErrTypeCheck(err error) error {
 	var tce TypeCheckError
 	errs := multierr.Errors(err)
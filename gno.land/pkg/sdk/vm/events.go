@@ -0,0 +1,36 @@
+package vm
+
+import (
+	"strconv"
+
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Storage deposit event types, emitted on behalf of the realm.
+const (
+	EventStorageDeposit = "StorageDeposit"
+	EventStorageUnlock  = "StorageUnlock"
+)
+
+func newStorageDepositEvent(rlmPath string, bytes int64, deposit std.Coins) gnostd.GnoEvent {
+	return gnostd.GnoEvent{
+		Type: EventStorageDeposit,
+		Attributes: []gnostd.GnoEventAttribute{
+			{Key: "Deposit", Value: deposit.String()},
+			{Key: "Storage", Value: strconv.FormatInt(bytes, 10) + " bytes"},
+		},
+		PkgPath: rlmPath,
+	}
+}
+
+func newStorageUnlockEvent(rlmPath string, bytes uint64, refund std.Coins) gnostd.GnoEvent {
+	return gnostd.GnoEvent{
+		Type: EventStorageUnlock,
+		Attributes: []gnostd.GnoEventAttribute{
+			{Key: "Refund", Value: refund.String()},
+			{Key: "ReleasedStorage", Value: strconv.FormatUint(bytes, 10) + " bytes"},
+		},
+		PkgPath: rlmPath,
+	}
+}
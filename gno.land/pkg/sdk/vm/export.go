@@ -0,0 +1,122 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// StoreEntry is a raw key/value entry of a store.
+type StoreEntry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// RealmState is the persisted state of a realm, as raw store entries: its
+// realm info and objects, and the hashes of its escaped objects.
+type RealmState struct {
+	Path    string       `json:"path"`
+	Objects []StoreEntry `json:"objects"`
+	Escaped []StoreEntry `json:"escaped,omitempty"`
+}
+
+// realmStatePrefixes returns the key prefixes of the realm state of pkgPath,
+// in the base and iavl stores.
+func realmStatePrefixes(pkgPath string) (objects, escaped []byte) {
+	pkgID := gno.PkgIDFromPkgPath(pkgPath)
+	// NOTE: keep in sync with the ObjectID encoding, "<pkgid>:<time>".
+	oidPrefix := hex.EncodeToString(pkgID.Hashlet[:]) + ":"
+	return []byte("oid:" + oidPrefix), []byte(oidPrefix)
+}
+
+// ExportPackages returns the (non-stdlib) packages of the store, in the
+// order they were added; the imports of a package are always before it.
+func (vm *VMKeeper) ExportPackages(ctx sdk.Context) []*std.MemPackage {
+	gnostore := gno.NewStore(nil, ctx.Store(vm.baseKey), ctx.Store(vm.iavlKey))
+
+	var mpkgs []*std.MemPackage
+	for mpkg := range gnostore.IterMemPackage() {
+		// packages indexed after the store version are missing.
+		if mpkg == nil || gno.IsStdlib(mpkg.Path) {
+			continue
+		}
+		mpkgs = append(mpkgs, mpkg)
+	}
+	return mpkgs
+}
+
+// ExportRealmState returns the persisted state of the realm at pkgPath.
+func (vm *VMKeeper) ExportRealmState(ctx sdk.Context, pkgPath string) RealmState {
+	objects, escaped := realmStatePrefixes(pkgPath)
+	return RealmState{
+		Path:    pkgPath,
+		Objects: exportStoreEntries(ctx.Store(vm.baseKey), objects),
+		Escaped: exportStoreEntries(ctx.Store(vm.iavlKey), escaped),
+	}
+}
+
+// Validate checks that rs is the state of a realm, with entries all under
+// the prefixes of the realm.
+func (rs RealmState) Validate() error {
+	if !gno.IsRealmPath(rs.Path) {
+		return fmt.Errorf("invalid realm path %q", rs.Path)
+	}
+	objects, escaped := realmStatePrefixes(rs.Path)
+	if err := checkStoreEntries(objects, rs.Objects); err != nil {
+		return fmt.Errorf("invalid state of realm %s: %w", rs.Path, err)
+	}
+	if err := checkStoreEntries(escaped, rs.Escaped); err != nil {
+		return fmt.Errorf("invalid state of realm %s: %w", rs.Path, err)
+	}
+	return nil
+}
+
+// RestoreRealmState replaces the persisted state of the realm with rs, e.g.
+// once the realm was added again at genesis.
+func (vm *VMKeeper) RestoreRealmState(ctx sdk.Context, rs RealmState) error {
+	if err := rs.Validate(); err != nil {
+		return err
+	}
+	objects, escaped := realmStatePrefixes(rs.Path)
+	restoreStoreEntries(ctx.Store(vm.baseKey), objects, rs.Objects)
+	restoreStoreEntries(ctx.Store(vm.iavlKey), escaped, rs.Escaped)
+	return nil
+}
+
+func exportStoreEntries(stor store.Store, prefix []byte) []StoreEntry {
+	iter := store.PrefixIterator(stor, prefix)
+	defer iter.Close()
+
+	var entries []StoreEntry
+	for ; iter.Valid(); iter.Next() {
+		entries = append(entries, StoreEntry{
+			Key:   string(iter.Key()),
+			Value: iter.Value(),
+		})
+	}
+	return entries
+}
+
+// checkStoreEntries checks that the entries are all under prefix, so that a
+// realm state can't overwrite anything else.
+func checkStoreEntries(prefix []byte, entries []StoreEntry) error {
+	for _, entry := range entries {
+		if !bytes.HasPrefix([]byte(entry.Key), prefix) {
+			return fmt.Errorf("key %q is not under %q", entry.Key, prefix)
+		}
+	}
+	return nil
+}
+
+// restoreStoreEntries replaces the entries of stor under prefix.
+func restoreStoreEntries(stor store.Store, prefix []byte, entries []StoreEntry) {
+	deletePrefix(stor, prefix)
+	for _, entry := range entries {
+		stor.Set([]byte(entry.Key), entry.Value)
+	}
+}
@@ -70,7 +70,7 @@ func TestAddPkgDeliverTx(t *testing.T) {
 	assert.True(t, res.IsOK())
 
 	// NOTE: let's try to keep this bellow 150_000 :)
-	assert.Equal(t, int64(145477), gasDeliver)
+	assert.Equal(t, int64(145797), gasDeliver)
 }
 
 // Enough gas for a failed transaction.
@@ -1,17 +1,41 @@
 package vm
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 // GenesisState - all state that must be provided at genesis
 type GenesisState struct {
 	Params      Params         `json:"params" yaml:"params"`
 	RealmParams []params.Param `json:"realm_params" yaml:"realm_params"`
+
+	// StorageDeposits and ScheduledCalls are set by exports of the chain
+	// state, along with the state of the realms they belong to. The heights
+	// of the calls are relative to the exported height.
+	StorageDeposits []GenesisStorageDeposit `json:"storage_deposits,omitempty" yaml:"storage_deposits,omitempty"`
+	ScheduledCalls  []ScheduledCall         `json:"scheduled_calls,omitempty" yaml:"scheduled_calls,omitempty"`
+}
+
+// GenesisStorageDeposit is the storage deposit locked for the realm at
+// RealmPath by Depositor, and the bytes of the realm's storage it pays for.
+type GenesisStorageDeposit struct {
+	RealmPath string         `json:"realm_path" yaml:"realm_path"`
+	Depositor crypto.Address `json:"depositor" yaml:"depositor"`
+	Storage   uint64         `json:"storage" yaml:"storage"`
+	Deposit   std.Coins      `json:"deposit" yaml:"deposit"`
 }
 
 // NewGenesisState - Create a new genesis state
@@ -40,6 +64,41 @@ func ValidateGenesis(gs GenesisState) error {
 	// XXX validate RealmParams.
 	// 1. all keys must be realm paths.
 	// 2. all values must be supported types.
+
+	deposits := make(map[string]struct{}, len(gs.StorageDeposits))
+	for _, sd := range gs.StorageDeposits {
+		switch {
+		case !gno.IsRealmPath(sd.RealmPath):
+			return fmt.Errorf("invalid storage deposit realm path %q", sd.RealmPath)
+		case sd.Depositor.IsZero():
+			return fmt.Errorf("missing depositor of a storage deposit for %s", sd.RealmPath)
+		case !sd.Deposit.IsValid():
+			return fmt.Errorf("invalid storage deposit %s of %s for %s", sd.Deposit, sd.Depositor, sd.RealmPath)
+		}
+		key := string(storageDepositKey(sd.RealmPath, sd.Depositor))
+		if _, ok := deposits[key]; ok {
+			return fmt.Errorf("duplicate storage deposit of %s for %s", sd.Depositor, sd.RealmPath)
+		}
+		deposits[key] = struct{}{}
+	}
+
+	calls := make(map[uint64]struct{}, len(gs.ScheduledCalls))
+	for _, sc := range gs.ScheduledCalls {
+		switch {
+		case !gno.IsRealmPath(sc.PkgPath):
+			return fmt.Errorf("invalid scheduled call realm path %q", sc.PkgPath)
+		case sc.Func == "":
+			return fmt.Errorf("scheduled call %d function is empty", sc.ID)
+		case (sc.Height > 0) == (sc.Timestamp > 0):
+			return fmt.Errorf("scheduled call %d must have either a height or a timestamp", sc.ID)
+		case sc.GasLimit <= 0 || sc.GasLimit > maxScheduledCallGas:
+			return fmt.Errorf("invalid scheduled call %d gas limit %d", sc.ID, sc.GasLimit)
+		}
+		if _, ok := calls[sc.ID]; ok {
+			return fmt.Errorf("duplicate scheduled call %d", sc.ID)
+		}
+		calls[sc.ID] = struct{}{}
+	}
 	return nil
 }
 
@@ -56,10 +115,117 @@ func (vm *VMKeeper) InitGenesis(ctx sdk.Context, gs GenesisState) {
 	for _, rp := range gs.RealmParams {
 		vm.prmk.SetAny(ctx, "vm:"+rp.Key, rp.Value)
 	}
+	vm.restoreStorageDeposits(ctx, gs.StorageDeposits)
+	if len(gs.ScheduledCalls) > 0 {
+		vm.restoreScheduledCalls(ctx, gs.ScheduledCalls)
+	}
 }
 
-// ExportGenesis returns a GenesisState for a given context and keeper
+// ExportGenesis returns a GenesisState for a given context and keeper. The
+// heights of the scheduled calls are made relative to the context's height.
 func (vm *VMKeeper) ExportGenesis(ctx sdk.Context) GenesisState {
 	params := vm.GetParams(ctx)
-	return NewGenesisState(params)
+	gs := NewGenesisState(params)
+	gs.StorageDeposits = vm.exportStorageDeposits(ctx)
+	gs.ScheduledCalls = vm.exportScheduledCalls(ctx)
+	return gs
+}
+
+func (vm *VMKeeper) exportStorageDeposits(ctx sdk.Context) []GenesisStorageDeposit {
+	iter := store.PrefixIterator(ctx.Store(vm.iavlKey), []byte(storageDepositPrefix))
+	defer iter.Close()
+
+	var deposits []GenesisStorageDeposit
+	for ; iter.Valid(); iter.Next() {
+		// deposit:<realm path>:<depositor>
+		key := strings.TrimPrefix(string(iter.Key()), storageDepositPrefix)
+		sep := strings.LastIndexByte(key, ':')
+		depositor, err := crypto.AddressFromBech32(key[sep+1:])
+		if sep < 0 || err != nil {
+			panic(fmt.Sprintf("invalid storage deposit key %q", iter.Key()))
+		}
+		var deposit StorageDeposit
+		amino.MustUnmarshal(iter.Value(), &deposit)
+		deposits = append(deposits, GenesisStorageDeposit{
+			RealmPath: key[:sep],
+			Depositor: depositor,
+			Storage:   deposit.Storage,
+			Deposit:   deposit.Deposit,
+		})
+	}
+	return deposits
+}
+
+// restoreStorageDeposits replaces the storage accounting of the realms with
+// the deposits, and their totals. The deposits recorded while the exported
+// genesis txs were replayed, without storage price, are dropped.
+func (vm *VMKeeper) restoreStorageDeposits(ctx sdk.Context, deposits []GenesisStorageDeposit) {
+	stor := ctx.Store(vm.iavlKey)
+	deletePrefix(stor, []byte(realmStoragePrefix))
+	deletePrefix(stor, []byte(storageDepositPrefix))
+
+	totals := make(map[string]StorageDeposit)
+	for _, sd := range deposits {
+		vm.setStorageDeposit(ctx, sd.RealmPath, sd.Depositor, StorageDeposit{Storage: sd.Storage, Deposit: sd.Deposit})
+		total := totals[sd.RealmPath]
+		total.Storage += sd.Storage
+		total.Deposit = total.Deposit.Add(sd.Deposit)
+		totals[sd.RealmPath] = total
+	}
+	for _, rlmPath := range slices.Sorted(maps.Keys(totals)) {
+		vm.setRealmStorage(ctx, rlmPath, totals[rlmPath])
+	}
+}
+
+func (vm *VMKeeper) exportScheduledCalls(ctx sdk.Context) []ScheduledCall {
+	var calls []ScheduledCall
+	for _, prefix := range []string{scheduleHeightPrefix, scheduleTimestampPrefix} {
+		iter := store.PrefixIterator(ctx.Store(vm.iavlKey), []byte(prefix))
+		for ; iter.Valid(); iter.Next() {
+			var sc ScheduledCall
+			amino.MustUnmarshal(iter.Value(), &sc)
+			if sc.Height > 0 {
+				// calls deferred past their height run in the first block
+				sc.Height = max(sc.Height-ctx.BlockHeight(), 1)
+			}
+			calls = append(calls, sc)
+		}
+		iter.Close()
+	}
+	return calls
+}
+
+// restoreScheduledCalls replaces the scheduled calls with calls, whose
+// heights are relative to the context's height.
+func (vm *VMKeeper) restoreScheduledCalls(ctx sdk.Context, calls []ScheduledCall) {
+	stor := ctx.Store(vm.iavlKey)
+	deletePrefix(stor, []byte(scheduleHeightPrefix))
+	deletePrefix(stor, []byte(scheduleTimestampPrefix))
+
+	var nextID uint64
+	if bz := stor.Get([]byte(scheduleNextIDKey)); bz != nil {
+		nextID = binary.BigEndian.Uint64(bz)
+	}
+	for _, sc := range calls {
+		if sc.Height > 0 {
+			sc.Height += ctx.BlockHeight()
+		}
+		stor.Set(sc.key(), amino.MustMarshal(sc))
+		nextID = max(nextID, sc.ID+1)
+	}
+	stor.Set([]byte(scheduleNextIDKey), binary.BigEndian.AppendUint64(nil, nextID))
+}
+
+// deletePrefix deletes the entries of stor under prefix.
+func deletePrefix(stor store.Store, prefix []byte) {
+	var keys [][]byte
+	iter := store.PrefixIterator(stor, prefix)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, bytes.Clone(iter.Key()))
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		stor.Delete(key)
+	}
 }
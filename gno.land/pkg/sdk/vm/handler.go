@@ -31,6 +31,10 @@ func (vh vmHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 		return vh.handleMsgCall(ctx, msg)
 	case MsgRun:
 		return vh.handleMsgRun(ctx, msg)
+	case MsgAddSessionKey:
+		return vh.handleMsgAddSessionKey(ctx, msg)
+	case MsgRevokeSessionKey:
+		return vh.handleMsgRevokeSessionKey(ctx, msg)
 	default:
 		errMsg := fmt.Sprintf("unrecognized vm message type: %T", msg)
 		return abciResult(std.ErrUnknownRequest(errMsg))
@@ -66,17 +70,40 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 	return
 }
 
+// Handle MsgAddSessionKey.
+func (vh vmHandler) handleMsgAddSessionKey(ctx sdk.Context, msg MsgAddSessionKey) sdk.Result {
+	err := vh.vm.AddSessionKey(ctx, msg)
+	if err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
+}
+
+// Handle MsgRevokeSessionKey.
+func (vh vmHandler) handleMsgRevokeSessionKey(ctx sdk.Context, msg MsgRevokeSessionKey) sdk.Result {
+	err := vh.vm.RevokeSessionKey(ctx, msg)
+	if err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
+}
+
 // ----------------------------------------
 // Query
 
 // query paths
 const (
-	QueryRender = "qrender"
-	QueryFuncs  = "qfuncs"
-	QueryEval   = "qeval"
-	QueryFile   = "qfile"
-	QueryDoc    = "qdoc"
-	QueryPaths  = "qpaths"
+	QueryRender    = "qrender"
+	QueryFuncs     = "qfuncs"
+	QueryEval      = "qeval"
+	QueryFile      = "qfile"
+	QueryDoc       = "qdoc"
+	QueryPaths     = "qpaths"
+	QueryObject    = "qobject"
+	QueryStorage   = "qstorage"
+	QueryImports   = "qimports"
+	QueryImporters = "qimporters"
+	QueryMetadata  = "qmeta"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -98,6 +125,16 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 		res = vh.queryDoc(ctx, req)
 	case QueryPaths:
 		res = vh.queryPaths(ctx, req)
+	case QueryObject:
+		res = vh.queryObject(ctx, req)
+	case QueryStorage:
+		res = vh.queryStorage(ctx, req)
+	case QueryImports:
+		res = vh.queryImports(ctx, req)
+	case QueryImporters:
+		res = vh.queryImporters(ctx, req)
+	case QueryMetadata:
+		res = vh.queryMetadata(ctx, req)
 	default:
 		return sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -150,27 +187,93 @@ func (vh vmHandler) queryPaths(ctx sdk.Context, req abci.RequestQuery) (res abci
 
 	target := string(req.Data)
 
-	var query string
-	if i := strings.IndexByte(req.Path, '?'); i >= 0 {
-		query = req.Path[i+1:]
+	// XXX: implement pagination
+	_, limit, err := parsePagination(req.Path, defaultLimit, maxLimit)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
 	}
 
-	params, _ := url.ParseQuery(query)
+	paths, err := vh.vm.QueryPaths(ctx, target, limit)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
 
-	// XXX: implement pagination
+	res.Data = []byte(strings.Join(paths, "\n"))
+	return
+}
 
-	// Get limit param, if any
-	limit := defaultLimit // default
-	if l := params.Get("limit"); len(l) > 0 {
-		var err error
-		if limit, err = strconv.Atoi(l); err != nil {
-			return sdk.ABCIResponseQueryFromError(fmt.Errorf("invalid limit argument"))
-		}
+// queryObject returns the persisted object, its ownership info and a page of
+// its children references as JSON.
+// data is either a package path or an ObjectID.
+func (vh vmHandler) queryObject(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	const defaultLimit = 100
+	const maxLimit = 1_000
 
-		limit = min(limit, maxLimit) // cap to maxLimit
+	target := string(req.Data)
+
+	offset, limit, err := parsePagination(req.Path, defaultLimit, maxLimit)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
 	}
 
-	paths, err := vh.vm.QueryPaths(ctx, target, limit)
+	oi, err := vh.vm.QueryObject(ctx, target, offset, limit)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+
+	res.Data = []byte(oi.JSON())
+	return
+}
+
+// queryStorage returns the storage usage and locked deposit of a realm as JSON.
+func (vh vmHandler) queryStorage(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	rs, err := vh.vm.QueryStorage(ctx, pkgPath)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+	res.Data = []byte(rs.JSON())
+	return
+}
+
+// queryMetadata returns the metadata of a package as JSON.
+func (vh vmHandler) queryMetadata(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	pm, err := vh.vm.QueryMetadata(ctx, pkgPath)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+	res.Data = []byte(pm.JSON())
+	return
+}
+
+// queryImports returns a page of the paths of the packages imported by a
+// package, one per line.
+func (vh vmHandler) queryImports(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	return vh.queryImportGraph(ctx, req, vh.vm.QueryImports)
+}
+
+// queryImporters returns a page of the paths of the packages importing a
+// package, one per line.
+func (vh vmHandler) queryImporters(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	return vh.queryImportGraph(ctx, req, vh.vm.QueryImporters)
+}
+
+func (vh vmHandler) queryImportGraph(
+	ctx sdk.Context, req abci.RequestQuery,
+	query func(ctx sdk.Context, pkgPath string, offset, limit int) ([]string, error),
+) (res abci.ResponseQuery) {
+	const defaultLimit = 1_000
+	const maxLimit = 10_000
+
+	pkgPath := string(req.Data)
+
+	offset, limit, err := parsePagination(req.Path, defaultLimit, maxLimit)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+
+	paths, err := query(ctx, pkgPath, offset, limit)
 	if err != nil {
 		return sdk.ABCIResponseQueryFromError(err)
 	}
@@ -179,6 +282,34 @@ func (vh vmHandler) queryPaths(ctx sdk.Context, req abci.RequestQuery) (res abci
 	return
 }
 
+// parsePagination returns the offset and limit params of the query of the
+// request path. The limit defaults to defaultLimit, and is capped to maxLimit.
+func parsePagination(path string, defaultLimit, maxLimit int) (offset, limit int, err error) {
+	var query string
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		query = path[i+1:]
+	}
+
+	params, _ := url.ParseQuery(query)
+
+	if o := params.Get("offset"); len(o) > 0 {
+		if offset, err = strconv.Atoi(o); err != nil {
+			return 0, 0, fmt.Errorf("invalid offset argument")
+		}
+	}
+
+	limit = defaultLimit
+	if l := params.Get("limit"); len(l) > 0 {
+		if limit, err = strconv.Atoi(l); err != nil {
+			return 0, 0, fmt.Errorf("invalid limit argument")
+		}
+
+		limit = min(limit, maxLimit) // cap to maxLimit
+	}
+
+	return offset, limit, nil
+}
+
 // queryEval evaluates any expression in readonly mode and returns the results.
 func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	pkgPath, expr := parseQueryEvalData(string(req.Data))
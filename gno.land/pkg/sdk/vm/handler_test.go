@@ -5,10 +5,13 @@ import (
 	"testing"
 
 	"github.com/gnolang/gno/gnovm/pkg/doc"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_parseQueryEvalData(t *testing.T) {
@@ -54,6 +57,33 @@ func Test_parseQueryEval_panic(t *testing.T) {
 	})
 }
 
+func Test_parsePagination(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		path   string
+		offset int
+		limit  int
+		err    string
+	}{
+		{"vm/qimports", 0, 10, ""},
+		{"vm/qimports?offset=5", 5, 10, ""},
+		{"vm/qimports?offset=5&limit=3", 5, 3, ""},
+		{"vm/qimports?limit=1000", 0, 100, ""},
+		{"vm/qimports?offset=a", 0, 0, "invalid offset argument"},
+		{"vm/qimports?limit=a", 0, 0, "invalid limit argument"},
+	}
+	for _, tc := range tt {
+		offset, limit, err := parsePagination(tc.path, 10, 100)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.offset, offset)
+		assert.Equal(t, tc.limit, limit)
+	}
+}
+
 func TestVmHandlerQuery_Eval(t *testing.T) {
 	tt := []struct {
 		input               []byte
@@ -442,3 +472,232 @@ func Hello(msg string) (res string) { res = prefix+" "+msg; return }
 		})
 	}
 }
+
+func TestVmHandlerQuery_Object(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	vmHandler := env.vmh
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{Name: "hello.gno", Body: `
+package hello
+
+type item struct{ name string }
+
+var items = []*item{{"a"}, {"b"}}
+var counter = 42
+`},
+	}
+	pkgPath := "gno.land/r/hello"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	query := func(path, data string) abci.ResponseQuery {
+		return vmHandler.Query(env.ctx, abci.RequestQuery{
+			Path: path,
+			Data: []byte(data),
+		})
+	}
+
+	// Query the package value.
+	res := query("vm/qobject", pkgPath)
+	assert.True(t, res.IsOK(), "should not have error")
+	var pkgObj ObjectInfo
+	assert.NoError(t, amino.UnmarshalJSON(res.Data, &pkgObj))
+	assert.Equal(t, gno.ObjectIDFromPkgPath(pkgPath).String(), pkgObj.ObjectID)
+	assert.Equal(t, 2, pkgObj.NumChildren) // package block and file block
+	assert.Len(t, pkgObj.Children, 2)
+
+	// Walk down to the package block.
+	res = query("vm/qobject", pkgObj.Children[0])
+	assert.True(t, res.IsOK(), "should not have error")
+	var blockObj ObjectInfo
+	assert.NoError(t, amino.UnmarshalJSON(res.Data, &blockObj))
+	assert.Equal(t, pkgObj.Children[0], blockObj.ObjectID)
+	assert.Equal(t, 2, blockObj.RefCount) // package and file block
+	assert.IsType(t, &gno.Block{}, blockObj.Object)
+	assert.Greater(t, blockObj.NumChildren, 0)
+
+	// Paging.
+	res = query("vm/qobject?offset=1&limit=1", pkgObj.Children[0])
+	assert.True(t, res.IsOK(), "should not have error")
+	var paged ObjectInfo
+	assert.NoError(t, amino.UnmarshalJSON(res.Data, &paged))
+	assert.Equal(t, blockObj.NumChildren, paged.NumChildren)
+	assert.LessOrEqual(t, len(paged.Children), 1)
+
+	// Errors.
+	res = query("vm/qobject", "gno.land/r/doesnotexist")
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Regexp(t, "object not found", res.Error.Error())
+
+	res = query("vm/qobject", "invalid")
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Regexp(t, "invalid object id", res.Error.Error())
+
+	res = query("vm/qobject?limit=abc", pkgPath)
+	assert.False(t, res.IsOK(), "should have an error")
+}
+
+func TestVmHandlerQuery_Imports(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	vmHandler := env.vmh
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test packages.
+	libPath := "gno.land/p/demo/lib"
+	msg1 := NewMsgAddPackage(addr, libPath, []*std.MemFile{
+		{Name: "lib.gno", Body: `
+package lib
+
+import "strings"
+
+func Upper(s string) string { return strings.ToUpper(s) }
+`},
+	})
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		msg := NewMsgAddPackage(addr, "gno.land/r/demo/"+name, []*std.MemFile{
+			{Name: name + ".gno", Body: `
+package ` + name + `
+
+import (
+	"strconv"
+
+	"gno.land/p/demo/lib"
+)
+
+func Render(_ string) string { return lib.Upper(strconv.Itoa(42)) }
+`},
+			{Name: name + "_test.gno", Body: `
+package ` + name + `
+
+import "testing"
+
+func TestRender(t *testing.T) {}
+`},
+		})
+		err := env.vmk.AddPackage(ctx, msg)
+		require.NoError(t, err)
+	}
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	query := func(path, data string) abci.ResponseQuery {
+		return vmHandler.Query(env.ctx, abci.RequestQuery{
+			Path: path,
+			Data: []byte(data),
+		})
+	}
+
+	// Forward dependencies, test imports excluded.
+	res := query("vm/qimports", "gno.land/r/demo/alpha")
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Equal(t, "gno.land/p/demo/lib\nstrconv", string(res.Data))
+
+	res = query("vm/qimports", libPath)
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Equal(t, "strings", string(res.Data))
+
+	// Reverse dependencies.
+	res = query("vm/qimporters", libPath)
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Equal(t, "gno.land/r/demo/alpha\ngno.land/r/demo/beta\ngno.land/r/demo/gamma", string(res.Data))
+
+	res = query("vm/qimporters", "strings")
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Equal(t, libPath, string(res.Data))
+
+	// Paging.
+	res = query("vm/qimporters?offset=1&limit=1", libPath)
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Equal(t, "gno.land/r/demo/beta", string(res.Data))
+
+	res = query("vm/qimporters?offset=3", libPath)
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Empty(t, res.Data)
+
+	// Errors.
+	res = query("vm/qimports", "gno.land/r/doesnotexist")
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Regexp(t, "is not available", res.Error.Error())
+
+	res = query("vm/qimporters?limit=abc", libPath)
+	assert.False(t, res.IsOK(), "should have an error")
+}
+
+func TestVmHandlerQuery_Metadata(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	vmHandler := env.vmh
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test packages, with and without metadata.
+	pkgPath := "gno.land/p/demo/meta"
+	msg1 := NewMsgAddPackage(addr, pkgPath, []*std.MemFile{
+		{Name: "gno.mod", Body: `module gno.land/p/demo/meta
+
+description "Metadata test package"
+license Apache-2.0
+author "Alice <alice@example.com>"
+repository "https://github.com/gnolang/gno"
+`},
+		{Name: "meta.gno", Body: "package meta\n"},
+	})
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	noMetaPath := "gno.land/p/demo/nometa"
+	msg2 := NewMsgAddPackage(addr, noMetaPath, []*std.MemFile{
+		{Name: "nometa.gno", Body: "package nometa\n"},
+	})
+	err = env.vmk.AddPackage(ctx, msg2)
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	query := func(path, data string) abci.ResponseQuery {
+		return vmHandler.Query(env.ctx, abci.RequestQuery{
+			Path: path,
+			Data: []byte(data),
+		})
+	}
+
+	res := query("vm/qmeta", pkgPath)
+	require.True(t, res.IsOK(), "should not have error")
+	var pm PackageMetadata
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &pm))
+	assert.Equal(t, PackageMetadata{
+		Description: "Metadata test package",
+		License:     "Apache-2.0",
+		Authors:     []string{"Alice <alice@example.com>"},
+		Repository:  "https://github.com/gnolang/gno",
+	}, pm)
+
+	res = query("vm/qmeta", noMetaPath)
+	require.True(t, res.IsOK(), "should not have error")
+	assert.Equal(t, "{}", string(res.Data))
+
+	res = query("vm/qmeta", "gno.land/p/demo/doesnotexist")
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Regexp(t, "is not available", res.Error.Error())
+}
@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Keys of the package import graph, in the VM's iavl store.
+// Every import of a package added with AddPackage is indexed twice, as
+// "pkgimports:<pkgPath>\x00<import>" and "pkgimporters:<import>\x00<pkgPath>",
+// so that both directions can be listed in order with a prefix iteration.
+const (
+	importsPrefix   = "pkgimports:"
+	importersPrefix = "pkgimporters:"
+	importsSep      = "\x00"
+)
+
+// indexImports records the imports of the (non-test) files of memPkg in the
+// import graph.
+func (vm *VMKeeper) indexImports(ctx sdk.Context, memPkg *std.MemPackage) error {
+	imports, err := packages.Imports(memPkg, nil)
+	if err != nil {
+		return err
+	}
+	stor := ctx.Store(vm.iavlKey)
+	for _, im := range imports.Merge(packages.FileKindPackageSource) {
+		stor.Set([]byte(importsPrefix+memPkg.Path+importsSep+im.PkgPath), []byte{})
+		stor.Set([]byte(importersPrefix+im.PkgPath+importsSep+memPkg.Path), []byte{})
+	}
+	return nil
+}
+
+// QueryImports returns the paths of the packages imported by pkgPath, in
+// lexical order, skipping the first offset ones.
+func (vm *VMKeeper) QueryImports(ctx sdk.Context, pkgPath string, offset, limit int) ([]string, error) {
+	return vm.queryImportGraph(ctx, importsPrefix, pkgPath, offset, limit)
+}
+
+// QueryImporters returns the paths of the packages importing pkgPath, in
+// lexical order, skipping the first offset ones.
+func (vm *VMKeeper) QueryImporters(ctx sdk.Context, pkgPath string, offset, limit int) ([]string, error) {
+	return vm.queryImportGraph(ctx, importersPrefix, pkgPath, offset, limit)
+}
+
+func (vm *VMKeeper) queryImportGraph(ctx sdk.Context, prefix, pkgPath string, offset, limit int) ([]string, error) {
+	if offset < 0 || limit < 0 {
+		return nil, errors.New("cannot have negative offset or limit value")
+	}
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	if store.GetMemPackage(pkgPath) == nil {
+		return nil, fmt.Errorf("package %q is not available", pkgPath) // TODO: XSS protection
+	}
+
+	start := prefix + pkgPath + importsSep
+	iter := ctx.Store(vm.iavlKey).Iterator([]byte(start), []byte(prefix+pkgPath+"\x01"))
+	defer iter.Close()
+	paths := []string{}
+	for ; iter.Valid() && len(paths) < limit; iter.Next() {
+		if offset > 0 {
+			offset--
+			continue
+		}
+		paths = append(paths, string(iter.Key()[len(start):]))
+	}
+	return paths, nil
+}
@@ -5,14 +5,17 @@ package vm
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	goerrors "errors"
 	"fmt"
 	"io"
 	"iter"
 	"log/slog"
+	"maps"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -20,10 +23,13 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/stdlibs"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/overflow"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
@@ -53,6 +59,8 @@ type VMKeeperI interface {
 	MakeGnoTransactionStore(ctx sdk.Context) sdk.Context
 	CommitGnoTransactionStore(ctx sdk.Context)
 	InitGenesis(ctx sdk.Context, data GenesisState)
+	RestoreRealmState(ctx sdk.Context, rs RealmState) error
+	RunScheduledCalls(ctx sdk.Context) []abci.Event
 }
 
 var _ VMKeeperI = &VMKeeper{}
@@ -129,6 +137,16 @@ func (vm *VMKeeper) Initialize(
 	}
 }
 
+// Reinitialize initializes the VMKeeper again from the given store, replacing
+// the gno store, e.g. once the state is restored from a snapshot.
+func (vm *VMKeeper) Reinitialize(
+	logger *slog.Logger,
+	ms store.MultiStore,
+) {
+	vm.gnoStore = nil
+	vm.Initialize(logger, ms)
+}
+
 type stdlibCache struct {
 	dir  string
 	base store.Store
@@ -393,6 +411,7 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
@@ -409,6 +428,25 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	defer doRecover(m2, &err)
 	m2.RunMemPackage(memPkg, true)
 
+	// Record the package imports in the import graph.
+	if err := vm.indexImports(ctx, memPkg); err != nil {
+		return err
+	}
+
+	// Store the package metadata, if any.
+	pm, err := packageMetadata(memPkg)
+	if err != nil {
+		return ErrInvalidPkgMetadata(err.Error())
+	}
+	if pm != nil {
+		vm.setPackageMetadata(ctx, pkgPath, pm)
+	}
+
+	// Lock the storage deposit for the new realm state.
+	if _, err := vm.processStorageDeposit(ctx, creator, msg.MaxDeposit, gnostore); err != nil {
+		return err
+	}
+
 	// Log the telemetry
 	logTelemetry(
 		m2.GasMeter.GasConsumed(),
@@ -483,6 +521,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 	// Construct machine and evaluate.
@@ -506,6 +545,15 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		}
 	}
 
+	// Lock or release storage deposits of modified realms.
+	deposit, err := vm.processStorageDeposit(ctx, caller, msg.MaxDeposit, gnostore)
+	if err != nil {
+		return "", err
+	}
+	if err := vm.spendSessionKeyDeposit(ctx, caller, deposit); err != nil {
+		return "", err
+	}
+
 	// Log the telemetry
 	logTelemetry(
 		m.GasMeter.GasConsumed(),
@@ -614,6 +662,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 
@@ -661,6 +710,11 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	m2.RunMain()
 	res = buf.String()
 
+	// Lock or release storage deposits of modified realms.
+	if _, err := vm.processStorageDeposit(ctx, caller, msg.MaxDeposit, gnostore); err != nil {
+		return "", err
+	}
+
 	// Log the telemetry
 	logTelemetry(
 		m2.GasMeter.GasConsumed(),
@@ -674,6 +728,147 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	return res, nil
 }
 
+// processStorageDeposit settles the storage deposits of all the realms whose
+// persisted size changed since the last call. Storage growth is paid for by
+// caller at the storage price, up to maxDeposit (uncapped if empty), and
+// locked in the realm's storage deposit address. Released storage is taken
+// from the bytes caller paid for in the realm, whose deposit is refunded to
+// caller pro rata, in the coins it was locked in; callers cannot collect the
+// deposits of others, which stay locked until their depositors release them.
+// It returns the coins locked from caller.
+func (vm *VMKeeper) processStorageDeposit(ctx sdk.Context, caller crypto.Address, maxDeposit std.Coins, gnostore gno.Store) (std.Coins, error) {
+	diffs := gnostore.RealmStorageDiffs()
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+	defer clear(diffs)
+
+	price := vm.getStoragePriceParam(ctx)
+	capped := !maxDeposit.IsZero()
+	remaining := maxDeposit.AmountOf(price.Denom)
+	var locked std.Coins
+
+	// iterate in a deterministic order.
+	for _, rlmPath := range slices.Sorted(maps.Keys(diffs)) {
+		diff := diffs[rlmPath]
+		if diff == 0 {
+			continue
+		}
+		pv := gnostore.GetPackage(rlmPath, false)
+		if pv == nil || pv.Realm == nil {
+			continue // not a persisted realm, e.g. MsgRun.
+		}
+		total := vm.getRealmStorage(ctx, rlmPath)
+		deposit := vm.getStorageDeposit(ctx, rlmPath, caller)
+		depositAddr := gno.DeriveStorageDepositCryptoAddr(rlmPath)
+
+		if diff > 0 {
+			amount := overflow.Mulp(price.Amount, diff)
+			if capped {
+				if amount > remaining {
+					return nil, ErrInsufficientDeposit(fmt.Sprintf(
+						"not enough deposit to cover %d bytes of storage in %s; required %d%s, available %d%s",
+						diff, rlmPath, amount, price.Denom, remaining, price.Denom))
+				}
+				remaining -= amount
+			}
+			var coins std.Coins
+			if amount > 0 {
+				coins = std.Coins{std.NewCoin(price.Denom, amount)}
+				if err := vm.bank.SendCoins(ctx, caller, depositAddr, coins); err != nil {
+					return nil, err
+				}
+				locked = locked.Add(coins)
+				ctx.EventLogger().EmitEvent(newStorageDepositEvent(rlmPath, diff, coins))
+			}
+			deposit.Storage += uint64(diff)
+			deposit.Deposit = deposit.Deposit.Add(coins)
+			total.Storage += uint64(diff)
+			total.Deposit = total.Deposit.Add(coins)
+		} else {
+			// only the storage paid for by caller is released.
+			released := min(uint64(-diff), deposit.Storage)
+			if released == 0 {
+				continue
+			}
+			refund := deposit.Deposit
+			if released < deposit.Storage {
+				refund = make(std.Coins, 0, len(deposit.Deposit))
+				for _, coin := range deposit.Deposit {
+					amount := overflow.Mulp(coin.Amount, int64(released)) / int64(deposit.Storage)
+					if amount > 0 {
+						refund = append(refund, std.NewCoin(coin.Denom, amount))
+					}
+				}
+			}
+			if !refund.IsZero() {
+				if err := vm.bank.SendCoins(ctx, depositAddr, caller, refund); err != nil {
+					return nil, err
+				}
+				ctx.EventLogger().EmitEvent(newStorageUnlockEvent(rlmPath, released, refund))
+			}
+			deposit.Storage -= released
+			deposit.Deposit = deposit.Deposit.Sub(refund)
+			total.Storage -= released
+			total.Deposit = total.Deposit.Sub(refund)
+		}
+		vm.setStorageDeposit(ctx, rlmPath, caller, deposit)
+		vm.setRealmStorage(ctx, rlmPath, total)
+	}
+	return locked, nil
+}
+
+// Keys of the storage accounting of realms, in the VM's iavl store.
+const (
+	// storage:<realm path> -> total of the storage deposits of the realm.
+	realmStoragePrefix = "storage:"
+	// deposit:<realm path>:<depositor> -> storage deposit of depositor.
+	storageDepositPrefix = "deposit:"
+)
+
+// getRealmStorage returns the persisted bytes of the realm at rlmPath that
+// are paid for by storage deposits, and the total of these deposits.
+func (vm *VMKeeper) getRealmStorage(ctx sdk.Context, rlmPath string) StorageDeposit {
+	var total StorageDeposit
+	if bz := ctx.Store(vm.iavlKey).Get([]byte(realmStoragePrefix + rlmPath)); bz != nil {
+		amino.MustUnmarshal(bz, &total)
+	}
+	return total
+}
+
+func (vm *VMKeeper) setRealmStorage(ctx sdk.Context, rlmPath string, total StorageDeposit) {
+	key := []byte(realmStoragePrefix + rlmPath)
+	if total.Storage == 0 && total.Deposit.IsZero() {
+		ctx.Store(vm.iavlKey).Delete(key)
+		return
+	}
+	ctx.Store(vm.iavlKey).Set(key, amino.MustMarshal(total))
+}
+
+func storageDepositKey(rlmPath string, depositor crypto.Address) []byte {
+	return []byte(storageDepositPrefix + rlmPath + ":" + depositor.String())
+}
+
+// getStorageDeposit returns the storage deposit locked for rlmPath by
+// depositor, and the bytes it pays for.
+func (vm *VMKeeper) getStorageDeposit(ctx sdk.Context, rlmPath string, depositor crypto.Address) StorageDeposit {
+	var deposit StorageDeposit
+	if bz := ctx.Store(vm.iavlKey).Get(storageDepositKey(rlmPath, depositor)); bz != nil {
+		amino.MustUnmarshal(bz, &deposit)
+	}
+	return deposit
+}
+
+func (vm *VMKeeper) setStorageDeposit(ctx sdk.Context, rlmPath string, depositor crypto.Address, deposit StorageDeposit) {
+	stor := ctx.Store(vm.iavlKey)
+	key := storageDepositKey(rlmPath, depositor)
+	if deposit.Storage == 0 && deposit.Deposit.IsZero() {
+		stor.Delete(key)
+		return
+	}
+	stor.Set(key, amino.MustMarshal(deposit))
+}
+
 var reUserNamespace = regexp.MustCompile(`^[~_a-zA-Z0-9/]+$`)
 
 // QueryPaths returns public facing function signatures.
@@ -907,6 +1102,73 @@ func (vm *VMKeeper) QueryFile(ctx sdk.Context, filepath string) (res string, err
 	}
 }
 
+// QueryObject returns the persisted object identified by target, which is
+// either a package path or an ObjectID, along with the page of its children
+// references starting at offset.
+func (vm *VMKeeper) QueryObject(ctx sdk.Context, target string, offset, limit int) (*ObjectInfo, error) {
+	if offset < 0 || limit < 0 {
+		return nil, errors.New("cannot have negative offset or limit value")
+	}
+
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+
+	var oid gno.ObjectID
+	if strings.IndexByte(target, '/') >= 0 {
+		oid = gno.ObjectIDFromPkgPath(target)
+	} else if err := oid.UnmarshalAmino(target); err != nil {
+		return nil, ErrInvalidObjectID(err.Error())
+	}
+
+	oo, size := store.GetObjectImage(oid)
+	if oo == nil {
+		return nil, ErrObjectNotFound(fmt.Sprintf(
+			"object not found: %s", target))
+	}
+
+	oi := oo.GetObjectInfo()
+	res := &ObjectInfo{
+		ObjectID:  oid.String(),
+		Hash:      hex.EncodeToString(oi.Hash.Bytes()),
+		RefCount:  oi.RefCount,
+		IsEscaped: oi.IsEscaped,
+		Size:      size,
+		Object:    oo,
+	}
+	if !oi.OwnerID.IsZero() {
+		res.OwnerID = oi.OwnerID.String()
+	}
+
+	refs := gno.GetChildRefs(oo)
+	res.NumChildren = len(refs)
+	res.Children = []string{}
+	for i := offset; i < len(refs) && len(res.Children) < limit; i++ {
+		res.Children = append(res.Children, refs[i].ObjectID.String())
+	}
+	return res, nil
+}
+
+// QueryStorage returns the storage usage and the locked storage deposit of
+// the realm at pkgPath.
+func (vm *VMKeeper) QueryStorage(ctx sdk.Context, pkgPath string) (*RealmStorage, error) {
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	if !gno.IsRealmPath(pkgPath) {
+		return nil, ErrInvalidPkgPath(fmt.Sprintf(
+			"package is not realm: %s", pkgPath))
+	}
+	rlm := store.GetPackageRealm(pkgPath)
+	if rlm == nil {
+		return nil, ErrInvalidPkgPath(fmt.Sprintf(
+			"realm not found: %s", pkgPath))
+	}
+	total := vm.getRealmStorage(ctx, pkgPath)
+	return &RealmStorage{
+		PkgPath:        pkgPath,
+		Storage:        total.Storage,
+		Deposit:        total.Deposit,
+		DepositAddress: gno.DeriveStorageDepositCryptoAddr(pkgPath),
+	}, nil
+}
+
 func (vm *VMKeeper) QueryDoc(ctx sdk.Context, pkgPath string) (*doc.JSONDocumentation, error) {
 	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
 
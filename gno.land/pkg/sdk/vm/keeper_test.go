@@ -414,6 +414,162 @@ func Do() string {
 	assert.Equal(t, int64(1337), bar)
 }
 
+// Issued and removed realm coins are reflected in the total supply.
+func TestVMKeeperRealmIssue(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{Name: "issue.gno", Body: `
+package issue
+
+import "std"
+
+func denom() string {
+	return "/" + std.CurrentRealm().PkgPath() + ":token"
+}
+
+func Issue(amount int64) int64 {
+	crossing()
+
+	banker := std.NewBanker(std.BankerTypeRealmIssue)
+	banker.IssueCoin(std.OriginCaller(), denom(), amount)
+	return banker.TotalCoin(denom())
+}
+
+func Remove(amount int64) int64 {
+	crossing()
+
+	banker := std.NewBanker(std.BankerTypeRealmIssue)
+	banker.RemoveCoin(std.OriginCaller(), denom(), amount)
+	return banker.TotalCoin(denom())
+}`},
+	}
+	pkgPath := "gno.land/r/test/issue"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Issue", []string{"100"}))
+	require.NoError(t, err)
+	assert.Equal(t, "(100 int64)\n\n", res)
+
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Remove", []string{"30"}))
+	require.NoError(t, err)
+	assert.Equal(t, "(70 int64)\n\n", res)
+	assert.Equal(t, int64(70), env.bankk.TotalCoin(ctx, "/"+pkgPath+":token"))
+}
+
+// Realm storage growth locks a deposit; freed storage refunds its depositor.
+func TestVMKeeperStorageDeposit(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	env.prmk.SetString(ctx, "vm:p:storage_price", "10ugnot")
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{Name: "store.gno", Body: `
+package store
+
+import "strings"
+
+var data []string
+
+func Add(n int) {
+	crossing()
+
+	for i := 0; i < n; i++ {
+		data = append(data, strings.Repeat("x", 100))
+	}
+}
+
+func Reset() {
+	crossing()
+
+	data = nil
+}`},
+	}
+	pkgPath := "gno.land/r/test/store"
+	depositAddr := gnolang.DeriveStorageDepositCryptoAddr(pkgPath)
+
+	// Not enough max deposit.
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	msg1.MaxDeposit = std.MustParseCoins(ugnot.ValueString(10))
+	err := env.vmk.AddPackage(env.vmk.MakeGnoTransactionStore(ctx.WithMultiStore(ctx.MultiStore().MultiCacheWrap())), msg1)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, InsufficientDepositError{}))
+
+	// Uncapped deposit.
+	msg1.MaxDeposit = nil
+	err = env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	rs, err := env.vmk.QueryStorage(ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Greater(t, rs.Storage, uint64(0))
+	assert.Equal(t, std.MustParseCoins(ugnot.ValueString(int64(rs.Storage)*10)), rs.Deposit)
+	assert.Equal(t, rs.Deposit, env.bankk.GetCoins(ctx, depositAddr))
+	initial := *rs
+
+	// Growing the realm locks more deposit.
+	msg2 := NewMsgCall(addr, nil, pkgPath, "Add", []string{"10"})
+	_, err = env.vmk.Call(ctx, msg2)
+	require.NoError(t, err)
+
+	rs, err = env.vmk.QueryStorage(ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Greater(t, rs.Storage, initial.Storage+1000)
+	assert.Equal(t, rs.Deposit, env.bankk.GetCoins(ctx, depositAddr))
+
+	// Shrinking the realm does not refund the deposits of others, and keeps
+	// the storage they paid for.
+	other := crypto.AddressFromPreimage([]byte("addr2"))
+	env.acck.SetAccount(ctx, env.acck.NewAccountWithAddress(ctx, other))
+	env.bankk.SetCoins(ctx, other, std.MustParseCoins(coinsString))
+	_, err = env.vmk.Call(ctx, NewMsgCall(other, nil, pkgPath, "Reset", []string{}))
+	require.NoError(t, err)
+
+	rs2, err := env.vmk.QueryStorage(ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Equal(t, rs, rs2)
+	assert.Equal(t, std.MustParseCoins(coinsString), env.bankk.GetCoins(ctx, other))
+
+	_, err = env.vmk.Call(ctx, msg2)
+	require.NoError(t, err)
+	rs, err = env.vmk.QueryStorage(ctx, pkgPath)
+	require.NoError(t, err)
+	balance := env.bankk.GetCoins(ctx, addr)
+
+	// Shrinking the realm refunds the depositor, in the coins it locked even
+	// if the storage price changed since.
+	env.prmk.SetString(ctx, "vm:p:storage_price", "10foo")
+	msg3 := NewMsgCall(addr, nil, pkgPath, "Reset", []string{})
+	_, err = env.vmk.Call(ctx, msg3)
+	require.NoError(t, err)
+
+	rs2, err = env.vmk.QueryStorage(ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Less(t, rs2.Storage, rs.Storage)
+	refund := rs.Deposit.Sub(rs2.Deposit)
+	assert.True(t, refund.IsAllPositive())
+	assert.Equal(t, refund, std.Coins{std.NewCoin(ugnot.Denom, refund.AmountOf(ugnot.Denom))})
+	assert.Equal(t, balance.Add(refund), env.bankk.GetCoins(ctx, addr))
+	assert.Equal(t, rs2.Deposit, env.bankk.GetCoins(ctx, depositAddr))
+}
+
 // Assign admin as OriginCaller on deploying the package.
 func TestVMKeeperOriginCallerInit(t *testing.T) {
 	env := setupTestEnv()
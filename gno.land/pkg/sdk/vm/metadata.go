@@ -0,0 +1,146 @@
+package vm
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Package metadata limits.
+const (
+	maxPkgMetadataSize      = 2048 // sum of all fields, in bytes
+	maxPkgDescriptionLength = 512
+	maxPkgLicenseLength     = 64
+	maxPkgRepositoryLength  = 256
+	maxPkgAuthors           = 16
+	maxPkgAuthorLength      = 128
+)
+
+// Key prefix of the package metadata, in the VM's iavl store.
+const pkgMetadataKeyPrefix = "pkgmeta:"
+
+// reLicense matches SPDX license expressions, e.g. "MIT" or
+// "(Apache-2.0 OR GPL-2.0+)".
+var reLicense = regexp.MustCompile(`^[A-Za-z0-9.+\-() ]+$`)
+
+// PackageMetadata is the optional metadata of a package, declared in its
+// gno.mod with the description, license, author and repository directives.
+type PackageMetadata struct {
+	Description string   `json:"description,omitempty"`
+	License     string   `json:"license,omitempty"`
+	Authors     []string `json:"authors,omitempty"`
+	Repository  string   `json:"repository,omitempty"`
+}
+
+func (pm PackageMetadata) JSON() string {
+	bz := amino.MustMarshalJSON(pm)
+	return string(bz)
+}
+
+func (pm PackageMetadata) IsEmpty() bool {
+	return pm.Description == "" && pm.License == "" &&
+		len(pm.Authors) == 0 && pm.Repository == ""
+}
+
+// Validate checks the size and format of the metadata fields.
+func (pm PackageMetadata) Validate() error {
+	size := len(pm.Description) + len(pm.License) + len(pm.Repository)
+	for _, author := range pm.Authors {
+		size += len(author)
+	}
+	switch {
+	case size > maxPkgMetadataSize:
+		return fmt.Errorf("metadata too large: %d > %d bytes", size, maxPkgMetadataSize)
+	case len(pm.Description) > maxPkgDescriptionLength:
+		return fmt.Errorf("description too long: %d > %d bytes", len(pm.Description), maxPkgDescriptionLength)
+	case len(pm.License) > maxPkgLicenseLength:
+		return fmt.Errorf("license too long: %d > %d bytes", len(pm.License), maxPkgLicenseLength)
+	case len(pm.Repository) > maxPkgRepositoryLength:
+		return fmt.Errorf("repository too long: %d > %d bytes", len(pm.Repository), maxPkgRepositoryLength)
+	case len(pm.Authors) > maxPkgAuthors:
+		return fmt.Errorf("too many authors: %d > %d", len(pm.Authors), maxPkgAuthors)
+	}
+
+	if hasControlChars(pm.Description) {
+		return fmt.Errorf("description contains control characters")
+	}
+	if pm.License != "" && !reLicense.MatchString(pm.License) {
+		return fmt.Errorf("invalid license %q: must be a SPDX license expression", pm.License)
+	}
+	for _, author := range pm.Authors {
+		switch {
+		case author == "":
+			return fmt.Errorf("empty author")
+		case len(author) > maxPkgAuthorLength:
+			return fmt.Errorf("author too long: %d > %d bytes", len(author), maxPkgAuthorLength)
+		case hasControlChars(author):
+			return fmt.Errorf("author contains control characters")
+		}
+	}
+	if pm.Repository != "" {
+		u, err := url.Parse(pm.Repository)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid repository %q: must be an http(s) URL", pm.Repository)
+		}
+	}
+	return nil
+}
+
+func hasControlChars(s string) bool {
+	return strings.IndexFunc(s, unicode.IsControl) >= 0
+}
+
+// packageMetadata returns the metadata declared in the gno.mod of memPkg, or
+// nil if there is none.
+func packageMetadata(memPkg *std.MemPackage) (*PackageMetadata, error) {
+	if memPkg.GetFile("gno.mod") == nil {
+		return nil, nil
+	}
+	mod, err := gnomod.ParseMemPackage(memPkg)
+	if err != nil {
+		return nil, err
+	}
+	pm := &PackageMetadata{
+		Description: mod.Description,
+		License:     mod.License,
+		Authors:     mod.Authors,
+		Repository:  mod.Repository,
+	}
+	if pm.IsEmpty() {
+		return nil, nil
+	}
+	if err := pm.Validate(); err != nil {
+		return nil, err
+	}
+	return pm, nil
+}
+
+func pkgMetadataKey(pkgPath string) []byte {
+	return []byte(pkgMetadataKeyPrefix + pkgPath)
+}
+
+// setPackageMetadata stores the metadata of the package, alongside it.
+func (vm *VMKeeper) setPackageMetadata(ctx sdk.Context, pkgPath string, pm *PackageMetadata) {
+	ctx.Store(vm.iavlKey).Set(pkgMetadataKey(pkgPath), amino.MustMarshal(pm))
+}
+
+// QueryMetadata returns the metadata of the package at pkgPath. It is empty
+// if the package declared none.
+func (vm *VMKeeper) QueryMetadata(ctx sdk.Context, pkgPath string) (*PackageMetadata, error) {
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	if store.GetMemPackage(pkgPath) == nil {
+		return nil, fmt.Errorf("package %q is not available", pkgPath) // TODO: XSS protection
+	}
+	pm := &PackageMetadata{}
+	if bz := ctx.Store(vm.iavlKey).Get(pkgMetadataKey(pkgPath)); bz != nil {
+		amino.MustUnmarshal(bz, pm)
+	}
+	return pm, nil
+}
@@ -0,0 +1,76 @@
+package vm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageMetadata_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		pm          PackageMetadata
+		errContains string
+	}{
+		{
+			name: "valid",
+			pm: PackageMetadata{
+				Description: "A package",
+				License:     "(Apache-2.0 OR GPL-2.0+)",
+				Authors:     []string{"Alice", "Bob <bob@example.com>"},
+				Repository:  "https://github.com/gnolang/gno/tree/master/examples",
+			},
+		},
+		{
+			name:        "description too long",
+			pm:          PackageMetadata{Description: strings.Repeat("x", maxPkgDescriptionLength+1)},
+			errContains: "description too long",
+		},
+		{
+			name:        "too large",
+			pm:          PackageMetadata{Authors: []string{strings.Repeat("x", maxPkgMetadataSize+1)}},
+			errContains: "metadata too large",
+		},
+		{
+			name:        "control characters",
+			pm:          PackageMetadata{Description: "line\nbreak"},
+			errContains: "control characters",
+		},
+		{
+			name:        "invalid license",
+			pm:          PackageMetadata{License: "<script>"},
+			errContains: "invalid license",
+		},
+		{
+			name:        "empty author",
+			pm:          PackageMetadata{Authors: []string{""}},
+			errContains: "empty author",
+		},
+		{
+			name:        "too many authors",
+			pm:          PackageMetadata{Authors: strings.Split(strings.Repeat("a,", maxPkgAuthors), ",")},
+			errContains: "too many authors",
+		},
+		{
+			name:        "invalid repository",
+			pm:          PackageMetadata{Repository: "javascript:alert(1)"},
+			errContains: "invalid repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.pm.Validate()
+			if tc.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.errContains)
+			}
+		})
+	}
+}
@@ -16,9 +16,10 @@ import (
 
 // MsgAddPackage - create and initialize new package
 type MsgAddPackage struct {
-	Creator crypto.Address  `json:"creator" yaml:"creator"`
-	Package *std.MemPackage `json:"package" yaml:"package"`
-	Deposit std.Coins       `json:"deposit" yaml:"deposit"`
+	Creator    crypto.Address  `json:"creator" yaml:"creator"`
+	Package    *std.MemPackage `json:"package" yaml:"package"`
+	Deposit    std.Coins       `json:"deposit" yaml:"deposit"`
+	MaxDeposit std.Coins       `json:"max_deposit,omitempty" yaml:"max_deposit"` // storage deposit cap; no cap if empty.
 }
 
 var _ std.Msg = MsgAddPackage{}
@@ -59,6 +60,12 @@ func (msg MsgAddPackage) ValidateBasic() error {
 	if !msg.Deposit.IsValid() {
 		return std.ErrInvalidCoins(msg.Deposit.String())
 	}
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrInvalidCoins(msg.MaxDeposit.String())
+	}
+	if _, err := packageMetadata(msg.Package); err != nil {
+		return ErrInvalidPkgMetadata(err.Error())
+	}
 	// XXX validate files.
 	return nil
 }
@@ -83,14 +90,15 @@ func (msg MsgAddPackage) GetReceived() std.Coins {
 
 // MsgCall - executes a Gno statement.
 type MsgCall struct {
-	Caller  crypto.Address `json:"caller" yaml:"caller"`
-	Send    std.Coins      `json:"send" yaml:"send"`
-	PkgPath string         `json:"pkg_path" yaml:"pkg_path"`
-	Func    string         `json:"func" yaml:"func"`
-	Args    []string       `json:"args" yaml:"args"`
+	Caller     crypto.Address `json:"caller" yaml:"caller"`
+	Send       std.Coins      `json:"send" yaml:"send"`
+	PkgPath    string         `json:"pkg_path" yaml:"pkg_path"`
+	Func       string         `json:"func" yaml:"func"`
+	Args       []string       `json:"args" yaml:"args"`
+	MaxDeposit std.Coins      `json:"max_deposit,omitempty" yaml:"max_deposit"` // storage deposit cap; no cap if empty.
 }
 
-var _ std.Msg = MsgCall{}
+var _ std.ReceiversMsg = MsgCall{}
 
 func NewMsgCall(caller crypto.Address, send sdk.Coins, pkgPath, fnc string, args []string) MsgCall {
 	return MsgCall{
@@ -125,6 +133,9 @@ func (msg MsgCall) ValidateBasic() error {
 	if msg.Func == "" { // XXX
 		return ErrInvalidExpr("missing function to call")
 	}
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrInvalidCoins(msg.MaxDeposit.String())
+	}
 	return nil
 }
 
@@ -143,14 +154,24 @@ func (msg MsgCall) GetReceived() std.Coins {
 	return msg.Send
 }
 
+// Implements ReceiversMsg.
+// The realm called receives the coins sent, if any.
+func (msg MsgCall) GetReceivers() []crypto.Address {
+	if msg.Send.IsZero() {
+		return nil
+	}
+	return []crypto.Address{gno.DerivePkgCryptoAddr(msg.PkgPath)}
+}
+
 //----------------------------------------
 // MsgRun
 
 // MsgRun - executes arbitrary Gno code.
 type MsgRun struct {
-	Caller  crypto.Address  `json:"caller" yaml:"caller"`
-	Send    std.Coins       `json:"send" yaml:"send"`
-	Package *std.MemPackage `json:"package" yaml:"package"`
+	Caller     crypto.Address  `json:"caller" yaml:"caller"`
+	Send       std.Coins       `json:"send" yaml:"send"`
+	Package    *std.MemPackage `json:"package" yaml:"package"`
+	MaxDeposit std.Coins       `json:"max_deposit,omitempty" yaml:"max_deposit"` // storage deposit cap; no cap if empty.
 }
 
 var _ std.Msg = MsgRun{}
@@ -195,6 +216,10 @@ func (msg MsgRun) ValidateBasic() error {
 		}
 	}
 
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrInvalidCoins(msg.MaxDeposit.String())
+	}
+
 	return nil
 }
 
@@ -212,3 +237,83 @@ func (msg MsgRun) GetSigners() []crypto.Address {
 func (msg MsgRun) GetReceived() std.Coins {
 	return msg.Send
 }
+
+//----------------------------------------
+// MsgAddSessionKey
+
+// MsgAddSessionKey - registers a session key on the account of the creator.
+type MsgAddSessionKey struct {
+	Creator    crypto.Address `json:"creator" yaml:"creator"`
+	SessionKey SessionKey     `json:"session_key" yaml:"session_key"`
+}
+
+var _ std.Msg = MsgAddSessionKey{}
+
+// Implements Msg.
+func (msg MsgAddSessionKey) Route() string { return RouterKey }
+
+// Implements Msg.
+func (msg MsgAddSessionKey) Type() string { return "add_session_key" }
+
+// Implements Msg.
+func (msg MsgAddSessionKey) ValidateBasic() error {
+	if msg.Creator.IsZero() {
+		return std.ErrInvalidAddress("missing creator address")
+	}
+	if err := msg.SessionKey.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.SessionKey.PubKey.Address() == msg.Creator {
+		return std.ErrInvalidPubKey("session key must not be the creator's key")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgAddSessionKey) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// Implements Msg.
+func (msg MsgAddSessionKey) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Creator}
+}
+
+//----------------------------------------
+// MsgRevokeSessionKey
+
+// MsgRevokeSessionKey - removes a session key from the account of the
+// creator.
+type MsgRevokeSessionKey struct {
+	Creator crypto.Address `json:"creator" yaml:"creator"`
+	PubKey  crypto.PubKey  `json:"pub_key" yaml:"pub_key"`
+}
+
+var _ std.Msg = MsgRevokeSessionKey{}
+
+// Implements Msg.
+func (msg MsgRevokeSessionKey) Route() string { return RouterKey }
+
+// Implements Msg.
+func (msg MsgRevokeSessionKey) Type() string { return "revoke_session_key" }
+
+// Implements Msg.
+func (msg MsgRevokeSessionKey) ValidateBasic() error {
+	if msg.Creator.IsZero() {
+		return std.ErrInvalidAddress("missing creator address")
+	}
+	if msg.PubKey == nil {
+		return std.ErrInvalidPubKey("missing session key public key")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgRevokeSessionKey) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// Implements Msg.
+func (msg MsgRevokeSessionKey) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Creator}
+}
@@ -84,6 +84,26 @@ func TestMsgAddPackage_ValidateBasic(t *testing.T) {
 			},
 			expectErr: std.InvalidCoinsError{},
 		},
+		{
+			name: "valid metadata",
+			msg: NewMsgAddPackage(creator, pkgPath, append([]*std.MemFile{{
+				Name: "gno.mod",
+				Body: "module gno.land/r/namespace/test\n\nlicense MIT\nrepository \"https://github.com/gnolang/gno\"\n",
+			}}, files...)),
+			expectSignBytes: `{"creator":"g14ch5q26mhx3jk5cxl88t278nper264ces4m8nt","deposit":"",` +
+				`"package":{"files":[{"body":"module gno.land/r/namespace/test\n\nlicense MIT\nrepository \"https://github.com/gnolang/gno\"\n",` +
+				`"name":"gno.mod"},{"body":"package test\n\t\tfunc Echo() string {return \"hello world\"}",` +
+				`"name":"test.gno"}],"name":"test","path":"gno.land/r/namespace/test"}}`,
+			expectErr: nil,
+		},
+		{
+			name: "invalid metadata",
+			msg: NewMsgAddPackage(creator, pkgPath, append([]*std.MemFile{{
+				Name: "gno.mod",
+				Body: "module gno.land/r/namespace/test\n\nrepository \"ftp://example.com\"\n",
+			}}, files...)),
+			expectErr: InvalidPkgMetadataError{},
+		},
 	}
 
 	for _, tc := range tests {
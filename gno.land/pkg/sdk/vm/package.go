@@ -15,6 +15,11 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	MsgCall{}, "m_call",
 	MsgRun{}, "m_run",
 	MsgAddPackage{}, "m_addpkg", // TODO rename both to MsgAddPkg?
+	ScheduledCall{}, "ScheduledCall",
+	CallAuthorization{}, "CallAuthorization",
+	MsgAddSessionKey{}, "m_add_session_key",
+	MsgRevokeSessionKey{}, "m_revoke_session_key",
+	SessionKey{}, "SessionKey",
 
 	// errors
 	InvalidPkgPathError{}, "InvalidPkgPathError",
@@ -24,4 +29,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	InvalidExprError{}, "InvalidExprError",
 	TypeCheckError{}, "TypeCheckError",
 	UnauthorizedUserError{}, "UnauthorizedUserError",
+	InvalidObjectIDError{}, "InvalidObjectIDError",
+	ObjectNotFoundError{}, "ObjectNotFoundError",
+	InsufficientDepositError{}, "InsufficientDepositError",
+	InvalidPkgMetadataError{}, "InvalidPkgMetadataError",
 ))
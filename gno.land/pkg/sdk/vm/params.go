@@ -8,11 +8,13 @@ import (
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 const (
-	sysNamesPkgDefault = "gno.land/r/sys/names"
-	chainDomainDefault = "gno.land"
+	sysNamesPkgDefault  = "gno.land/r/sys/names"
+	chainDomainDefault  = "gno.land"
+	storagePriceDefault = "0ugnot" // per byte; deposits disabled until set.
 )
 
 var ASCIIDomain = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z]{2,}$`)
@@ -21,19 +23,21 @@ var ASCIIDomain = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-
 type Params struct {
 	SysNamesPkgPath string `json:"sysnames_pkgpath" yaml:"sysnames_pkgpath"`
 	ChainDomain     string `json:"chain_domain" yaml:"chain_domain"`
+	StoragePrice    string `json:"storage_price" yaml:"storage_price"` // per byte of realm storage
 }
 
 // NewParams creates a new Params object
-func NewParams(namesPkgPath, chainDomain string) Params {
+func NewParams(namesPkgPath, chainDomain, storagePrice string) Params {
 	return Params{
 		SysNamesPkgPath: namesPkgPath,
 		ChainDomain:     chainDomain,
+		StoragePrice:    storagePrice,
 	}
 }
 
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
-	return NewParams(sysNamesPkgDefault, chainDomainDefault)
+	return NewParams(sysNamesPkgDefault, chainDomainDefault, storagePriceDefault)
 }
 
 // String implements the stringer interface.
@@ -42,6 +46,7 @@ func (p Params) String() string {
 	sb.WriteString("Params: \n")
 	sb.WriteString(fmt.Sprintf("SysUsersPkgPath: %q\n", p.SysNamesPkgPath))
 	sb.WriteString(fmt.Sprintf("ChainDomain: %q\n", p.ChainDomain))
+	sb.WriteString(fmt.Sprintf("StoragePrice: %q\n", p.StoragePrice))
 	return sb.String()
 }
 
@@ -52,6 +57,11 @@ func (p Params) Validate() error {
 	if p.ChainDomain != "" && !ASCIIDomain.MatchString(p.ChainDomain) {
 		return fmt.Errorf("invalid chain domain %q, failed to match %q", p.ChainDomain, ASCIIDomain)
 	}
+	if p.StoragePrice != "" {
+		if _, err := std.ParseCoin(p.StoragePrice); err != nil {
+			return fmt.Errorf("invalid storage price %q: %w", p.StoragePrice, err)
+		}
+	}
 	return nil
 }
 
@@ -75,8 +85,9 @@ func (vm *VMKeeper) GetParams(ctx sdk.Context) Params {
 }
 
 const (
-	sysUsersPkgParamPath = "vm:p:sysnames_pkgpath"
-	chainDomainParamPath = "vm:p:chain_domain"
+	sysUsersPkgParamPath  = "vm:p:sysnames_pkgpath"
+	chainDomainParamPath  = "vm:p:chain_domain"
+	storagePriceParamPath = "vm:p:storage_price"
 )
 
 func (vm *VMKeeper) getChainDomainParam(ctx sdk.Context) string {
@@ -91,6 +102,15 @@ func (vm *VMKeeper) getSysNamesPkgParam(ctx sdk.Context) string {
 	return sysNamesPkg
 }
 
+func (vm *VMKeeper) getStoragePriceParam(ctx sdk.Context) std.Coin {
+	storagePrice := storagePriceDefault
+	vm.prmk.GetString(ctx, storagePriceParamPath, &storagePrice)
+	if storagePrice == "" {
+		storagePrice = storagePriceDefault
+	}
+	return std.MustParseCoin(storagePrice)
+}
+
 func (vm *VMKeeper) WillSetParam(ctx sdk.Context, key string, value any) {
 	// XXX validate input?
 }
@@ -12,13 +12,15 @@ func TestParamsString(t *testing.T) {
 	p := Params{
 		SysNamesPkgPath: "gno.land/r/sys/names", // XXX what is this really for now
 		ChainDomain:     "example.com",
+		StoragePrice:    "100ugnot",
 	}
 	result := p.String()
 
 	// Construct the expected string.
 	expected := "Params: \n" +
 		fmt.Sprintf("SysUsersPkgPath: %q\n", p.SysNamesPkgPath) +
-		fmt.Sprintf("ChainDomain: %q\n", p.ChainDomain)
+		fmt.Sprintf("ChainDomain: %q\n", p.ChainDomain) +
+		fmt.Sprintf("StoragePrice: %q\n", p.StoragePrice)
 
 	// Assert: check if the result matches the expected string.
 	if result != expected {
@@ -64,6 +66,17 @@ func TestWillSetParam(t *testing.T) {
 			isUpdated:   true,
 			isEqual:     true,
 		},
+		{
+			name:  "update storage_price",
+			key:   "storage_price",
+			value: "100ugnot",
+			getExpectedValue: func(prms Params) string {
+				return prms.StoragePrice
+			},
+			shouldPanic: false,
+			isUpdated:   true,
+			isEqual:     true,
+		},
 		/* unknown parameter keys are OK
 		{
 			name:             "unknown parameter key panics",
@@ -0,0 +1,229 @@
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+const (
+	// maxScheduledCallGas is the maximum gas limit of a single scheduled call.
+	maxScheduledCallGas = 10_000_000
+	// maxScheduledGasPerBlock bounds the sum of the gas limits of the
+	// scheduled calls executed in a single block. Calls that do not fit are
+	// deferred to the following blocks.
+	maxScheduledGasPerBlock = 100_000_000
+	// maxScheduledCallArgs is the maximum number of arguments of a scheduled call.
+	maxScheduledCallArgs = 32
+)
+
+// Keys of the scheduled calls, in the VM's iavl store.
+// Calls are indexed by the big endian encoding of their height (or
+// timestamp) followed by their ID, so that iteration yields them in
+// execution order.
+const (
+	scheduleNextIDKey       = "sched:id"
+	scheduleHeightPrefix    = "sched:h:"
+	scheduleTimestampPrefix = "sched:t:"
+)
+
+// Scheduled call event type, emitted on behalf of the realm when a scheduled
+// call is executed.
+const EventScheduledCall = "ScheduledCall"
+
+// ScheduledCall is a call to a realm function registered with
+// std.ScheduleCall, to be run at the end of a future block.
+type ScheduledCall struct {
+	ID        uint64   `json:"id"`
+	PkgPath   string   `json:"pkg_path"`
+	Func      string   `json:"func"`
+	Args      []string `json:"args"`
+	Height    int64    `json:"height,omitempty"`
+	Timestamp int64    `json:"timestamp,omitempty"`
+	GasLimit  int64    `json:"gas_limit"`
+}
+
+func (sc ScheduledCall) key() []byte {
+	if sc.Height > 0 {
+		return scheduleKey(scheduleHeightPrefix, sc.Height, sc.ID)
+	}
+	return scheduleKey(scheduleTimestampPrefix, sc.Timestamp, sc.ID)
+}
+
+func scheduleKey(prefix string, at int64, id uint64) []byte {
+	key := make([]byte, 0, len(prefix)+16)
+	key = append(key, prefix...)
+	key = binary.BigEndian.AppendUint64(key, uint64(at))
+	key = binary.BigEndian.AppendUint64(key, id)
+	return key
+}
+
+// ----------------------------------------
+// SDKScheduler
+
+// This implements SchedulerInterface,
+// which is available as ExecContext.Scheduler.
+
+type SDKScheduler struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKScheduler(vmk *VMKeeper, ctx sdk.Context) *SDKScheduler {
+	return &SDKScheduler{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (sch *SDKScheduler) ScheduleCall(pkgPath, fn string, args []string, height, timestamp, gasLimit int64) (uint64, error) {
+	switch {
+	case fn == "":
+		return 0, fmt.Errorf("scheduled call function is empty")
+	case len(args) > maxScheduledCallArgs:
+		return 0, fmt.Errorf("scheduled call has too many arguments: %d > %d", len(args), maxScheduledCallArgs)
+	case gasLimit > maxScheduledCallGas:
+		return 0, fmt.Errorf("scheduled call gas limit too high: %d > %d", gasLimit, maxScheduledCallGas)
+	case height > 0 && timestamp > 0:
+		return 0, fmt.Errorf("scheduled call cannot have both a height and a timestamp")
+	case height > 0 && height <= sch.ctx.BlockHeight():
+		return 0, fmt.Errorf("scheduled call height %d is not in the future", height)
+	case height <= 0 && timestamp <= sch.ctx.BlockTime().Unix():
+		return 0, fmt.Errorf("scheduled call timestamp %d is not in the future", timestamp)
+	}
+
+	stor := sch.ctx.Store(sch.vmk.iavlKey)
+	var id uint64
+	if bz := stor.Get([]byte(scheduleNextIDKey)); bz != nil {
+		id = binary.BigEndian.Uint64(bz)
+	}
+	stor.Set([]byte(scheduleNextIDKey), binary.BigEndian.AppendUint64(nil, id+1))
+
+	sc := ScheduledCall{
+		ID:        id,
+		PkgPath:   pkgPath,
+		Func:      fn,
+		Args:      args,
+		Height:    height,
+		Timestamp: timestamp,
+		GasLimit:  gasLimit,
+	}
+	stor.Set(sc.key(), amino.MustMarshal(sc))
+	return id, nil
+}
+
+// ----------------------------------------
+// Scheduled calls execution
+
+// RunScheduledCalls executes the scheduled calls which are due at the
+// current block height or time, in order of height (or timestamp) and ID.
+// The sum of the gas limits of the executed calls is bounded by
+// maxScheduledGasPerBlock; remaining calls are run in the next blocks.
+//
+// Each call runs in its own cached context, and only its state changes are
+// committed if it succeeds. A ScheduledCall event is returned for every
+// executed call, reporting failures as well.
+func (vm *VMKeeper) RunScheduledCalls(ctx sdk.Context) []abci.Event {
+	due := vm.dueScheduledCalls(ctx)
+	if len(due) == 0 {
+		return nil
+	}
+
+	stor := ctx.Store(vm.iavlKey)
+	events := make([]abci.Event, 0, len(due))
+	for _, sc := range due {
+		// Remove the call before running it, whatever its outcome.
+		stor.Delete(sc.key())
+		events = append(events, vm.runScheduledCall(ctx, sc)...)
+	}
+	return events
+}
+
+// dueScheduledCalls returns the calls to be executed in the current block,
+// within the per-block gas budget.
+func (vm *VMKeeper) dueScheduledCalls(ctx sdk.Context) []ScheduledCall {
+	var (
+		stor   = ctx.Store(vm.iavlKey)
+		budget = int64(maxScheduledGasPerBlock)
+		due    []ScheduledCall
+	)
+	collect := func(prefix string, until int64) bool {
+		start := []byte(prefix)
+		end := scheduleKey(prefix, until+1, 0)
+		iter := stor.Iterator(start, end)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			var sc ScheduledCall
+			amino.MustUnmarshal(iter.Value(), &sc)
+			if sc.GasLimit > budget {
+				return false
+			}
+			budget -= sc.GasLimit
+			due = append(due, sc)
+		}
+		return true
+	}
+	if collect(scheduleHeightPrefix, ctx.BlockHeight()) {
+		collect(scheduleTimestampPrefix, ctx.BlockTime().Unix())
+	}
+	return due
+}
+
+func (vm *VMKeeper) runScheduledCall(ctx sdk.Context, sc ScheduledCall) []abci.Event {
+	cctx, write := ctx.CacheContext()
+	cctx = cctx.WithGasMeter(store.NewGasMeter(sc.GasLimit))
+	cctx = vm.MakeGnoTransactionStore(cctx)
+
+	msg := MsgCall{
+		Caller:  gno.DerivePkgCryptoAddr(sc.PkgPath),
+		PkgPath: sc.PkgPath,
+		Func:    sc.Func,
+		Args:    sc.Args,
+	}
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				switch r := r.(type) {
+				case error:
+					err = r
+				default:
+					err = fmt.Errorf("%v", r)
+				}
+			}
+		}()
+		_, err = vm.Call(cctx, msg)
+		return err
+	}()
+
+	status := "success"
+	var events []abci.Event
+	if err == nil {
+		vm.CommitGnoTransactionStore(cctx)
+		write()
+		events = cctx.EventLogger().Events()
+	} else {
+		status = "failure"
+	}
+
+	attrs := []gnostd.GnoEventAttribute{
+		{Key: "ID", Value: strconv.FormatUint(sc.ID, 10)},
+		{Key: "Func", Value: sc.Func},
+		{Key: "Status", Value: status},
+		{Key: "GasUsed", Value: strconv.FormatInt(cctx.GasMeter().GasConsumed(), 10)},
+	}
+	if err != nil {
+		attrs = append(attrs, gnostd.GnoEventAttribute{Key: "Error", Value: err.Error()})
+	}
+	return append(events, gnostd.GnoEvent{
+		Type:       EventScheduledCall,
+		Attributes: attrs,
+		PkgPath:    sc.PkgPath,
+	})
+}
@@ -0,0 +1,160 @@
+package vm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+func TestVMKeeperScheduledCalls(t *testing.T) {
+	env := setupTestEnv()
+	now := time.Unix(1_700_000_000, 0)
+	atBlock := func(height int64, t time.Time) sdk.Context {
+		return env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: height, Time: t})
+	}
+	ctx := env.vmk.MakeGnoTransactionStore(atBlock(10, now))
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{Name: "sched.gno", Body: `
+package sched
+
+import (
+	"std"
+	"strconv"
+)
+
+var ticks []string
+
+func Schedule(height int64, val string) uint64 {
+	crossing()
+
+	return std.ScheduleCall(height, 1_000_000, "Tick", val)
+}
+
+func ScheduleAt(timestamp int64, val string) uint64 {
+	crossing()
+
+	return std.ScheduleCallAt(timestamp, 1_000_000, "Tick", val)
+}
+
+func ScheduleFail(height int64) uint64 {
+	crossing()
+
+	return std.ScheduleCall(height, 1_000_000, "Fail")
+}
+
+func Tick(val string) {
+	crossing()
+
+	ticks = append(ticks, val)
+}
+
+func Fail() {
+	crossing()
+
+	ticks = append(ticks, "fail")
+	panic("boom")
+}
+
+func Ticks() string {
+	return strconv.Itoa(len(ticks)) + ":" + strings(ticks)
+}
+
+func strings(ss []string) (res string) {
+	for _, s := range ss {
+		res += s + ","
+	}
+	return
+}`},
+	}
+	pkgPath := "gno.land/r/test/sched"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	// The gas limit of the scheduled call is prepaid.
+	gctx := ctx.WithGasMeter(store.NewInfiniteGasMeter())
+	_, err = env.vmk.Call(gctx, NewMsgCall(addr, nil, pkgPath, "Schedule", []string{"11", "a"}))
+	require.NoError(t, err)
+	assert.Greater(t, gctx.GasMeter().GasConsumed(), int64(1_000_000))
+
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "ScheduleAt", []string{"1700000060", "b"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "ScheduleFail", []string{"12"}))
+	require.NoError(t, err)
+
+	// Scheduling in the past is rejected.
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Schedule", []string{"10", "c"}))
+	require.Error(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	ticks := func(ctx sdk.Context) string {
+		res, err := env.vmk.QueryEval(env.vmk.MakeGnoTransactionStore(ctx), pkgPath, "Ticks()")
+		require.NoError(t, err)
+		return res
+	}
+
+	// Nothing is due yet.
+	events := env.vmk.RunScheduledCalls(atBlock(10, now))
+	assert.Empty(t, events)
+
+	// Height 11: the first call runs.
+	events = env.vmk.RunScheduledCalls(atBlock(11, now.Add(5*time.Second)))
+	require.Len(t, events, 1)
+	ev := events[0].(gnostd.GnoEvent)
+	assert.Equal(t, EventScheduledCall, ev.Type)
+	assert.Equal(t, pkgPath, ev.PkgPath)
+	assert.Contains(t, ev.Attributes, gnostd.GnoEventAttribute{Key: "Status", Value: "success"})
+	assert.Equal(t, `("1:a," string)`, ticks(atBlock(11, now)))
+
+	// Height 12 and past the timestamp: the failing call is reverted and
+	// reported, the timed call runs.
+	events = env.vmk.RunScheduledCalls(atBlock(12, now.Add(time.Minute)))
+	require.Len(t, events, 2)
+	ev = events[0].(gnostd.GnoEvent)
+	assert.Contains(t, ev.Attributes, gnostd.GnoEventAttribute{Key: "Status", Value: "failure"})
+	assert.Contains(t, ev.Attributes, gnostd.GnoEventAttribute{Key: "Error", Value: "boom"})
+	ev = events[1].(gnostd.GnoEvent)
+	assert.Contains(t, ev.Attributes, gnostd.GnoEventAttribute{Key: "Status", Value: "success"})
+	assert.Equal(t, `("2:a,b," string)`, ticks(atBlock(12, now)))
+
+	// Executed calls are removed.
+	events = env.vmk.RunScheduledCalls(atBlock(13, now.Add(time.Hour)))
+	assert.Empty(t, events)
+}
+
+func TestVMKeeperScheduledCallsBlockBudget(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1})
+	sch := NewSDKScheduler(env.vmk, ctx)
+
+	n := maxScheduledGasPerBlock/maxScheduledCallGas + 1
+	for i := 0; i < n; i++ {
+		_, err := sch.ScheduleCall("gno.land/r/test/missing", "Do", nil, 2, 0, maxScheduledCallGas)
+		require.NoError(t, err)
+	}
+	_, err := sch.ScheduleCall("gno.land/r/test/missing", "Do", nil, 2, 0, maxScheduledCallGas+1)
+	require.Error(t, err)
+
+	// Only the calls fitting in the block budget run, the others are deferred.
+	events := env.vmk.RunScheduledCalls(env.ctx.WithBlockHeader(&bft.Header{Height: 2}))
+	assert.Len(t, events, n-1)
+	events = env.vmk.RunScheduledCalls(env.ctx.WithBlockHeader(&bft.Header{Height: 3}))
+	assert.Len(t, events, 1)
+}
@@ -0,0 +1,215 @@
+package vm
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// MaxSessionKeys is the maximum number of session keys of an account.
+const MaxSessionKeys = 16
+
+// SessionKey is a secondary public key of an account, allowed to sign the
+// transactions of the account which only contain calls to the realms
+// AllowedPaths, until the block height ExpiresAt.
+//
+// The fee of the transactions it signs cannot exceed MaxFee, and the coins
+// they send and their storage deposits are taken from the account up to a
+// total of SpendLimit, which cannot be empty. Calls must cap their storage
+// deposit with MsgCall.MaxDeposit, which SpendLimit must cover along with the
+// sent coins; only the deposit actually locked is charged to SpendLimit.
+type SessionKey struct {
+	PubKey       crypto.PubKey `json:"pub_key" yaml:"pub_key"`
+	AllowedPaths []string      `json:"allowed_paths" yaml:"allowed_paths"`
+	MaxFee       std.Coin      `json:"max_fee" yaml:"max_fee"`
+	SpendLimit   std.Coins     `json:"spend_limit" yaml:"spend_limit"`
+	ExpiresAt    int64         `json:"expires_at" yaml:"expires_at"`
+}
+
+// SessionKeyAccount is implemented by accounts which can register session
+// keys.
+type SessionKeyAccount interface {
+	std.Account
+	std.SessionKeyAccount
+
+	GetSessionKeys() []SessionKey
+	SetSessionKeys(keys []SessionKey)
+}
+
+// ValidateBasic does a simple validation check that doesn't require access to
+// any other information.
+func (sk SessionKey) ValidateBasic() error {
+	if sk.PubKey == nil {
+		return std.ErrInvalidPubKey("missing session key public key")
+	}
+	if len(sk.AllowedPaths) == 0 {
+		return ErrInvalidPkgPath("session key must allow at least one realm")
+	}
+	for _, path := range sk.AllowedPaths {
+		if path == "" {
+			return ErrInvalidPkgPath("missing package path")
+		}
+	}
+	if !sk.MaxFee.IsValid() {
+		return std.ErrInvalidCoins(sk.MaxFee.String())
+	}
+	if !sk.SpendLimit.IsValid() {
+		return std.ErrInvalidCoins(sk.SpendLimit.String())
+	}
+	if sk.SpendLimit.IsZero() {
+		// calls need a max deposit, which the spend limit must cover.
+		return std.ErrInvalidCoins("session key spend limit must cover the storage deposits of its calls")
+	}
+	if sk.ExpiresAt <= 0 {
+		return std.ErrUnknownRequest("session key expiry height must be positive")
+	}
+	return nil
+}
+
+// IsExpired returns whether the session key can no longer be used in the block
+// at height.
+func (sk SessionKey) IsExpired(height int64) bool {
+	return height > sk.ExpiresAt
+}
+
+// Accept checks that the session key is allowed to sign tx in the block at
+// height. It returns the session key left after spending the coins sent by
+// tx, whose maximum storage deposits must also fit in the spend limit; the
+// deposits are charged by the VM keeper once they are known.
+func (sk SessionKey) Accept(tx std.Tx, height int64) (SessionKey, error) {
+	if sk.IsExpired(height) {
+		return sk, std.ErrUnauthorized(
+			fmt.Sprintf("session key expired at height %d", sk.ExpiresAt))
+	}
+
+	if fee := tx.Fee.GasFee; !fee.IsZero() && (fee.Denom != sk.MaxFee.Denom || sk.MaxFee.IsLT(fee)) {
+		return sk, std.ErrUnauthorized(
+			fmt.Sprintf("session key max fee exceeded; %s < %s", sk.MaxFee, tx.Fee.GasFee))
+	}
+
+	var spent, reserved std.Coins
+	for _, msg := range tx.GetMsgs() {
+		call, ok := msg.(MsgCall)
+		if !ok {
+			return sk, std.ErrUnauthorized(fmt.Sprintf("session key does not allow %T", msg))
+		}
+		if !slices.Contains(sk.AllowedPaths, call.PkgPath) {
+			return sk, std.ErrUnauthorized(
+				fmt.Sprintf("session key does not allow calling %s", call.PkgPath))
+		}
+		// an empty MaxDeposit does not cap the storage deposit.
+		if call.MaxDeposit.IsZero() {
+			return sk, std.ErrUnauthorized("session key requires calls with a max deposit")
+		}
+		spent = spent.Add(call.Send)
+		reserved = reserved.Add(call.MaxDeposit)
+	}
+
+	if required := spent.Add(reserved); !sk.SpendLimit.IsAllGTE(required) {
+		return sk, std.ErrInsufficientFunds(
+			fmt.Sprintf("session key spend limit exceeded; %s < %s", sk.SpendLimit, required))
+	}
+	sk.SpendLimit = sk.SpendLimit.Sub(spent)
+	return sk, nil
+}
+
+// AddSessionKey registers the session key of msg on the account of its
+// creator, replacing the session key with the same public key if any. Expired
+// session keys are removed.
+func (vm *VMKeeper) AddSessionKey(ctx sdk.Context, msg MsgAddSessionKey) error {
+	acc, err := vm.getSessionKeyAccount(ctx, msg.Creator)
+	if err != nil {
+		return err
+	}
+
+	if msg.SessionKey.IsExpired(ctx.BlockHeight()) {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("session key expired at height %d", msg.SessionKey.ExpiresAt))
+	}
+
+	keys := slices.DeleteFunc(acc.GetSessionKeys(), func(key SessionKey) bool {
+		return key.IsExpired(ctx.BlockHeight()) || key.PubKey.Equals(msg.SessionKey.PubKey)
+	})
+	if len(keys) >= MaxSessionKeys {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("account %s already has %d session keys", msg.Creator, MaxSessionKeys))
+	}
+
+	acc.SetSessionKeys(append(keys, msg.SessionKey))
+	vm.acck.SetAccount(ctx, acc)
+	return nil
+}
+
+// RevokeSessionKey removes the session key of msg from the account of its
+// creator.
+func (vm *VMKeeper) RevokeSessionKey(ctx sdk.Context, msg MsgRevokeSessionKey) error {
+	acc, err := vm.getSessionKeyAccount(ctx, msg.Creator)
+	if err != nil {
+		return err
+	}
+
+	keys := acc.GetSessionKeys()
+	index := slices.IndexFunc(keys, func(key SessionKey) bool {
+		return key.PubKey.Equals(msg.PubKey)
+	})
+	if index < 0 {
+		return std.ErrUnknownRequest(
+			fmt.Sprintf("account %s has no session key %s", msg.Creator, msg.PubKey))
+	}
+
+	acc.SetSessionKeys(slices.Delete(keys, index, index+1))
+	vm.acck.SetAccount(ctx, acc)
+	return nil
+}
+
+// spendSessionKeyDeposit charges the storage deposit locked by a call of
+// caller to the spend limit of the session key which signed it, if any.
+func (vm *VMKeeper) spendSessionKeyDeposit(ctx sdk.Context, caller crypto.Address, deposit std.Coins) error {
+	if deposit.IsZero() {
+		return nil
+	}
+	sessionKeys, _ := ctx.Value(auth.SessionKeysContextKey{}).(map[crypto.Address]crypto.PubKey)
+	pubKey, ok := sessionKeys[caller]
+	if !ok {
+		return nil
+	}
+	acc, err := vm.getSessionKeyAccount(ctx, caller)
+	if err != nil {
+		return err
+	}
+
+	keys := acc.GetSessionKeys()
+	index := slices.IndexFunc(keys, func(key SessionKey) bool {
+		return key.PubKey.Equals(pubKey)
+	})
+	if index < 0 {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("account %s has no session key %s", caller, pubKey))
+	}
+	if !keys[index].SpendLimit.IsAllGTE(deposit) {
+		return std.ErrInsufficientFunds(
+			fmt.Sprintf("session key spend limit exceeded; %s < %s", keys[index].SpendLimit, deposit))
+	}
+	keys[index].SpendLimit = keys[index].SpendLimit.Sub(deposit)
+
+	acc.SetSessionKeys(keys)
+	vm.acck.SetAccount(ctx, acc)
+	return nil
+}
+
+func (vm *VMKeeper) getSessionKeyAccount(ctx sdk.Context, addr crypto.Address) (SessionKeyAccount, error) {
+	acc := vm.acck.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, std.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+	}
+	skacc, ok := acc.(SessionKeyAccount)
+	if !ok {
+		return nil, std.ErrUnauthorized(
+			fmt.Sprintf("account %s does not support session keys", addr))
+	}
+	return skacc, nil
+}
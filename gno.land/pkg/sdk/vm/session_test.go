@@ -0,0 +1,210 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func newTestSessionKey() SessionKey {
+	return SessionKey{
+		PubKey:       ed25519.GenPrivKey().PubKey(),
+		AllowedPaths: []string{"gno.land/r/demo/game"},
+		MaxFee:       std.NewCoin("ugnot", 1000),
+		SpendLimit:   std.NewCoins(std.NewCoin("ugnot", 100)),
+		ExpiresAt:    10,
+	}
+}
+
+func TestSessionKey_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		malleate  func(sk *SessionKey)
+		expectErr error
+	}{
+		{"valid session key", func(sk *SessionKey) {}, nil},
+		{"no spend limit", func(sk *SessionKey) { sk.SpendLimit = nil }, std.InvalidCoinsError{}},
+		{"missing public key", func(sk *SessionKey) { sk.PubKey = nil }, std.InvalidPubKeyError{}},
+		{"no allowed path", func(sk *SessionKey) { sk.AllowedPaths = nil }, InvalidPkgPathError{}},
+		{"empty allowed path", func(sk *SessionKey) { sk.AllowedPaths = []string{""} }, InvalidPkgPathError{}},
+		{"invalid max fee", func(sk *SessionKey) { sk.MaxFee = std.Coin{Denom: "ugnot", Amount: -1} }, std.InvalidCoinsError{}},
+		{"invalid spend limit", func(sk *SessionKey) { sk.SpendLimit = std.Coins{{Denom: "ugnot", Amount: 0}} }, std.InvalidCoinsError{}},
+		{"no expiry", func(sk *SessionKey) { sk.ExpiresAt = 0 }, std.UnknownRequestError{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sk := newTestSessionKey()
+			tc.malleate(&sk)
+
+			err := sk.ValidateBasic()
+			if tc.expectErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorAs(t, err, &tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestSessionKey_Accept(t *testing.T) {
+	t.Parallel()
+
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	call := func(pkgPath string, send std.Coins) MsgCall {
+		msg := NewMsgCall(caller, send, pkgPath, "Play", nil)
+		msg.MaxDeposit = std.NewCoins(std.NewCoin("ugnot", 10))
+		return msg
+	}
+	fee := std.NewFee(10000, std.NewCoin("ugnot", 500))
+
+	tests := []struct {
+		name      string
+		msgs      []std.Msg
+		fee       std.Fee
+		height    int64
+		spent     std.Coins
+		expectErr error
+	}{
+		{
+			name:   "allowed call",
+			msgs:   []std.Msg{call("gno.land/r/demo/game", nil)},
+			fee:    fee,
+			height: 5,
+		},
+		{
+			name:   "last valid height",
+			msgs:   []std.Msg{call("gno.land/r/demo/game", nil)},
+			fee:    fee,
+			height: 10,
+		},
+		{
+			name:   "coins sent within the spend limit",
+			msgs:   []std.Msg{call("gno.land/r/demo/game", std.NewCoins(std.NewCoin("ugnot", 30))), call("gno.land/r/demo/game", std.NewCoins(std.NewCoin("ugnot", 50)))},
+			fee:    fee,
+			height: 5,
+			spent:  std.NewCoins(std.NewCoin("ugnot", 80)),
+		},
+		{
+			name:      "uncapped storage deposit",
+			msgs:      []std.Msg{NewMsgCall(caller, nil, "gno.land/r/demo/game", "Play", nil)},
+			fee:       fee,
+			height:    5,
+			expectErr: std.UnauthorizedError{},
+		},
+		{
+			name:      "expired",
+			msgs:      []std.Msg{call("gno.land/r/demo/game", nil)},
+			fee:       fee,
+			height:    11,
+			expectErr: std.UnauthorizedError{},
+		},
+		{
+			name:      "fee above max fee",
+			msgs:      []std.Msg{call("gno.land/r/demo/game", nil)},
+			fee:       std.NewFee(10000, std.NewCoin("ugnot", 1001)),
+			height:    5,
+			expectErr: std.UnauthorizedError{},
+		},
+		{
+			name:      "fee in another denom",
+			msgs:      []std.Msg{call("gno.land/r/demo/game", nil)},
+			fee:       std.NewFee(10000, std.NewCoin("atom", 1)),
+			height:    5,
+			expectErr: std.UnauthorizedError{},
+		},
+		{
+			name:      "call to another realm",
+			msgs:      []std.Msg{call("gno.land/r/demo/game", nil), call("gno.land/r/demo/bank", nil)},
+			fee:       fee,
+			height:    5,
+			expectErr: std.UnauthorizedError{},
+		},
+		{
+			name:      "not a call",
+			msgs:      []std.Msg{bank.NewMsgSend(caller, caller, std.NewCoins(std.NewCoin("ugnot", 1)))},
+			fee:       fee,
+			height:    5,
+			expectErr: std.UnauthorizedError{},
+		},
+		{
+			name:      "spend limit exceeded",
+			msgs:      []std.Msg{call("gno.land/r/demo/game", std.NewCoins(std.NewCoin("ugnot", 91)))},
+			fee:       fee,
+			height:    5,
+			expectErr: std.InsufficientFundsError{},
+		},
+		{
+			name:      "max deposits above the spend limit",
+			msgs:      []std.Msg{call("gno.land/r/demo/game", std.NewCoins(std.NewCoin("ugnot", 45))), call("gno.land/r/demo/game", std.NewCoins(std.NewCoin("ugnot", 45)))},
+			fee:       fee,
+			height:    5,
+			expectErr: std.InsufficientFundsError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sk := newTestSessionKey()
+			tx := std.NewTx(tc.msgs, tc.fee, nil, "")
+
+			left, err := sk.Accept(tx, tc.height)
+			if tc.expectErr != nil {
+				assert.ErrorAs(t, err, &tc.expectErr)
+				assert.Equal(t, sk, left)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, sk.SpendLimit.Sub(tc.spent), left.SpendLimit)
+		})
+	}
+}
+
+func TestMsgAddSessionKey_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	priv := ed25519.GenPrivKey()
+	creator := crypto.AddressFromPreimage([]byte("creator"))
+	sk := newTestSessionKey()
+
+	assert.NoError(t, MsgAddSessionKey{Creator: creator, SessionKey: sk}.ValidateBasic())
+
+	err := MsgAddSessionKey{SessionKey: sk}.ValidateBasic()
+	assert.ErrorAs(t, err, &std.InvalidAddressError{})
+
+	sk.PubKey = priv.PubKey()
+	err = MsgAddSessionKey{Creator: priv.PubKey().Address(), SessionKey: sk}.ValidateBasic()
+	assert.ErrorAs(t, err, &std.InvalidPubKeyError{})
+
+	sk.ExpiresAt = 0
+	err = MsgAddSessionKey{Creator: creator, SessionKey: sk}.ValidateBasic()
+	assert.ErrorAs(t, err, &std.UnknownRequestError{})
+}
+
+func TestAddSessionKey_UnsupportedAccount(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+
+	res := env.vmh.Process(ctx, MsgAddSessionKey{Creator: addr, SessionKey: newTestSessionKey()})
+	assert.False(t, res.IsOK())
+	assert.ErrorAs(t, res.Error, &std.UnauthorizedError{})
+}
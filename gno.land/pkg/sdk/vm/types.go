@@ -1,6 +1,7 @@
 package vm
 
 import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
@@ -11,6 +12,7 @@ import (
 // AccountKeeperI is the limited interface only needed for VM.
 type AccountKeeperI interface {
 	GetAccount(ctx sdk.Context, addr crypto.Address) std.Account
+	SetAccount(ctx sdk.Context, acc std.Account)
 }
 
 // BankKeeperI is the limited interface only needed for VM.
@@ -19,6 +21,7 @@ type BankKeeperI interface {
 	SendCoins(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
 	SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 	AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
+	TotalCoin(ctx sdk.Context, denom string) int64
 }
 
 // ParamsKeeperI is the limited interface only needed for VM.
@@ -49,3 +52,44 @@ func (fsigs FunctionSignatures) JSON() string {
 	bz := amino.MustMarshalJSON(fsigs)
 	return string(bz)
 }
+
+// ObjectInfo describes a persisted realm object, as returned by the
+// vm/qobject query. Children is paginated; NumChildren is the total count.
+type ObjectInfo struct {
+	ObjectID    string
+	OwnerID     string
+	Hash        string
+	RefCount    int
+	IsEscaped   bool
+	Size        int
+	Object      gno.Object // with children as RefValues
+	NumChildren int
+	Children    []string // ObjectIDs
+}
+
+func (oi ObjectInfo) JSON() string {
+	bz := amino.MustMarshalJSON(oi)
+	return string(bz)
+}
+
+// RealmStorage is the storage usage of a realm, as returned by the
+// vm/qstorage query.
+type RealmStorage struct {
+	PkgPath        string
+	Storage        uint64 // bytes
+	Deposit        std.Coins
+	DepositAddress crypto.Address
+}
+
+func (rs RealmStorage) JSON() string {
+	bz := amino.MustMarshalJSON(rs)
+	return string(bz)
+}
+
+// StorageDeposit is a storage deposit locked for a realm, and the bytes of the
+// realm's storage it pays for. The deposit of each depositor, and the total
+// of them for the realm, are kept as a StorageDeposit.
+type StorageDeposit struct {
+	Storage uint64 // bytes
+	Deposit std.Coins
+}
@@ -13,6 +13,8 @@ import (
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"go.uber.org/multierr"
@@ -67,8 +69,14 @@ func newModGraphCmd(io commands.IO) *commands.Command {
 	return commands.NewCommand(
 		commands.Metadata{
 			Name:       "graph",
-			ShortUsage: "graph [path]",
+			ShortUsage: "graph [flags] [path]",
 			ShortHelp:  "print module requirement graph",
+			LongHelp: `Prints the import graph of the packages in path (default: current
+directory), one "package import" edge per line.
+
+With -remote, the arguments are package paths, and the graph of their
+transitive imports is read from the given gno.land node. Standard libraries
+are listed but not walked.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -160,16 +168,35 @@ func (c *modDownloadCfg) RegisterFlags(fs *flag.FlagSet) {
 	)
 }
 
-type modGraphCfg struct{}
+type modGraphCfg struct {
+	remote string
+}
 
 func (c *modGraphCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.remote,
+		"remote",
+		"",
+		"rpc url of a gno.land node to read the graph of on-chain packages from",
+	)
 	// /out std
-	// /out remote
 	// /out _test processing
 	// ...
 }
 
 func execModGraph(cfg *modGraphCfg, args []string, io commands.IO) error {
+	if cfg.remote != "" {
+		if len(args) == 0 {
+			return flag.ErrHelp
+		}
+		cli, err := client.NewHTTPClient(cfg.remote)
+		if err != nil {
+			return fmt.Errorf("failed to instantiate tm2 client with remote %q: %w", cfg.remote, err)
+		}
+		defer cli.Close()
+		return printRemoteModGraph(cli, args, io)
+	}
+
 	// default to current directory if no args provided
 	if len(args) == 0 {
 		args = []string{"."}
@@ -192,6 +219,65 @@ func execModGraph(cfg *modGraphCfg, args []string, io commands.IO) error {
 	return nil
 }
 
+// abciQuerier is the subset of the tm2 rpc client used by printRemoteModGraph.
+type abciQuerier interface {
+	ABCIQuery(path string, data []byte) (*ctypes.ResultABCIQuery, error)
+}
+
+// printRemoteModGraph walks the on-chain import graph of the given packages,
+// breadth first, and prints its edges.
+func printRemoteModGraph(q abciQuerier, pkgPaths []string, io commands.IO) error {
+	stdout := io.Out()
+
+	seen := make(map[string]struct{})
+	queue := pkgPaths
+	for len(queue) > 0 {
+		pkgPath := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[pkgPath]; ok {
+			continue
+		}
+		seen[pkgPath] = struct{}{}
+
+		imports, err := qimports(q, pkgPath)
+		if err != nil {
+			return fmt.Errorf("query imports of pkg %q: %w", pkgPath, err)
+		}
+		for _, dep := range imports {
+			fmt.Fprintf(stdout, "%s %s\n", pkgPath, dep)
+			if !gno.IsStdlib(dep) {
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// qimports returns all the imports of pkgPath, fetching them page by page.
+func qimports(q abciQuerier, pkgPath string) ([]string, error) {
+	const pageSize = 1_000
+
+	var res []string
+	for offset := 0; ; offset += pageSize {
+		path := fmt.Sprintf("vm/qimports?offset=%d&limit=%d", offset, pageSize)
+		qres, err := q.ABCIQuery(path, []byte(pkgPath))
+		if err != nil {
+			return nil, fmt.Errorf("query qimports: %w", err)
+		}
+		if qres.Response.Error != nil {
+			return nil, fmt.Errorf("qimports failed: %w\n%s", qres.Response.Error, qres.Response.Log)
+		}
+		if len(qres.Response.Data) == 0 {
+			return res, nil
+		}
+		page := strings.Split(string(qres.Response.Data), "\n")
+		res = append(res, page...)
+		if len(page) < pageSize {
+			return res, nil
+		}
+	}
+}
+
 func execModDownload(cfg *modDownloadCfg, args []string, io commands.IO) error {
 	if len(args) > 0 {
 		return flag.ErrHelp
@@ -1,7 +1,16 @@
 package main
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
 )
 
 func TestModApp(t *testing.T) {
@@ -207,3 +216,43 @@ valid.gno
 
 	testMainCaseRun(t, tc)
 }
+
+type mockABCIQuerier map[string]string // pkgPath -> imports
+
+func (m mockABCIQuerier) ABCIQuery(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+	res := &ctypes.ResultABCIQuery{}
+	imports, ok := m[string(data)]
+	switch {
+	case !strings.HasPrefix(path, "vm/qimports?offset=0&"):
+		res.Response.Data = nil // single page
+	case !ok:
+		res.Response.Error = abci.StringError("package is not available")
+	default:
+		res.Response.Data = []byte(imports)
+	}
+	return res, nil
+}
+
+func TestPrintRemoteModGraph(t *testing.T) {
+	q := mockABCIQuerier{
+		"gno.land/r/demo/app":  "gno.land/p/demo/avl\ngno.land/p/demo/ufmt\nstd",
+		"gno.land/p/demo/ufmt": "gno.land/p/demo/avl\nstrconv",
+		"gno.land/p/demo/avl":  "",
+	}
+
+	mockOut := bytes.NewBufferString("")
+	io := commands.NewTestIO()
+	io.SetOut(commands.WriteNopCloser(mockOut))
+
+	err := printRemoteModGraph(q, []string{"gno.land/r/demo/app"}, io)
+	require.NoError(t, err)
+	assert.Equal(t, `gno.land/r/demo/app gno.land/p/demo/avl
+gno.land/r/demo/app gno.land/p/demo/ufmt
+gno.land/r/demo/app std
+gno.land/p/demo/ufmt gno.land/p/demo/avl
+gno.land/p/demo/ufmt strconv
+`, mockOut.String())
+
+	err = printRemoteModGraph(q, []string{"gno.land/r/demo/missing"}, io)
+	assert.ErrorContains(t, err, "package is not available")
+}
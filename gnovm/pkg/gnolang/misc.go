@@ -173,6 +173,14 @@ func DerivePkgCryptoAddr(pkgPath string) crypto.Address {
 	return crypto.AddressFromPreimage([]byte("pkgPath:" + pkgPath))
 }
 
+// DeriveStorageDepositCryptoAddr returns the address holding the storage
+// deposit locked for the realm at pkgPath. Unlike the package address, it
+// cannot be spent from by the realm itself.
+func DeriveStorageDepositCryptoAddr(pkgPath string) crypto.Address {
+	// NOTE: must not collide with pubkey addrs.
+	return crypto.AddressFromPreimage([]byte("pkgPath:" + pkgPath + ".storageDeposit"))
+}
+
 func DerivePkgBech32Addr(pkgPath string) crypto.Bech32Address {
 	if pkgPath == "" {
 		panic("pkgpath cannot be empty")
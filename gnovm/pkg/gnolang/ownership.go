@@ -124,6 +124,8 @@ type Object interface {
 
 	GetLastGCCycle() int64
 	SetLastGCCycle(int64)
+	GetLastObjectSize() int64
+	SetLastObjectSize(int64)
 
 	// Saves to realm along the way if owned, and also (dirty
 	// or new).
@@ -167,6 +169,9 @@ type ObjectInfo struct {
 	isNewDeleted bool
 	lastGCCycle  int64
 	owner        Object // mem reference to owner.
+
+	// Size of the object as last persisted, for storage accounting.
+	lastObjectSize int64
 }
 
 // Copy used for serialization of objects.
@@ -355,6 +360,14 @@ func (oi *ObjectInfo) SetLastGCCycle(c int64) {
 	oi.lastGCCycle = c
 }
 
+func (oi *ObjectInfo) GetLastObjectSize() int64 {
+	return oi.lastObjectSize
+}
+
+func (oi *ObjectInfo) SetLastObjectSize(size int64) {
+	oi.lastObjectSize = size
+}
+
 func (oi *ObjectInfo) GetIsTransient() bool {
 	return false
 }
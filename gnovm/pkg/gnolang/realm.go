@@ -126,6 +126,8 @@ type Realm struct {
 	updated []Object // real objects that were modified.
 	deleted []Object // real objects that became deleted.
 	escaped []Object // real objects with refcount > 1.
+
+	sumDiff int64 // persisted size difference of the current transaction.
 }
 
 // Creates a blank new realm with counter 0.
@@ -378,6 +380,11 @@ func (rlm *Realm) FinalizeRealmTransaction(store Store) {
 	rlm.saveUnsavedObjects(store)
 	// delete all deleted objects.
 	rlm.removeDeletedObjects(store)
+	// record the storage size difference for deposit accounting.
+	if rlm.sumDiff != 0 {
+		store.AddRealmStorageDiff(rlm.Path, rlm.sumDiff)
+		rlm.sumDiff = 0
+	}
 	// reset realm state for new transaction.
 	rlm.clearMarks()
 }
@@ -785,7 +792,7 @@ func (rlm *Realm) saveObject(store Store, oo Object) {
 	}
 	// set object to store.
 	// NOTE: also sets the hash to object.
-	store.SetObject(oo)
+	rlm.sumDiff += store.SetObject(oo)
 	// set index.
 	if oo.GetIsEscaped() {
 		// XXX save oid->hash to iavl.
@@ -797,7 +804,7 @@ func (rlm *Realm) saveObject(store Store, oo Object) {
 
 func (rlm *Realm) removeDeletedObjects(store Store) {
 	for _, do := range rlm.deleted {
-		store.DelObject(do)
+		rlm.sumDiff += store.DelObject(do)
 	}
 }
 
@@ -942,6 +949,20 @@ func getChildObjects2(store Store, val Value) []Object {
 	return objs
 }
 
+// GetChildRefs returns the references to the child objects of an object
+// image, as returned by Store.GetObjectImage().
+// Shallow; doesn't recurse into objects.
+func GetChildRefs(oo Object) []RefValue {
+	chos := getChildObjects(oo, nil)
+	refs := make([]RefValue, 0, len(chos))
+	for _, child := range chos {
+		if ref, ok := child.(RefValue); ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
 //----------------------------------------
 // getUnsavedChildObjects
 
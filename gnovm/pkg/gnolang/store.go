@@ -45,8 +45,8 @@ type Store interface {
 	SetPackageRealm(*Realm)
 	GetObject(oid ObjectID) Object
 	GetObjectSafe(oid ObjectID) Object
-	SetObject(Object)
-	DelObject(Object)
+	SetObject(Object) int64 // returns size difference of the object
+	DelObject(Object) int64 // returns size difference of the object
 	GetType(tid TypeID) Type
 	GetTypeSafe(tid TypeID) Type
 	SetCacheType(Type)
@@ -64,6 +64,9 @@ type Store interface {
 	AddMemPackage(mpkg *std.MemPackage, mtype MemPackageType)
 	GetMemPackage(path string) *std.MemPackage
 	GetMemFile(path string, name string) *std.MemFile
+	GetObjectImage(oid ObjectID) (oo Object, size int)
+	AddRealmStorageDiff(rlmpath string, diff int64)
+	RealmStorageDiffs() map[string]int64 // by realm path, since the transaction began
 	FindPathsByPrefix(prefix string) iter.Seq[string]
 	IterMemPackage() <-chan *std.MemPackage
 	ClearObjectCache() // run before processing a message
@@ -146,6 +149,9 @@ type defaultStore struct {
 	opslog  io.Writer // for logging store operations.
 	current []string  // for detecting import cycles.
 
+	// storage size differences of realms finalized in this transaction.
+	realmStorageDiffs map[string]int64
+
 	// gas
 	gasMeter  store.GasMeter
 	gasConfig GasConfig
@@ -162,6 +168,8 @@ func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore
 		cacheTypes:   txlog.GoMap[TypeID, Type](map[TypeID]Type{}),
 		cacheNodes:   txlog.GoMap[Location, BlockNode](map[Location]BlockNode{}),
 
+		realmStorageDiffs: make(map[string]int64),
+
 		// store configuration
 		pkgGetter:      nil,
 		nativeResolver: nil,
@@ -201,6 +209,8 @@ func (ds *defaultStore) BeginTransaction(baseStore, iavlStore store.Store, gasMe
 		// transient
 		current: nil,
 		opslog:  nil,
+
+		realmStorageDiffs: make(map[string]int64),
 	}
 	ds2.SetCachePackage(Uverse())
 
@@ -458,6 +468,7 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 			}
 		}
 		oo.SetHash(ValueHash{NewHashlet(hash)})
+		oo.SetLastObjectSize(int64(size))
 		ds.cacheObjects[oid] = oo
 		_ = fillTypesOfValue(ds, oo)
 		return oo
@@ -467,7 +478,9 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 
 // NOTE: unlike GetObject(), SetObject() is also used to persist updated
 // package values.
-func (ds *defaultStore) SetObject(oo Object) {
+// Returns the difference between the new size of the object and the size it
+// was last loaded or persisted with.
+func (ds *defaultStore) SetObject(oo Object) int64 {
 	if bm.OpsEnabled {
 		bm.PauseOpCode()
 		defer bm.ResumeOpCode()
@@ -534,6 +547,8 @@ func (ds *defaultStore) SetObject(oo Object) {
 		ds.baseStore.Set([]byte(key), hashbz)
 		size = len(hashbz)
 	}
+	diff := int64(size) - oo.GetLastObjectSize()
+	oo.SetLastObjectSize(int64(size))
 	// save object to cache.
 	if debug {
 		if oid.IsZero() {
@@ -555,6 +570,35 @@ func (ds *defaultStore) SetObject(oo Object) {
 		value = hash.Bytes()
 		ds.iavlStore.Set(key, value)
 	}
+	return diff
+}
+
+// GetObjectImage returns the object as it is persisted in the backend, that is
+// with child objects replaced by RefValues, along with its encoded size in
+// bytes (hash included). It neither consumes gas nor populates the object
+// cache; it is intended for inspection of the realm state.
+// Returns a nil object if there is no such persisted object.
+func (ds *defaultStore) GetObjectImage(oid ObjectID) (Object, int) {
+	if ds.baseStore == nil {
+		return nil, 0
+	}
+	key := backendObjectKey(oid)
+	hashbz := ds.baseStore.Get([]byte(key))
+	if hashbz == nil {
+		return nil, 0
+	}
+	var oo Object
+	amino.MustUnmarshal(hashbz[HashSize:], &oo)
+	oo.SetHash(ValueHash{NewHashlet(hashbz[:HashSize])})
+	return oo, len(hashbz)
+}
+
+func (ds *defaultStore) AddRealmStorageDiff(rlmpath string, diff int64) {
+	ds.realmStorageDiffs[rlmpath] += diff
+}
+
+func (ds *defaultStore) RealmStorageDiffs() map[string]int64 {
+	return ds.realmStorageDiffs
 }
 
 func (ds *defaultStore) loadForLog(oid ObjectID) Object {
@@ -569,7 +613,9 @@ func (ds *defaultStore) loadForLog(oid ObjectID) Object {
 	return oo
 }
 
-func (ds *defaultStore) DelObject(oo Object) {
+// DelObject deletes the object from the backend and returns the (negative)
+// size difference.
+func (ds *defaultStore) DelObject(oo Object) int64 {
 	if bm.OpsEnabled {
 		bm.PauseOpCode()
 		defer bm.ResumeOpCode()
@@ -594,6 +640,9 @@ func (ds *defaultStore) DelObject(oo Object) {
 	if ds.opslog != nil {
 		fmt.Fprintf(ds.opslog, "d[%v]\n", oo.GetObjectID())
 	}
+	diff := -oo.GetLastObjectSize()
+	oo.SetLastObjectSize(0)
+	return diff
 }
 
 // NOTE: not used quite yet.
@@ -25,6 +25,12 @@ type File struct {
 	Gno     *modfile.Go
 	Replace []*modfile.Replace
 
+	// Optional package metadata.
+	Description string
+	License     string
+	Authors     []string
+	Repository  string
+
 	Syntax *modfile.FileSyntax
 }
 
@@ -106,7 +106,7 @@ func ParseBytes(fname string, data []byte) (*File, error) {
 					Err:      fmt.Errorf("unknown block type: %s", strings.Join(x.Token, " ")),
 				})
 				continue
-			case "module", "replace":
+			case "module", "replace", "author":
 				for _, l := range x.Line {
 					f.add(&errs, x, l, x.Token[0], l.Token)
 				}
@@ -219,5 +219,33 @@ func (f *File) add(errs *modfile.ErrorList, block *modfile.LineBlock, line *modf
 			return
 		}
 		f.Replace = append(f.Replace, replace)
+
+	case "description", "license", "repository", "author":
+		if len(args) != 1 {
+			errorf("%s directive expects exactly one argument", verb)
+			return
+		}
+		s, err := parseString(&args[0])
+		if err != nil {
+			errorf("invalid quoted string: %v", err)
+			return
+		}
+		var field *string
+		switch verb {
+		case "author":
+			f.Authors = append(f.Authors, s)
+			return
+		case "description":
+			field = &f.Description
+		case "license":
+			field = &f.License
+		case "repository":
+			field = &f.Repository
+		}
+		if *field != "" {
+			errorf("repeated %s statement", verb)
+			return
+		}
+		*field = s
 	}
 }
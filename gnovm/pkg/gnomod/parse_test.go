@@ -171,6 +171,64 @@ func TestParseDraft(t *testing.T) {
 	}
 }
 
+func TestParseMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		desc, in    string
+		expected    *File
+		errContains string
+	}{
+		{
+			desc:     "none",
+			in:       `module m`,
+			expected: &File{},
+		},
+		{
+			desc: "all",
+			in: `module m
+
+description "A simple AVL tree"
+license Apache-2.0
+repository "https://github.com/gnolang/gno"
+author "Jae Kwon"
+author (
+	"Alice <alice@example.com>"
+	Bob
+)`,
+			expected: &File{
+				Description: "A simple AVL tree",
+				License:     "Apache-2.0",
+				Repository:  "https://github.com/gnolang/gno",
+				Authors:     []string{"Jae Kwon", "Alice <alice@example.com>", "Bob"},
+			},
+		},
+		{
+			desc: "repeated",
+			in: `module m
+license MIT
+license Apache-2.0`,
+			errContains: "repeated license statement",
+		},
+		{
+			desc:        "too_many_args",
+			in:          `description a simple tree`,
+			errContains: "description directive expects exactly one argument",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			f, err := ParseBytes("in", []byte(tc.in))
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.Description, f.Description)
+			assert.Equal(t, tc.expected.License, f.License)
+			assert.Equal(t, tc.expected.Repository, f.Repository)
+			assert.Equal(t, tc.expected.Authors, f.Authors)
+		})
+	}
+}
+
 func TestParseFilepath(t *testing.T) {
 	pkgDir := "bar"
 	for _, tc := range []struct {
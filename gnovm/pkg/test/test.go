@@ -37,8 +37,8 @@ const (
 
 // Context returns a TestExecContext. Usable for test purpose only.
 // The caller should be empty for package initialization.
-// The returned context has a mock banker, params and event logger. It will give
-// the pkgAddr the coins in `send` by default, and only that.
+// The returned context has a mock banker, params, scheduler and event logger.
+// It will give the pkgAddr the coins in `send` by default, and only that.
 // The Height and Timestamp parameters are set to the [DefaultHeight] and
 // [DefaultTimestamp].
 func Context(caller crypto.Bech32Address, pkgPath string, send std.Coins) *teststd.TestExecContext {
@@ -60,6 +60,7 @@ func Context(caller crypto.Bech32Address, pkgPath string, send std.Coins) *tests
 		OriginSendSpent: new(std.Coins),
 		Banker:          banker,
 		Params:          newTestParams(),
+		Scheduler:       newTestScheduler(),
 		EventLogger:     sdk.NewEventLogger(),
 	}
 	return &teststd.TestExecContext{
@@ -111,6 +112,21 @@ func (tp *testParams) SetUint64(key string, val uint64)    { /* noop */ }
 func (tp *testParams) SetString(key string, val string)    { /* noop */ }
 func (tp *testParams) SetStrings(key string, val []string) { /* noop */ }
 
+// testScheduler only assigns IDs; scheduled calls are never executed.
+type testScheduler struct {
+	nextID uint64
+}
+
+func newTestScheduler() *testScheduler {
+	return &testScheduler{}
+}
+
+func (ts *testScheduler) ScheduleCall(pkgPath, fn string, args []string, height, timestamp, gasLimit int64) (uint64, error) {
+	id := ts.nextID
+	ts.nextID++
+	return id, nil
+}
+
 // ----------------------------------------
 // main test function
 
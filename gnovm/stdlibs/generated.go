@@ -883,6 +883,62 @@ var nativeFuncs = [...]NativeFunc{
 				p0, p1)
 		},
 	},
+	{
+		"std",
+		"scheduleCall",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("p3"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p4"), Type: gno.X("[]string")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint64")},
+		},
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  int64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  int64
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  int64
+				rp2 = reflect.ValueOf(&p2).Elem()
+				p3  string
+				rp3 = reflect.ValueOf(&p3).Elem()
+				p4  []string
+				rp4 = reflect.ValueOf(&p4).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+			tv3 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 3, "")).TV
+			tv3.DeepFill(m.Store)
+			gno.Gno2GoValue(tv3, rp3)
+			tv4 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 4, "")).TV
+			tv4.DeepFill(m.Store)
+			gno.Gno2GoValue(tv4, rp4)
+
+			r0 := libs_std.X_scheduleCall(
+				m,
+				p0, p1, p2, p3, p4)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
 	{
 		"sys/params",
 		"setSysParamString",
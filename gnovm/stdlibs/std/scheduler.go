@@ -0,0 +1,42 @@
+package std
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// SchedulerInterface is the interface through which Gno realms can register
+// callbacks to be executed by the chain at a later block.
+type SchedulerInterface interface {
+	// ScheduleCall registers a call to fn in pkgPath, to be executed once the
+	// chain reaches the given height or block timestamp (whichever is set).
+	// It returns the identifier of the scheduled call.
+	ScheduleCall(pkgPath, fn string, args []string, height, timestamp, gasLimit int64) (uint64, error)
+}
+
+func X_scheduleCall(m *gno.Machine, height, timestamp, gasLimit int64, fn string, args []string) uint64 {
+	ctx := GetContext(m)
+	if ctx.Scheduler == nil {
+		m.Panic(typedString("scheduled calls are not supported in this context"))
+		return 0
+	}
+	_, pkgPath := currentRealm(m)
+	if !gno.IsRealmPath(pkgPath) {
+		m.Panic(typedString("scheduled calls can only be registered by realms"))
+		return 0
+	}
+	if gasLimit <= 0 {
+		m.Panic(typedString("scheduled call gas limit must be positive"))
+		return 0
+	}
+	// The gas of the scheduled call is prepaid by the current transaction.
+	if m.GasMeter != nil {
+		m.GasMeter.ConsumeGas(store.Gas(gasLimit), "ScheduleCall")
+	}
+	id, err := ctx.Scheduler.ScheduleCall(pkgPath, fn, args, height, timestamp, gasLimit)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+	return id
+}
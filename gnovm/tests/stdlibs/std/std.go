@@ -228,7 +228,11 @@ func (tb *TestBanker) SendCoins(from, to crypto.Bech32Address, amt tm2std.Coins)
 
 // TotalCoin implements the Banker interface.
 func (tb *TestBanker) TotalCoin(denom string) int64 {
-	panic("not yet implemented")
+	var total int64
+	for _, coins := range tb.CoinTable {
+		total += coins.AmountOf(denom)
+	}
+	return total
 }
 
 // IssueCoin implements the Banker interface.
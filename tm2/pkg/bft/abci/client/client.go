@@ -41,6 +41,10 @@ type Client interface {
 	InitChainSync(abci.RequestInitChain) (abci.ResponseInitChain, error)
 	BeginBlockSync(abci.RequestBeginBlock) (abci.ResponseBeginBlock, error)
 	EndBlockSync(abci.RequestEndBlock) (abci.ResponseEndBlock, error)
+	ListSnapshotsSync(abci.RequestListSnapshots) (abci.ResponseListSnapshots, error)
+	OfferSnapshotSync(abci.RequestOfferSnapshot) (abci.ResponseOfferSnapshot, error)
+	LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk) (abci.ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk) (abci.ResponseApplySnapshotChunk, error)
 }
 
 // ----------------------------------------
@@ -146,6 +146,22 @@ func (app *PersistentKVStoreApplication) EndBlock(req abci.RequestEndBlock) abci
 	return abci.ResponseEndBlock{ValidatorUpdates: app.ValSetChanges}
 }
 
+func (app *PersistentKVStoreApplication) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	return abci.ResponseListSnapshots{}
+}
+
+func (app *PersistentKVStoreApplication) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	return abci.ResponseOfferSnapshot{}
+}
+
+func (app *PersistentKVStoreApplication) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	return abci.ResponseLoadSnapshotChunk{}
+}
+
+func (app *PersistentKVStoreApplication) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	return abci.ResponseApplySnapshotChunk{}
+}
+
 // ---------------------------------------------
 // update validators
 
@@ -91,6 +91,30 @@ type RequestCommit struct {
 	RequestBase
 }
 
+type RequestListSnapshots struct {
+	RequestBase
+}
+
+type RequestOfferSnapshot struct {
+	RequestBase
+	Snapshot *Snapshot // snapshot offered by a peer
+	AppHash  []byte    // light client-verified app hash at the snapshot height
+}
+
+type RequestLoadSnapshotChunk struct {
+	RequestBase
+	Height int64
+	Format uint32
+	Chunk  uint32
+}
+
+type RequestApplySnapshotChunk struct {
+	RequestBase
+	Index  uint32
+	Chunk  []byte
+	Sender string // nondeterministic, ID of the peer which sent the chunk
+}
+
 // ----------------------------------------
 // Response types
 
@@ -176,8 +200,16 @@ type ResponseBeginBlock struct {
 
 type ResponseCheckTx struct {
 	ResponseBase
-	GasWanted int64 // nondeterministic
-	GasUsed   int64
+	GasWanted        int64 // nondeterministic
+	GasUsed          int64
+	TimeoutHeight    int64 // height after which the tx is no longer valid, if not zero
+	TimeoutTimestamp int64 // unix time after which the tx is no longer valid, if not zero
+
+	// Used by the mempool to order the txs.
+	Priority    int64          // higher first, ie. the gas price of the tx
+	Sender      crypto.Address // account whose txs are kept in sequence order, if not zero
+	Sequence    uint64         // sequence of the sender used by the tx
+	Replacement bool           // whether the tx replaces the pending tx of the sender with the same sequence
 }
 
 type ResponseDeliverTx struct {
@@ -195,6 +227,62 @@ type ResponseEndBlock struct {
 
 type ResponseCommit struct {
 	ResponseBase
+	RetainHeight int64 // blocks below this height may be pruned, if not zero
+}
+
+type ResponseListSnapshots struct {
+	ResponseBase
+	Snapshots []*Snapshot
+}
+
+type OfferSnapshotResult int
+
+const (
+	OfferSnapshotUnknown      OfferSnapshotResult = iota // unknown result, abort the state sync
+	OfferSnapshotAccept                                  // snapshot accepted, apply its chunks
+	OfferSnapshotAbort                                   // abort the state sync
+	OfferSnapshotReject                                  // reject this snapshot, try others
+	OfferSnapshotRejectFormat                            // reject all the snapshots of this format, try others
+)
+
+type ResponseOfferSnapshot struct {
+	ResponseBase
+	Result OfferSnapshotResult
+}
+
+type ResponseLoadSnapshotChunk struct {
+	ResponseBase
+	Chunk []byte
+}
+
+type ApplySnapshotChunkResult int
+
+const (
+	ApplySnapshotChunkUnknown        ApplySnapshotChunkResult = iota // unknown result, abort the state sync
+	ApplySnapshotChunkAccept                                         // chunk applied
+	ApplySnapshotChunkAbort                                          // abort the state sync
+	ApplySnapshotChunkRetry                                          // fetch and apply the chunk again
+	ApplySnapshotChunkRejectSnapshot                                 // reject this snapshot, try others
+)
+
+type ResponseApplySnapshotChunk struct {
+	ResponseBase
+	Result        ApplySnapshotChunkResult
+	RefetchChunks []uint32 // chunks to fetch again, ie. from other peers
+	RejectSenders []string // peers to reject, ie. which sent invalid chunks
+}
+
+// ----------------------------------------
+// Snapshot types
+
+// Snapshot is a snapshot of the application state at a height, split into
+// chunks. Identical snapshots have the same height, format and hash.
+type Snapshot struct {
+	Height   int64  // height at which the snapshot was taken
+	Format   uint32 // application-specific snapshot format
+	Chunks   uint32 // number of chunks
+	Hash     []byte // application-specific hash of the snapshot
+	Metadata []byte // application-specific metadata, ie. the chunk hashes
 }
 
 // ----------------------------------------
@@ -15,6 +15,7 @@ type AppConns interface {
 	Mempool() Mempool
 	Consensus() Consensus
 	Query() Query
+	Snapshot() Snapshot
 }
 
 // NewABCIClient returns newly connected client
@@ -29,7 +30,7 @@ func NewAppConns(clientCreator ClientCreator) AppConns {
 //-----------------------------
 // multi implements AppConns
 
-// a multi is made of a few appConns (mempool, consensus, query)
+// a multi is made of a few appConns (mempool, consensus, query, snapshot)
 // and manages their underlying abci clients
 // TODO: on app restart, clients must reboot together
 type multi struct {
@@ -38,6 +39,7 @@ type multi struct {
 	mempoolConn   *mempool
 	consensusConn *consensus
 	queryConn     *query
+	snapshotConn  *snapshot
 
 	clientCreator ClientCreator
 }
@@ -66,6 +68,11 @@ func (app *multi) Query() Query {
 	return app.queryConn
 }
 
+// Returns the snapshot Connection
+func (app *multi) Snapshot() Snapshot {
+	return app.snapshotConn
+}
+
 func (app *multi) OnStart() error {
 	// query connection
 	querycli, err := app.clientCreator.NewABCIClient()
@@ -78,6 +85,17 @@ func (app *multi) OnStart() error {
 	}
 	app.queryConn = NewQuery(querycli)
 
+	// snapshot connection
+	snapcli, err := app.clientCreator.NewABCIClient()
+	if err != nil {
+		return errors.Wrap(err, "Error creating ABCI client (snapshot connection)")
+	}
+	snapcli.SetLogger(app.Logger.With("module", "abci-client", "connection", "snapshot"))
+	if err := snapcli.Start(); err != nil {
+		return errors.Wrap(err, "Error starting ABCI client (snapshot connection)")
+	}
+	app.snapshotConn = NewSnapshot(snapcli)
+
 	// mempool connection
 	memcli, err := app.clientCreator.NewABCIClient()
 	if err != nil {
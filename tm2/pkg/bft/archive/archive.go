@@ -0,0 +1,248 @@
+// Package archive implements the block archives, used to sync a node
+// offline from a range of blocks exported by another node.
+//
+// An archive is a zlib-compressed stream of length-prefixed amino items: a
+// header with the chain ID and the height range, the blocks of the range
+// with their commits, and a footer with the hash of the previous items, so
+// that a truncated or corrupted archive is detected while it is read.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+)
+
+// CurrentFormat is the format of the written archives
+const CurrentFormat uint32 = 1
+
+// maxItemSize is the maximum size of an archive item, a block and its commit
+const maxItemSize = 2 * types.MaxBlockSizeBytes
+
+var (
+	ErrInvalidRange     = errors.New("invalid block range")
+	ErrUnknownFormat    = errors.New("unknown archive format")
+	ErrChecksumMismatch = errors.New("archive checksum mismatch")
+)
+
+// Header is the header of an archive
+type Header struct {
+	Format      uint32
+	ChainID     string
+	StartHeight int64
+	EndHeight   int64
+}
+
+// Block is an archived block, along with the commit of its validators
+type Block struct {
+	Block  *types.Block
+	Commit *types.Commit
+}
+
+// footer ends an archive, with the hash of the encoded header and blocks
+type footer struct {
+	Hash []byte
+}
+
+// item is an item of an archive stream
+type item struct {
+	Header *Header
+	Block  *Block
+	Footer *footer
+}
+
+// Export writes the blocks of the given height range to w, as an archive
+func Export(w io.Writer, bs sm.BlockStoreRPC, startHeight, endHeight int64) error {
+	if startHeight <= 0 || startHeight > endHeight {
+		return fmt.Errorf("%w, %d to %d", ErrInvalidRange, startHeight, endHeight)
+	}
+	if startHeight < bs.Base() || endHeight > bs.Height() {
+		return fmt.Errorf(
+			"%w, %d to %d is not within the stored blocks %d to %d",
+			ErrInvalidRange, startHeight, endHeight, bs.Base(), bs.Height(),
+		)
+	}
+
+	first := bs.LoadBlockMeta(startHeight)
+	if first == nil {
+		return fmt.Errorf("block %d not found", startHeight)
+	}
+
+	var (
+		zw     = zlib.NewWriter(w)
+		hasher = tmhash.New()
+		mw     = io.MultiWriter(zw, hasher)
+	)
+
+	writeItem := func(it item) error {
+		_, err := amino.MarshalSizedWriter(mw, it)
+		return err
+	}
+
+	header := &Header{
+		Format:      CurrentFormat,
+		ChainID:     first.Header.ChainID,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+	if err := writeItem(item{Header: header}); err != nil {
+		return err
+	}
+
+	for height := startHeight; height <= endHeight; height++ {
+		block := bs.LoadBlock(height)
+		if block == nil {
+			return fmt.Errorf("block %d not found", height)
+		}
+
+		// The commit of the latest block is only in the seen commits
+		commit := bs.LoadBlockCommit(height)
+		if commit == nil {
+			commit = bs.LoadSeenCommit(height)
+		}
+		if commit == nil {
+			return fmt.Errorf("commit of block %d not found", height)
+		}
+
+		if err := writeItem(item{Block: &Block{Block: block, Commit: commit}}); err != nil {
+			return err
+		}
+	}
+
+	// The footer is not part of its own hash
+	if _, err := amino.MarshalSizedWriter(zw, item{Footer: &footer{Hash: hasher.Sum(nil)}}); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// Reader reads the blocks of an archive
+type Reader struct {
+	header Header
+	zr     io.ReadCloser
+	tr     io.Reader // reads zr through the hasher
+	hasher hash.Hash
+	next   int64 // height of the next block
+	err    error // result of the footer verification
+}
+
+// NewReader returns a reader of the archive read from r, after reading its
+// header
+func NewReader(r io.Reader) (*Reader, error) {
+	zr, err := zlib.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read archive, %w", err)
+	}
+
+	ar := &Reader{
+		zr:     zr,
+		hasher: tmhash.New(),
+	}
+	ar.tr = io.TeeReader(bufio.NewReader(zr), ar.hasher)
+
+	it, err := ar.readItem()
+	if err != nil {
+		zr.Close()
+		return nil, err
+	}
+	if it.Header == nil {
+		zr.Close()
+		return nil, errors.New("missing archive header")
+	}
+	if it.Header.Format != CurrentFormat {
+		zr.Close()
+		return nil, fmt.Errorf("%w %d", ErrUnknownFormat, it.Header.Format)
+	}
+	if it.Header.StartHeight <= 0 || it.Header.StartHeight > it.Header.EndHeight {
+		zr.Close()
+		return nil, fmt.Errorf("%w, %d to %d", ErrInvalidRange, it.Header.StartHeight, it.Header.EndHeight)
+	}
+
+	ar.header = *it.Header
+	ar.next = ar.header.StartHeight
+
+	return ar, nil
+}
+
+// Header returns the header of the archive
+func (ar *Reader) Header() Header {
+	return ar.header
+}
+
+// Next returns the next block of the archive, in height order. It returns
+// io.EOF after the last block, once the checksum of the archive is verified.
+func (ar *Reader) Next() (*Block, error) {
+	if ar.next > ar.header.EndHeight {
+		if ar.err == nil {
+			ar.err = ar.verifyFooter()
+		}
+
+		return nil, ar.err
+	}
+
+	it, err := ar.readItem()
+	if err != nil {
+		return nil, err
+	}
+
+	b := it.Block
+	switch {
+	case b == nil || b.Block == nil || b.Commit == nil:
+		return nil, fmt.Errorf("missing block %d in archive", ar.next)
+	case b.Block.Height != ar.next:
+		return nil, fmt.Errorf("unexpected block %d in archive, expected %d", b.Block.Height, ar.next)
+	case b.Block.ChainID != ar.header.ChainID:
+		return nil, fmt.Errorf("block %d of chain %q in archive of chain %q", b.Block.Height, b.Block.ChainID, ar.header.ChainID)
+	}
+
+	ar.next++
+
+	return b, nil
+}
+
+// Close closes the archive. It does not close the underlying reader.
+func (ar *Reader) Close() error {
+	return ar.zr.Close()
+}
+
+func (ar *Reader) readItem() (item, error) {
+	var it item
+
+	if _, err := amino.UnmarshalSizedReader(ar.tr, &it, maxItemSize); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return item{}, fmt.Errorf("unable to read archive item, %w", err)
+	}
+
+	return it, nil
+}
+
+func (ar *Reader) verifyFooter() error {
+	// The footer is not part of its own hash
+	sum := ar.hasher.Sum(nil)
+
+	it, err := ar.readItem()
+	if err != nil {
+		return err
+	}
+	if it.Footer == nil {
+		return errors.New("missing archive footer")
+	}
+	if !bytes.Equal(it.Footer.Hash, sum) {
+		return ErrChecksumMismatch
+	}
+
+	return io.EOF
+}
@@ -0,0 +1,307 @@
+package archive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/abci/example/kvstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/mempool/mock"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+const testChainID = "archive"
+
+var testGenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testNode struct {
+	state      sm.State
+	privVal    types.PrivValidator
+	blockStore *store.BlockStore
+	blockExec  *sm.BlockExecutor
+}
+
+// newTestNode returns a node at the genesis of a chain with a single
+// validator, executing blocks with the kvstore app
+func newTestNode(t *testing.T, privVal types.PrivValidator) *testNode {
+	t.Helper()
+
+	proxyApp := appconn.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication()))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { proxyApp.Stop() })
+
+	pubKey := privVal.GetPubKey()
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		GenesisTime: testGenesisTime,
+		ChainID:     testChainID,
+		Validators: []types.GenesisValidator{
+			{Address: pubKey.Address(), PubKey: pubKey, Power: 10, Name: "validator"},
+		},
+	})
+	require.NoError(t, err)
+
+	stateDB := memdb.NewMemDB()
+	sm.SaveState(stateDB, state)
+
+	return &testNode{
+		state:      state,
+		privVal:    privVal,
+		blockStore: store.NewBlockStore(memdb.NewMemDB()),
+		blockExec:  sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mock.Mempool{}),
+	}
+}
+
+// commitBlocks saves and executes the given number of blocks
+func (n *testNode) commitBlocks(t *testing.T, count int) {
+	t.Helper()
+
+	lastCommit := n.blockStore.LoadSeenCommit(n.state.LastBlockHeight)
+	if lastCommit == nil {
+		lastCommit = new(types.Commit)
+	}
+
+	for range count {
+		height := n.state.LastBlockHeight + 1
+		txs := []types.Tx{[]byte{byte(height)}}
+
+		block, parts := n.state.MakeBlock(height, txs, lastCommit, n.state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+
+		vote, err := types.MakeVote(height, blockID, n.state.Validators, n.privVal, testChainID)
+		require.NoError(t, err)
+		commit := types.NewCommit(blockID, []*types.CommitSig{vote.CommitSig()})
+
+		n.blockStore.SaveBlock(block, parts, commit)
+		n.state, err = n.blockExec.ApplyBlock(n.state, blockID, block)
+		require.NoError(t, err)
+
+		lastCommit = commit
+	}
+}
+
+func exportArchive(t *testing.T, n *testNode, startHeight, endHeight int64) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(&buf, n.blockStore, startHeight, endHeight))
+
+	return buf.Bytes()
+}
+
+// rewriteFooter rewrites the given archive with a footer of the given hash
+func rewriteFooter(t *testing.T, archive []byte, hash []byte) []byte {
+	t.Helper()
+
+	var (
+		buf bytes.Buffer
+		zw  = zlib.NewWriter(&buf)
+	)
+
+	ar, err := NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	defer ar.Close()
+
+	header := ar.Header()
+	_, err = amino.MarshalSizedWriter(zw, item{Header: &header})
+	require.NoError(t, err)
+
+	for height := header.StartHeight; height <= header.EndHeight; height++ {
+		b, err := ar.Next()
+		require.NoError(t, err)
+
+		_, err = amino.MarshalSizedWriter(zw, item{Block: b})
+		require.NoError(t, err)
+	}
+
+	_, err = amino.MarshalSizedWriter(zw, item{Footer: &footer{Hash: hash}})
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	privVal := types.NewMockPV()
+	source := newTestNode(t, privVal)
+	source.commitBlocks(t, 3)
+
+	archive := exportArchive(t, source, 1, 3)
+
+	ar, err := NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	assert.Equal(t, Header{Format: CurrentFormat, ChainID: testChainID, StartHeight: 1, EndHeight: 3}, ar.Header())
+	require.NoError(t, ar.Close())
+
+	dest := newTestNode(t, privVal)
+	state, err := Import(bytes.NewReader(archive), dest.state, dest.blockStore, dest.blockExec)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), state.LastBlockHeight)
+	assert.Equal(t, source.state.AppHash, state.AppHash)
+	assert.Equal(t, source.state.LastBlockID, state.LastBlockID)
+
+	assert.Equal(t, int64(3), dest.blockStore.Height())
+	for height := int64(1); height <= 3; height++ {
+		assert.Equal(t, source.blockStore.LoadBlock(height).Hash(), dest.blockStore.LoadBlock(height).Hash())
+	}
+	assert.NotNil(t, dest.blockStore.LoadSeenCommit(3))
+}
+
+func TestImport_Overlap(t *testing.T) {
+	t.Parallel()
+
+	privVal := types.NewMockPV()
+	source := newTestNode(t, privVal)
+	source.commitBlocks(t, 4)
+
+	dest := newTestNode(t, privVal)
+
+	state, err := Import(bytes.NewReader(exportArchive(t, source, 1, 2)), dest.state, dest.blockStore, dest.blockExec)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), state.LastBlockHeight)
+
+	// The blocks already executed are skipped
+	state, err = Import(bytes.NewReader(exportArchive(t, source, 2, 4)), state, dest.blockStore, dest.blockExec)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), state.LastBlockHeight)
+	assert.Equal(t, source.state.AppHash, state.AppHash)
+}
+
+func TestImport_Errors(t *testing.T) {
+	t.Parallel()
+
+	privVal := types.NewMockPV()
+	source := newTestNode(t, privVal)
+	source.commitBlocks(t, 3)
+
+	t.Run("missing blocks", func(t *testing.T) {
+		t.Parallel()
+
+		dest := newTestNode(t, privVal)
+
+		_, err := Import(bytes.NewReader(exportArchive(t, source, 2, 3)), dest.state, dest.blockStore, dest.blockExec)
+		assert.ErrorIs(t, err, ErrInvalidRange)
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		t.Parallel()
+
+		dest := newTestNode(t, privVal)
+		dest.state.ChainID = "other"
+
+		_, err := Import(bytes.NewReader(exportArchive(t, source, 1, 3)), dest.state, dest.blockStore, dest.blockExec)
+		assert.ErrorContains(t, err, "archive of chain")
+	})
+
+	t.Run("other validators", func(t *testing.T) {
+		t.Parallel()
+
+		dest := newTestNode(t, types.NewMockPVWithParams(ed25519.GenPrivKey(), false, false))
+
+		state, err := Import(bytes.NewReader(exportArchive(t, source, 1, 3)), dest.state, dest.blockStore, dest.blockExec)
+		assert.ErrorContains(t, err, "invalid commit of block 1")
+		assert.Equal(t, int64(0), state.LastBlockHeight)
+		assert.Equal(t, int64(0), dest.blockStore.Height())
+	})
+
+	t.Run("diverging blocks", func(t *testing.T) {
+		t.Parallel()
+
+		// The blocks of the same validator, whose commits have other times
+		dest := newTestNode(t, privVal)
+		dest.commitBlocks(t, 2)
+
+		_, err := Import(bytes.NewReader(exportArchive(t, source, 1, 3)), dest.state, dest.blockStore, dest.blockExec)
+		assert.ErrorContains(t, err, "does not match the stored one")
+	})
+
+	t.Run("diverging saved block", func(t *testing.T) {
+		t.Parallel()
+
+		// A block saved but not executed, as after a crash. The first blocks
+		// are the same, the commits of the next ones have other times.
+		other := newTestNode(t, privVal)
+		other.commitBlocks(t, 2)
+
+		dest := newTestNode(t, privVal)
+		dest.commitBlocks(t, 1)
+
+		block := other.blockStore.LoadBlock(2)
+		dest.blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), other.blockStore.LoadSeenCommit(2))
+
+		state, err := Import(bytes.NewReader(exportArchive(t, source, 1, 3)), dest.state, dest.blockStore, dest.blockExec)
+		assert.ErrorContains(t, err, "block 2 does not match the stored one")
+		assert.Equal(t, int64(1), state.LastBlockHeight)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		dest := newTestNode(t, privVal)
+		archive := rewriteFooter(t, exportArchive(t, source, 1, 3), []byte("invalid"))
+
+		// No block is executed before the archive is verified
+		state, err := Import(bytes.NewReader(archive), dest.state, dest.blockStore, dest.blockExec)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+		assert.Equal(t, int64(0), state.LastBlockHeight)
+		assert.Equal(t, int64(0), dest.blockStore.Height())
+	})
+}
+
+func TestExport_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	source := newTestNode(t, types.NewMockPV())
+	source.commitBlocks(t, 2)
+
+	for _, r := range [][2]int64{{0, 1}, {2, 1}, {1, 3}} {
+		assert.ErrorIs(t, Export(io.Discard, source.blockStore, r[0], r[1]), ErrInvalidRange)
+	}
+}
+
+func TestReader_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	source := newTestNode(t, types.NewMockPV())
+	source.commitBlocks(t, 2)
+
+	readAll := func(archive []byte) error {
+		_, err := Verify(bytes.NewReader(archive))
+		return err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, readAll(exportArchive(t, source, 1, 2)))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		archive := exportArchive(t, source, 1, 2)
+		assert.Error(t, readAll(archive[:len(archive)/2]))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		archive := rewriteFooter(t, exportArchive(t, source, 1, 2), []byte("invalid"))
+		assert.ErrorIs(t, readAll(archive), ErrChecksumMismatch)
+	})
+}
@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// Verify reads the whole archive from r, and verifies its checksum
+func Verify(r io.Reader) (Header, error) {
+	ar, err := NewReader(r)
+	if err != nil {
+		return Header{}, err
+	}
+	defer ar.Close()
+
+	for {
+		if _, err := ar.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return ar.Header(), nil
+			}
+
+			return Header{}, err
+		}
+	}
+}
+
+// Import saves the blocks of the archive read from r in the block store, and
+// executes them on top of the given state, as the fast sync does. The archive
+// is verified first, so that no block of a corrupted archive is executed, and
+// the commit of each block is verified with the validators of the state.
+//
+// The blocks already saved or executed are skipped, as long as they match the
+// stored ones. It returns the state after the last executed block, which is
+// also returned along with the error if an import fails.
+func Import(r io.ReadSeeker, state sm.State, bs sm.BlockStore, blockExec *sm.BlockExecutor) (sm.State, error) {
+	if _, err := Verify(r); err != nil {
+		return state, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return state, fmt.Errorf("unable to rewind archive, %w", err)
+	}
+
+	ar, err := NewReader(r)
+	if err != nil {
+		return state, err
+	}
+	defer ar.Close()
+
+	header := ar.Header()
+	if header.ChainID != state.ChainID {
+		return state, fmt.Errorf("archive of chain %q, expected %q", header.ChainID, state.ChainID)
+	}
+	if header.StartHeight > state.LastBlockHeight+1 {
+		return state, fmt.Errorf(
+			"%w, the archive starts at %d, after the next height %d",
+			ErrInvalidRange, header.StartHeight, state.LastBlockHeight+1,
+		)
+	}
+
+	for {
+		b, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			return state, nil
+		}
+		if err != nil {
+			return state, err
+		}
+
+		var (
+			block   = b.Block
+			parts   = block.MakePartSet(types.BlockPartSizeBytes)
+			blockID = types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		)
+
+		if block.Height <= state.LastBlockHeight {
+			if err := checkStoredBlock(bs, block.Height, blockID); err != nil {
+				return state, err
+			}
+
+			continue
+		}
+
+		if err := state.Validators.VerifyCommit(state.ChainID, blockID, block.Height, b.Commit); err != nil {
+			return state, fmt.Errorf("invalid commit of block %d, %w", block.Height, err)
+		}
+
+		// The block may already be saved, if it was not executed
+		if bs.Height() >= block.Height {
+			if err := checkStoredBlock(bs, block.Height, blockID); err != nil {
+				return state, err
+			}
+		} else {
+			bs.SaveBlock(block, parts, b.Commit)
+		}
+
+		next, err := blockExec.ApplyBlock(state, blockID, block)
+		if err != nil {
+			return state, fmt.Errorf("unable to execute block %d, %w", block.Height, err)
+		}
+		state = next
+	}
+}
+
+// checkStoredBlock verifies that the block stored at the given height has the
+// given ID
+func checkStoredBlock(bs sm.BlockStore, height int64, blockID types.BlockID) error {
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil || !meta.BlockID.Equals(blockID) {
+		return fmt.Errorf("block %d does not match the stored one", height)
+	}
+
+	return nil
+}
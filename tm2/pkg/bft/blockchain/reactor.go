@@ -131,6 +131,32 @@ func (bcR *BlockchainReactor) OnStop() {
 	bcR.pool.Stop()
 }
 
+// SwitchToFastSync is called by the state sync, once the node is
+// bootstrapped at the state height, to fast sync the next blocks.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	if bcR.fastSync {
+		return errors.New("fast sync already in progress")
+	}
+	if state.LastBlockHeight != bcR.store.Height() {
+		return fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
+			bcR.store.Height())
+	}
+
+	bcR.fastSync = true
+	bcR.initialState = state
+
+	bcR.pool.mtx.Lock()
+	bcR.pool.height = state.LastBlockHeight + 1
+	bcR.pool.mtx.Unlock()
+
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+	go bcR.poolRoutine()
+
+	return nil
+}
+
 // GetChannels implements Reactor
 func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
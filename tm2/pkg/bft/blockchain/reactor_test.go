@@ -25,6 +25,7 @@ import (
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 	"github.com/gnolang/gno/tm2/pkg/testutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var config *cfg.Config
@@ -307,6 +308,43 @@ func TestFlappyBadBlockStopsPeer(t *testing.T) {
 	assert.True(t, len(lastReactorPair.reactor.Switch.Peers().List()) < len(reactorPairs)-1)
 }
 
+func TestSwitchToFastSync(t *testing.T) {
+	t.Parallel()
+
+	config, _ = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	var (
+		blockStore = store.NewBlockStore(memdb.NewMemDB())
+		blockExec  = sm.NewBlockExecutor(memdb.NewMemDB(), log.NewNoopLogger(), nil, mock.Mempool{})
+		bcReactor  = NewBlockchainReactor(state.Copy(), blockExec, blockStore, false, nil)
+	)
+	bcReactor.SetLogger(log.NewNoopLogger())
+
+	// Bootstrap the store at the height of the synced state
+	vote, err := types.MakeVote(10, types.BlockID{}, state.Validators, privVals[0], genDoc.ChainID)
+	require.NoError(t, err)
+	require.NoError(t, blockStore.Bootstrap(10, types.NewCommit(types.BlockID{}, []*types.CommitSig{vote.CommitSig()})))
+
+	// The state must be at the store height
+	require.Error(t, bcReactor.SwitchToFastSync(state))
+
+	state.LastBlockHeight = 10
+	require.NoError(t, bcReactor.SwitchToFastSync(state))
+	defer bcReactor.pool.Stop()
+
+	height, _, _ := bcReactor.pool.GetStatus()
+	assert.Equal(t, int64(11), height)
+	assert.True(t, bcReactor.pool.IsRunning())
+
+	// Fast sync can only be started once
+	require.Error(t, bcReactor.SwitchToFastSync(state))
+}
+
 func TestBcBlockRequestMessageValidateBasic(t *testing.T) {
 	t.Parallel()
 
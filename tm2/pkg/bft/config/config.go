@@ -14,6 +14,7 @@ import (
 	mem "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	rpc "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
 	eventstore "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	statesync "github.com/gnolang/gno/tm2/pkg/bft/statesync/config"
 	"github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	osm "github.com/gnolang/gno/tm2/pkg/os"
@@ -32,6 +33,7 @@ var (
 	errInvalidPrivValidatorListenAddress = errors.New("invalid PrivValidator listen address")
 	errInvalidProfListenAddress          = errors.New("invalid profiling server listen address")
 	errInvalidNodeKeyPath                = errors.New("invalid p2p node key path")
+	errInvalidMinRetainBlocks            = errors.New("invalid min retain blocks")
 )
 
 const (
@@ -50,13 +52,14 @@ type Config struct {
 	BaseConfig `toml:",squash"`
 
 	// Options for services
-	RPC          *rpc.RPCConfig       `json:"rpc" toml:"rpc" comment:"##### rpc server configuration options #####"`
-	P2P          *p2p.P2PConfig       `json:"p2p" toml:"p2p" comment:"##### peer to peer configuration options #####"`
-	Mempool      *mem.MempoolConfig   `json:"mempool" toml:"mempool" comment:"##### mempool configuration options #####"`
-	Consensus    *cns.ConsensusConfig `json:"consensus" toml:"consensus" comment:"##### consensus configuration options #####"`
-	TxEventStore *eventstore.Config   `json:"tx_event_store" toml:"tx_event_store" comment:"##### event store #####"`
-	Telemetry    *telemetry.Config    `json:"telemetry" toml:"telemetry" comment:"##### node telemetry #####"`
-	Application  *sdk.AppConfig       `json:"application" toml:"application" comment:"##### app settings #####"`
+	RPC          *rpc.RPCConfig             `json:"rpc" toml:"rpc" comment:"##### rpc server configuration options #####"`
+	P2P          *p2p.P2PConfig             `json:"p2p" toml:"p2p" comment:"##### peer to peer configuration options #####"`
+	Mempool      *mem.MempoolConfig         `json:"mempool" toml:"mempool" comment:"##### mempool configuration options #####"`
+	Consensus    *cns.ConsensusConfig       `json:"consensus" toml:"consensus" comment:"##### consensus configuration options #####"`
+	StateSync    *statesync.StateSyncConfig `json:"statesync" toml:"statesync" comment:"##### state sync configuration options #####"`
+	TxEventStore *eventstore.Config         `json:"tx_event_store" toml:"tx_event_store" comment:"##### event store #####"`
+	Telemetry    *telemetry.Config          `json:"telemetry" toml:"telemetry" comment:"##### node telemetry #####"`
+	Application  *sdk.AppConfig             `json:"application" toml:"application" comment:"##### app settings #####"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -67,6 +70,7 @@ func DefaultConfig() *Config {
 		P2P:          p2p.DefaultP2PConfig(),
 		Mempool:      mem.DefaultMempoolConfig(),
 		Consensus:    cns.DefaultConsensusConfig(),
+		StateSync:    statesync.DefaultStateSyncConfig(),
 		TxEventStore: eventstore.DefaultEventStoreConfig(),
 		Telemetry:    telemetry.DefaultTelemetryConfig(),
 		Application:  sdk.DefaultAppConfig(),
@@ -184,6 +188,7 @@ func TestConfig() *Config {
 		P2P:          testP2PConfig(),
 		Mempool:      mem.TestMempoolConfig(),
 		Consensus:    cns.TestConsensusConfig(),
+		StateSync:    statesync.TestStateSyncConfig(),
 		TxEventStore: eventstore.DefaultEventStoreConfig(),
 		Telemetry:    telemetry.DefaultTelemetryConfig(),
 		Application:  sdk.DefaultAppConfig(),
@@ -242,6 +247,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [consensus] section")
 	}
+	if err := cfg.StateSync.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "Error in [statesync] section")
+	}
 	if err := cfg.Application.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [application] section")
 	}
@@ -317,6 +325,12 @@ type BaseConfig struct {
 	// Database directory
 	DBPath string `toml:"db_dir" comment:"Database directory"`
 
+	// Number of the latest blocks to keep, pruning the older blocks and states.
+	// The application can also request the pruning of the blocks below a
+	// retain height, in which case the lowest retain height is used.
+	// 0 disables the pruning by the node, keeping all the blocks
+	MinRetainBlocks int64 `toml:"min_retain_blocks" comment:"Number of the latest blocks to keep, pruning the older blocks and states.\n The application can also request the pruning of the blocks below a\n retain height, in which case the lowest retain height is used.\n 0 disables the pruning by the node, keeping all the blocks"`
+
 	// Path to the JSON file containing the private key to use as a validator in the consensus protocol
 	PrivValidatorKey string `toml:"priv_validator_key_file" comment:"Path to the JSON file containing the private key to use as a validator in the consensus protocol"`
 
@@ -424,6 +438,11 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errInvalidDBPath
 	}
 
+	// Verify the number of retained blocks
+	if cfg.MinRetainBlocks < 0 {
+		return errInvalidMinRetainBlocks
+	}
+
 	// Verify the validator private key path is set
 	if cfg.PrivValidatorKey == "" {
 		return errInvalidPrivValidatorKeyPath
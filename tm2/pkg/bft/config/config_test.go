@@ -131,6 +131,15 @@ func TestConfig_ValidateBaseConfig(t *testing.T) {
 		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errInvalidDBPath)
 	})
 
+	t.Run("invalid min retain blocks", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultConfig()
+		c.MinRetainBlocks = -1
+
+		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errInvalidMinRetainBlocks)
+	})
+
 	t.Run("priv validator key path not set", func(t *testing.T) {
 		t.Parallel()
 
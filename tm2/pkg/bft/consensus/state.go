@@ -860,7 +860,12 @@ func (cs *ConsensusState) needProofBlock(height int64) bool {
 		return true
 	}
 
+	// The last block is missing from a block store bootstrapped by state sync
 	lastBlockMeta := cs.blockStore.LoadBlockMeta(height - 1)
+	if lastBlockMeta == nil {
+		return true
+	}
+
 	return !bytes.Equal(cs.state.AppHash, lastBlockMeta.Header.AppHash)
 }
 
@@ -0,0 +1,385 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+const (
+	defaultMaxClockDrift = 10 * time.Second
+	defaultPruningSize   = 1000
+)
+
+// ErrConflictingHeaders is returned when a witness has a different header
+// than the primary, at the same height.
+var ErrConflictingHeaders = errors.New("conflicting headers")
+
+type verificationMode int
+
+const (
+	skipping verificationMode = iota
+	sequential
+)
+
+// Client is a light client, verifying the headers of a chain fetched from a
+// primary provider, from a header trusted out of band. The verified headers
+// are cross-checked with witness providers, and persisted in a trusted
+// store.
+type Client struct {
+	chainID          string
+	trustingPeriod   time.Duration
+	verificationMode verificationMode
+	trustLevel       TrustLevel
+	maxClockDrift    time.Duration
+	pruningSize      int
+	logger           *slog.Logger
+
+	primary   Provider
+	witnesses []Provider
+	store     *Store
+
+	mtx           sync.Mutex
+	latestTrusted *LightBlock
+}
+
+// NewClient returns a new light client. If the store already has trusted
+// light blocks, the client resumes from the latest one. Otherwise, the
+// trusted header is fetched from the primary, and checked against the trust
+// options.
+func NewClient(
+	chainID string,
+	trustOptions TrustOptions,
+	primary Provider,
+	witnesses []Provider,
+	store *Store,
+	opts ...Option,
+) (*Client, error) {
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid trust options, %w", err)
+	}
+
+	c := &Client{
+		chainID:          chainID,
+		trustingPeriod:   trustOptions.Period,
+		verificationMode: skipping,
+		trustLevel:       DefaultTrustLevel,
+		maxClockDrift:    defaultMaxClockDrift,
+		pruningSize:      defaultPruningSize,
+		logger:           log.NewNoopLogger(),
+		primary:          primary,
+		witnesses:        witnesses,
+		store:            store,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.trustLevel.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if err := c.restoreTrustedLightBlock(trustOptions); err != nil {
+		return nil, err
+	}
+
+	if c.latestTrusted == nil {
+		if err := c.initializeWithTrustOptions(trustOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// restoreTrustedLightBlock loads the latest trusted light block of the
+// store, if any, checking that it agrees with the trust options
+func (c *Client) restoreTrustedLightBlock(trustOptions TrustOptions) error {
+	height := c.store.LastLightBlockHeight()
+	if height == 0 {
+		return nil
+	}
+
+	latest, err := c.store.LightBlock(height)
+	if err != nil {
+		return fmt.Errorf("unable to load the latest trusted light block, %w", err)
+	}
+
+	// The stored light block at the trusted height must have the trusted hash
+	lb, err := c.store.LightBlock(trustOptions.Height)
+	switch {
+	case err == nil && !bytes.Equal(lb.Hash(), trustOptions.Hash):
+		return fmt.Errorf("stored header hash %X at height %d does not match the trusted hash %X",
+			lb.Hash(), trustOptions.Height, trustOptions.Hash)
+	case err == nil, errors.Is(err, ErrLightBlockNotFound):
+	default:
+		return err
+	}
+
+	c.latestTrusted = latest
+	c.logger.Info("Restored trusted light block", "height", latest.Height, "hash", fmt.Sprintf("%X", latest.Hash()))
+
+	return nil
+}
+
+// initializeWithTrustOptions fetches the trusted light block from the
+// primary, and checks it against the trust options
+func (c *Client) initializeWithTrustOptions(trustOptions TrustOptions) error {
+	lb, err := c.primary.LightBlock(trustOptions.Height)
+	if err != nil {
+		return fmt.Errorf("unable to fetch the trusted light block, %w", err)
+	}
+
+	if !bytes.Equal(lb.Hash(), trustOptions.Hash) {
+		return fmt.Errorf("header hash %X at height %d does not match the trusted hash %X",
+			lb.Hash(), trustOptions.Height, trustOptions.Hash)
+	}
+
+	if err := lb.ValidatorSet.VerifyCommit(c.chainID, lb.Commit.BlockID, lb.Height, lb.Commit); err != nil {
+		return fmt.Errorf("invalid commit at trusted height %d, %w", lb.Height, err)
+	}
+
+	if err := c.compareWithWitnesses(lb); err != nil {
+		return err
+	}
+
+	return c.updateTrustedLightBlock(lb)
+}
+
+// TrustedLightBlock returns the trusted light block at the height, or the
+// latest one if the height is 0. The light block is not fetched, use
+// VerifyLightBlockAtHeight to verify new heights.
+func (c *Client) TrustedLightBlock(height int64) (*LightBlock, error) {
+	if height == 0 {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		return c.latestTrusted, nil
+	}
+
+	return c.store.LightBlock(height)
+}
+
+// LastTrustedHeight returns the height of the latest trusted light block
+func (c *Client) LastTrustedHeight() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.latestTrusted.Height
+}
+
+// Update verifies the latest light block of the primary, if it is above the
+// latest trusted one. It returns the new trusted light block, or nil if
+// there is none.
+func (c *Client) Update(now time.Time) (*LightBlock, error) {
+	latest, err := c.primary.LightBlock(0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the latest light block, %w", err)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if latest.Height <= c.latestTrusted.Height {
+		return nil, nil
+	}
+
+	if err := c.verifyForwards(latest, now); err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+// VerifyLightBlockAtHeight returns the trusted light block at the height,
+// fetching and verifying it if needed. Heights above the latest trusted one
+// are verified from it, and lower heights are verified backwards, through
+// the hash chain of the headers.
+func (c *Client) VerifyLightBlockAtHeight(height int64, now time.Time) (*LightBlock, error) {
+	if height <= 0 {
+		return nil, errors.New("height must be positive")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	lb, err := c.store.LightBlock(height)
+	if err == nil {
+		return lb, nil
+	}
+	if !errors.Is(err, ErrLightBlockNotFound) {
+		return nil, err
+	}
+
+	lb, err = c.primary.LightBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	if height > c.latestTrusted.Height {
+		err = c.verifyForwards(lb, now)
+	} else {
+		err = c.verifyBackwards(lb, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return lb, nil
+}
+
+// verifyForwards verifies a light block above the latest trusted one. The
+// witnesses are checked first, so a conflicting header is never trusted
+func (c *Client) verifyForwards(target *LightBlock, now time.Time) error {
+	if err := c.compareWithWitnesses(target); err != nil {
+		return err
+	}
+
+	switch c.verificationMode {
+	case sequential:
+		return c.verifySequential(target, now)
+	default:
+		return c.verifySkipping(target, now)
+	}
+}
+
+// verifySequential verifies every header up to the target
+func (c *Client) verifySequential(target *LightBlock, now time.Time) error {
+	verified := c.latestTrusted
+
+	for height := verified.Height + 1; height <= target.Height; height++ {
+		untrusted := target
+		if height < target.Height {
+			lb, err := c.primary.LightBlock(height)
+			if err != nil {
+				return err
+			}
+			untrusted = lb
+		}
+
+		if err := VerifyAdjacent(c.chainID, verified, untrusted, c.trustingPeriod, now, c.maxClockDrift); err != nil {
+			return fmt.Errorf("unable to verify header at height %d, %w", height, err)
+		}
+
+		if err := c.updateTrustedLightBlock(untrusted); err != nil {
+			return err
+		}
+		verified = untrusted
+	}
+
+	return nil
+}
+
+// verifySkipping verifies the target from the latest trusted header, using
+// bisection to verify intermediate headers when the trusted validators
+// changed too much
+func (c *Client) verifySkipping(target *LightBlock, now time.Time) error {
+	var (
+		verified = c.latestTrusted
+		pending  = []*LightBlock{target} // the last one is verified next
+	)
+
+	for len(pending) > 0 {
+		untrusted := pending[len(pending)-1]
+
+		err := Verify(c.chainID, verified, untrusted, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+		switch {
+		case err == nil:
+			if err := c.updateTrustedLightBlock(untrusted); err != nil {
+				return err
+			}
+
+			verified = untrusted
+			pending = pending[:len(pending)-1]
+		case errors.Is(err, ErrNewValSetCantBeTrusted):
+			pivotHeight := verified.Height + (untrusted.Height-verified.Height)/2
+
+			c.logger.Debug("Bisecting", "trusted", verified.Height, "untrusted", untrusted.Height, "pivot", pivotHeight)
+
+			pivot, err := c.primary.LightBlock(pivotHeight)
+			if err != nil {
+				return err
+			}
+			pending = append(pending, pivot)
+		default:
+			return fmt.Errorf("unable to verify header at height %d, %w", untrusted.Height, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyBackwards verifies a light block below the latest trusted one, from
+// the closest trusted light block above it
+func (c *Client) verifyBackwards(target *LightBlock, now time.Time) error {
+	trusted, err := c.store.LightBlockAfter(target.Height)
+	if err != nil {
+		return fmt.Errorf("no trusted light block above height %d, %w", target.Height, err)
+	}
+
+	if HeaderExpired(trusted.SignedHeader, c.trustingPeriod, now) {
+		return fmt.Errorf("%w, at height %d", ErrOldHeaderExpired, trusted.Height)
+	}
+
+	for height := trusted.Height - 1; height >= target.Height; height-- {
+		untrusted := target
+		if height > target.Height {
+			lb, err := c.primary.LightBlock(height)
+			if err != nil {
+				return err
+			}
+			untrusted = lb
+		}
+
+		if err := VerifyBackwards(c.chainID, untrusted, trusted); err != nil {
+			return fmt.Errorf("unable to verify header at height %d, %w", height, err)
+		}
+		trusted = untrusted
+	}
+
+	return c.store.SaveLightBlock(target)
+}
+
+// compareWithWitnesses checks that the witnesses have the same header as the
+// primary. The unavailable witnesses are skipped
+func (c *Client) compareWithWitnesses(lb *LightBlock) error {
+	for i, witness := range c.witnesses {
+		witnessLB, err := witness.LightBlock(lb.Height)
+		if err != nil {
+			c.logger.Error("Unable to fetch light block from witness", "witness", i, "height", lb.Height, "err", err)
+
+			continue
+		}
+
+		if !bytes.Equal(witnessLB.Hash(), lb.Hash()) {
+			return fmt.Errorf("%w, witness %d has header %X at height %d, primary has %X",
+				ErrConflictingHeaders, i, witnessLB.Hash(), lb.Height, lb.Hash())
+		}
+	}
+
+	return nil
+}
+
+// updateTrustedLightBlock saves a verified light block, and prunes the
+// oldest ones
+func (c *Client) updateTrustedLightBlock(lb *LightBlock) error {
+	if err := c.store.SaveLightBlock(lb); err != nil {
+		return err
+	}
+
+	if c.pruningSize > 0 {
+		c.store.Prune(c.pruningSize)
+	}
+
+	if c.latestTrusted == nil || lb.Height > c.latestTrusted.Height {
+		c.latestTrusted = lb
+	}
+
+	return nil
+}
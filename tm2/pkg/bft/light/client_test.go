@@ -0,0 +1,362 @@
+package light
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+// mockProvider serves generated light blocks
+type mockProvider struct {
+	blocks map[int64]*LightBlock
+	latest int64
+
+	mtx     sync.Mutex
+	fetched map[int64]int
+}
+
+func newMockProvider(blocks map[int64]*LightBlock) *mockProvider {
+	return &mockProvider{
+		blocks:  blocks,
+		latest:  int64(len(blocks)),
+		fetched: make(map[int64]int),
+	}
+}
+
+func (p *mockProvider) ChainID() string {
+	return testChainID
+}
+
+func (p *mockProvider) LightBlock(height int64) (*LightBlock, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if height == 0 {
+		height = p.latest
+	}
+
+	lb, ok := p.blocks[height]
+	if !ok {
+		return nil, fmt.Errorf("%w, height %d", ErrLightBlockNotFound, height)
+	}
+	p.fetched[height]++
+
+	return lb, nil
+}
+
+func TestClient_VerifyLightBlockAtHeight(t *testing.T) {
+	t.Parallel()
+
+	var (
+		vals1 = newTestValidators(3)
+		vals2 = newTestValidators(3)
+		now   = genesisTime.Add(time.Hour)
+
+		// The validators are entirely replaced at height 10
+		blocks = genLightBlocks(t, 20, func(h int64) testValidators {
+			if h >= 10 {
+				return vals2
+			}
+
+			return vals1
+		})
+
+		trustOptions = TrustOptions{
+			Period: trustingPeriod,
+			Height: 1,
+			Hash:   blocks[1].Hash(),
+		}
+	)
+
+	t.Run("skipping", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			primary = newMockProvider(blocks)
+			store   = NewStore(memdb.NewMemDB())
+		)
+
+		c, err := NewClient(testChainID, trustOptions, primary, nil, store)
+		require.NoError(t, err)
+
+		lb, err := c.VerifyLightBlockAtHeight(20, now)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[20].Hash(), lb.Hash())
+		assert.Equal(t, int64(20), c.LastTrustedHeight())
+
+		// The headers are bisected until the validators change
+		assert.Less(t, store.Size(), 10)
+		assert.Less(t, len(primary.fetched), 10)
+
+		_, err = store.LightBlock(9)
+		require.NoError(t, err)
+
+		// A verified light block is not fetched again
+		_, err = c.VerifyLightBlockAtHeight(20, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, primary.fetched[20])
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			primary = newMockProvider(blocks)
+			store   = NewStore(memdb.NewMemDB())
+		)
+
+		c, err := NewClient(testChainID, trustOptions, primary, nil, store, WithSequentialVerification())
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(20, now)
+		require.NoError(t, err)
+
+		// Every header is verified
+		assert.Equal(t, 20, store.Size())
+	})
+
+	t.Run("backwards", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			primary = newMockProvider(blocks)
+			store   = NewStore(memdb.NewMemDB())
+		)
+
+		c, err := NewClient(testChainID, TrustOptions{
+			Period: trustingPeriod,
+			Height: 15,
+			Hash:   blocks[15].Hash(),
+		}, primary, nil, store)
+		require.NoError(t, err)
+
+		lb, err := c.VerifyLightBlockAtHeight(5, now)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[5].Hash(), lb.Hash())
+
+		// The latest trusted header is unchanged
+		assert.Equal(t, int64(15), c.LastTrustedHeight())
+	})
+
+	t.Run("pruning", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			primary = newMockProvider(blocks)
+			store   = NewStore(memdb.NewMemDB())
+		)
+
+		c, err := NewClient(
+			testChainID,
+			trustOptions,
+			primary,
+			nil,
+			store,
+			WithSequentialVerification(),
+			WithPruningSize(5),
+		)
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(20, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, store.Size())
+		assert.Equal(t, int64(16), store.FirstLightBlockHeight())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewClient(testChainID, trustOptions, newMockProvider(blocks), nil, NewStore(memdb.NewMemDB()))
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(20, genesisTime.Add(2*trustingPeriod))
+		assert.ErrorIs(t, err, ErrOldHeaderExpired)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := make(map[int64]*LightBlock, len(blocks))
+		for h, lb := range blocks {
+			tampered[h] = lb
+		}
+
+		header := *blocks[20].Header
+		header.AppHash = []byte("tampered")
+		tampered[20] = &LightBlock{
+			SignedHeader: signHeader(t, &header, newTestValidators(3)),
+			ValidatorSet: blocks[20].ValidatorSet,
+		}
+
+		c, err := NewClient(testChainID, trustOptions, newMockProvider(tampered), nil, NewStore(memdb.NewMemDB()))
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(20, now)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+
+		// Only the verified pivots are trusted
+		assert.Less(t, c.LastTrustedHeight(), int64(20))
+	})
+}
+
+func TestClient_Update(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blocks  = genLightBlocks(t, 10, staticValidators(newTestValidators(4)))
+		primary = newMockProvider(blocks)
+		now     = genesisTime.Add(time.Hour)
+	)
+
+	c, err := NewClient(testChainID, TrustOptions{
+		Period: trustingPeriod,
+		Height: 1,
+		Hash:   blocks[1].Hash(),
+	}, primary, nil, NewStore(memdb.NewMemDB()))
+	require.NoError(t, err)
+
+	lb, err := c.Update(now)
+	require.NoError(t, err)
+	require.NotNil(t, lb)
+	assert.Equal(t, int64(10), lb.Height)
+
+	// There is no new light block
+	lb, err = c.Update(now)
+	require.NoError(t, err)
+	assert.Nil(t, lb)
+
+	latest, err := c.TrustedLightBlock(0)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[10].Hash(), latest.Hash())
+}
+
+func TestClient_Witnesses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		vals   = newTestValidators(4)
+		blocks = genLightBlocks(t, 10, staticValidators(vals))
+		now    = genesisTime.Add(time.Hour)
+
+		trustOptions = TrustOptions{
+			Period: trustingPeriod,
+			Height: 1,
+			Hash:   blocks[1].Hash(),
+		}
+	)
+
+	// The witness has another header at height 10
+	conflicting := make(map[int64]*LightBlock, len(blocks))
+	for h, lb := range blocks {
+		conflicting[h] = lb
+	}
+
+	header := *blocks[10].Header
+	header.AppHash = []byte("conflicting")
+	conflicting[10] = &LightBlock{
+		SignedHeader: signHeader(t, &header, vals),
+		ValidatorSet: vals.set,
+	}
+
+	c, err := NewClient(
+		testChainID,
+		trustOptions,
+		newMockProvider(blocks),
+		[]Provider{
+			newMockProvider(blocks),
+			newMockProvider(conflicting),
+		},
+		NewStore(memdb.NewMemDB()),
+	)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(5, now)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(10, now)
+	assert.ErrorIs(t, err, ErrConflictingHeaders)
+	assert.ErrorContains(t, err, "witness 1")
+	assert.Equal(t, int64(5), c.LastTrustedHeight())
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blocks = genLightBlocks(t, 10, staticValidators(newTestValidators(2)))
+		now    = genesisTime.Add(time.Hour)
+
+		trustOptions = TrustOptions{
+			Period: trustingPeriod,
+			Height: 1,
+			Hash:   blocks[1].Hash(),
+		}
+	)
+
+	t.Run("invalid trust options", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient(testChainID, TrustOptions{Height: 1, Hash: blocks[1].Hash()}, newMockProvider(blocks), nil, NewStore(memdb.NewMemDB()))
+		assert.ErrorContains(t, err, "trusting period must be positive")
+	})
+
+	t.Run("invalid trust level", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient(
+			testChainID,
+			trustOptions,
+			newMockProvider(blocks),
+			nil,
+			NewStore(memdb.NewMemDB()),
+			WithSkippingVerification(TrustLevel{Numerator: 1, Denominator: 4}),
+		)
+		assert.ErrorContains(t, err, "trust level must be within [1/3, 1]")
+	})
+
+	t.Run("trusted hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient(testChainID, TrustOptions{
+			Period: trustingPeriod,
+			Height: 1,
+			Hash:   blocks[2].Hash(),
+		}, newMockProvider(blocks), nil, NewStore(memdb.NewMemDB()))
+		assert.ErrorContains(t, err, "does not match the trusted hash")
+	})
+
+	t.Run("restored from store", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewStore(memdb.NewMemDB())
+
+		c, err := NewClient(testChainID, trustOptions, newMockProvider(blocks), nil, store)
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(8, now)
+		require.NoError(t, err)
+
+		// The client resumes from the latest trusted header
+		primary := newMockProvider(blocks)
+
+		c, err = NewClient(testChainID, trustOptions, primary, nil, store)
+		require.NoError(t, err)
+		assert.Equal(t, int64(8), c.LastTrustedHeight())
+		assert.Empty(t, primary.fetched)
+
+		// The stored headers must match the trust options
+		_, err = NewClient(testChainID, TrustOptions{
+			Period: trustingPeriod,
+			Height: 1,
+			Hash:   blocks[2].Hash(),
+		}, primary, nil, store)
+		assert.ErrorContains(t, err, "does not match the trusted hash")
+	})
+}
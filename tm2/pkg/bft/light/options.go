@@ -0,0 +1,50 @@
+package light
+
+import (
+	"log/slog"
+	"time"
+)
+
+type Option func(*Client)
+
+// WithSequentialVerification makes the client verify every header between
+// the trusted header and the new one
+func WithSequentialVerification() Option {
+	return func(c *Client) {
+		c.verificationMode = sequential
+	}
+}
+
+// WithSkippingVerification makes the client skip the headers between the
+// trusted header and the new one, as long as the trusted validators signed
+// more than the trust level of the new header. This is the default, with
+// DefaultTrustLevel
+func WithSkippingVerification(trustLevel TrustLevel) Option {
+	return func(c *Client) {
+		c.verificationMode = skipping
+		c.trustLevel = trustLevel
+	}
+}
+
+// WithMaxClockDrift sets the maximum drift of the header times, relative to
+// the local clock
+func WithMaxClockDrift(drift time.Duration) Option {
+	return func(c *Client) {
+		c.maxClockDrift = drift
+	}
+}
+
+// WithPruningSize sets the maximum number of trusted light blocks kept in
+// the store. 0 keeps all of them
+func WithPruningSize(size int) Option {
+	return func(c *Client) {
+		c.pruningSize = size
+	}
+}
+
+// WithLogger sets the light client logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
@@ -0,0 +1,94 @@
+package light
+
+import (
+	"fmt"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// Provider provides the light blocks of a chain. The light blocks are not
+// verified, except for their internal consistency.
+type Provider interface {
+	// ChainID returns the chain ID of the provided light blocks
+	ChainID() string
+
+	// LightBlock returns the light block at the height, or the latest one
+	// if the height is 0
+	LightBlock(height int64) (*LightBlock, error)
+}
+
+// rpcClient is the RPC client used to fetch the light blocks
+type rpcClient interface {
+	Commit(height *int64) (*ctypes.ResultCommit, error)
+	Validators(height *int64) (*ctypes.ResultValidators, error)
+}
+
+// rpcProvider is a Provider fetching the light blocks from an RPC server
+type rpcProvider struct {
+	chainID string
+	client  rpcClient
+}
+
+// NewProvider returns a Provider fetching the light blocks with the given
+// RPC client
+func NewProvider(chainID string, client rpcclient.SignClient) Provider {
+	return &rpcProvider{
+		chainID: chainID,
+		client:  client,
+	}
+}
+
+// NewHTTPProvider returns a Provider fetching the light blocks from the RPC
+// server at the given address
+func NewHTTPProvider(chainID, remote string) (Provider, error) {
+	client, err := rpcclient.NewHTTPClient(remote)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create RPC client for %s, %w", remote, err)
+	}
+
+	return NewProvider(chainID, client), nil
+}
+
+// ChainID implements Provider.
+func (p *rpcProvider) ChainID() string {
+	return p.chainID
+}
+
+// LightBlock implements Provider.
+func (p *rpcProvider) LightBlock(height int64) (*LightBlock, error) {
+	var heightPtr *int64
+	if height > 0 {
+		heightPtr = &height
+	}
+
+	commit, err := p.client.Commit(heightPtr)
+	if err != nil {
+		return nil, fmt.Errorf("%w, unable to fetch commit at height %d, %w", ErrLightBlockNotFound, height, err)
+	}
+
+	if commit.Header == nil {
+		return nil, fmt.Errorf("missing header at height %d", height)
+	}
+
+	if height > 0 && commit.Height != height {
+		return nil, fmt.Errorf("header at height %d returned for height %d", commit.Height, height)
+	}
+
+	res, err := p.client.Validators(&commit.Height)
+	if err != nil {
+		return nil, fmt.Errorf("%w, unable to fetch validators at height %d, %w", ErrLightBlockNotFound, commit.Height, err)
+	}
+
+	lb := &LightBlock{
+		SignedHeader: &commit.SignedHeader,
+		ValidatorSet: &types.ValidatorSet{Validators: res.Validators},
+	}
+
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, fmt.Errorf("invalid light block at height %d, %w", commit.Height, err)
+	}
+
+	return lb, nil
+}
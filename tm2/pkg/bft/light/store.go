@@ -0,0 +1,165 @@
+package light
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var lightBlockKeyPrefix = []byte("lb/") // lb/<height>
+
+// ErrLightBlockNotFound is returned when a light block does not exist.
+var ErrLightBlockNotFound = errors.New("light block not found")
+
+// Store persists the trusted light blocks in a DB, so the client can resume
+// from its latest trusted header.
+type Store struct {
+	db dbm.DB
+}
+
+// NewStore returns a new trusted store using the given DB
+func NewStore(db dbm.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// SaveLightBlock saves a trusted light block
+func (s *Store) SaveLightBlock(lb *LightBlock) error {
+	bz, err := amino.Marshal(lb)
+	if err != nil {
+		return fmt.Errorf("unable to marshal light block, %w", err)
+	}
+
+	s.db.SetSync(lightBlockKey(lb.Height), bz)
+
+	return nil
+}
+
+// DeleteLightBlock deletes the light block at the given height
+func (s *Store) DeleteLightBlock(height int64) {
+	s.db.DeleteSync(lightBlockKey(height))
+}
+
+// LightBlock returns the light block at the given height
+func (s *Store) LightBlock(height int64) (*LightBlock, error) {
+	bz := s.db.Get(lightBlockKey(height))
+	if bz == nil {
+		return nil, ErrLightBlockNotFound
+	}
+
+	return unmarshalLightBlock(bz)
+}
+
+// LightBlockBefore returns the highest light block below the given height
+func (s *Store) LightBlockBefore(height int64) (*LightBlock, error) {
+	itr := s.db.ReverseIterator(lightBlockKey(0), lightBlockKey(height))
+	defer itr.Close()
+
+	if !itr.Valid() {
+		return nil, ErrLightBlockNotFound
+	}
+
+	return unmarshalLightBlock(itr.Value())
+}
+
+// LightBlockAfter returns the lowest light block above the given height
+func (s *Store) LightBlockAfter(height int64) (*LightBlock, error) {
+	itr := s.db.Iterator(lightBlockKey(height+1), lightBlockKey(math.MaxInt64))
+	defer itr.Close()
+
+	if !itr.Valid() {
+		return nil, ErrLightBlockNotFound
+	}
+
+	return unmarshalLightBlock(itr.Value())
+}
+
+// LastLightBlockHeight returns the height of the latest light block, or 0 if
+// the store is empty
+func (s *Store) LastLightBlockHeight() int64 {
+	itr := s.db.ReverseIterator(lightBlockKey(0), lightBlockKey(math.MaxInt64))
+	defer itr.Close()
+
+	if !itr.Valid() {
+		return 0
+	}
+
+	return lightBlockHeight(itr.Key())
+}
+
+// FirstLightBlockHeight returns the height of the earliest light block, or 0
+// if the store is empty
+func (s *Store) FirstLightBlockHeight() int64 {
+	itr := dbm.IteratePrefix(s.db, lightBlockKeyPrefix)
+	defer itr.Close()
+
+	if !itr.Valid() {
+		return 0
+	}
+
+	return lightBlockHeight(itr.Key())
+}
+
+// Size returns the number of saved light blocks
+func (s *Store) Size() int {
+	itr := dbm.IteratePrefix(s.db, lightBlockKeyPrefix)
+	defer itr.Close()
+
+	size := 0
+	for ; itr.Valid(); itr.Next() {
+		size++
+	}
+
+	return size
+}
+
+// Prune deletes the light blocks except the size most recent ones, and
+// returns the number of deleted light blocks
+func (s *Store) Prune(size int) int {
+	total := s.Size()
+	if total <= size {
+		return 0
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	itr := dbm.IteratePrefix(s.db, lightBlockKeyPrefix)
+	pruned := 0
+	for ; itr.Valid() && pruned < total-size; itr.Next() {
+		batch.Delete(itr.Key())
+		pruned++
+	}
+	itr.Close()
+
+	batch.WriteSync()
+
+	return pruned
+}
+
+// lightBlockKey returns the key of a light block, ordered by height
+func lightBlockKey(height int64) []byte {
+	key := make([]byte, 0, len(lightBlockKeyPrefix)+8)
+	key = append(key, lightBlockKeyPrefix...)
+
+	return binary.BigEndian.AppendUint64(key, uint64(height))
+}
+
+// lightBlockHeight returns the height of a light block key
+func lightBlockHeight(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(lightBlockKeyPrefix):]))
+}
+
+func unmarshalLightBlock(bz []byte) (*LightBlock, error) {
+	lb := new(LightBlock)
+	if err := amino.Unmarshal(bz, lb); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal light block, %w", err)
+	}
+
+	return lb, nil
+}
@@ -0,0 +1,62 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		store  = NewStore(memdb.NewMemDB())
+		blocks = genLightBlocks(t, 5, staticValidators(newTestValidators(2)))
+	)
+
+	assert.Zero(t, store.LastLightBlockHeight())
+	assert.Zero(t, store.FirstLightBlockHeight())
+
+	_, err := store.LightBlock(1)
+	assert.ErrorIs(t, err, ErrLightBlockNotFound)
+
+	for _, h := range []int64{2, 3, 5} {
+		require.NoError(t, store.SaveLightBlock(blocks[h]))
+	}
+
+	assert.Equal(t, int64(5), store.LastLightBlockHeight())
+	assert.Equal(t, int64(2), store.FirstLightBlockHeight())
+	assert.Equal(t, 3, store.Size())
+
+	// The light blocks are persisted with their validators
+	lb, err := store.LightBlock(3)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[3].Hash(), lb.Hash())
+	assert.Equal(t, blocks[3].ValidatorSet.Hash(), lb.ValidatorSet.Hash())
+	assert.NoError(t, lb.ValidateBasic(testChainID))
+
+	lb, err = store.LightBlockBefore(5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), lb.Height)
+
+	lb, err = store.LightBlockAfter(3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), lb.Height)
+
+	_, err = store.LightBlockBefore(2)
+	assert.ErrorIs(t, err, ErrLightBlockNotFound)
+
+	_, err = store.LightBlockAfter(5)
+	assert.ErrorIs(t, err, ErrLightBlockNotFound)
+
+	// The oldest light blocks are pruned
+	assert.Equal(t, 1, store.Prune(2))
+	assert.Zero(t, store.Prune(2))
+	assert.Equal(t, int64(3), store.FirstLightBlockHeight())
+
+	store.DeleteLightBlock(5)
+	assert.Equal(t, int64(3), store.LastLightBlockHeight())
+}
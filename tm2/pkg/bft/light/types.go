@@ -0,0 +1,94 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// LightBlock is a signed header, with the validator set which signed it.
+type LightBlock struct {
+	*types.SignedHeader `json:"signed_header"`
+	ValidatorSet        *types.ValidatorSet `json:"validator_set"`
+}
+
+// ValidateBasic checks that the signed header is valid for the chain, and
+// that the validator set matches its validators hash. The commit signatures
+// are not verified.
+func (lb *LightBlock) ValidateBasic(chainID string) error {
+	if lb.SignedHeader == nil {
+		return errors.New("missing signed header")
+	}
+	if lb.ValidatorSet == nil {
+		return errors.New("missing validator set")
+	}
+
+	if err := lb.SignedHeader.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid signed header, %w", err)
+	}
+
+	if valsHash := lb.ValidatorSet.Hash(); !bytes.Equal(valsHash, lb.ValidatorsHash) {
+		return fmt.Errorf("validator set hash %X does not match the header validators hash %X",
+			valsHash, lb.ValidatorsHash)
+	}
+
+	return nil
+}
+
+// TrustLevel is the minimum fraction of the voting power of a trusted
+// validator set that must sign a header, for the header to be trusted
+// when skipping the headers in between.
+type TrustLevel struct {
+	Numerator   int64 `json:"numerator"`
+	Denominator int64 `json:"denominator"`
+}
+
+// DefaultTrustLevel is the default trust level, which guarantees that at
+// least one correct validator signed the header.
+var DefaultTrustLevel = TrustLevel{Numerator: 1, Denominator: 3}
+
+// ValidateBasic checks that the trust level is within [1/3, 1].
+func (tl TrustLevel) ValidateBasic() error {
+	if tl.Denominator <= 0 || tl.Numerator <= 0 ||
+		tl.Numerator*3 < tl.Denominator || // < 1/3
+		tl.Numerator > tl.Denominator { // > 1
+		return fmt.Errorf("trust level must be within [1/3, 1], given %d/%d", tl.Numerator, tl.Denominator)
+	}
+
+	return nil
+}
+
+func (tl TrustLevel) String() string {
+	return fmt.Sprintf("%d/%d", tl.Numerator, tl.Denominator)
+}
+
+// TrustOptions are the options to trust the first header of the client,
+// which must be obtained from a trusted source, out of band.
+type TrustOptions struct {
+	// Period during which the validators of a trusted header can be trusted
+	// to verify the next headers. It should be significantly less than the
+	// unbonding period.
+	Period time.Duration
+
+	// Height and hash of the trusted header
+	Height int64
+	Hash   []byte
+}
+
+// ValidateBasic performs basic validation of the trust options.
+func (opts TrustOptions) ValidateBasic() error {
+	if opts.Period <= 0 {
+		return errors.New("trusting period must be positive")
+	}
+	if opts.Height <= 0 {
+		return errors.New("trusted height must be positive")
+	}
+	if len(opts.Hash) == 0 {
+		return errors.New("trusted hash is required")
+	}
+
+	return nil
+}
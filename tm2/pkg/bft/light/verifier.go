@@ -0,0 +1,251 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+var (
+	// ErrOldHeaderExpired is returned when the trusted header is outside of
+	// the trusting period, and can't be used to verify new headers.
+	ErrOldHeaderExpired = errors.New("trusted header has expired")
+
+	// ErrNewValSetCantBeTrusted is returned when the trusted validators did
+	// not sign enough of a new header, for it to be verified by skipping the
+	// headers in between.
+	ErrNewValSetCantBeTrusted = errors.New("new validator set can't be trusted")
+
+	// ErrInvalidHeader is returned when a new header is invalid, or can't be
+	// verified from the trusted header.
+	ErrInvalidHeader = errors.New("invalid header")
+)
+
+// VerifyAdjacent verifies a new light block directly following the trusted
+// one, which must be signed by the next validators of the trusted header.
+func VerifyAdjacent(
+	chainID string,
+	trusted, untrusted *LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+) error {
+	if untrusted.Height != trusted.Height+1 {
+		return errors.New("headers must be adjacent in height")
+	}
+
+	if HeaderExpired(trusted.SignedHeader, trustingPeriod, now) {
+		return fmt.Errorf("%w, at height %d", ErrOldHeaderExpired, trusted.Height)
+	}
+
+	if err := verifyNewHeaderAndVals(chainID, trusted, untrusted, now, maxClockDrift); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidHeader, err)
+	}
+
+	return VerifyAdjacentHeader(chainID, trusted, untrusted)
+}
+
+// VerifyAdjacentHeader verifies that a new light block directly follows the
+// trusted one in the hash chain, and is signed by the next validators of the
+// trusted header. Unlike VerifyAdjacent, the times of the headers are not
+// checked against the trusting period.
+func VerifyAdjacentHeader(chainID string, trusted, untrusted *LightBlock) error {
+	if untrusted.Height != trusted.Height+1 {
+		return errors.New("headers must be adjacent in height")
+	}
+
+	if err := untrusted.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidHeader, err)
+	}
+
+	if !bytes.Equal(untrusted.LastBlockID.Hash, trusted.Hash()) {
+		return fmt.Errorf("%w, last block ID %X does not match the trusted header hash %X",
+			ErrInvalidHeader, untrusted.LastBlockID.Hash, trusted.Hash())
+	}
+
+	if !bytes.Equal(untrusted.ValidatorsHash, trusted.NextValidatorsHash) {
+		return fmt.Errorf("%w, validators hash %X does not match the trusted next validators hash %X",
+			ErrInvalidHeader, untrusted.ValidatorsHash, trusted.NextValidatorsHash)
+	}
+
+	// +2/3 of the new validators must have signed the header
+	if err := untrusted.ValidatorSet.VerifyCommit(
+		chainID,
+		untrusted.Commit.BlockID,
+		untrusted.Height,
+		untrusted.Commit,
+	); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidHeader, err)
+	}
+
+	return nil
+}
+
+// VerifyNonAdjacent verifies a new light block above the trusted one,
+// skipping the headers in between. The trusted validators, which are
+// assumed to still be bonded within the trusting period, must have signed
+// more than the trust level of the new header.
+func VerifyNonAdjacent(
+	chainID string,
+	trusted, untrusted *LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel TrustLevel,
+) error {
+	if untrusted.Height == trusted.Height+1 {
+		return errors.New("headers must be non adjacent in height")
+	}
+
+	if HeaderExpired(trusted.SignedHeader, trustingPeriod, now) {
+		return fmt.Errorf("%w, at height %d", ErrOldHeaderExpired, trusted.Height)
+	}
+
+	if err := verifyNewHeaderAndVals(chainID, trusted, untrusted, now, maxClockDrift); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidHeader, err)
+	}
+
+	if err := verifyCommitTrusting(chainID, trusted.ValidatorSet, untrusted.SignedHeader, trustLevel); err != nil {
+		return err
+	}
+
+	// +2/3 of the new validators must have signed the header
+	if err := untrusted.ValidatorSet.VerifyCommit(
+		chainID,
+		untrusted.Commit.BlockID,
+		untrusted.Height,
+		untrusted.Commit,
+	); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidHeader, err)
+	}
+
+	return nil
+}
+
+// Verify verifies a new light block above the trusted one, using the
+// adjacent or the non adjacent verification depending on their heights.
+func Verify(
+	chainID string,
+	trusted, untrusted *LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel TrustLevel,
+) error {
+	if untrusted.Height == trusted.Height+1 {
+		return VerifyAdjacent(chainID, trusted, untrusted, trustingPeriod, now, maxClockDrift)
+	}
+
+	return VerifyNonAdjacent(chainID, trusted, untrusted, trustingPeriod, now, maxClockDrift, trustLevel)
+}
+
+// VerifyBackwards verifies a light block below the trusted one, which it
+// must directly precede in the hash chain.
+func VerifyBackwards(chainID string, untrusted, trusted *LightBlock) error {
+	if err := untrusted.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidHeader, err)
+	}
+
+	if untrusted.Height != trusted.Height-1 {
+		return errors.New("headers must be adjacent in height")
+	}
+
+	if !untrusted.Time.Before(trusted.Time) {
+		return fmt.Errorf("%w, header time %s is not before the trusted header time %s",
+			ErrInvalidHeader, untrusted.Time, trusted.Time)
+	}
+
+	if !bytes.Equal(untrusted.Hash(), trusted.LastBlockID.Hash) {
+		return fmt.Errorf("%w, header hash %X does not match the trusted last block ID %X",
+			ErrInvalidHeader, untrusted.Hash(), trusted.LastBlockID.Hash)
+	}
+
+	return nil
+}
+
+// HeaderExpired returns true if the header is outside of the trusting
+// period at the given time.
+func HeaderExpired(h *types.SignedHeader, trustingPeriod time.Duration, now time.Time) bool {
+	expiration := h.Time.Add(trustingPeriod)
+
+	return !expiration.After(now)
+}
+
+// verifyNewHeaderAndVals performs the checks common to all verifications of
+// a new light block
+func verifyNewHeaderAndVals(
+	chainID string,
+	trusted, untrusted *LightBlock,
+	now time.Time,
+	maxClockDrift time.Duration,
+) error {
+	if err := untrusted.ValidateBasic(chainID); err != nil {
+		return err
+	}
+
+	if untrusted.Height <= trusted.Height {
+		return fmt.Errorf("header height %d is not above the trusted height %d",
+			untrusted.Height, trusted.Height)
+	}
+
+	if !untrusted.Time.After(trusted.Time) {
+		return fmt.Errorf("header time %s is not after the trusted header time %s",
+			untrusted.Time, trusted.Time)
+	}
+
+	if maxTime := now.Add(maxClockDrift); !untrusted.Time.Before(maxTime) {
+		return fmt.Errorf("header time %s is from the future (now: %s, max clock drift: %s)",
+			untrusted.Time, now, maxClockDrift)
+	}
+
+	return nil
+}
+
+// verifyCommitTrusting verifies that the trusted validators signed more than
+// the trust level of the header. The validators are matched by address, as
+// the new validator set may differ from the trusted one.
+func verifyCommitTrusting(
+	chainID string,
+	trustedVals *types.ValidatorSet,
+	header *types.SignedHeader,
+	trustLevel TrustLevel,
+) error {
+	var (
+		commit = header.Commit
+		seen   = make(map[int]bool)
+
+		talliedVotingPower int64
+		neededVotingPower  = trustedVals.TotalVotingPower() * trustLevel.Numerator / trustLevel.Denominator
+	)
+
+	for idx, precommit := range commit.Precommits {
+		if precommit == nil {
+			continue
+		}
+
+		valIdx, val := trustedVals.GetByAddress(precommit.ValidatorAddress)
+		if val == nil || seen[valIdx] {
+			continue // not a trusted validator, or a double vote
+		}
+		seen[valIdx] = true
+
+		if !val.PubKey.VerifyBytes(commit.VoteSignBytes(chainID, idx), precommit.Signature) {
+			return fmt.Errorf("%w, invalid commit signature from %s", ErrInvalidHeader, val.Address)
+		}
+
+		// Only the votes for the block count
+		if commit.BlockID.Equals(precommit.BlockID) {
+			talliedVotingPower += val.VotingPower
+		}
+
+		if talliedVotingPower > neededVotingPower {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w, signed voting power %d, needed more than %d (trust level %s)",
+		ErrNewValSetCantBeTrusted, talliedVotingPower, neededVotingPower, trustLevel)
+}
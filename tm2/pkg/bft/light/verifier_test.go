@@ -0,0 +1,281 @@
+package light
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+const testChainID = "test-chain"
+
+var (
+	genesisTime    = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	trustingPeriod = 24 * time.Hour
+)
+
+// testValidators is a validator set, with its private validators
+type testValidators struct {
+	set      *types.ValidatorSet
+	privVals []types.PrivValidator
+}
+
+func newTestValidators(n int) testValidators {
+	set, privVals := types.RandValidatorSet(n, 10)
+
+	return testValidators{set: set, privVals: privVals}
+}
+
+// genLightBlocks generates a chain of light blocks up to the given height,
+// one minute apart. The validators of each height are returned by valsAt
+func genLightBlocks(t *testing.T, height int64, valsAt func(int64) testValidators) map[int64]*LightBlock {
+	t.Helper()
+
+	var (
+		blocks      = make(map[int64]*LightBlock, height)
+		lastBlockID types.BlockID
+	)
+
+	for h := int64(1); h <= height; h++ {
+		vals := valsAt(h)
+
+		header := &types.Header{
+			ChainID:            testChainID,
+			Height:             h,
+			Time:               genesisTime.Add(time.Duration(h) * time.Minute),
+			TotalTxs:           h,
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.set.Hash(),
+			NextValidatorsHash: valsAt(h + 1).set.Hash(),
+			AppHash:            fmt.Appendf(nil, "app hash %d", h),
+			ProposerAddress:    vals.set.Validators[0].Address,
+		}
+
+		blocks[h] = &LightBlock{
+			SignedHeader: signHeader(t, header, vals),
+			ValidatorSet: vals.set.Copy(),
+		}
+		lastBlockID = blocks[h].Commit.BlockID
+	}
+
+	return blocks
+}
+
+// signHeader returns the header, signed by the validators
+func signHeader(t *testing.T, header *types.Header, vals testValidators) *types.SignedHeader {
+	t.Helper()
+
+	blockID := types.BlockID{Hash: header.Hash()}
+	voteSet := types.NewVoteSet(testChainID, header.Height, 0, types.PrecommitType, vals.set)
+
+	commit, err := types.MakeCommit(blockID, header.Height, 0, voteSet, vals.privVals)
+	require.NoError(t, err)
+
+	return &types.SignedHeader{Header: header, Commit: commit}
+}
+
+// staticValidators returns the same validators at all heights
+func staticValidators(vals testValidators) func(int64) testValidators {
+	return func(int64) testValidators {
+		return vals
+	}
+}
+
+func TestVerifyAdjacent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		vals   = newTestValidators(4)
+		blocks = genLightBlocks(t, 3, staticValidators(vals))
+		now    = genesisTime.Add(time.Hour)
+	)
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, VerifyAdjacent(testChainID, blocks[1], blocks[2], trustingPeriod, now, time.Second))
+	})
+
+	t.Run("not adjacent", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, VerifyAdjacent(testChainID, blocks[1], blocks[3], trustingPeriod, now, time.Second))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		expired := genesisTime.Add(trustingPeriod + time.Hour)
+
+		err := VerifyAdjacent(testChainID, blocks[1], blocks[2], trustingPeriod, expired, time.Second)
+		assert.ErrorIs(t, err, ErrOldHeaderExpired)
+	})
+
+	t.Run("from the future", func(t *testing.T) {
+		t.Parallel()
+
+		err := VerifyAdjacent(testChainID, blocks[1], blocks[2], trustingPeriod, genesisTime, time.Second)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("wrong chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := VerifyAdjacent("other-chain", blocks[1], blocks[2], trustingPeriod, now, time.Second)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("other validators", func(t *testing.T) {
+		t.Parallel()
+
+		// The header is signed by validators other than the trusted next ones
+		header := *blocks[2].Header
+		other := newTestValidators(4)
+		header.ValidatorsHash = other.set.Hash()
+
+		untrusted := &LightBlock{
+			SignedHeader: signHeader(t, &header, other),
+			ValidatorSet: other.set,
+		}
+
+		err := VerifyAdjacent(testChainID, blocks[1], untrusted, trustingPeriod, now, time.Second)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+		assert.ErrorContains(t, err, "does not match the trusted next validators hash")
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		t.Parallel()
+
+		header := *blocks[2].Header
+		header.AppHash = []byte("tampered")
+
+		untrusted := &LightBlock{
+			SignedHeader: &types.SignedHeader{Header: &header, Commit: blocks[2].Commit},
+			ValidatorSet: blocks[2].ValidatorSet,
+		}
+
+		err := VerifyAdjacent(testChainID, blocks[1], untrusted, trustingPeriod, now, time.Second)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
+
+func TestVerifyNonAdjacent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		vals1 = newTestValidators(3)
+		vals2 = newTestValidators(3)
+		now   = genesisTime.Add(time.Hour)
+	)
+
+	t.Run("same validators", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := genLightBlocks(t, 10, staticValidators(vals1))
+
+		assert.NoError(t, VerifyNonAdjacent(testChainID, blocks[1], blocks[10], trustingPeriod, now, time.Second, DefaultTrustLevel))
+	})
+
+	t.Run("overlapping validators", func(t *testing.T) {
+		t.Parallel()
+
+		// One of the 3 validators is replaced: 2/3 of the trusted voting
+		// power signed the new header
+		overlapping := testValidators{
+			set: types.NewValidatorSet([]*types.Validator{
+				vals1.set.Validators[0].Copy(),
+				vals1.set.Validators[1].Copy(),
+				vals2.set.Validators[0].Copy(),
+			}),
+		}
+		for _, val := range overlapping.set.Validators {
+			for _, privVal := range append(vals1.privVals, vals2.privVals...) {
+				if privVal.GetPubKey().Address() == val.Address {
+					overlapping.privVals = append(overlapping.privVals, privVal)
+				}
+			}
+		}
+
+		blocks := genLightBlocks(t, 10, func(h int64) testValidators {
+			if h >= 5 {
+				return overlapping
+			}
+
+			return vals1
+		})
+
+		assert.NoError(t, VerifyNonAdjacent(testChainID, blocks[1], blocks[10], trustingPeriod, now, time.Second, DefaultTrustLevel))
+
+		// The trust level is not reached
+		err := VerifyNonAdjacent(testChainID, blocks[1], blocks[10], trustingPeriod, now, time.Second, TrustLevel{Numerator: 1, Denominator: 1})
+		assert.ErrorIs(t, err, ErrNewValSetCantBeTrusted)
+	})
+
+	t.Run("new validators", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := genLightBlocks(t, 10, func(h int64) testValidators {
+			if h >= 5 {
+				return vals2
+			}
+
+			return vals1
+		})
+
+		err := VerifyNonAdjacent(testChainID, blocks[1], blocks[10], trustingPeriod, now, time.Second, DefaultTrustLevel)
+		assert.ErrorIs(t, err, ErrNewValSetCantBeTrusted)
+
+		// The headers are verified across the change
+		assert.NoError(t, Verify(testChainID, blocks[1], blocks[4], trustingPeriod, now, time.Second, DefaultTrustLevel))
+		assert.NoError(t, Verify(testChainID, blocks[4], blocks[5], trustingPeriod, now, time.Second, DefaultTrustLevel))
+		assert.NoError(t, Verify(testChainID, blocks[5], blocks[10], trustingPeriod, now, time.Second, DefaultTrustLevel))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		blocks := genLightBlocks(t, 10, staticValidators(vals1))
+		expired := genesisTime.Add(trustingPeriod + time.Hour)
+
+		err := VerifyNonAdjacent(testChainID, blocks[1], blocks[10], trustingPeriod, expired, time.Second, DefaultTrustLevel)
+		assert.ErrorIs(t, err, ErrOldHeaderExpired)
+	})
+}
+
+func TestVerifyBackwards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		vals   = newTestValidators(2)
+		blocks = genLightBlocks(t, 3, staticValidators(vals))
+	)
+
+	assert.NoError(t, VerifyBackwards(testChainID, blocks[2], blocks[3]))
+	assert.Error(t, VerifyBackwards(testChainID, blocks[1], blocks[3]))
+
+	// A header signed by the same validators, but not in the hash chain
+	header := *blocks[2].Header
+	header.AppHash = []byte("other")
+
+	other := &LightBlock{
+		SignedHeader: signHeader(t, &header, vals),
+		ValidatorSet: vals.set,
+	}
+	assert.ErrorIs(t, VerifyBackwards(testChainID, other, blocks[3]), ErrInvalidHeader)
+}
+
+func TestTrustLevel_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultTrustLevel.ValidateBasic())
+	assert.NoError(t, TrustLevel{Numerator: 2, Denominator: 3}.ValidateBasic())
+	assert.NoError(t, TrustLevel{Numerator: 1, Denominator: 1}.ValidateBasic())
+
+	assert.Error(t, TrustLevel{Numerator: 1, Denominator: 4}.ValidateBasic())
+	assert.Error(t, TrustLevel{Numerator: 4, Denominator: 3}.ValidateBasic())
+	assert.Error(t, TrustLevel{Numerator: 1, Denominator: 0}.ValidateBasic())
+}
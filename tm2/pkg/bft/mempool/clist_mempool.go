@@ -17,6 +17,7 @@ import (
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/clist"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	osm "github.com/gnolang/gno/tm2/pkg/os"
@@ -320,6 +321,12 @@ func (mem *CListMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
+		if checkRes, ok := res.(abci.ResponseCheckTx); ok && checkRes.Error == nil && checkRes.Replacement {
+			// pending txs are never replaced, see PriorityMempool
+			checkRes.Error = abci.StringError(ErrReplacedTxNotFound.Error())
+			res = checkRes
+		}
+
 		mem.resCbFirstTime(tx, peerID, res)
 
 		// Passed in by the caller of CheckTx, eg. the RPC.
@@ -380,9 +387,11 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Respo
 	case abci.ResponseCheckTx:
 		if res.Error == nil {
 			memTx := &mempoolTx{
-				height:    mem.height,
-				gasWanted: res.GasWanted,
-				tx:        tx,
+				height:           mem.height,
+				gasWanted:        res.GasWanted,
+				timeoutHeight:    res.TimeoutHeight,
+				timeoutTimestamp: res.TimeoutTimestamp,
+				tx:               tx,
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
@@ -419,10 +428,12 @@ func (mem *CListMempool) resCbRecheck(req abci.Request, res abci.Response) {
 				memTx.tx,
 				tx))
 		}
-		if res.Error == nil {
+		if res.Error == nil && !res.Replacement {
 			// Good, nothing to do.
 		} else {
-			// Tx became invalidated due to newly committed block.
+			// Tx became invalidated due to newly committed block. A pending tx
+			// passing as a replacement uses a committed sequence, and would
+			// fail in DeliverTx.
 			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", res, "err", res.Error)
 			// NOTE: we remove tx from the cache because it might be good later
 			mem.removeTx(tx, mem.recheckCursor, true)
@@ -528,6 +539,7 @@ func (mem *CListMempool) ReapMaxTxs(maxVal int) types.Txs {
 
 func (mem *CListMempool) Update(
 	height int64,
+	blockTime time.Time,
 	txs types.Txs,
 	deliverTxResponses []abci.ResponseDeliverTx,
 	preCheck PreCheckFunc,
@@ -568,6 +580,19 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	// Evict the txs which can no longer be included in a block.
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.expired(height, blockTime) {
+			mem.logger.Info("Evicted expired transaction",
+				"tx", txID(memTx.tx),
+				"timeout_height", memTx.timeoutHeight,
+				"timeout_timestamp", memTx.timeoutTimestamp,
+			)
+			mem.removeTx(memTx.tx, e, false)
+		}
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
@@ -624,9 +649,17 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height           int64    // height that this tx had been validated in
+	gasWanted        int64    // amount of gas this tx states it will require
+	timeoutHeight    int64    // height after which this tx is no longer valid, if not zero
+	timeoutTimestamp int64    // unix time after which this tx is no longer valid, if not zero
+	tx               types.Tx //
+
+	// used by the PriorityMempool
+	priority int64          // priority of this tx, higher first
+	sender   crypto.Address // account whose txs are kept in sequence order, if not zero
+	sequence uint64         // sequence of the sender used by this tx
+	index    uint64         // order in which this tx was added
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -638,6 +671,14 @@ func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
 
+// expired reports whether this tx can no longer be included in the block
+// following the committed block of the given height and time. The times of
+// the blocks increase, so the next block is at least as late.
+func (memTx *mempoolTx) expired(height int64, blockTime time.Time) bool {
+	return (memTx.timeoutHeight != 0 && memTx.timeoutHeight <= height) ||
+		(memTx.timeoutTimestamp != 0 && blockTime.Unix() > memTx.timeoutTimestamp)
+}
+
 // --------------------------------------------------------------------------------
 
 type txCache interface {
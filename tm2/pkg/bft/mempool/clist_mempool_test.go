@@ -173,7 +173,7 @@ func TestMempoolFilters(t *testing.T) {
 		{10, 1024, PreCheckMaxTxBytes(-1), 10},
 	}
 	for tcIndex, tt := range tests {
-		mempool.Update(1, emptyTxArr, abciResponses(len(emptyTxArr), nil), nil, tt.postFilter, tt.maxTxBytes)
+		mempool.Update(1, time.Time{}, emptyTxArr, abciResponses(len(emptyTxArr), nil), nil, tt.postFilter, tt.maxTxBytes)
 		checkTxs(t, mempool, tt.numTxsToCreate, UnknownPeerID, false)
 		require.Equal(t, tt.expectedNumTxs, mempool.Size(), "mempool had the incorrect size, on test case %d", tcIndex)
 		mempool.Flush()
@@ -189,7 +189,7 @@ func TestMempoolUpdate(t *testing.T) {
 
 	// 1. Adds valid txs to the cache
 	{
-		mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, nil), nil, 0)
+		mempool.Update(1, time.Time{}, []types.Tx{[]byte{0x01}}, abciResponses(1, nil), nil, 0)
 		err := mempool.CheckTx([]byte{0x01}, nil)
 		if assert.Error(t, err) {
 			assert.Equal(t, ErrTxInCache, err)
@@ -200,7 +200,7 @@ func TestMempoolUpdate(t *testing.T) {
 	{
 		err := mempool.CheckTx([]byte{0x02}, nil)
 		require.NoError(t, err)
-		mempool.Update(1, []types.Tx{[]byte{0x02}}, abciResponses(1, nil), nil, 0)
+		mempool.Update(1, time.Time{}, []types.Tx{[]byte{0x02}}, abciResponses(1, nil), nil, 0)
 		assert.Zero(t, mempool.Size())
 	}
 
@@ -208,7 +208,7 @@ func TestMempoolUpdate(t *testing.T) {
 	{
 		err := mempool.CheckTx([]byte{0x03}, nil)
 		require.NoError(t, err)
-		mempool.Update(1, []types.Tx{[]byte{0x03}}, abciResponses(1, abci.StringError("1")), nil, 0)
+		mempool.Update(1, time.Time{}, []types.Tx{[]byte{0x03}}, abciResponses(1, abci.StringError("1")), nil, 0)
 		assert.Zero(t, mempool.Size())
 
 		err = mempool.CheckTx([]byte{0x03}, nil)
@@ -216,6 +216,44 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+// timeoutApp is a kvstore application whose txs time out at the height
+// given by their first byte, and at the unix time given by their second
+// byte, if any.
+type timeoutApp struct {
+	*kvstore.KVStoreApplication
+}
+
+func (app timeoutApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{GasWanted: 1, TimeoutHeight: int64(req.Tx[0])}
+	if len(req.Tx) > 1 {
+		res.TimeoutTimestamp = int64(req.Tx[1])
+	}
+	return res
+}
+
+func TestMempoolUpdateEvictsExpiredTxs(t *testing.T) {
+	app := timeoutApp{kvstore.NewKVStoreApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	for _, tx := range []types.Tx{{0x00, 0x00}, {0x02}, {0x03}, {0x05}, {0x00, 0x0a}} {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+	require.Equal(t, 5, mempool.Size())
+
+	// Txs timing out at or before the committed height, or before the
+	// committed time, are evicted; txs without timeout are kept.
+	mempool.Update(3, time.Unix(10, 0), nil, nil, nil, 0)
+	assert.Equal(t, types.Txs{{0x00, 0x00}, {0x05}, {0x00, 0x0a}}, mempool.ReapMaxTxs(-1))
+
+	mempool.Update(4, time.Unix(11, 0), nil, nil, nil, 0)
+	assert.Equal(t, types.Txs{{0x00, 0x00}, {0x05}}, mempool.ReapMaxTxs(-1))
+
+	mempool.Update(5, time.Unix(12, 0), nil, nil, nil, 0)
+	assert.Equal(t, types.Txs{{0x00, 0x00}}, mempool.ReapMaxTxs(-1))
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewKVStoreApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -237,7 +275,7 @@ func TestTxsAvailable(t *testing.T) {
 	// it should fire once now for the new height
 	// since there are still txs left
 	committedTxs, txs := txs[:50], txs[50:]
-	if err := mempool.Update(1, committedTxs, abciResponses(len(committedTxs), nil), nil, 0); err != nil {
+	if err := mempool.Update(1, time.Time{}, committedTxs, abciResponses(len(committedTxs), nil), nil, 0); err != nil {
 		t.Error(err)
 	}
 	ensureFire(t, mempool.TxsAvailable(), timeoutMS)
@@ -249,7 +287,7 @@ func TestTxsAvailable(t *testing.T) {
 
 	// now call update with all the txs. it should not fire as there are no txs left
 	committedTxs = append(txs, moreTxs...) //nolint: gocritic
-	if err := mempool.Update(2, committedTxs, abciResponses(len(committedTxs), nil), nil, 0); err != nil {
+	if err := mempool.Update(2, time.Time{}, committedTxs, abciResponses(len(committedTxs), nil), nil, 0); err != nil {
 		t.Error(err)
 	}
 	ensureNoFire(t, mempool.TxsAvailable(), timeoutMS)
@@ -307,7 +345,7 @@ func TestSerialReap(t *testing.T) {
 			binary.BigEndian.PutUint64(txBytes, uint64(i))
 			txs = append(txs, txBytes)
 		}
-		if err := mempool.Update(0, txs, abciResponses(len(txs), nil), nil, 0); err != nil {
+		if err := mempool.Update(0, time.Time{}, txs, abciResponses(len(txs), nil), nil, 0); err != nil {
 			t.Error(err)
 		}
 	}
@@ -476,7 +514,7 @@ func TestMempoolMaxPendingTxsBytes(t *testing.T) {
 	assert.EqualValues(t, 1, mempool.TxsBytes())
 
 	// 3. zero again after tx is removed by Update
-	mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, nil), nil, 0)
+	mempool.Update(1, time.Time{}, []types.Tx{[]byte{0x01}}, abciResponses(1, nil), nil, 0)
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
 	// 4. zero after Flush
@@ -521,7 +559,7 @@ func TestMempoolMaxPendingTxsBytes(t *testing.T) {
 	require.NotEmpty(t, res2.Data)
 
 	// Pretend like we committed nothing so txBytes gets rechecked and removed.
-	mempool.Update(1, []types.Tx{}, abciResponses(0, nil), nil, 0)
+	mempool.Update(1, time.Time{}, []types.Tx{}, abciResponses(0, nil), nil, 0)
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 }
 
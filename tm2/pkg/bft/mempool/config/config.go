@@ -2,23 +2,34 @@ package config
 
 import "github.com/gnolang/gno/tm2/pkg/errors"
 
+const (
+	// TypeCList is the FIFO mempool.
+	TypeCList = "clist"
+
+	// TypePriority is the mempool ordered by gas price.
+	TypePriority = "priority"
+)
+
 // -----------------------------------------------------------------------------
 // MempoolConfig
 
 // MempoolConfig defines the configuration options for the Tendermint mempool
 type MempoolConfig struct {
 	RootDir            string `json:"home" toml:"home"`
+	Type               string `json:"type" toml:"type" comment:"Mempool implementation: \"clist\" keeps the transactions in the order they are received,\n \"priority\" orders them by gas price, keeping the transactions of an account in sequence order"`
 	Recheck            bool   `json:"recheck" toml:"recheck"`
 	Broadcast          bool   `json:"broadcast" toml:"broadcast"`
 	WalPath            string `json:"wal_dir" toml:"wal_dir"`
 	Size               int    `json:"size" toml:"size" comment:"Maximum number of transactions in the mempool"`
 	MaxPendingTxsBytes int64  `json:"max_pending_txs_bytes" toml:"max_pending_txs_bytes" comment:"Limit the total size of all txs in the mempool.\n This only accounts for raw transactions (e.g. given 1MB transactions and\n max_txs_bytes=5MB, mempool will only accept 5 transactions)."`
 	CacheSize          int    `json:"cache_size" toml:"cache_size" comment:"Size of the cache (used to filter transactions we saw earlier) in transactions"`
+	PriceBump          int64  `json:"price_bump" toml:"price_bump" comment:"Minimum gas price increase, in percent, for a transaction to replace the pending\n transaction with the same sequence of its account (priority mempool only)"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
+		Type:      TypeCList,
 		Recheck:   true,
 		Broadcast: true,
 		WalPath:   "",
@@ -27,6 +38,7 @@ func DefaultMempoolConfig() *MempoolConfig {
 		Size:               5000,
 		MaxPendingTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:          10000,
+		PriceBump:          10,
 	}
 }
 
@@ -50,6 +62,9 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	if cfg.Type != TypeCList && cfg.Type != TypePriority {
+		return errors.New("type must be %q or %q", TypeCList, TypePriority)
+	}
 	if cfg.Size < 0 {
 		return errors.New("size can't be negative")
 	}
@@ -59,5 +74,8 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.CacheSize < 0 {
 		return errors.New("cache_size can't be negative")
 	}
+	if cfg.PriceBump < 0 {
+		return errors.New("price_bump can't be negative")
+	}
 	return nil
 }
@@ -34,3 +34,33 @@ func (e MempoolIsFullError) Error() string {
 		e.numTxs, e.maxTxs,
 		e.txsBytes, e.maxTxsBytes)
 }
+
+// ErrReplacedTxNotFound is returned when a tx replaces a pending tx which is
+// not in the mempool.
+var ErrReplacedTxNotFound = errors.New("no pending tx to replace")
+
+// ReplacementPriceTooLowError means a tx doesn't pay enough to replace the
+// pending tx with the same sender and sequence.
+type ReplacementPriceTooLowError struct {
+	priority    int64
+	minPriority int64
+}
+
+func (e ReplacementPriceTooLowError) Error() string {
+	return fmt.Sprintf(
+		"replacement tx priority too low: got %d, pending tx replaced from %d",
+		e.priority, e.minPriority)
+}
+
+// SequenceDroppedError means a tx follows a tx of the same sender which was
+// dropped from the mempool, and which must be submitted again first.
+type SequenceDroppedError struct {
+	sequence        uint64
+	droppedSequence uint64
+}
+
+func (e SequenceDroppedError) Error() string {
+	return fmt.Sprintf(
+		"tx sequence %d follows the dropped tx sequence %d, which must be submitted again first",
+		e.sequence, e.droppedSequence)
+}
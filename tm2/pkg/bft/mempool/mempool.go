@@ -1,8 +1,12 @@
 package mempool
 
 import (
+	"log/slog"
+	"time"
+
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/clist"
 )
 
 // Mempool defines the mempool interface.
@@ -39,9 +43,10 @@ type Mempool interface {
 	Unlock()
 
 	// Update informs the mempool that the given txs were committed and can be discarded.
+	// The txs which time out at the height or time of the committed block are evicted.
 	// NOTE: this should be called *after* block is committed by consensus.
 	// NOTE: unsafe; Lock/Unlock must be managed by caller
-	Update(blockHeight int64, blockTxs types.Txs, deliverTxResponses []abci.ResponseDeliverTx, newPreFn PreCheckFunc, maxTxBytes int64) error
+	Update(blockHeight int64, blockTime time.Time, blockTxs types.Txs, deliverTxResponses []abci.ResponseDeliverTx, newPreFn PreCheckFunc, maxTxBytes int64) error
 
 	// FlushAppConn flushes the mempool connection to ensure async reqResCb calls are
 	// done. E.g. from CheckTx.
@@ -76,6 +81,23 @@ type Mempool interface {
 	CloseWAL()
 }
 
+// GossipMempool is a Mempool which keeps its txs in a concurrent list, for
+// the Reactor to broadcast them to peers.
+type GossipMempool interface {
+	Mempool
+
+	// SetLogger sets the Logger.
+	SetLogger(l *slog.Logger)
+
+	// TxsFront returns the first transaction in the ordered list for peer
+	// goroutines to call .NextWait() on.
+	TxsFront() *clist.CElement
+
+	// TxsWaitChan returns a channel to wait on transactions. It will be closed
+	// once the mempool is not empty.
+	TxsWaitChan() <-chan struct{}
+}
+
 //--------------------------------------------------------------------------------
 
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
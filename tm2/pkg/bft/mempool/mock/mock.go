@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"time"
+
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
@@ -28,6 +30,7 @@ func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
 func (Mempool) Update(
 	_ int64,
+	_ time.Time,
 	_ types.Txs,
 	_ []abci.ResponseDeliverTx,
 	_ mempl.PreCheckFunc,
@@ -0,0 +1,843 @@
+package mempool
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	auto "github.com/gnolang/gno/tm2/pkg/autofile"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/clist"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+// --------------------------------------------------------------------------------
+
+// PriorityMempool is an in-memory pool for transactions before they are
+// proposed in a consensus round, which reaps them by decreasing priority (ie.
+// gas price) as returned by CheckTx. The transactions of a sender are kept in
+// sequence order, and a transaction can replace the pending transaction with
+// the same sender and sequence if its priority is higher by at least
+// config.PriceBump percent. When the mempool is full, the lowest priority
+// transactions are evicted to make room for higher priority ones.
+//
+// Like the CListMempool, the transactions are stored in a concurrent list in
+// the order they were added, to be gossiped to peers.
+//
+// A tx dropped from the mempool after the application checked it, because it
+// was evicted or could not be added, left its sequence used in the check state
+// of the application until the next block. The sender can submit a tx with
+// that sequence again, which the application checks as a replacement, and the
+// txs following it are refused until then.
+//
+// NOTE: the mempool relies on the application connection calling the CheckTx
+// callbacks synchronously, while the mempool lock is held.
+type PriorityMempool struct {
+	config *cfg.MempoolConfig
+
+	mtx          sync.Mutex
+	proxyAppConn appconn.Mempool
+	txs          *clist.CList // concurrent linked-list of good txs, in the order they were added
+	preCheck     PreCheckFunc
+	height       int64  // the last block Update()'d to
+	maxTxBytes   int64  //
+	nextIndex    uint64 // index of the next tx added
+
+	// Pending txs of each sender, in sequence order.
+	// senderTxs: sender -> []CElement
+	senderTxs map[crypto.Address][]*clist.CElement
+
+	// Lowest sequence of each sender dropped since the last Update.
+	// droppedSeqs: sender -> sequence
+	droppedSeqs map[crypto.Address]uint64
+
+	// Track whether we're rechecking txs.
+	// recheckQueue holds the txs being rechecked, in the order of the requests,
+	// recheckCursor is the index of the next expected response.
+	recheckQueue  []*clist.CElement
+	recheckCursor int
+
+	// notify listeners (ie. consensus) when txs are available
+	notifiedTxsAvailable bool
+	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+
+	// Map for quick access to txs to record sender in CheckTx.
+	// txsMap: txKey -> CElement
+	txsMap sync.Map
+
+	// Atomic integers
+	txsBytes   int64 // total size of mempool, in bytes
+	rechecking int32 // for re-checking filtered txs on Update()
+
+	// Keep a cache of already-seen txs.
+	// This reduces the pressure on the proxyApp.
+	cache txCache
+
+	// A log of mempool txs
+	wal *auto.AutoFile
+
+	logger *slog.Logger
+}
+
+var _ Mempool = &PriorityMempool{}
+
+// PriorityMempoolOption sets an optional parameter on the mempool.
+type PriorityMempoolOption func(*PriorityMempool)
+
+// NewPriorityMempool returns a new mempool with the given configuration and
+// connection to an application.
+func NewPriorityMempool(
+	config *cfg.MempoolConfig,
+	proxyAppConn appconn.Mempool,
+	height int64,
+	maxTxBytes int64,
+	options ...PriorityMempoolOption,
+) *PriorityMempool {
+	if maxTxBytes <= 0 {
+		panic("maxTxBytes must be positive")
+	}
+	mempool := &PriorityMempool{
+		config:       config,
+		proxyAppConn: proxyAppConn,
+		txs:          clist.New(),
+		height:       height,
+		maxTxBytes:   maxTxBytes,
+		senderTxs:    make(map[crypto.Address][]*clist.CElement),
+		droppedSeqs:  make(map[crypto.Address]uint64),
+		logger:       log.NewNoopLogger(),
+	}
+	if config.CacheSize > 0 {
+		mempool.cache = newMapTxCache(config.CacheSize)
+	} else {
+		mempool.cache = nopTxCache{}
+	}
+	proxyAppConn.SetResponseCallback(mempool.globalCb)
+	for _, option := range options {
+		option(mempool)
+	}
+	return mempool
+}
+
+// WithPriorityPreCheck sets a filter for the mempool to reject a tx if f(tx)
+// returns false. This is ran before CheckTx.
+func WithPriorityPreCheck(f PreCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.preCheck = f }
+}
+
+// NOTE: not thread safe - should only be called once, on startup
+func (mem *PriorityMempool) EnableTxsAvailable() {
+	mem.txsAvailable = make(chan struct{}, 1)
+}
+
+// SetLogger sets the Logger.
+func (mem *PriorityMempool) SetLogger(l *slog.Logger) {
+	mem.logger = l
+}
+
+// *panics* if can't create directory or open file.
+// *not thread safe*
+func (mem *PriorityMempool) InitWAL() {
+	walDir := mem.config.WalDir()
+	err := osm.EnsureDir(walDir, 0o700)
+	if err != nil {
+		panic(errors.Wrap(err, "Error ensuring WAL dir"))
+	}
+	af, err := auto.OpenAutoFile(walDir + "/wal")
+	if err != nil {
+		panic(errors.Wrap(err, "Error opening WAL file"))
+	}
+	mem.wal = af
+}
+
+func (mem *PriorityMempool) CloseWAL() {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if err := mem.wal.Close(); err != nil {
+		mem.logger.Error("Error closing WAL", "err", err)
+	}
+	mem.wal = nil
+}
+
+func (mem *PriorityMempool) Lock() {
+	mem.mtx.Lock()
+}
+
+func (mem *PriorityMempool) Unlock() {
+	mem.mtx.Unlock()
+}
+
+func (mem *PriorityMempool) Size() int {
+	return mem.txs.Len()
+}
+
+func (mem *PriorityMempool) MaxTxBytes() int64 {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+	return mem.maxTxBytes
+}
+
+func (mem *PriorityMempool) TxsBytes() int64 {
+	return atomic.LoadInt64(&mem.txsBytes)
+}
+
+func (mem *PriorityMempool) FlushAppConn() error {
+	return mem.proxyAppConn.FlushSync()
+}
+
+func (mem *PriorityMempool) Flush() {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	mem.cache.Reset()
+
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		mem.txs.Remove(e)
+		e.DetachPrev()
+	}
+
+	mem.txsMap = sync.Map{}
+	mem.senderTxs = make(map[crypto.Address][]*clist.CElement)
+	mem.droppedSeqs = make(map[crypto.Address]uint64)
+	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+}
+
+// TxsFront returns the first transaction in the ordered list for peer
+// goroutines to call .NextWait() on.
+func (mem *PriorityMempool) TxsFront() *clist.CElement {
+	return mem.txs.Front()
+}
+
+// TxsWaitChan returns a channel to wait on transactions. It will be closed
+// once the mempool is not empty.
+func (mem *PriorityMempool) TxsWaitChan() <-chan struct{} {
+	return mem.txs.WaitChan()
+}
+
+// It blocks if we're waiting on Update() or Reap().
+// cb: A callback from the CheckTx command.
+//
+// CONTRACT: Either cb will get called, or err returned.
+func (mem *PriorityMempool) CheckTx(tx types.Tx, cb func(abci.Response)) (err error) {
+	return mem.CheckTxWithInfo(tx, cb, TxInfo{SenderID: UnknownPeerID})
+}
+
+// CheckTxWithInfo checks tx with the application. Unlike the CListMempool,
+// whether the mempool is full is known once the application returned the
+// priority of tx, in which case the response passed to cb is an error.
+func (mem *PriorityMempool) CheckTxWithInfo(tx types.Tx, cb func(abci.Response), txInfo TxInfo) (err error) {
+	mem.mtx.Lock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.mtx.Unlock()
+
+	txSize := len(tx)
+
+	// Check max tx bytes
+	if int64(txSize) > mem.maxTxBytes {
+		return TxTooLargeError{mem.maxTxBytes, int64(txSize)}
+	}
+
+	// Check custom preCheck function
+	if mem.preCheck != nil {
+		if err := mem.preCheck(tx); err != nil {
+			return err
+		}
+	}
+
+	// CACHE
+	if !mem.cache.Push(tx) {
+		// Record a new sender for a tx we've already seen.
+		if e, ok := mem.txsMap.Load(txKey(tx)); ok {
+			memTx := e.(*clist.CElement).Value.(*mempoolTx)
+			memTx.senders.LoadOrStore(txInfo.SenderID, true)
+		}
+
+		return ErrTxInCache
+	}
+	// END CACHE
+
+	// WAL
+	if mem.wal != nil {
+		// TODO: Notify administrators when WAL fails
+		_, err := mem.wal.Write([]byte(tx))
+		if err != nil {
+			mem.logger.Error("Error writing to WAL", "err", err)
+		}
+		_, err = mem.wal.Write([]byte("\n"))
+		if err != nil {
+			mem.logger.Error("Error writing to WAL", "err", err)
+		}
+	}
+	// END WAL
+
+	// NOTE: proxyAppConn may error if tx buffer is full
+	if err = mem.proxyAppConn.Error(); err != nil {
+		return err
+	}
+
+	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, cb))
+
+	return nil
+}
+
+// Global callback that will be called after every ABCI response.
+// When rechecking, the recheck callback happens here.
+func (mem *PriorityMempool) globalCb(req abci.Request, res abci.Response) {
+	if mem.recheckQueue == nil {
+		return
+	}
+	mem.resCbRecheck(req, res)
+}
+
+// Request specific callback that should be set on individual reqRes objects
+// to incorporate local information when processing the response.
+//
+// If the tx is valid but can't be added to the mempool, the response passed to
+// externalCb is an error.
+func (mem *PriorityMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci.Response)) func(res abci.Response) {
+	return func(res abci.Response) {
+		if mem.recheckQueue != nil {
+			// this should never happen
+			panic("recheck queue is not nil in reqResCb")
+		}
+
+		if checkRes, ok := res.(abci.ResponseCheckTx); ok {
+			if err := mem.resCbFirstTime(tx, peerID, checkRes); err != nil {
+				checkRes.Error = abci.StringError(err.Error())
+				res = checkRes
+			}
+		}
+
+		// Passed in by the caller of CheckTx, eg. the RPC.
+		if externalCb != nil {
+			externalCb(res)
+		}
+	}
+}
+
+// callback, which is called after the app checked the tx for the first time.
+// It returns an error if the tx is valid, but could not be added.
+func (mem *PriorityMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.ResponseCheckTx) error {
+	if res.Error != nil {
+		// ignore bad transaction
+		mem.logger.Info("Rejected bad transaction", "tx", txID(tx), "res", res, "err", res.Error)
+		// remove from cache (it might be good later)
+		mem.cache.Remove(tx)
+		return nil
+	}
+
+	memTx := &mempoolTx{
+		height:           mem.height,
+		gasWanted:        res.GasWanted,
+		timeoutHeight:    res.TimeoutHeight,
+		timeoutTimestamp: res.TimeoutTimestamp,
+		tx:               tx,
+		priority:         res.Priority,
+		sender:           res.Sender,
+		sequence:         res.Sequence,
+	}
+	if err := mem.makeRoom(memTx, res.Replacement); err != nil {
+		mem.logger.Info("Rejected transaction", "tx", txID(tx), "priority", memTx.priority, "err", err)
+		// remove from cache (it might be accepted later)
+		mem.cache.Remove(tx)
+		if !res.Replacement {
+			// the application used the sequence of the tx
+			mem.dropSequence(memTx.sender, memTx.sequence)
+		}
+		return err
+	}
+	if dropped, ok := mem.droppedSeqs[memTx.sender]; ok && memTx.sequence == dropped {
+		// the next sequence is the dropped one now, if any
+		mem.droppedSeqs[memTx.sender] = dropped + 1
+	}
+
+	memTx.senders.Store(peerID, true)
+	mem.addTx(memTx)
+	mem.logger.Info("Added good transaction",
+		"tx", txID(tx),
+		"res", res,
+		"height", memTx.height,
+		"total", mem.Size(),
+	)
+	mem.notifyTxsAvailable()
+	return nil
+}
+
+// makeRoom removes the pending tx replaced by memTx if replacement is true or
+// if memTx uses the sequence of a pending tx, or the lowest priority txs until
+// memTx fits in the mempool. It returns an error if memTx doesn't pay enough
+// to replace them, or if it follows a dropped sequence of its sender. A tx
+// using the dropped sequence is added as a new tx.
+func (mem *PriorityMempool) makeRoom(memTx *mempoolTx, replacement bool) error {
+	txSize := int64(len(memTx.tx))
+
+	pending := mem.senderTx(memTx.sender, memTx.sequence)
+	if dropped, ok := mem.droppedSeqs[memTx.sender]; ok && pending == nil {
+		switch {
+		case memTx.sequence == dropped:
+			replacement = false
+		case memTx.sequence > dropped:
+			return SequenceDroppedError{memTx.sequence, dropped}
+		}
+	}
+	if replacement && pending == nil {
+		return ErrReplacedTxNotFound
+	}
+	if pending != nil {
+		replaced := pending.Value.(*mempoolTx)
+		if minPriority := bumpPriority(replaced.priority, mem.config.PriceBump); memTx.priority < minPriority {
+			return ReplacementPriceTooLowError{memTx.priority, minPriority}
+		}
+		if txsBytes := mem.TxsBytes() - int64(len(replaced.tx)) + txSize; txsBytes > mem.config.MaxPendingTxsBytes {
+			return MempoolIsFullError{
+				mem.Size(), mem.config.Size,
+				mem.TxsBytes(), mem.config.MaxPendingTxsBytes,
+			}
+		}
+
+		mem.logger.Info("Replaced transaction", "tx", txID(replaced.tx), "by", txID(memTx.tx))
+		mem.removeTx(replaced.tx, pending, false)
+		return nil
+	}
+
+	for mem.Size() >= mem.config.Size || mem.TxsBytes()+txSize > mem.config.MaxPendingTxsBytes {
+		e := mem.evictionCandidate(memTx.sender)
+		if e == nil || e.Value.(*mempoolTx).priority >= memTx.priority {
+			return MempoolIsFullError{
+				mem.Size(), mem.config.Size,
+				mem.TxsBytes(), mem.config.MaxPendingTxsBytes,
+			}
+		}
+
+		evicted := e.Value.(*mempoolTx)
+		mem.logger.Info("Evicted low priority transaction", "tx", txID(evicted.tx), "priority", evicted.priority)
+		// NOTE: we remove tx from the cache because it might be good later
+		mem.removeTx(evicted.tx, e, true)
+		mem.dropSequence(evicted.sender, evicted.sequence)
+	}
+	return nil
+}
+
+// dropSequence records that the tx of sender with the given sequence was
+// dropped after the application checked it.
+func (mem *PriorityMempool) dropSequence(sender crypto.Address, sequence uint64) {
+	if sender.IsZero() {
+		return
+	}
+	if dropped, ok := mem.droppedSeqs[sender]; !ok || sequence < dropped {
+		mem.droppedSeqs[sender] = sequence
+	}
+}
+
+// evictionCandidate returns the tx to evict first to make room for a tx of
+// sender: the lowest priority tx, and the last added in case of a tie, among
+// the last txs of the other senders. The txs of a sender are evicted in
+// reverse sequence order, so that the remaining ones stay valid.
+func (mem *PriorityMempool) evictionCandidate(sender crypto.Address) *clist.CElement {
+	var candidate *clist.CElement
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if !memTx.sender.IsZero() {
+			if memTx.sender == sender {
+				continue
+			}
+			if txs := mem.senderTxs[memTx.sender]; txs[len(txs)-1] != e {
+				continue
+			}
+		}
+
+		if candidate == nil || memTx.priority <= candidate.Value.(*mempoolTx).priority {
+			candidate = e
+		}
+	}
+	return candidate
+}
+
+// senderTx returns the pending tx of sender with the given sequence, if any.
+func (mem *PriorityMempool) senderTx(sender crypto.Address, sequence uint64) *clist.CElement {
+	if sender.IsZero() {
+		return nil
+	}
+	for _, e := range mem.senderTxs[sender] {
+		if e.Value.(*mempoolTx).sequence == sequence {
+			return e
+		}
+	}
+	return nil
+}
+
+// bumpPriority returns the minimum priority of a tx replacing a tx with the
+// given priority, increased by bump percent.
+func bumpPriority(priority, bump int64) int64 {
+	bumped := priority + priority/100*bump + priority%100*bump/100
+	if bumped < priority {
+		// overflow
+		return priority
+	}
+	return bumped
+}
+
+// Called from:
+//   - resCbFirstTime (lock held) if tx is valid
+func (mem *PriorityMempool) addTx(memTx *mempoolTx) {
+	memTx.index = mem.nextIndex
+	mem.nextIndex++
+
+	e := mem.txs.PushBack(memTx)
+	mem.txsMap.Store(txKey(memTx.tx), e)
+	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+
+	if !memTx.sender.IsZero() {
+		txs := mem.senderTxs[memTx.sender]
+		i, _ := slices.BinarySearchFunc(txs, memTx.sequence, func(e *clist.CElement, sequence uint64) int {
+			return cmpUint64(e.Value.(*mempoolTx).sequence, sequence)
+		})
+		mem.senderTxs[memTx.sender] = slices.Insert(txs, i, e)
+	}
+}
+
+// Called from:
+//   - Update (lock held) if tx was committed
+//   - resCbFirstTime (lock held) if tx was replaced or evicted
+//   - resCbRecheck (lock held) if tx was invalidated
+func (mem *PriorityMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
+	mem.txs.Remove(elem)
+	elem.DetachPrev()
+	mem.txsMap.Delete(txKey(tx))
+	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+
+	if sender := elem.Value.(*mempoolTx).sender; !sender.IsZero() {
+		txs := slices.DeleteFunc(mem.senderTxs[sender], func(e *clist.CElement) bool {
+			return e == elem
+		})
+		if len(txs) == 0 {
+			delete(mem.senderTxs, sender)
+		} else {
+			mem.senderTxs[sender] = txs
+		}
+	}
+
+	if removeFromCache {
+		mem.cache.Remove(tx)
+	}
+}
+
+// callback, which is called after the app rechecked the tx.
+func (mem *PriorityMempool) resCbRecheck(req abci.Request, res abci.Response) {
+	switch res := res.(type) {
+	case abci.ResponseCheckTx:
+		tx := req.(abci.RequestCheckTx).Tx
+		e := mem.recheckQueue[mem.recheckCursor]
+		memTx := e.Value.(*mempoolTx)
+		if !bytes.Equal(tx, memTx.tx) {
+			panic(fmt.Sprintf(
+				"Unexpected tx response from proxy during recheck\nExpected %X, got %X",
+				memTx.tx,
+				tx))
+		}
+		if res.Error == nil && !res.Replacement {
+			// Good, nothing to do.
+		} else {
+			// Tx became invalidated due to newly committed block. A pending tx
+			// passing as a replacement uses a committed sequence, and would
+			// fail in DeliverTx.
+			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", res, "err", res.Error)
+			// NOTE: we remove tx from the cache because it might be good later
+			mem.removeTx(tx, e, true)
+		}
+		mem.recheckCursor++
+		if mem.recheckCursor == len(mem.recheckQueue) {
+			// Done!
+			mem.recheckQueue = nil
+			atomic.StoreInt32(&mem.rechecking, 0)
+			mem.logger.Info("Done rechecking txs")
+
+			// incase the recheck removed all txs
+			if mem.Size() > 0 {
+				mem.notifyTxsAvailable()
+			}
+		}
+	default:
+		// ignore other messages
+	}
+}
+
+func (mem *PriorityMempool) TxsAvailable() <-chan struct{} {
+	return mem.txsAvailable
+}
+
+func (mem *PriorityMempool) notifyTxsAvailable() {
+	if mem.Size() == 0 {
+		panic("notified txs available but mempool is empty!")
+	}
+	if mem.txsAvailable != nil && !mem.notifiedTxsAvailable {
+		// channel cap is 1, so this will send once
+		mem.notifiedTxsAvailable = true
+		select {
+		case mem.txsAvailable <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ReapMaxBytesMaxGas reaps the txs by decreasing priority, the txs of each
+// sender in sequence order, until one doesn't fit in maxDataBytes or maxGas.
+func (mem *PriorityMempool) ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Txs {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if maxDataBytes == 0 {
+		panic("ReapMaxBytesMaxGas requires maxDataBytes > 0")
+	}
+
+	for atomic.LoadInt32(&mem.rechecking) > 0 {
+		// TODO: Something better?
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	var totalBytes int64
+	var totalGas int64
+	txs := make([]types.Tx, 0, mem.txs.Len())
+	for _, memTx := range mem.priorityOrder() {
+		// Check total size requirement
+		if maxDataBytes > -1 && totalBytes+int64(len(memTx.tx)) > maxDataBytes {
+			return txs
+		}
+		totalBytes += int64(len(memTx.tx))
+		// Check total gas requirement.
+		// If maxGas is negative, skip this check.
+		newTotalGas := totalGas + memTx.gasWanted
+		if maxGas > -1 && newTotalGas > maxGas {
+			return txs
+		}
+		totalGas = newTotalGas
+		txs = append(txs, memTx.tx)
+	}
+	return txs
+}
+
+// ReapMaxTxs reaps up to max txs by decreasing priority, the txs of each
+// sender in sequence order.
+func (mem *PriorityMempool) ReapMaxTxs(maxVal int) types.Txs {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if maxVal < 0 {
+		maxVal = mem.txs.Len()
+	}
+
+	for atomic.LoadInt32(&mem.rechecking) > 0 {
+		// TODO: Something better?
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	txs := make([]types.Tx, 0, min(mem.txs.Len(), maxVal))
+	for _, memTx := range mem.priorityOrder() {
+		if len(txs) >= maxVal {
+			break
+		}
+		txs = append(txs, memTx.tx)
+	}
+	return txs
+}
+
+// priorityOrder returns the txs of the mempool by decreasing priority, and in
+// the order they were added in case of a tie. The txs of a sender are returned
+// in sequence order: a tx is only considered once the txs of the sender with a
+// lower sequence are returned.
+func (mem *PriorityMempool) priorityOrder() []*mempoolTx {
+	queues := make(txQueues, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.sender.IsZero() {
+			queues = append(queues, []*clist.CElement{e})
+		} else if txs := mem.senderTxs[memTx.sender]; txs[0] == e {
+			queues = append(queues, txs)
+		}
+	}
+	heap.Init(&queues)
+
+	ordered := make([]*mempoolTx, 0, mem.txs.Len())
+	for queues.Len() > 0 {
+		txs := queues[0]
+		ordered = append(ordered, txs[0].Value.(*mempoolTx))
+		if len(txs) > 1 {
+			queues[0] = txs[1:]
+			heap.Fix(&queues, 0)
+		} else {
+			heap.Pop(&queues)
+		}
+	}
+	return ordered
+}
+
+func (mem *PriorityMempool) Update(
+	height int64,
+	blockTime time.Time,
+	txs types.Txs,
+	deliverTxResponses []abci.ResponseDeliverTx,
+	preCheck PreCheckFunc,
+	maxTxBytes int64,
+) error {
+	// Set height
+	mem.height = height
+	mem.notifiedTxsAvailable = false
+
+	// The check state of the application is reset to the committed state,
+	// and rebuilt by rechecking the pending txs
+	clear(mem.droppedSeqs)
+
+	if preCheck != nil {
+		mem.preCheck = preCheck
+	}
+	if maxTxBytes != 0 {
+		mem.maxTxBytes = maxTxBytes
+	}
+
+	for i, tx := range txs {
+		if deliverTxResponses[i].Error == nil {
+			// Add valid committed tx to the cache (if missing).
+			_ = mem.cache.Push(tx)
+		} else {
+			// Allow invalid transactions to be resubmitted.
+			mem.cache.Remove(tx)
+		}
+
+		// Remove committed tx from the mempool.
+		if e, ok := mem.txsMap.Load(txKey(tx)); ok {
+			mem.removeTx(tx, e.(*clist.CElement), false)
+		}
+	}
+
+	// Evict the txs which can no longer be included in a block.
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.expired(height, blockTime) {
+			mem.logger.Info("Evicted expired transaction",
+				"tx", txID(memTx.tx),
+				"timeout_height", memTx.timeoutHeight,
+				"timeout_timestamp", memTx.timeoutTimestamp,
+			)
+			mem.removeTx(memTx.tx, e, false)
+		}
+	}
+
+	// Either recheck non-committed txs to see if they became invalid
+	// or just notify there're some txs left.
+	if mem.Size() > 0 {
+		if mem.config.Recheck {
+			mem.logger.Info("Recheck txs", "numtxs", mem.Size(), "height", height)
+			mem.recheckTxs()
+		} else {
+			mem.notifyTxsAvailable()
+		}
+	}
+
+	return nil
+}
+
+// recheckTxs rechecks the txs of the mempool, in the order they were added
+// except for the txs of a sender, which are rechecked in sequence order.
+func (mem *PriorityMempool) recheckTxs() {
+	if mem.Size() == 0 {
+		panic("recheckTxs is called, but the mempool is empty")
+	}
+
+	var (
+		queue = make([]*clist.CElement, 0, mem.Size())
+		seen  = make(map[crypto.Address]bool)
+	)
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.sender.IsZero() {
+			queue = append(queue, e)
+		} else if !seen[memTx.sender] {
+			seen[memTx.sender] = true
+			queue = append(queue, mem.senderTxs[memTx.sender]...)
+		}
+	}
+
+	// check tx size and run precheck
+	queue = slices.DeleteFunc(queue, func(e *clist.CElement) bool {
+		memTx := e.Value.(*mempoolTx)
+		if int64(len(memTx.tx)) > mem.maxTxBytes {
+			mem.removeTx(memTx.tx, e, false)
+			return true
+		}
+		if mem.preCheck != nil {
+			if err := mem.preCheck(memTx.tx); err != nil {
+				mem.removeTx(memTx.tx, e, false)
+				return true
+			}
+		}
+		return false
+	})
+	if len(queue) == 0 {
+		return
+	}
+
+	atomic.StoreInt32(&mem.rechecking, 1)
+	mem.recheckQueue = queue
+	mem.recheckCursor = 0
+
+	// Push txs to proxyAppConn
+	// NOTE: globalCb may be called concurrently.
+	for _, e := range queue {
+		mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
+			Tx:   e.Value.(*mempoolTx).tx,
+			Type: abci.CheckTxTypeRecheck,
+		})
+	}
+
+	mem.proxyAppConn.FlushAsync()
+}
+
+// --------------------------------------------------------------------------------
+
+// txQueues is a max-heap of the pending txs of each sender, by the priority of
+// their first tx.
+type txQueues [][]*clist.CElement
+
+func (q txQueues) Len() int { return len(q) }
+
+func (q txQueues) Less(i, j int) bool {
+	a, b := q[i][0].Value.(*mempoolTx), q[j][0].Value.(*mempoolTx)
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.index < b.index
+}
+
+func (q txQueues) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *txQueues) Push(x any) { *q = append(*q, x.([]*clist.CElement)) }
+
+func (q *txQueues) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
+
+func cmpUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
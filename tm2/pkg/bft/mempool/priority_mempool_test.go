@@ -0,0 +1,299 @@
+package mempool
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/abci/example/kvstore"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+func newPriorityMempoolWithAppAndConfig(cc proxy.ClientCreator, config *cfg.MempoolConfig) (*PriorityMempool, cleanupFunc) {
+	appConnMem, _ := cc.NewABCIClient()
+	appConnMem.SetLogger(log.NewNoopLogger().With("module", "abci-client", "connection", "mempool"))
+	err := appConnMem.Start()
+	if err != nil {
+		panic(err)
+	}
+	mempool := NewPriorityMempool(config, appConnMem, 0, testMaxTxBytes)
+	mempool.SetLogger(log.NewNoopLogger())
+	return mempool, func() {
+		if config.RootDir != "" {
+			os.RemoveAll(config.RootDir)
+		}
+	}
+}
+
+// priorityApp is a kvstore application whose txs are of the form
+// "<sender> <sequence> <priority> [replace]", with "-" for no sender.
+// The txs listed in invalid fail CheckTx, and the ones listed in replacements
+// are replacements, like the txs with the "replace" suffix.
+type priorityApp struct {
+	*kvstore.KVStoreApplication
+	invalid      map[string]bool
+	replacements map[string]bool
+}
+
+func (app priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if app.invalid[string(req.Tx)] {
+		return abci.ResponseCheckTx{ResponseBase: abci.ResponseBase{Error: abci.StringError("invalid")}}
+	}
+
+	fields := strings.Fields(string(req.Tx))
+	res := abci.ResponseCheckTx{GasWanted: 1}
+	if fields[0] != "-" {
+		res.Sender = crypto.AddressFromPreimage([]byte(fields[0]))
+	}
+	res.Sequence, _ = strconv.ParseUint(fields[1], 10, 64)
+	res.Priority, _ = strconv.ParseInt(fields[2], 10, 64)
+	res.Replacement = (len(fields) > 3 && fields[3] == "replace") || app.replacements[string(req.Tx)]
+	return res
+}
+
+func newPriorityApp() priorityApp {
+	return priorityApp{kvstore.NewKVStoreApplication(), map[string]bool{}, map[string]bool{}}
+}
+
+// checkPriorityTxs checks the txs, and returns the error of the response of
+// each tx.
+func checkPriorityTxs(t *testing.T, mempool Mempool, txs ...string) []error {
+	t.Helper()
+
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		err := mempool.CheckTx(types.Tx(tx), func(res abci.Response) {
+			if res := res.(abci.ResponseCheckTx); res.Error != nil {
+				errs[i] = res.Error
+			}
+		})
+		require.NoError(t, err)
+	}
+	return errs
+}
+
+func reaped(txs types.Txs) []string {
+	res := make([]string, len(txs))
+	for i, tx := range txs {
+		res[i] = string(tx)
+	}
+	return res
+}
+
+func TestPriorityMempool_ReapOrder(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(newPriorityApp())
+	mempool, cleanup := newPriorityMempoolWithAppAndConfig(cc, cfg.TestMempoolConfig())
+	defer cleanup()
+
+	errs := checkPriorityTxs(t, mempool,
+		"a 0 10",
+		"b 0 30",
+		"a 1 50", // waits for "a 0 10"
+		"- 0 20",
+		"c 0 30", // same priority as "b 0 30", added later
+		"b 1 5",
+	)
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 6, mempool.Size())
+
+	expected := []string{"b 0 30", "c 0 30", "- 0 20", "a 0 10", "a 1 50", "b 1 5"}
+	assert.Equal(t, expected, reaped(mempool.ReapMaxTxs(-1)))
+	assert.Equal(t, expected[:3], reaped(mempool.ReapMaxTxs(3)))
+	// each tx has 1 gas
+	assert.Equal(t, expected[:4], reaped(mempool.ReapMaxBytesMaxGas(-1, 4)))
+
+	// Txs of a sender are reaped in sequence order, whatever the order they
+	// were added in.
+	mempool.Flush()
+	checkPriorityTxs(t, mempool, "a 1 50", "a 0 10", "- 0 20")
+	assert.Equal(t, []string{"- 0 20", "a 0 10", "a 1 50"}, reaped(mempool.ReapMaxTxs(-1)))
+}
+
+func TestPriorityMempool_Replacement(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(newPriorityApp())
+	config := cfg.TestMempoolConfig()
+	config.PriceBump = 10
+	mempool, cleanup := newPriorityMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	checkPriorityTxs(t, mempool, "a 0 100", "a 1 100")
+
+	// No pending tx to replace.
+	errs := checkPriorityTxs(t, mempool, "a 2 200 replace")
+	assert.ErrorContains(t, errs[0], ErrReplacedTxNotFound.Error())
+
+	// The priority must be increased by at least 10%.
+	errs = checkPriorityTxs(t, mempool, "a 0 109 replace", "a 1 109")
+	assert.ErrorContains(t, errs[0], ReplacementPriceTooLowError{109, 110}.Error())
+	assert.ErrorContains(t, errs[1], ReplacementPriceTooLowError{109, 110}.Error())
+
+	errs = checkPriorityTxs(t, mempool, "a 0 110 replace")
+	require.NoError(t, errs[0])
+	assert.Equal(t, []string{"a 1 100", "a 0 110 replace"}, reaped(mempool.txsList()))
+	assert.Equal(t, []string{"a 0 110 replace", "a 1 100"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The replaced tx stays in the cache.
+	err := mempool.CheckTx(types.Tx("a 0 100"), nil)
+	assert.ErrorIs(t, err, ErrTxInCache)
+}
+
+func TestPriorityMempool_Eviction(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(newPriorityApp())
+	config := cfg.TestMempoolConfig()
+	config.Size = 3
+	mempool, cleanup := newPriorityMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	checkPriorityTxs(t, mempool, "a 0 50", "a 1 10", "- 0 20")
+
+	// The tx doesn't pay more than the lowest priority tx.
+	errs := checkPriorityTxs(t, mempool, "b 0 10")
+	assert.ErrorContains(t, errs[0], "mempool is full")
+
+	// The last tx of a sender is evicted first.
+	errs = checkPriorityTxs(t, mempool, "b 0 15")
+	require.NoError(t, errs[0])
+	assert.Equal(t, []string{"a 0 50", "- 0 20", "b 0 15"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The txs of the same sender are not evicted, to keep the sequence.
+	errs = checkPriorityTxs(t, mempool, "b 1 100")
+	require.NoError(t, errs[0])
+	assert.Equal(t, []string{"a 0 50", "b 0 15", "b 1 100"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The evicted txs are removed from the cache.
+	errs = checkPriorityTxs(t, mempool, "a 1 10")
+	assert.ErrorContains(t, errs[0], "mempool is full")
+}
+
+func TestPriorityMempool_DroppedSequence(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(newPriorityApp())
+	config := cfg.TestMempoolConfig()
+	config.Size = 3
+	mempool, cleanup := newPriorityMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	checkPriorityTxs(t, mempool, "a 0 10", "a 1 10", "c 0 50")
+	errs := checkPriorityTxs(t, mempool, "b 0 20")
+	require.NoError(t, errs[0])
+	assert.Equal(t, []string{"c 0 50", "b 0 20", "a 0 10"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The txs following the evicted sequence are refused until it is
+	// submitted again, which the application checks as a replacement.
+	errs = checkPriorityTxs(t, mempool, "a 2 30", "a 1 30 replace")
+	assert.ErrorContains(t, errs[0], SequenceDroppedError{2, 1}.Error())
+	require.NoError(t, errs[1])
+	assert.Equal(t, []string{"c 0 50", "a 0 10", "a 1 30 replace"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The sequence of a tx refused after the application checked it can be
+	// used again, as well.
+	errs = checkPriorityTxs(t, mempool, "d 0 5", "d 0 5 replace")
+	assert.ErrorContains(t, errs[0], "mempool is full")
+	assert.ErrorContains(t, errs[1], "mempool is full")
+
+	// The check state of the application is reset on update.
+	mempool.Lock()
+	err := mempool.Update(1, time.Time{}, nil, nil, nil, 0)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Empty(t, mempool.droppedSeqs)
+}
+
+func TestPriorityMempool_Update(t *testing.T) {
+	t.Parallel()
+
+	app := newPriorityApp()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newPriorityMempoolWithAppAndConfig(cc, cfg.TestMempoolConfig())
+	defer cleanup()
+
+	checkPriorityTxs(t, mempool, "a 0 10", "a 1 10", "a 2 10", "b 0 20", "- 0 5")
+
+	// Committed txs are removed, and the remaining txs are rechecked.
+	app.invalid["a 2 10"] = true
+	mempool.Lock()
+	err := mempool.Update(1, time.Time{}, types.Txs{types.Tx("a 0 10"), types.Tx("b 0 20")}, abciResponses(2, nil), nil, 0)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a 1 10", "- 0 5"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The invalid tx can be checked again.
+	delete(app.invalid, "a 2 10")
+	errs := checkPriorityTxs(t, mempool, "a 2 10")
+	require.NoError(t, errs[0])
+	assert.Equal(t, []string{"a 1 10", "a 2 10", "- 0 5"}, reaped(mempool.ReapMaxTxs(-1)))
+}
+
+func TestPriorityMempool_RecheckReplacement(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(newPriorityApp())
+	mempool, cleanup := newPriorityMempoolWithAppAndConfig(cc, cfg.TestMempoolConfig())
+	defer cleanup()
+
+	errs := checkPriorityTxs(t, mempool, "a 0 10", "a 0 20 replace", "a 1 10")
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a 0 20 replace", "a 1 10"}, reaped(mempool.ReapMaxTxs(-1)))
+
+	// The replaced tx is committed by another node; the pending tx with the
+	// committed sequence is evicted if it passes as its replacement again on
+	// recheck.
+	mempool.Lock()
+	err := mempool.Update(1, time.Time{}, types.Txs{types.Tx("a 0 10")}, abciResponses(1, nil), nil, 0)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a 1 10"}, reaped(mempool.ReapMaxTxs(-1)))
+}
+
+func TestCListMempool_RejectsReplacement(t *testing.T) {
+	t.Parallel()
+
+	app := newPriorityApp()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	errs := checkPriorityTxs(t, mempool, "a 0 10", "a 0 20 replace")
+	require.NoError(t, errs[0])
+	assert.ErrorContains(t, errs[1], ErrReplacedTxNotFound.Error())
+	assert.Equal(t, 1, mempool.Size())
+
+	// A pending tx whose sequence was committed by another tx passes as a
+	// replacement on recheck, and is evicted.
+	app.replacements["a 0 10"] = true
+	mempool.Lock()
+	err := mempool.Update(1, time.Time{}, types.Txs{types.Tx("a 0 30")}, abciResponses(1, nil), nil, 0)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Zero(t, mempool.Size())
+}
+
+// txsList returns the txs of the mempool, in the order they were added.
+func (mem *PriorityMempool) txsList() types.Txs {
+	var txs types.Txs
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).tx)
+	}
+	return txs
+}
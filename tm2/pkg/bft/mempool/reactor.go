@@ -34,7 +34,7 @@ const (
 type Reactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
-	mempool *CListMempool
+	mempool GossipMempool
 	ids     *mempoolIDs
 }
 
@@ -102,7 +102,7 @@ func newMempoolIDs() *mempoolIDs {
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mempool GossipMempool) *Reactor {
 	memR := &Reactor{
 		config:  config,
 		mempool: mempool,
@@ -0,0 +1,114 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/archive"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/mempool/mock"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+)
+
+const archiveListenerID = "archive-import"
+
+// ImportArchive executes the blocks of the archive read from r on the node
+// with the given config, which must be stopped, without the p2p networking.
+// The node is synced with its application first, as on start, and the txs and
+// block events of the executed blocks are stored in its tx event store. The
+// archive is read twice, to verify it before any block is executed.
+//
+// It returns the state after the last executed block.
+func ImportArchive(
+	config *cfg.Config,
+	r io.ReadSeeker,
+	clientCreator appconn.ClientCreator,
+	genesisDocProvider GenesisDocProvider,
+	dbProvider DBProvider,
+	evsw events.EventSwitch,
+	logger *slog.Logger,
+) (sm.State, error) {
+	blockStoreDB, err := dbProvider(&DBContext{"blockstore", config})
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := dbProvider(&DBContext{"state", config})
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer stateDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer proxyApp.Stop()
+
+	// The txs are stored as they are executed, so that none is lost once
+	// the import returns
+	txEventStore, err := newTxEventStore(config, dbProvider)
+	if err != nil {
+		return sm.State{}, err
+	}
+	if err := txEventStore.Start(); err != nil {
+		return sm.State{}, fmt.Errorf("unable to start transaction event store, %w", err)
+	}
+	defer txEventStore.Stop()
+
+	blockEventStore, storesBlocks := txEventStore.(eventstore.BlockEventStore)
+
+	var storeErr error
+	evsw.AddListener(archiveListenerID, func(ev events.Event) {
+		if storeErr != nil {
+			return
+		}
+
+		switch ev := ev.(type) {
+		case types.EventTx:
+			storeErr = txEventStore.Append(ev.Result)
+		case types.EventNewBlock:
+			if storesBlocks {
+				storeErr = blockEventStore.AppendBlock(
+					types.NewBlockResult(ev.Block.Header, ev.ResultBeginBlock, ev.ResultEndBlock),
+				)
+			}
+		}
+	})
+	defer evsw.RemoveListener(archiveListenerID)
+
+	consensusLogger := logger.With("module", consensusModuleName)
+	if err := doHandshake(stateDB, state, blockStore, genDoc, evsw, proxyApp, consensusLogger); err != nil {
+		return sm.State{}, err
+	}
+
+	// Reload the state, updated by the handshake
+	state = sm.LoadState(stateDB)
+
+	blockExec := sm.NewBlockExecutor(stateDB, logger.With("module", "state"), proxyApp.Consensus(), mock.Mempool{})
+	blockExec.SetEventSwitch(evsw)
+
+	state, err = archive.Import(r, state, blockStore, blockExec)
+	if err != nil {
+		return state, err
+	}
+	if storeErr != nil {
+		return state, fmt.Errorf("unable to store events, %w", storeErr)
+	}
+
+	return state, nil
+}
@@ -0,0 +1,227 @@
+package node
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	cnscfg "github.com/gnolang/gno/tm2/pkg/bft/consensus/config"
+	cstypes "github.com/gnolang/gno/tm2/pkg/bft/consensus/types"
+	rpccore "github.com/gnolang/gno/tm2/pkg/bft/rpc/core"
+	rpcserver "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/server"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+// inspectRoutes are the RPC routes served by the Inspector, which only read
+// the block store and the state
+var inspectRoutes = []string{
+	"health",
+	"genesis",
+	"blockchain",
+	"block",
+	"block_results",
+	"commit",
+	"tx",
+	"tx_search",
+	"account_txs",
+	"block_search",
+	"validators",
+	"consensus_params",
+}
+
+// Inspector serves the read-only RPC routes over the databases of a stopped
+// node, without starting the application, the consensus nor the p2p
+// networking, e.g. to investigate a node which can't start anymore.
+type Inspector struct {
+	service.BaseService
+
+	config       *cfg.Config
+	genesisDoc   *types.GenesisDoc
+	dbs          []dbm.DB
+	stateDB      dbm.DB
+	blockStore   *store.BlockStore
+	txEventStore eventstore.TxEventStore
+	rpcListeners []net.Listener
+}
+
+// NewInspector opens the databases of the node with the given config.
+// The node must have been started at least once, and must be stopped.
+func NewInspector(config *cfg.Config, dbProvider DBProvider, logger *slog.Logger) (*Inspector, error) {
+	in := &Inspector{config: config}
+	in.BaseService = *service.NewBaseService(logger, "Inspector", in)
+
+	openDB := func(id string) (dbm.DB, error) {
+		db, err := dbProvider(&DBContext{id, config})
+		if err != nil {
+			in.closeDBs()
+			return nil, fmt.Errorf("unable to open the %s database, %w", id, err)
+		}
+		in.dbs = append(in.dbs, db)
+		return db, nil
+	}
+
+	blockStoreDB, err := openDB("blockstore")
+	if err != nil {
+		return nil, err
+	}
+	in.blockStore = store.NewBlockStore(blockStoreDB)
+
+	if in.stateDB, err = openDB("state"); err != nil {
+		return nil, err
+	}
+
+	// The genesis doc is saved in the state DB once the node is started
+	if in.genesisDoc, err = loadGenesisDoc(in.stateDB); err != nil {
+		in.closeDBs()
+		return nil, errors.New("unable to load the genesis doc, the node was never started")
+	}
+
+	// Only the indexed transactions can be searched
+	in.txEventStore = null.NewNullEventStore()
+	if config.TxEventStore.EventStoreType == kv.EventStoreType {
+		txIndexDB, err := openDB("tx_index")
+		if err != nil {
+			return nil, err
+		}
+		in.txEventStore = kv.NewTxEventStore(txIndexDB)
+	}
+
+	return in, nil
+}
+
+// OnStart starts the RPC servers. It implements service.Service.
+func (in *Inspector) OnStart() error {
+	rpccore.SetStateDB(in.stateDB)
+	rpccore.SetBlockStore(in.blockStore)
+	rpccore.SetConsensusState(&inspectConsensus{stateDB: in.stateDB})
+	rpccore.SetTxEventStore(in.txEventStore)
+	rpccore.SetGenesisDoc(in.genesisDoc)
+	rpccore.SetLogger(in.Logger.With("module", "rpc"))
+	rpccore.SetConfig(*in.config.RPC)
+
+	listeners, err := in.startRPC()
+	if err != nil {
+		return err
+	}
+	in.rpcListeners = listeners
+
+	return nil
+}
+
+// OnStop closes the RPC listeners and the databases. It implements
+// service.Service.
+func (in *Inspector) OnStop() {
+	for _, l := range in.rpcListeners {
+		if err := l.Close(); err != nil {
+			in.Logger.Error("Error closing listener", "listener", l, "err", err)
+		}
+	}
+
+	in.closeDBs()
+}
+
+// Listeners returns the addresses of the RPC listeners.
+func (in *Inspector) Listeners() []string {
+	addrs := make([]string, 0, len(in.rpcListeners))
+	for _, l := range in.rpcListeners {
+		addrs = append(addrs, l.Addr().String())
+	}
+
+	return addrs
+}
+
+func (in *Inspector) closeDBs() {
+	for _, db := range in.dbs {
+		db.Close()
+	}
+	in.dbs = nil
+}
+
+func (in *Inspector) startRPC() (listeners []net.Listener, err error) {
+	defer func() {
+		if err != nil {
+			for _, ln := range listeners {
+				ln.Close()
+			}
+		}
+	}()
+
+	routes := make(map[string]*rpcserver.RPCFunc, len(inspectRoutes))
+	for _, name := range inspectRoutes {
+		routes[name] = rpccore.Routes[name]
+	}
+
+	config := rpcserver.DefaultConfig()
+	config.MaxBodyBytes = in.config.RPC.MaxBodyBytes
+	config.MaxHeaderBytes = in.config.RPC.MaxHeaderBytes
+	config.MaxOpenConnections = in.config.RPC.MaxOpenConnections
+
+	rpcLogger := in.Logger.With("module", "rpc-server")
+
+	var rebuildAddresses bool
+	for _, listenAddr := range splitAndTrimEmpty(in.config.RPC.ListenAddress, ",", " ") {
+		mux := http.NewServeMux()
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		if strings.HasPrefix(listenAddr, "tcp://") && strings.HasSuffix(listenAddr, ":0") {
+			rebuildAddresses = true
+		}
+
+		listener, err := rpcserver.Listen(listenAddr, config)
+		if err != nil {
+			return nil, err
+		}
+
+		go rpcserver.StartHTTPServer(listener, mux, rpcLogger, config)
+
+		listeners = append(listeners, listener)
+	}
+	if rebuildAddresses {
+		in.config.RPC.ListenAddress = joinListenerAddresses(listeners)
+	}
+
+	return listeners, nil
+}
+
+// inspectConsensus is the consensus state of a stopped node, as persisted
+// in its state DB. Only the state is available, there is no round state.
+type inspectConsensus struct {
+	stateDB dbm.DB
+}
+
+var _ rpccore.Consensus = (*inspectConsensus)(nil)
+
+func (c *inspectConsensus) GetState() sm.State {
+	return sm.LoadState(c.stateDB)
+}
+
+func (c *inspectConsensus) GetLastHeight() int64 {
+	return c.GetState().LastBlockHeight
+}
+
+func (c *inspectConsensus) GetValidators() (int64, []*types.Validator) {
+	state := c.GetState()
+	return state.LastBlockHeight, state.Validators.Copy().Validators
+}
+
+func (c *inspectConsensus) GetConfigDeepCopy() *cnscfg.ConsensusConfig {
+	return nil
+}
+
+func (c *inspectConsensus) GetRoundStateDeepCopy() *cstypes.RoundState {
+	return &cstypes.RoundState{}
+}
+
+func (c *inspectConsensus) GetRoundStateSimple() cstypes.RoundStateSimple {
+	return cstypes.RoundStateSimple{}
+}
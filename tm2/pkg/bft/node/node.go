@@ -14,6 +14,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/discovery"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
@@ -24,6 +25,7 @@ import (
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	cs "github.com/gnolang/gno/tm2/pkg/bft/consensus"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	rpccore "github.com/gnolang/gno/tm2/pkg/bft/rpc/core"
@@ -32,6 +34,7 @@ import (
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync"
 	"github.com/gnolang/gno/tm2/pkg/bft/store"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
@@ -52,6 +55,7 @@ const (
 	blockchainReactorName = "BLOCKCHAIN"
 	consensusReactorName  = "CONSENSUS"
 	discoveryReactorName  = "DISCOVERY"
+	stateSyncReactorName  = "STATESYNC"
 )
 
 const (
@@ -60,6 +64,7 @@ const (
 	consensusModuleName  = "consensus"
 	p2pModuleName        = "p2p"
 	discoveryModuleName  = "discovery"
+	stateSyncModuleName  = "statesync"
 )
 
 // ------------------------------------------------------------------------------
@@ -161,6 +166,7 @@ type Node struct {
 	evsw              events.EventSwitch
 	stateDB           dbm.DB
 	blockStore        *store.BlockStore // store the blockchain to disk
+	pruner            *sm.Pruner        // prune the blocks and states
 	bcReactor         p2p.Reactor       // for fast-syncing
 	mempoolReactor    *mempl.Reactor    // for gossipping transactions
 	mempool           mempl.Mempool
@@ -171,6 +177,11 @@ type Node struct {
 	txEventStore      eventstore.TxEventStore
 	eventStoreService *eventstore.Service
 	firstBlockSignal  <-chan struct{}
+
+	// state sync
+	stateSync        bool               // bootstrap the node with state sync
+	stateSyncReactor *statesync.Reactor // for serving and restoring snapshots
+	stateProvider    statesync.StateProvider
 }
 
 func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
@@ -200,25 +211,13 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger *slog
 
 func createAndStartEventStoreService(
 	cfg *cfg.Config,
+	dbProvider DBProvider,
 	evsw events.EventSwitch,
 	logger *slog.Logger,
 ) (*eventstore.Service, eventstore.TxEventStore, error) {
-	var (
-		err          error
-		txEventStore eventstore.TxEventStore
-	)
-
-	// Instantiate the event store based on the configuration
-	switch cfg.TxEventStore.EventStoreType {
-	case file.EventStoreType:
-		// Transaction events should be logged to files
-		txEventStore, err = file.NewTxEventStore(cfg.TxEventStore)
-		if err != nil {
-			return nil, nil, fmt.Errorf("unable to create file tx event store, %w", err)
-		}
-	default:
-		// Transaction event storing should be omitted
-		txEventStore = null.NewNullEventStore()
+	txEventStore, err := newTxEventStore(cfg, dbProvider)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	indexerService := eventstore.NewEventStoreService(txEventStore, evsw)
@@ -230,6 +229,31 @@ func createAndStartEventStoreService(
 	return indexerService, txEventStore, nil
 }
 
+// newTxEventStore returns the tx event store set in the configuration
+func newTxEventStore(cfg *cfg.Config, dbProvider DBProvider) (eventstore.TxEventStore, error) {
+	switch cfg.TxEventStore.EventStoreType {
+	case file.EventStoreType:
+		// Transaction events should be logged to files
+		txEventStore, err := file.NewTxEventStore(cfg.TxEventStore)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create file tx event store, %w", err)
+		}
+
+		return txEventStore, nil
+	case kv.EventStoreType:
+		// Transaction events should be indexed in a database
+		txIndexDB, err := dbProvider(&DBContext{"tx_index", cfg})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create kv tx event store, %w", err)
+		}
+
+		return kv.NewTxEventStore(txIndexDB), nil
+	default:
+		// Transaction event storing should be omitted
+		return null.NewNullEventStore(), nil
+	}
+}
+
 func doHandshake(stateDB dbm.DB, state sm.State, blockStore sm.BlockStore,
 	genDoc *types.GenesisDoc, evsw events.EventSwitch, proxyApp appconn.AppConns, consensusLogger *slog.Logger,
 ) error {
@@ -267,14 +291,26 @@ func onlyValidatorIsUs(state sm.State, privVal types.PrivValidator) bool {
 
 func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp appconn.AppConns,
 	state sm.State, logger *slog.Logger,
-) (*mempl.Reactor, *mempl.CListMempool) {
-	mempool := mempl.NewCListMempool(
-		config.Mempool,
-		proxyApp.Mempool(),
-		state.LastBlockHeight,
-		state.ConsensusParams.Block.MaxTxBytes,
-		mempl.WithPreCheck(sm.TxPreCheck(state)),
-	)
+) (*mempl.Reactor, mempl.GossipMempool) {
+	var mempool mempl.GossipMempool
+	switch config.Mempool.Type {
+	case memplcfg.TypePriority:
+		mempool = mempl.NewPriorityMempool(
+			config.Mempool,
+			proxyApp.Mempool(),
+			state.LastBlockHeight,
+			state.ConsensusParams.Block.MaxTxBytes,
+			mempl.WithPriorityPreCheck(sm.TxPreCheck(state)),
+		)
+	default:
+		mempool = mempl.NewCListMempool(
+			config.Mempool,
+			proxyApp.Mempool(),
+			state.LastBlockHeight,
+			state.ConsensusParams.Block.MaxTxBytes,
+			mempl.WithPreCheck(sm.TxPreCheck(state)),
+		)
+	}
 	mempoolLogger := logger.With("module", mempoolModuleName)
 	mempoolReactor := mempl.NewReactor(config.Mempool, mempool)
 	mempoolReactor.SetLogger(mempoolLogger)
@@ -309,7 +345,7 @@ func createConsensusReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
-	mempool *mempl.CListMempool,
+	mempool mempl.Mempool,
 	privValidator types.PrivValidator,
 	fastSync bool,
 	evsw events.EventSwitch,
@@ -381,22 +417,47 @@ func NewNode(config *cfg.Config,
 	})
 
 	// Transaction event storing
-	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, evsw, logger)
+	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, dbProvider, evsw, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	// Bootstrap the node with state sync if its state is empty, instead of
+	// replaying the blocks from genesis. The node is bootstrapped once the
+	// switch is started, see OnStart.
+	stateSync := config.StateSync.Enable && state.LastBlockHeight == 0
+
+	var stateProvider statesync.StateProvider
+	if stateSync {
+		trustHash, err := config.StateSync.TrustHashBytes()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid state sync trust hash")
+		}
+
+		stateProvider, err = statesync.NewRPCStateProvider(
+			genDoc.ChainID,
+			config.StateSync.RPCServers,
+			config.StateSync.TrustHeight,
+			trustHash,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create state sync provider")
+		}
+	}
+
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync tendermint with the app.
 	consensusLogger := logger.With("module", consensusModuleName)
-	if err := doHandshake(stateDB, state, blockStore, genDoc, evsw, proxyApp, consensusLogger); err != nil {
-		return nil, err
-	}
+	if !stateSync {
+		if err := doHandshake(stateDB, state, blockStore, genDoc, evsw, proxyApp, consensusLogger); err != nil {
+			return nil, err
+		}
 
-	// Reload the state. It will have the Version.Consensus.App set by the
-	// Handshake, and may have other modifications as well (ie. depending on
-	// what happened during block replay).
-	state = sm.LoadState(stateDB)
+		// Reload the state. It will have the Version.Consensus.App set by the
+		// Handshake, and may have other modifications as well (ie. depending on
+		// what happened during block replay).
+		state = sm.LoadState(stateDB)
+	}
 
 	// If an address is provided, listen on the socket for a connection from an
 	// external signing process.
@@ -423,26 +484,32 @@ func NewNode(config *cfg.Config,
 	// Make MempoolReactor
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, logger)
 
+	// Make the pruner of the blocks and states below the retain height
+	pruner := sm.NewPruner(stateDB, blockStore, config.MinRetainBlocks)
+	pruner.SetLogger(logger.With("module", "pruner"))
+
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateDB,
 		logger.With("module", "state"),
 		proxyApp.Consensus(),
 		mempool,
+		sm.WithPruner(pruner),
 	)
 
-	// Make ConsensusReactor
+	// Make ConsensusReactor, waiting for the state sync or the fast sync
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool,
-		privValidator, fastSync, evsw, consensusLogger,
+		privValidator, fastSync || stateSync, evsw, consensusLogger,
 	)
 
-	// Make BlockchainReactor
+	// Make BlockchainReactor, which is switched to fast sync after the
+	// state sync
 	bcReactor, err := createBlockchainReactor(
 		state,
 		blockExec,
 		blockStore,
-		fastSync,
+		fastSync && !stateSync,
 		consensusReactor.SwitchToConsensus,
 		logger,
 	)
@@ -450,6 +517,14 @@ func NewNode(config *cfg.Config,
 		return nil, errors.Wrap(err, "could not create blockchain reactor")
 	}
 
+	// Make StateSyncReactor, serving the snapshots of the app
+	stateSyncReactor := statesync.NewReactor(
+		proxyApp.Snapshot(),
+		proxyApp.Query(),
+		config.StateSync.ChunkRequestTimeout,
+	)
+	stateSyncReactor.SetLogger(logger.With("module", stateSyncModuleName))
+
 	reactors := []nodeReactor{
 		{
 			mempoolReactorName, mempoolReactor,
@@ -460,6 +535,9 @@ func NewNode(config *cfg.Config,
 		{
 			consensusReactorName, consensusReactor,
 		},
+		{
+			stateSyncReactorName, stateSyncReactor,
+		},
 	}
 
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txEventStore, genDoc, state)
@@ -559,6 +637,10 @@ func NewNode(config *cfg.Config,
 		consensusState:    consensusState,
 		consensusReactor:  consensusReactor,
 		proxyApp:          proxyApp,
+		stateSync:         stateSync,
+		stateSyncReactor:  stateSyncReactor,
+		stateProvider:     stateProvider,
+		pruner:            pruner,
 		txEventStore:      txEventStore,
 		eventStoreService: eventStoreService,
 		firstBlockSignal:  cFirstBlock,
@@ -581,6 +663,11 @@ func (n *Node) OnStart() error {
 		time.Sleep(genTime.Sub(now))
 	}
 
+	// Start the pruner before the reactors commit blocks
+	if err := n.pruner.Start(); err != nil {
+		return fmt.Errorf("unable to start pruner, %w", err)
+	}
+
 	// Set up the GLOBAL variables in rpc/core which refer to this node.
 	// This is done separately from startRPC(), as the values in rpc/core are used,
 	// for instance, to set up Local clients (rpc/client) which work without
@@ -642,6 +729,46 @@ func (n *Node) OnStart() error {
 	// Dial the persistent peers
 	n.sw.DialPeers(peerAddrs...)
 
+	// Bootstrap the node with state sync, from the snapshots of the peers
+	if n.stateSync {
+		go func() {
+			if err := n.startStateSync(); err != nil {
+				n.Logger.Error("State sync failed", "err", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// startStateSync restores a snapshot of the peers, bootstraps the state and
+// the block store at its height, and switches to fast sync or consensus.
+func (n *Node) startStateSync() error {
+	n.Logger.Info("Starting state sync")
+
+	state, commit, err := n.stateSyncReactor.Sync(n.stateProvider, n.config.StateSync.DiscoveryTime)
+	if err != nil {
+		return fmt.Errorf("unable to sync state, %w", err)
+	}
+
+	sm.BootstrapState(n.stateDB, state)
+	if err := n.blockStore.Bootstrap(state.LastBlockHeight, commit); err != nil {
+		return fmt.Errorf("unable to bootstrap block store, %w", err)
+	}
+
+	n.Logger.Info("State sync complete", "height", state.LastBlockHeight, "hash", fmt.Sprintf("%X", state.AppHash))
+
+	if n.config.FastSyncMode && !onlyValidatorIsUs(state, n.privValidator) {
+		bcReactor, ok := n.bcReactor.(*bc.BlockchainReactor)
+		if !ok {
+			return errors.New("unable to switch to fast sync")
+		}
+
+		return bcReactor.SwitchToFastSync(state)
+	}
+
+	n.consensusReactor.SwitchToConsensus(state, 0)
+
 	return nil
 }
 
@@ -654,6 +781,7 @@ func (n *Node) OnStop() {
 	// first stop the non-reactor services
 	n.evsw.Stop()
 	n.eventStoreService.Stop()
+	n.pruner.Stop()
 
 	// Stop the node p2p transport
 	if err := n.transport.Close(); err != nil {
@@ -697,6 +825,7 @@ func (n *Node) configureRPC() {
 	rpccore.SetBlockStore(n.blockStore)
 	rpccore.SetConsensusState(n.consensusState)
 	rpccore.SetMempool(n.mempool)
+	rpccore.SetTxEventStore(n.txEventStore)
 	rpccore.SetP2PPeers(n.sw)
 	rpccore.SetP2PTransport(n)
 	pubKey := n.privValidator.GetPubKey()
@@ -741,10 +870,7 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
 		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
-			rpcserver.OnDisconnect(func(remoteAddr string) {
-				// any cleanup...
-				// (we used to unsubscribe from all event subscriptions)
-			}),
+			rpcserver.OnDisconnect(rpccore.UnsubscribeClient),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 		)
 		wm.SetLogger(wmLogger)
@@ -906,6 +1032,7 @@ func makeNodeInfo(
 			bcChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
+			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
 		Moniker: config.Moniker,
 		Other: p2pTypes.NodeInfoOther{
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"os"
@@ -12,12 +13,17 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gnolang/gno/tm2/pkg/bft/abci/example/kvstore"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/archive"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
@@ -25,6 +31,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 	"github.com/gnolang/gno/tm2/pkg/random"
 )
 
@@ -69,6 +76,229 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodePruneBlocks(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	config.MinRetainBlocks = 2
+
+	// create & start node
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop()
+
+	// wait for the node to prune the first blocks
+	require.Eventually(t, func() bool {
+		return n.BlockStore().Base() > 2
+	}, 30*time.Second, 100*time.Millisecond)
+
+	base := n.BlockStore().Base()
+	assert.Nil(t, n.BlockStore().LoadBlock(base-1))
+	assert.NotNil(t, n.BlockStore().LoadBlock(base))
+	assert.GreaterOrEqual(t, n.BlockStore().Height()-base, int64(1))
+}
+
+func TestInspector(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	// The databases are kept once the node is stopped
+	dbs := make(map[string]dbm.DB)
+	dbProvider := func(ctx *DBContext) (dbm.DB, error) {
+		if _, ok := dbs[ctx.ID]; !ok {
+			dbs[ctx.ID] = memdb.NewMemDB()
+		}
+		return dbs[ctx.ID], nil
+	}
+
+	// The node must have been started
+	_, err := NewInspector(config, dbProvider, log.NewNoopLogger())
+	require.Error(t, err)
+
+	nodeKey, err := p2pTypes.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	n, err := NewNode(
+		config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication()),
+		DefaultGenesisDocProviderFunc(genesisFile),
+		dbProvider,
+		events.NewEventSwitch(),
+		log.NewNoopLogger(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	require.Eventually(t, func() bool {
+		return n.BlockStore().Height() >= 2
+	}, 30*time.Second, 100*time.Millisecond)
+	require.NoError(t, n.Stop())
+	n.Wait()
+
+	config.RPC.ListenAddress = "tcp://127.0.0.1:0"
+	in, err := NewInspector(config, dbProvider, log.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, in.Start())
+	defer in.Stop()
+
+	require.Len(t, in.Listeners(), 1)
+	c, err := client.NewHTTPClient("http://" + in.Listeners()[0])
+	require.NoError(t, err)
+
+	height := int64(2)
+	block, err := c.Block(&height)
+	require.NoError(t, err)
+	assert.Equal(t, height, block.Block.Height)
+
+	vals, err := c.Validators(nil)
+	require.NoError(t, err)
+	assert.Len(t, vals.Validators, 1)
+
+	// The routes which need the running node are not served
+	_, err = c.Status()
+	assert.Error(t, err)
+}
+
+func TestImportArchive(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_archive_test")
+	defer os.RemoveAll(config.RootDir)
+
+	newDBProvider := func() DBProvider {
+		dbs := make(map[string]dbm.DB)
+		return func(ctx *DBContext) (dbm.DB, error) {
+			if _, ok := dbs[ctx.ID]; !ok {
+				dbs[ctx.ID] = memdb.NewMemDB()
+			}
+			return dbs[ctx.ID], nil
+		}
+	}
+
+	nodeKey, err := p2pTypes.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	// Run a node to export its blocks
+	n, err := NewNode(
+		config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication()),
+		DefaultGenesisDocProviderFunc(genesisFile),
+		newDBProvider(),
+		events.NewEventSwitch(),
+		log.NewNoopLogger(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	require.Eventually(t, func() bool {
+		return n.BlockStore().Height() >= 3
+	}, 30*time.Second, 100*time.Millisecond)
+	require.NoError(t, n.Stop())
+	n.Wait()
+
+	var buf bytes.Buffer
+	require.NoError(t, archive.Export(&buf, n.BlockStore(), 1, 3))
+
+	// Import the blocks in a new node, with its own app
+	dbProvider := newDBProvider()
+	state, err := ImportArchive(
+		config,
+		bytes.NewReader(buf.Bytes()),
+		proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication()),
+		DefaultGenesisDocProviderFunc(genesisFile),
+		dbProvider,
+		events.NewEventSwitch(),
+		log.NewNoopLogger(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), state.LastBlockHeight)
+	assert.Equal(t, n.BlockStore().LoadBlockMeta(3).BlockID, state.LastBlockID)
+
+	stateDB, err := dbProvider(&DBContext{"state", config})
+	require.NoError(t, err)
+	assert.True(t, state.Equals(sm.LoadState(stateDB)))
+}
+
+func TestReindexEvents(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_reindex_test")
+	defer os.RemoveAll(config.RootDir)
+
+	dbs := make(map[string]dbm.DB)
+	dbProvider := func(ctx *DBContext) (dbm.DB, error) {
+		if _, ok := dbs[ctx.ID]; !ok {
+			dbs[ctx.ID] = memdb.NewMemDB()
+		}
+		return dbs[ctx.ID], nil
+	}
+
+	genDoc, err := types.GenesisDocFromFile(genesisFile)
+	require.NoError(t, err)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	blockStoreDB, _ := dbProvider(&DBContext{"blockstore", config})
+	stateDB, _ := dbProvider(&DBContext{"state", config})
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	// Store the executed blocks, with 1 and 2 txs
+	for height := int64(1); height <= 2; height++ {
+		txs := make([]types.Tx, height)
+		responses := sm.NewABCIResponsesFromNum(height)
+		for i := range txs {
+			txs[i] = []byte(fmt.Sprintf("tx %d/%d", height, i))
+			responses.DeliverTxs[i].Data = txs[i]
+		}
+		responses.EndBlock.Events = []abci.Event{abci.EventString(fmt.Sprintf("end block %d", height))}
+
+		block, parts := state.MakeBlock(height, txs, new(types.Commit), state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		blockStore.SaveBlock(block, parts, types.NewCommit(blockID, nil))
+		sm.SaveABCIResponses(stateDB, height, responses)
+
+		state.LastBlockHeight = height
+	}
+	sm.SaveState(stateDB, state)
+
+	t.Run("event store disabled", func(t *testing.T) {
+		_, err := ReindexEvents(config, dbProvider, 0, 0)
+		assert.ErrorIs(t, err, errEventStoreDisabled)
+	})
+
+	config.TxEventStore.EventStoreType = kv.EventStoreType
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := ReindexEvents(config, dbProvider, 1, 3)
+		assert.ErrorContains(t, err, "invalid block range")
+	})
+
+	t.Run("all blocks", func(t *testing.T) {
+		// A tx indexed before, which is not in the blocks
+		txIndexDB, _ := dbProvider(&DBContext{"tx_index", config})
+		require.NoError(t, kv.NewTxEventStore(txIndexDB).Append(types.TxResult{Height: 2, Index: 2, Tx: []byte("stale")}))
+
+		count, err := ReindexEvents(config, dbProvider, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		hashes, _, err := kv.NewTxEventStore(txIndexDB).Search("tx.height = 2", 0, -1, false)
+		require.NoError(t, err)
+		require.Len(t, hashes, 2)
+
+		result, err := kv.NewTxEventStore(txIndexDB).GetTx(hashes[1])
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), result.Index)
+		assert.Equal(t, []byte("tx 2/1"), result.Response.Data)
+
+		// The block events are reindexed along
+		blockResult, err := kv.NewTxEventStore(txIndexDB).GetBlock(2)
+		require.NoError(t, err)
+		require.NotNil(t, blockResult)
+		assert.Equal(t, state.Validators.GetProposer().Address, blockResult.ProposerAddress)
+		assert.Equal(t, abci.EventString("end block 2"), blockResult.ResultEndBlock.Events[0])
+	})
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
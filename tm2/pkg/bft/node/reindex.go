@@ -0,0 +1,124 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+var errEventStoreDisabled = errors.New("the transaction event store is not enabled")
+
+// ReindexEvents appends the txs of the executed blocks from startHeight to
+// endHeight to the tx event store of the node with the given config, which
+// must be stopped, along with their results, and the results of the blocks
+// if the event store stores them. The heights default to the ones of the
+// first stored and the last executed block when zero.
+//
+// It rebuilds the indexes of the kv event store, e.g. after it is enabled or
+// its indexes change, and returns the number of reindexed txs. The events
+// already stored for the range are deleted first, if the event store can
+// delete them, so that none of their stale tags remains.
+func ReindexEvents(config *cfg.Config, dbProvider DBProvider, startHeight, endHeight int64) (int, error) {
+	if config.TxEventStore.EventStoreType == null.EventStoreType {
+		return 0, errEventStoreDisabled
+	}
+
+	blockStoreDB, err := dbProvider(&DBContext{"blockstore", config})
+	if err != nil {
+		return 0, err
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := dbProvider(&DBContext{"state", config})
+	if err != nil {
+		return 0, err
+	}
+	defer stateDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	state := sm.LoadState(stateDB)
+
+	// Only the executed blocks have results
+	if state.LastBlockHeight == 0 {
+		return 0, nil
+	}
+	if startHeight == 0 {
+		startHeight = max(blockStore.Base(), 1)
+	}
+	if endHeight == 0 {
+		endHeight = state.LastBlockHeight
+	}
+	if startHeight < blockStore.Base() || startHeight > endHeight || endHeight > state.LastBlockHeight {
+		return 0, fmt.Errorf(
+			"invalid block range %d to %d, the executed blocks are %d to %d",
+			startHeight, endHeight, blockStore.Base(), state.LastBlockHeight,
+		)
+	}
+
+	txEventStore, err := newTxEventStore(config, dbProvider)
+	if err != nil {
+		return 0, err
+	}
+	if err := txEventStore.Start(); err != nil {
+		return 0, fmt.Errorf("unable to start transaction event store, %w", err)
+	}
+	defer txEventStore.Stop()
+
+	if deleter, ok := txEventStore.(eventstore.RangeDeleter); ok {
+		if err := deleter.DeleteRange(startHeight, endHeight); err != nil {
+			return 0, fmt.Errorf("unable to delete the stored events, %w", err)
+		}
+	}
+
+	blockEventStore, storesBlocks := txEventStore.(eventstore.BlockEventStore)
+
+	count := 0
+	for height := startHeight; height <= endHeight; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return count, fmt.Errorf("block %d not found", height)
+		}
+		if len(block.Txs) == 0 && !storesBlocks {
+			continue
+		}
+
+		responses, err := sm.LoadABCIResponses(stateDB, height)
+		if err != nil {
+			return count, fmt.Errorf("unable to load the results of block %d, %w", height, err)
+		}
+		if len(responses.DeliverTxs) != len(block.Txs) {
+			return count, fmt.Errorf(
+				"block %d has %d txs, and %d results",
+				height, len(block.Txs), len(responses.DeliverTxs),
+			)
+		}
+
+		if storesBlocks {
+			result := types.NewBlockResult(block.Header, responses.BeginBlock, responses.EndBlock)
+			if err := blockEventStore.AppendBlock(result); err != nil {
+				return count, fmt.Errorf("unable to store block events, %w", err)
+			}
+		}
+
+		for i, tx := range block.Txs {
+			result := types.TxResult{
+				Height:   height,
+				Index:    uint32(i),
+				Tx:       tx,
+				Response: responses.DeliverTxs[i],
+			}
+			if err := txEventStore.Append(result); err != nil {
+				return count, fmt.Errorf("unable to store transaction, %w", err)
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
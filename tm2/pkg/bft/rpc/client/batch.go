@@ -327,6 +327,26 @@ func (b *RPCBatch) BlockchainInfo(minHeight, maxHeight int64) error {
 	return nil
 }
 
+func (b *RPCBatch) BlockSearch(query string, page, perPage int, orderBy string) error {
+	// Prepare the RPC request
+	request, err := newRequest(
+		blockSearchMethod,
+		map[string]any{
+			"query":    query,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	b.addRequest(request, &ctypes.ResultBlockSearch{})
+
+	return nil
+}
+
 func (b *RPCBatch) Genesis() error {
 	// Prepare the RPC request
 	request, err := newRequest(genesisMethod, map[string]any{})
@@ -407,6 +427,46 @@ func (b *RPCBatch) Tx(hash []byte) error {
 	return nil
 }
 
+func (b *RPCBatch) TxSearch(query string, page, perPage int, orderBy string) error {
+	// Prepare the RPC request
+	request, err := newRequest(
+		txSearchMethod,
+		map[string]any{
+			"query":    query,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	b.addRequest(request, &ctypes.ResultTxSearch{})
+
+	return nil
+}
+
+func (b *RPCBatch) AccountTxs(address string, page, perPage int, orderBy string) error {
+	// Prepare the RPC request
+	request, err := newRequest(
+		accountTxsMethod,
+		map[string]any{
+			"address":  address,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	b.addRequest(request, &ctypes.ResultTxSearch{})
+
+	return nil
+}
+
 func (b *RPCBatch) Validators(height *int64) error {
 	params := map[string]any{}
 	if height != nil {
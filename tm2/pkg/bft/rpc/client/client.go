@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -33,19 +35,30 @@ const (
 	consensusParamsMethod    = "consensus_params"
 	healthMethod             = "health"
 	blockchainMethod         = "blockchain"
+	blockSearchMethod        = "block_search"
 	genesisMethod            = "genesis"
 	blockMethod              = "block"
 	blockResultsMethod       = "block_results"
 	commitMethod             = "commit"
 	txMethod                 = "tx"
+	txSearchMethod           = "tx_search"
+	accountTxsMethod         = "account_txs"
 	validatorsMethod         = "validators"
+	subscribeMethod          = "subscribe"
+	unsubscribeMethod        = "unsubscribe"
+	unsubscribeAllMethod     = "unsubscribe_all"
 )
 
+var errSubscriptionsUnsupported = errors.New("subscriptions are only supported over WS")
+
 // RPCClient encompasses common RPC client methods
 type RPCClient struct {
 	requestTimeout time.Duration
 
 	caller rpcclient.Client
+
+	subscriptions    map[string]rpctypes.JSONRPCID // query -> subscription request ID
+	subscriptionsMux sync.Mutex
 }
 
 // NewRPCClient creates a new RPC client instance with the given caller
@@ -53,6 +66,7 @@ func NewRPCClient(caller rpcclient.Client, opts ...Option) *RPCClient {
 	c := &RPCClient{
 		requestTimeout: defaultTimeout,
 		caller:         caller,
+		subscriptions:  make(map[string]rpctypes.JSONRPCID),
 	}
 
 	for _, opt := range opts {
@@ -249,6 +263,20 @@ func (c *RPCClient) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBl
 	)
 }
 
+func (c *RPCClient) BlockSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	return sendRequestCommon[ctypes.ResultBlockSearch](
+		c.caller,
+		c.requestTimeout,
+		blockSearchMethod,
+		map[string]any{
+			"query":    query,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+}
+
 func (c *RPCClient) Genesis() (*ctypes.ResultGenesis, error) {
 	return sendRequestCommon[ctypes.ResultGenesis](
 		c.caller,
@@ -311,6 +339,150 @@ func (c *RPCClient) Tx(hash []byte) (*ctypes.ResultTx, error) {
 	)
 }
 
+func (c *RPCClient) TxSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return sendRequestCommon[ctypes.ResultTxSearch](
+		c.caller,
+		c.requestTimeout,
+		txSearchMethod,
+		map[string]any{
+			"query":    query,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+}
+
+func (c *RPCClient) AccountTxs(address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return sendRequestCommon[ctypes.ResultTxSearch](
+		c.caller,
+		c.requestTimeout,
+		accountTxsMethod,
+		map[string]any{
+			"address":  address,
+			"page":     page,
+			"per_page": perPage,
+			"order_by": orderBy,
+		},
+	)
+}
+
+// Subscribe subscribes to the node events matching the query, and returns
+// the channel on which they are delivered. The channel must be drained until
+// it is closed, which happens on Unsubscribe, or when the node cancels the
+// subscription because the events were not read fast enough.
+// Subscriptions are only supported by the WS client
+func (c *RPCClient) Subscribe(query string) (<-chan ctypes.ResultEvent, error) {
+	subscriber, ok := c.caller.(rpcclient.Subscriber)
+	if !ok {
+		return nil, errSubscriptionsUnsupported
+	}
+
+	request, err := newRequest(subscribeMethod, map[string]any{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), c.requestTimeout)
+	defer cancelFn()
+
+	notifications, err := subscriber.Subscribe(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call RPC method %s, %w", subscribeMethod, err)
+	}
+
+	c.subscriptionsMux.Lock()
+	c.subscriptions[query] = request.ID
+	c.subscriptionsMux.Unlock()
+
+	events := make(chan ctypes.ResultEvent)
+
+	go func() {
+		defer close(events)
+
+		for notification := range notifications {
+			if notification.Error != nil {
+				// The subscription was cancelled by the node
+				c.forgetSubscription(query, request.ID)
+
+				return
+			}
+
+			event, err := unmarshalResponseBytes[ctypes.ResultEvent](notification.Result)
+			if err != nil {
+				continue
+			}
+
+			events <- *event
+		}
+	}()
+
+	return events, nil
+}
+
+// Unsubscribe cancels the subscription to the query
+func (c *RPCClient) Unsubscribe(query string) error {
+	subscriber, ok := c.caller.(rpcclient.Subscriber)
+	if !ok {
+		return errSubscriptionsUnsupported
+	}
+
+	c.subscriptionsMux.Lock()
+	id, ok := c.subscriptions[query]
+	delete(c.subscriptions, query)
+	c.subscriptionsMux.Unlock()
+
+	if ok {
+		subscriber.Unsubscribe(id)
+	}
+
+	_, err := sendRequestCommon[ctypes.ResultUnsubscribe](
+		c.caller,
+		c.requestTimeout,
+		unsubscribeMethod,
+		map[string]any{"query": query},
+	)
+
+	return err
+}
+
+// UnsubscribeAll cancels all the subscriptions of the client
+func (c *RPCClient) UnsubscribeAll() error {
+	subscriber, ok := c.caller.(rpcclient.Subscriber)
+	if !ok {
+		return errSubscriptionsUnsupported
+	}
+
+	c.subscriptionsMux.Lock()
+	subscriptions := c.subscriptions
+	c.subscriptions = make(map[string]rpctypes.JSONRPCID)
+	c.subscriptionsMux.Unlock()
+
+	for _, id := range subscriptions {
+		subscriber.Unsubscribe(id)
+	}
+
+	_, err := sendRequestCommon[ctypes.ResultUnsubscribe](
+		c.caller,
+		c.requestTimeout,
+		unsubscribeAllMethod,
+		map[string]any{},
+	)
+
+	return err
+}
+
+// forgetSubscription removes the subscription to the query,
+// if it was made by the request with the given ID
+func (c *RPCClient) forgetSubscription(query string, id rpctypes.JSONRPCID) {
+	c.subscriptionsMux.Lock()
+	defer c.subscriptionsMux.Unlock()
+
+	if c.subscriptions[query] == id {
+		delete(c.subscriptions, query)
+	}
+}
+
 func (c *RPCClient) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	params := map[string]any{}
 	if height != nil {
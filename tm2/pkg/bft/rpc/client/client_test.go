@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -761,6 +762,256 @@ func TestRPCClient_Tx(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+func TestRPCClient_TxSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query   = "tx.height = 10"
+		page    = 2
+		perPage = 5
+		orderBy = "desc"
+
+		expectedResult = &ctypes.ResultTxSearch{
+			Txs: []*ctypes.ResultTx{
+				{
+					Hash:   []byte("tx hash"),
+					Height: 10,
+				},
+			},
+			TotalCount: 6,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, query, params["query"])
+			assert.Equal(t, fmt.Sprintf("%d", page), params["page"])
+			assert.Equal(t, fmt.Sprintf("%d", perPage), params["per_page"])
+			assert.Equal(t, orderBy, params["order_by"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			txSearchMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.TxSearch(query, page, perPage, orderBy)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
+func TestRPCClient_AccountTxs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		address = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+		page    = 1
+		perPage = 10
+		orderBy = "desc"
+
+		expectedResult = &ctypes.ResultTxSearch{
+			Txs: []*ctypes.ResultTx{
+				{
+					Hash:   []byte("tx hash"),
+					Height: 10,
+				},
+			},
+			TotalCount: 1,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, address, params["address"])
+			assert.Equal(t, fmt.Sprintf("%d", page), params["page"])
+			assert.Equal(t, fmt.Sprintf("%d", perPage), params["per_page"])
+			assert.Equal(t, orderBy, params["order_by"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			accountTxsMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.AccountTxs(address, page, perPage, orderBy)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
+func TestRPCClient_BlockSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query   = "event.type = 'ValidatorAdded'"
+		page    = 2
+		perPage = 5
+		orderBy = "asc"
+
+		expectedResult = &ctypes.ResultBlockSearch{
+			Blocks: []*ctypes.ResultBlockEvents{
+				{
+					BlockMeta: &bfttypes.BlockMeta{
+						Header: bfttypes.Header{Height: 10},
+					},
+				},
+			},
+			TotalCount: 6,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, query, params["query"])
+			assert.Equal(t, fmt.Sprintf("%d", page), params["page"])
+			assert.Equal(t, fmt.Sprintf("%d", perPage), params["per_page"])
+			assert.Equal(t, orderBy, params["order_by"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			blockSearchMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.BlockSearch(query, page, perPage, orderBy)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
+func TestRPCClient_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported caller", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewRPCClient(&mockClient{})
+
+		_, err := c.Subscribe("tm.event = 'NewBlock'")
+		assert.ErrorIs(t, err, errSubscriptionsUnsupported)
+
+		assert.ErrorIs(t, c.Unsubscribe("tm.event = 'NewBlock'"), errSubscriptionsUnsupported)
+		assert.ErrorIs(t, c.UnsubscribeAll(), errSubscriptionsUnsupported)
+	})
+
+	t.Run("events delivered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			query = "tm.event = 'NewBlock'"
+
+			expectedEvent = ctypes.ResultEvent{
+				Query: query,
+				Type:  ctypes.EventNewBlock,
+				Event: bfttypes.EventNewBlock{
+					Block: &bfttypes.Block{Header: bfttypes.Header{Height: 10}},
+				},
+			}
+
+			notifications = make(chan types.RPCResponse, 2)
+		)
+
+		mockClient := &mockSubscriber{
+			mockClient: &mockClient{},
+			subscribeFn: func(_ context.Context, request types.RPCRequest) (<-chan types.RPCResponse, error) {
+				require.Equal(t, subscribeMethod, request.Method)
+
+				var params map[string]any
+				require.NoError(t, json.Unmarshal(request.Params, &params))
+				assert.Equal(t, query, params["query"])
+
+				id := types.NotificationID(request.ID)
+				notifications <- types.NewRPCSuccessResponse(id, expectedEvent)
+				notifications <- types.RPCInternalError(id, errors.New("cancelled"))
+				close(notifications)
+
+				return notifications, nil
+			},
+		}
+
+		c := NewRPCClient(mockClient)
+
+		events, err := c.Subscribe(query)
+		require.NoError(t, err)
+
+		// The events are delivered until the subscription is cancelled
+		var received []ctypes.ResultEvent
+		for event := range events {
+			received = append(received, event)
+		}
+
+		assert.Equal(t, []ctypes.ResultEvent{expectedEvent}, received)
+
+		c.subscriptionsMux.Lock()
+		assert.Empty(t, c.subscriptions)
+		c.subscriptionsMux.Unlock()
+	})
+
+	t.Run("unsubscribed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			query = "tm.event = 'Tx'"
+
+			subscriptionID types.JSONRPCID
+			unsubscribedID types.JSONRPCID
+		)
+
+		requestClient := generateMockRequestClient(
+			t,
+			unsubscribeMethod,
+			func(t *testing.T, params map[string]any) {
+				t.Helper()
+
+				assert.Equal(t, query, params["query"])
+			},
+			&ctypes.ResultUnsubscribe{},
+		)
+
+		mockClient := &mockSubscriber{
+			mockClient: requestClient,
+			subscribeFn: func(_ context.Context, request types.RPCRequest) (<-chan types.RPCResponse, error) {
+				subscriptionID = request.ID
+
+				return make(chan types.RPCResponse), nil
+			},
+			unsubscribeFn: func(id types.JSONRPCID) {
+				unsubscribedID = id
+			},
+		}
+
+		c := NewRPCClient(mockClient)
+
+		_, err := c.Subscribe(query)
+		require.NoError(t, err)
+
+		require.NoError(t, c.Unsubscribe(query))
+		assert.Equal(t, subscriptionID, unsubscribedID)
+	})
+}
+
 func TestRPCClient_Validators(t *testing.T) {
 	t.Parallel()
 
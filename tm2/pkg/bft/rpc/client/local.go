@@ -110,6 +110,10 @@ func (c *Local) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockc
 	return core.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
 
+func (c *Local) BlockSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	return core.BlockSearch(c.ctx, query, page, perPage, orderBy)
+}
+
 func (c *Local) Genesis() (*ctypes.ResultGenesis, error) {
 	return core.Genesis(c.ctx)
 }
@@ -133,3 +137,11 @@ func (c *Local) Validators(height *int64) (*ctypes.ResultValidators, error) {
 func (c *Local) Tx(hash []byte) (*ctypes.ResultTx, error) {
 	return core.Tx(c.ctx, hash)
 }
+
+func (c *Local) TxSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return core.TxSearch(c.ctx, query, page, perPage, orderBy)
+}
+
+func (c *Local) AccountTxs(address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return core.AccountTxs(c.ctx, address, page, perPage, orderBy)
+}
@@ -17,10 +17,7 @@ var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
 
 // Client wraps most important rpc calls a client would make.
 //
-// NOTE: Events cannot be subscribed to from the RPC APIs. For events
-// subscriptions and filters and queries, an external API must be used that
-// first synchronously consumes the events from the node's synchronous event
-// switch, or reads logged events from the filesystem.
+// NOTE: Events can only be subscribed to over WS, see EventsClient.
 type Client interface {
 	ABCIClient
 	HistoryClient
@@ -62,6 +59,7 @@ type SignClient interface {
 type HistoryClient interface {
 	Genesis() (*ctypes.ResultGenesis, error)
 	BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	BlockSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
 }
 
 // StatusClient provides access to general chain info.
@@ -87,4 +85,14 @@ type MempoolClient interface {
 
 type TxClient interface {
 	Tx(hash []byte) (*ctypes.ResultTx, error)
+	TxSearch(query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	AccountTxs(address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+}
+
+// EventsClient provides the subscriptions to the node events
+// matching a query, which are only available over WS.
+type EventsClient interface {
+	Subscribe(query string) (<-chan ctypes.ResultEvent, error)
+	Unsubscribe(query string) error
+	UnsubscribeAll() error
 }
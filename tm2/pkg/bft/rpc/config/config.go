@@ -68,6 +68,18 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `json:"max_header_bytes" toml:"max_header_bytes" comment:"Maximum size of request header, in bytes"`
 
+	// Maximum number of unique WebSocket clients subscribed to events.
+	// 0 - subscriptions are disabled.
+	MaxSubscriptionClients int `json:"max_subscription_clients" toml:"max_subscription_clients" comment:"Maximum number of unique WebSocket clients subscribed to events.\n 0 - subscriptions are disabled."`
+
+	// Maximum number of unique queries a WebSocket client can subscribe to.
+	MaxSubscriptionsPerClient int `json:"max_subscriptions_per_client" toml:"max_subscriptions_per_client" comment:"Maximum number of unique queries a WebSocket client can subscribe to."`
+
+	// Maximum number of events buffered for a subscription. When a client
+	// does not read its events fast enough and the buffer is full, the
+	// subscription is cancelled.
+	SubscriptionBufferSize int `json:"subscription_buffer_size" toml:"subscription_buffer_size" comment:"Maximum number of events buffered for a subscription.\n When a client does not read its events fast enough and the buffer is full,\n the subscription is cancelled."`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to tendermint's config directory.
 	//
@@ -103,6 +115,10 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		MaxSubscriptionClients:    100,
+		MaxSubscriptionsPerClient: 5,
+		SubscriptionBufferSize:    100,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -135,6 +151,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.MaxSubscriptionClients < 0 {
+		return errors.New("max_subscription_clients can't be negative")
+	}
+	if cfg.MaxSubscriptionsPerClient < 0 {
+		return errors.New("max_subscriptions_per_client can't be negative")
+	}
+	if cfg.SubscriptionBufferSize < 1 {
+		return errors.New("subscription_buffer_size must be positive")
+	}
 	return nil
 }
 
@@ -6,7 +6,10 @@ import (
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
 )
 
 // Get block headers for minHeight <= height <= maxHeight.
@@ -80,6 +83,12 @@ func BlockchainInfo(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.
 	if err != nil {
 		return nil, err
 	}
+	if base := blockStore.Base(); minHeight < base {
+		if maxHeight < base {
+			return nil, heightPrunedError(maxHeight, base)
+		}
+		minHeight = base
+	}
 	logger.Debug("BlockchainInfoHandler", "maxHeight", maxHeight, "minHeight", minHeight)
 
 	blockMetas := []*types.BlockMeta{}
@@ -332,7 +341,12 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 		return nil, err
 	}
 
-	header := blockStore.LoadBlockMeta(height).Header
+	blockMeta := blockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		// Pruned since the height was checked
+		return nil, heightPrunedError(height, blockStore.Base())
+	}
+	header := blockMeta.Header
 
 	// If the next block has not been committed yet,
 	// use a non-canonical commit
@@ -400,7 +414,7 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 // ```
 func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockResults, error) {
 	storeHeight := blockStore.Height()
-	height, err := getHeightWithMin(storeHeight, heightPtr, 0)
+	height, err := getHeightWithMin(blockStore.Base(), storeHeight, heightPtr, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -417,11 +431,71 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 	return res, nil
 }
 
+// BlockSearch allows you to search the blocks indexed by the kv event
+// store, with a query like "block.time >= 1700000000 AND event.type = 'ValidatorAdded'".
+// The results are paginated, and ordered by height like the ones of
+// BlockchainInfo, either descending ("desc", the default) or ascending ("asc").
+// The blocks pruned from the block store are not searched
+func BlockSearch(_ *rpctypes.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	searcher, ok := txEventStore.(eventstore.BlockSearcher)
+	if !ok {
+		return nil, errors.New("block search is disabled, the kv event store is not enabled")
+	}
+
+	desc := true
+	switch orderBy {
+	case "", "desc":
+	case "asc":
+		desc = false
+	default:
+		return nil, fmt.Errorf("invalid order_by %q, expected \"asc\" or \"desc\"", orderBy)
+	}
+
+	// The metas of the pruned blocks are not stored anymore
+	query = fmt.Sprintf("%s AND %s >= %d", query, kv.KeyBlockHeight, max(blockStore.Base(), 1))
+
+	// Only the requested page is loaded, while the index is iterated
+	perPage = validatePerPage(perPage)
+	skipCount := (max(page, 1) - 1) * perPage
+	pageHeights, totalCount, err := searcher.SearchBlocks(query, skipCount, perPage, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := validatePage(page, perPage, totalCount); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*ctypes.ResultBlockEvents, 0, len(pageHeights))
+	for _, height := range pageHeights {
+		result, err := searcher.GetBlock(height)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load block %d, %w", height, err)
+		}
+		if result == nil {
+			continue
+		}
+
+		blocks = append(blocks, &ctypes.ResultBlockEvents{
+			BlockMeta:        blockStore.LoadBlockMeta(height),
+			ResultBeginBlock: result.ResultBeginBlock,
+			ResultEndBlock:   result.ResultEndBlock,
+		})
+	}
+
+	return &ctypes.ResultBlockSearch{
+		Blocks:     blocks,
+		TotalCount: totalCount,
+	}, nil
+}
+
 func getHeight(currentHeight int64, heightPtr *int64) (int64, error) {
-	return getHeightWithMin(currentHeight, heightPtr, 1)
+	return getHeightWithMin(blockStore.Base(), currentHeight, heightPtr, 1)
 }
 
-func getHeightWithMin(currentHeight int64, heightPtr *int64, minVal int64) (int64, error) {
+// getHeightWithMin returns the requested height, or the current height if
+// not set. Heights below the base of the block store have been pruned
+func getHeightWithMin(base, currentHeight int64, heightPtr *int64, minVal int64) (int64, error) {
 	if heightPtr != nil {
 		height := *heightPtr
 		if height < minVal {
@@ -430,7 +504,15 @@ func getHeightWithMin(currentHeight int64, heightPtr *int64, minVal int64) (int6
 		if height > currentHeight {
 			return 0, fmt.Errorf("height must be less than or equal to the current blockchain height")
 		}
+		if height > 0 && height < base {
+			return 0, heightPrunedError(height, base)
+		}
 		return height, nil
 	}
 	return currentHeight, nil
 }
+
+// heightPrunedError is returned for the heights pruned from the node
+func heightPrunedError(height, base int64) error {
+	return fmt.Errorf("height %d is pruned, the lowest available height is %d", height, base)
+}
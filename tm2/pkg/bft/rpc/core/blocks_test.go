@@ -4,7 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
 )
 
 func TestBlockchainInfo(t *testing.T) {
@@ -60,6 +68,7 @@ func TestGetHeight(t *testing.T) {
 	t.Parallel()
 
 	cases := []struct {
+		base          int64
 		currentHeight int64
 		heightPtr     *int64
 		minVal        int64
@@ -67,19 +76,27 @@ func TestGetHeight(t *testing.T) {
 		wantErr       bool
 	}{
 		// height >= min
-		{42, int64Ptr(0), 0, 0, false},
-		{42, int64Ptr(1), 0, 1, false},
+		{1, 42, int64Ptr(0), 0, 0, false},
+		{1, 42, int64Ptr(1), 0, 1, false},
 
 		// height < min
-		{42, int64Ptr(0), 1, 0, true},
+		{1, 42, int64Ptr(0), 1, 0, true},
 
 		// nil height
-		{42, nil, 1, 42, false},
+		{1, 42, nil, 1, 42, false},
+
+		// height > current height
+		{1, 42, int64Ptr(43), 1, 0, true},
+
+		// pruned height
+		{10, 42, int64Ptr(9), 1, 0, true},
+		{10, 42, int64Ptr(10), 1, 10, false},
+		{10, 42, nil, 1, 42, false},
 	}
 
 	for i, c := range cases {
 		caseString := fmt.Sprintf("test %d failed", i)
-		res, err := getHeightWithMin(c.currentHeight, c.heightPtr, c.minVal)
+		res, err := getHeightWithMin(c.base, c.currentHeight, c.heightPtr, c.minVal)
 		if c.wantErr {
 			require.Error(t, err, caseString)
 		} else {
@@ -89,6 +106,108 @@ func TestGetHeight(t *testing.T) {
 	}
 }
 
+func TestPrunedHeights(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	SetLogger(log.NewNoopLogger())
+	SetBlockStore(&mockBlockStore{
+		baseFn:   func() int64 { return 10 },
+		heightFn: func() int64 { return 42 },
+	})
+
+	const expectedErr = "height 9 is pruned, the lowest available height is 10"
+
+	_, err := Block(nil, int64Ptr(9))
+	assert.EqualError(t, err, expectedErr)
+
+	_, err = Commit(nil, int64Ptr(9))
+	assert.EqualError(t, err, expectedErr)
+
+	_, err = BlockResults(nil, int64Ptr(9))
+	assert.EqualError(t, err, expectedErr)
+
+	_, err = BlockchainInfo(nil, 1, 9)
+	assert.EqualError(t, err, expectedErr)
+
+	// The block metas start at the base
+	res, err := BlockchainInfo(nil, 1, 12)
+	require.NoError(t, err)
+	assert.Len(t, res.BlockMetas, 3)
+}
+
+func TestBlockSearchHandler(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	t.Run("event store without search", func(t *testing.T) {
+		// Set the GLOBALLY referenced event store
+		SetTxEventStore(null.NewNullEventStore())
+
+		result, err := BlockSearch(nil, "block.height = 1", 0, 0, "")
+		require.Nil(t, result)
+
+		assert.ErrorContains(t, err, "block search is disabled")
+	})
+
+	t.Run("paginated and ordered results", func(t *testing.T) {
+		store := kv.NewTxEventStore(memdb.NewMemDB())
+
+		for height := int64(1); height <= 5; height++ {
+			require.NoError(t, store.AppendBlock(types.BlockResult{
+				Height: height,
+				ResultEndBlock: abci.ResponseEndBlock{
+					Events: []abci.Event{abci.EventString(fmt.Sprintf("block %d", height))},
+				},
+			}))
+		}
+
+		// Set the GLOBALLY referenced stores, the first block is pruned
+		SetTxEventStore(store)
+		SetBlockStore(&mockBlockStore{
+			baseFn: func() int64 { return 2 },
+			loadBlockMetaFn: func(height int64) *types.BlockMeta {
+				if height < 2 {
+					return nil
+				}
+
+				return &types.BlockMeta{Header: types.Header{Height: height}}
+			},
+		})
+
+		// The highest blocks come first by default
+		result, err := BlockSearch(nil, "block.height >= 1", 1, 3, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, result.TotalCount)
+		require.Len(t, result.Blocks, 3)
+		assert.Equal(t, int64(5), result.Blocks[0].BlockMeta.Header.Height)
+		assert.Equal(t, int64(3), result.Blocks[2].BlockMeta.Header.Height)
+		assert.Equal(t, abci.EventString("block 5"), result.Blocks[0].ResultEndBlock.Events[0])
+
+		// The pruned block is not searched
+		result, err = BlockSearch(nil, "block.height >= 1", 1, 3, "asc")
+		require.NoError(t, err)
+
+		require.Len(t, result.Blocks, 3)
+		assert.Equal(t, int64(2), result.Blocks[0].BlockMeta.Header.Height)
+		assert.Equal(t, abci.EventString("block 2"), result.Blocks[0].ResultEndBlock.Events[0])
+
+		result, err = BlockSearch(nil, "block.height >= 1", 2, 3, "asc")
+		require.NoError(t, err)
+
+		require.Len(t, result.Blocks, 1)
+		assert.Equal(t, int64(5), result.Blocks[0].BlockMeta.Header.Height)
+
+		_, err = BlockSearch(nil, "block.height >= 1", 3, 3, "asc")
+		assert.Error(t, err)
+
+		_, err = BlockSearch(nil, "block.height >= 1", 1, 3, "random")
+		assert.ErrorContains(t, err, "invalid order_by")
+
+		_, err = BlockSearch(nil, "tx.height >= 1", 1, 3, "")
+		assert.ErrorContains(t, err, "unknown key")
+	})
+}
+
 func int64Ptr(v int64) *int64 {
 	return &v
 }
@@ -64,6 +64,26 @@ JSONRPC requests can be POST'd to the root RPC endpoint via HTTP (e.g. `http://l
 
 JSONRPC requests can be made via websocket. The websocket endpoint is at `/websocket`, e.g. `localhost:26657/websocket`.
 
+Over websocket, the `subscribe`, `unsubscribe` and `unsubscribe_all` endpoints manage
+subscriptions to the NewBlock, Tx and GnoEvent events matching a query, like:
+
+```json
+
+	{
+		"method": "subscribe",
+		"jsonrpc": "2.0",
+		"params": [ "tx.height > 100 AND gno.pkg_path = 'gno.land/r/demo/boards'" ],
+		"id": "boards"
+	}
+
+```
+
+The matching events are notified with the ID of the request suffixed by `#event`, here `boards#event`.
+The query conditions are joined by `AND`, and can use the `tm.event` (`NewBlock`, `Tx` or `GnoEvent`)
+and `block.height` keys, the `tx_search` keys for the Tx and GnoEvent events, and the `gno.type`,
+`gno.pkg_path` and `gno.attrs.<key>` keys for the GnoEvent events.
+When a client does not read its events fast enough, its subscription is cancelled with an error notification.
+
 ## More Examples
 
 See the various bash tests using curl in `test/`, and examples using the `Go` API in `rpc/client/`.
@@ -0,0 +1,312 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/events"
+)
+
+// Subscription query keys. The Tx and GnoEvent events can also be
+// filtered with the keys of the kv event store: tx.height, tx.hash,
+// tx.signer, msg.type, msg.pkg_path, event.type and event.<type>.<attr>
+const (
+	keyEvent       = "tm.event"     // type of the event: NewBlock, Tx or GnoEvent
+	keyBlockHeight = "block.height" // height of the block
+	keyGnoType     = "gno.type"     // type of the Gno event
+	keyGnoPkgPath  = "gno.pkg_path" // path of the package emitting the Gno event
+
+	gnoAttrPrefix = "gno.attrs." // gno.attrs.<attr>, attribute of the Gno event
+)
+
+var errSlowClient = errors.New("subscription cancelled, the events are not read fast enough")
+
+// subscription is a query subscribed to by a WS client
+type subscription struct {
+	listenerID string
+	query      string
+	conds      []query.Condition
+	id         rpctypes.JSONRPCID // ID of the notifications
+
+	events chan ctypes.ResultEvent
+	quit   chan struct{}
+	once   sync.Once
+	slow   atomic.Bool // set when cancelled because of a full buffer
+}
+
+// subscriptions are the subscriptions of the WS clients,
+// by remote address and query
+var (
+	subscriptions    = make(map[string]map[string]*subscription)
+	subscriptionsMux sync.Mutex
+)
+
+// Subscribe subscribes the WS client to the events matching the query, like
+// "tm.event = 'GnoEvent' AND gno.pkg_path = 'gno.land/r/demo/boards'".
+// The matching events are notified to the client with the ID of the request,
+// suffixed by "#event". When the client does not read its events fast enough,
+// the subscription is cancelled with an error notification
+func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+	conds, err := parseSubscriptionQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := ctx.RemoteAddr()
+
+	subscriptionsMux.Lock()
+	defer subscriptionsMux.Unlock()
+
+	clientSubs, ok := subscriptions[addr]
+	if !ok && len(subscriptions) >= config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
+	}
+	if _, ok := clientSubs[query]; ok {
+		return nil, fmt.Errorf("already subscribed to %q", query)
+	}
+	if len(clientSubs) >= config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", config.MaxSubscriptionsPerClient)
+	}
+
+	sub := &subscription{
+		listenerID: fmt.Sprintf("rpc-subscription-%s-%s", addr, query),
+		query:      query,
+		conds:      conds,
+		id:         rpctypes.NotificationID(ctx.JSONReq.ID),
+		events:     make(chan ctypes.ResultEvent, config.SubscriptionBufferSize),
+		quit:       make(chan struct{}),
+	}
+
+	if clientSubs == nil {
+		clientSubs = make(map[string]*subscription)
+		subscriptions[addr] = clientSubs
+	}
+	clientSubs[query] = sub
+
+	evsw.AddListener(sub.listenerID, sub.onEvent)
+	go sub.notify(ctx.WSConn, addr)
+
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// Unsubscribe cancels the subscription of the WS client to the query
+func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
+	addr := ctx.RemoteAddr()
+
+	subscriptionsMux.Lock()
+	defer subscriptionsMux.Unlock()
+
+	sub, ok := subscriptions[addr][query]
+	if !ok {
+		return nil, fmt.Errorf("not subscribed to %q", query)
+	}
+
+	removeSubscription(addr, sub)
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeAll cancels all the subscriptions of the WS client
+func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
+	UnsubscribeClient(ctx.RemoteAddr())
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeClient cancels all the subscriptions of the WS client
+// with the given remote address, when it disconnects
+func UnsubscribeClient(addr string) {
+	subscriptionsMux.Lock()
+	defer subscriptionsMux.Unlock()
+
+	for _, sub := range subscriptions[addr] {
+		removeSubscription(addr, sub)
+	}
+}
+
+// removeSubscription stops the subscription, and removes it
+// from the subscriptions of the client. Expects subscriptionsMux to be held
+func removeSubscription(addr string, sub *subscription) {
+	sub.stop()
+
+	clientSubs := subscriptions[addr]
+	if clientSubs[sub.query] != sub {
+		return
+	}
+
+	delete(clientSubs, sub.query)
+	if len(clientSubs) == 0 {
+		delete(subscriptions, addr)
+	}
+}
+
+// onEvent is the event switch callback of the subscription,
+// which must not block
+func (s *subscription) onEvent(event events.Event) {
+	for _, n := range eventNotifications(event) {
+		if !s.match(n.attrs) {
+			continue
+		}
+
+		n.result.Query = s.query
+
+		select {
+		case s.events <- n.result:
+		default:
+			s.slow.Store(true)
+			s.stop()
+
+			return
+		}
+	}
+}
+
+// notify writes the events of the subscription to the WS connection,
+// until the subscription is stopped
+func (s *subscription) notify(conn rpctypes.WSRPCConnection, addr string) {
+	for {
+		select {
+		case <-s.quit:
+			if !s.slow.Load() {
+				return
+			}
+
+			subscriptionsMux.Lock()
+			removeSubscription(addr, s)
+			subscriptionsMux.Unlock()
+
+			conn.WriteRPCResponses(rpctypes.RPCResponses{rpctypes.RPCInternalError(s.id, errSlowClient)})
+
+			return
+		case result := <-s.events:
+			conn.WriteRPCResponses(rpctypes.RPCResponses{rpctypes.NewRPCSuccessResponse(s.id, result)})
+		}
+	}
+}
+
+func (s *subscription) stop() {
+	s.once.Do(func() {
+		evsw.RemoveListener(s.listenerID)
+		close(s.quit)
+	})
+}
+
+// match returns true if the attributes satisfy all the conditions of
+// the subscription. A condition is satisfied by any value of its key
+func (s *subscription) match(attrs map[string][]string) bool {
+	for _, cond := range s.conds {
+		if !slices.ContainsFunc(attrs[cond.Key], cond.Match) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// notification is an event to notify, with the attributes
+// the subscription queries are matched against
+type notification struct {
+	result ctypes.ResultEvent
+	attrs  map[string][]string
+}
+
+// eventNotifications returns the notifications of the event switch event:
+// one for a new block, and for a transaction, one for the transaction
+// and one for each Gno event it emitted
+func eventNotifications(event events.Event) []notification {
+	switch ev := event.(type) {
+	case types.EventNewBlock:
+		return []notification{{
+			result: ctypes.ResultEvent{Type: ctypes.EventNewBlock, Event: ev},
+			attrs: map[string][]string{
+				keyEvent:       {ctypes.EventNewBlock},
+				keyBlockHeight: {strconv.FormatInt(ev.Block.Height, 10)},
+			},
+		}}
+	case types.EventTx:
+		height := strconv.FormatInt(ev.Result.Height, 10)
+		txAttrs := map[string][]string{
+			keyEvent:       {ctypes.EventTx},
+			keyBlockHeight: {height},
+			kv.KeyHeight:   {height},
+			kv.KeyHash:     {fmt.Sprintf("%X", ev.Result.Tx.Hash())},
+		}
+		for _, tag := range kv.TxTags(ev.Result) {
+			txAttrs[tag.Key] = append(txAttrs[tag.Key], tag.Value)
+		}
+
+		notifications := []notification{{
+			result: ctypes.ResultEvent{Type: ctypes.EventTx, Event: ev},
+			attrs:  txAttrs,
+		}}
+
+		for _, gnoEv := range ev.Result.Response.Events {
+			e, ok := kv.DecodeEvent(gnoEv)
+			if !ok {
+				continue
+			}
+
+			attrs := maps.Clone(txAttrs)
+			attrs[keyEvent] = []string{ctypes.EventGno}
+			attrs[keyGnoType] = []string{e.Type}
+			attrs[keyGnoPkgPath] = []string{e.PkgPath}
+			for _, attr := range e.Attrs {
+				attrs[gnoAttrPrefix+attr.Key] = append(attrs[gnoAttrPrefix+attr.Key], attr.Value)
+			}
+
+			notifications = append(notifications, notification{
+				result: ctypes.ResultEvent{Type: ctypes.EventGno, Event: ev, GnoEvent: gnoEv},
+				attrs:  attrs,
+			})
+		}
+
+		return notifications
+	}
+
+	return nil
+}
+
+// parseSubscriptionQuery parses the subscription query,
+// and validates its keys and values
+func parseSubscriptionQuery(q string) ([]query.Condition, error) {
+	conds, err := query.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cond := range conds {
+		integer := cond.Key == keyBlockHeight || cond.Key == kv.KeyHeight
+
+		switch {
+		case integer:
+		case cond.Key == keyEvent:
+			if cond.Value != ctypes.EventNewBlock && cond.Value != ctypes.EventTx && cond.Value != ctypes.EventGno {
+				return nil, fmt.Errorf("unknown event type %q", cond.Value)
+			}
+		case cond.Key == kv.KeyHash, cond.Key == kv.KeySigner, cond.Key == kv.KeyMsgType,
+			cond.Key == kv.KeyPkgPath, cond.Key == kv.KeyEvent,
+			cond.Key == keyGnoType, cond.Key == keyGnoPkgPath:
+		case strings.HasPrefix(cond.Key, "event.") && strings.Count(cond.Key, ".") >= 2:
+		case strings.HasPrefix(cond.Key, gnoAttrPrefix) && len(cond.Key) > len(gnoAttrPrefix):
+		default:
+			return nil, fmt.Errorf("unknown key %q", cond.Key)
+		}
+
+		if cond.Integer != integer {
+			return nil, fmt.Errorf("invalid value for %q", cond.Key)
+		}
+	}
+
+	return conds, nil
+}
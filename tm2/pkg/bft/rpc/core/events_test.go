@@ -0,0 +1,317 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+)
+
+// testGnoEvent has the same JSON representation as the std.Emit events
+type testGnoEvent struct {
+	Type       string             `json:"type"`
+	Attributes []testGnoEventAttr `json:"attrs"`
+	PkgPath    string             `json:"pkg_path"`
+}
+
+type testGnoEventAttr struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (testGnoEvent) AssertABCIEvent() {}
+
+var _ = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/core",
+	"core",
+	amino.GetCallersDirname(),
+).
+	WithDependencies(
+		abci.Package,
+	).
+	WithTypes(
+		testGnoEvent{},
+		testGnoEventAttr{},
+	))
+
+// mockWSConn is a WS connection recording the written responses
+type mockWSConn struct {
+	addr      string
+	responses chan rpctypes.RPCResponse
+}
+
+func newMockWSConn(addr string, capacity int) *mockWSConn {
+	return &mockWSConn{
+		addr:      addr,
+		responses: make(chan rpctypes.RPCResponse, capacity),
+	}
+}
+
+func (m *mockWSConn) GetRemoteAddr() string {
+	return m.addr
+}
+
+func (m *mockWSConn) WriteRPCResponses(responses rpctypes.RPCResponses) {
+	for _, response := range responses {
+		m.responses <- response
+	}
+}
+
+func (m *mockWSConn) TryWriteRPCResponses(responses rpctypes.RPCResponses) bool {
+	m.WriteRPCResponses(responses)
+
+	return true
+}
+
+func (m *mockWSConn) Context() context.Context {
+	return context.Background()
+}
+
+// context returns the context of a request sent over the connection
+func (m *mockWSConn) context(id string) *rpctypes.Context {
+	return &rpctypes.Context{
+		JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCStringID(id)},
+		WSConn:  m,
+	}
+}
+
+// next returns the next event notified on the connection
+func (m *mockWSConn) next(t *testing.T) (rpctypes.RPCResponse, ctypes.ResultEvent) {
+	t.Helper()
+
+	select {
+	case response := <-m.responses:
+		var result ctypes.ResultEvent
+		if response.Error == nil {
+			require.NoError(t, amino.UnmarshalJSON(response.Result, &result))
+		}
+
+		return response, result
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+
+	return rpctypes.RPCResponse{}, ctypes.ResultEvent{}
+}
+
+// setupSubscriptions sets up the event switch and limits of the subscriptions
+func setupSubscriptions(t *testing.T, maxClients, maxPerClient, bufferSize int) {
+	t.Helper()
+
+	evsw = events.NewEventSwitch()
+	config = cfg.RPCConfig{
+		MaxSubscriptionClients:    maxClients,
+		MaxSubscriptionsPerClient: maxPerClient,
+		SubscriptionBufferSize:    bufferSize,
+	}
+
+	t.Cleanup(func() {
+		for addr := range subscriptions {
+			UnsubscribeClient(addr)
+		}
+	})
+}
+
+func newTestEventTx(height int64, events ...abci.Event) types.EventTx {
+	return types.EventTx{
+		Result: types.TxResult{
+			Height: height,
+			Tx:     types.Tx("tx"),
+			Response: abci.ResponseDeliverTx{
+				ResponseBase: abci.ResponseBase{
+					Events: events,
+				},
+			},
+		},
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	t.Run("notifies the matching events", func(t *testing.T) {
+		setupSubscriptions(t, 10, 10, 10)
+
+		var (
+			conn  = newMockWSConn("client", 10)
+			query = "tx.height > 100 AND gno.pkg_path = 'gno.land/r/demo/boards'"
+
+			boardsEvent = testGnoEvent{
+				Type:       "PostCreated",
+				Attributes: []testGnoEventAttr{{Key: "board", Value: "1"}},
+				PkgPath:    "gno.land/r/demo/boards",
+			}
+			usersEvent = testGnoEvent{
+				Type:    "Registered",
+				PkgPath: "gno.land/r/demo/users",
+			}
+		)
+
+		_, err := Subscribe(conn.context("1"), query)
+		require.NoError(t, err)
+
+		evsw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 101}}})
+		evsw.FireEvent(newTestEventTx(100, boardsEvent))
+		evsw.FireEvent(newTestEventTx(101, usersEvent, boardsEvent))
+
+		response, result := conn.next(t)
+		assert.Equal(t, rpctypes.JSONRPCStringID("1#event"), response.ID)
+		assert.Equal(t, query, result.Query)
+		assert.Equal(t, ctypes.EventGno, result.Type)
+		assert.Equal(t, boardsEvent, result.GnoEvent)
+		require.IsType(t, types.EventTx{}, result.Event)
+		assert.Equal(t, int64(101), result.Event.(types.EventTx).Result.Height)
+
+		assert.Empty(t, conn.responses)
+	})
+
+	t.Run("notifies the event types", func(t *testing.T) {
+		setupSubscriptions(t, 10, 10, 10)
+
+		conn := newMockWSConn("client", 10)
+
+		_, err := Subscribe(conn.context("blocks"), "tm.event = 'NewBlock' AND block.height >= 2")
+		require.NoError(t, err)
+		_, err = Subscribe(conn.context("txs"), "tm.event = 'Tx'")
+		require.NoError(t, err)
+
+		evsw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 1}}})
+		evsw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 2}}})
+
+		response, result := conn.next(t)
+		assert.Equal(t, rpctypes.JSONRPCStringID("blocks#event"), response.ID)
+		assert.Equal(t, ctypes.EventNewBlock, result.Type)
+		assert.Equal(t, int64(2), result.Event.(types.EventNewBlock).Block.Height)
+
+		evsw.FireEvent(newTestEventTx(2, testGnoEvent{Type: "Transfer"}))
+
+		response, result = conn.next(t)
+		assert.Equal(t, rpctypes.JSONRPCStringID("txs#event"), response.ID)
+		assert.Equal(t, ctypes.EventTx, result.Type)
+		assert.Nil(t, result.GnoEvent)
+
+		assert.Empty(t, conn.responses)
+	})
+
+	t.Run("unsubscribes", func(t *testing.T) {
+		setupSubscriptions(t, 10, 10, 10)
+
+		conn := newMockWSConn("client", 10)
+
+		_, err := Subscribe(conn.context("1"), "tm.event = 'NewBlock'")
+		require.NoError(t, err)
+		_, err = Subscribe(conn.context("2"), "tm.event = 'Tx'")
+		require.NoError(t, err)
+
+		_, err = Unsubscribe(conn.context("3"), "tm.event = 'NewBlock'")
+		require.NoError(t, err)
+
+		_, err = Unsubscribe(conn.context("4"), "tm.event = 'NewBlock'")
+		assert.Error(t, err)
+
+		evsw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 1}}})
+		evsw.FireEvent(newTestEventTx(1))
+
+		response, _ := conn.next(t)
+		assert.Equal(t, rpctypes.JSONRPCStringID("2#event"), response.ID)
+
+		_, err = UnsubscribeAll(conn.context("5"))
+		require.NoError(t, err)
+		assert.Empty(t, subscriptions)
+
+		evsw.FireEvent(newTestEventTx(2))
+		assert.Empty(t, conn.responses)
+	})
+
+	t.Run("enforces the limits", func(t *testing.T) {
+		setupSubscriptions(t, 1, 1, 10)
+
+		var (
+			conn  = newMockWSConn("client", 10)
+			other = newMockWSConn("other", 10)
+		)
+
+		_, err := Subscribe(conn.context("1"), "tm.event = 'NewBlock'")
+		require.NoError(t, err)
+
+		_, err = Subscribe(conn.context("2"), "tm.event = 'NewBlock'")
+		assert.ErrorContains(t, err, "already subscribed")
+
+		_, err = Subscribe(conn.context("3"), "tm.event = 'Tx'")
+		assert.ErrorContains(t, err, "max_subscriptions_per_client")
+
+		_, err = Subscribe(other.context("1"), "tm.event = 'Tx'")
+		assert.ErrorContains(t, err, "max_subscription_clients")
+
+		UnsubscribeClient("client")
+
+		_, err = Subscribe(other.context("1"), "tm.event = 'Tx'")
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancels the slow subscriptions", func(t *testing.T) {
+		setupSubscriptions(t, 10, 10, 2)
+
+		// The connection does not accept any response,
+		// until the subscription buffer is full
+		conn := newMockWSConn("client", 0)
+
+		_, err := Subscribe(conn.context("1"), "tm.event = 'NewBlock'")
+		require.NoError(t, err)
+
+		for height := int64(1); height <= 4; height++ {
+			evsw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: height}}})
+		}
+
+		// Some buffered events can be notified before the cancellation
+		var response rpctypes.RPCResponse
+		for response.Error == nil {
+			response, _ = conn.next(t)
+		}
+
+		assert.Equal(t, rpctypes.JSONRPCStringID("1#event"), response.ID)
+		assert.Contains(t, response.Error.Data, errSlowClient.Error())
+
+		subscriptionsMux.Lock()
+		assert.Empty(t, subscriptions)
+		subscriptionsMux.Unlock()
+	})
+}
+
+func TestParseSubscriptionQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{
+		"tm.event = 'GnoEvent'",
+		"tx.height > 100 AND gno.pkg_path = 'gno.land/r/demo/boards'",
+		"block.height >= 10 AND tm.event = 'NewBlock'",
+		"tx.signer = 'g1abc' AND msg.pkg_path = 'gno.land/r/demo/boards'",
+		"event.Transfer.to = 'g1abc' AND gno.type = 'Transfer' AND gno.attrs.to = 'g1abc'",
+	} {
+		_, err := parseSubscriptionQuery(query)
+		assert.NoError(t, err, query)
+	}
+
+	for _, query := range []string{
+		"",
+		"tm.event = 'Vote'",
+		"tm.event = 1",
+		"tx.height = '10'",
+		"gno.pkg_path > 'a'",
+		"gno.attrs. = 'a'",
+		"unknown.key = 'a'",
+	} {
+		_, err := parseSubscriptionQuery(query)
+		assert.Error(t, err, query)
+	}
+}
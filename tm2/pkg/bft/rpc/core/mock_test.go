@@ -3,6 +3,7 @@ package core
 import "github.com/gnolang/gno/tm2/pkg/bft/types"
 
 type (
+	baseDelegate            func() int64
 	heightDelegate          func() int64
 	loadBlockMetaDelegate   func(int64) *types.BlockMeta
 	loadBlockDelegate       func(int64) *types.Block
@@ -10,17 +11,30 @@ type (
 	loadBlockCommitDelegate func(int64) *types.Commit
 	loadSeenCommitDelegate  func(int64) *types.Commit
 
-	saveBlockDelegate func(*types.Block, *types.PartSet, *types.Commit)
+	saveBlockDelegate         func(*types.Block, *types.PartSet, *types.Commit)
+	pruneBlocksDelegate       func(int64) (uint64, error)
+	deleteLatestBlockDelegate func() error
 )
 
 type mockBlockStore struct {
-	heightFn          heightDelegate
-	loadBlockMetaFn   loadBlockMetaDelegate
-	loadBlockFn       loadBlockDelegate
-	loadBlockPartFn   loadBlockPartDelegate
-	loadBlockCommitFn loadBlockCommitDelegate
-	loadSeenCommitFn  loadSeenCommitDelegate
-	saveBlockFn       saveBlockDelegate
+	baseFn              baseDelegate
+	heightFn            heightDelegate
+	loadBlockMetaFn     loadBlockMetaDelegate
+	loadBlockFn         loadBlockDelegate
+	loadBlockPartFn     loadBlockPartDelegate
+	loadBlockCommitFn   loadBlockCommitDelegate
+	loadSeenCommitFn    loadSeenCommitDelegate
+	saveBlockFn         saveBlockDelegate
+	pruneBlocksFn       pruneBlocksDelegate
+	deleteLatestBlockFn deleteLatestBlockDelegate
+}
+
+func (m *mockBlockStore) Base() int64 {
+	if m.baseFn != nil {
+		return m.baseFn()
+	}
+
+	return 0
 }
 
 func (m *mockBlockStore) Height() int64 {
@@ -76,3 +90,19 @@ func (m *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet
 		m.saveBlockFn(block, blockParts, seenCommit)
 	}
 }
+
+func (m *mockBlockStore) PruneBlocks(retainHeight int64) (uint64, error) {
+	if m.pruneBlocksFn != nil {
+		return m.pruneBlocksFn(retainHeight)
+	}
+
+	return 0, nil
+}
+
+func (m *mockBlockStore) DeleteLatestBlock() error {
+	if m.deleteLatestBlockFn != nil {
+		return m.deleteLatestBlockFn()
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -67,6 +68,7 @@ var (
 	evsw          events.EventSwitch
 	gTxDispatcher *txDispatcher
 	mempool       mempl.Mempool
+	txEventStore  eventstore.TxEventStore
 	getFastSync   func() bool // avoids dependency on consensus pkg
 
 	logger *slog.Logger
@@ -86,6 +88,10 @@ func SetMempool(mem mempl.Mempool) {
 	mempool = mem
 }
 
+func SetTxEventStore(store eventstore.TxEventStore) {
+	txEventStore = store
+}
+
 func SetConsensusState(cs Consensus) {
 	consensusState = cs
 }
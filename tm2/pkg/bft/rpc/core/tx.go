@@ -6,6 +6,10 @@ import (
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
 )
 
 // Tx allows you to query the transaction results. `nil` could mean the
@@ -26,6 +30,10 @@ func Tx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTx, error) {
 
 	// Load the block
 	block := blockStore.LoadBlock(height)
+	if block == nil {
+		// Pruned since the height was checked
+		return nil, heightPrunedError(height, blockStore.Base())
+	}
 	numTxs := len(block.Txs)
 
 	if int(resultIndex.TxIndex) > numTxs || numTxs == 0 {
@@ -64,3 +72,73 @@ func Tx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTx, error) {
 		Tx:       rawTx,
 	}, nil
 }
+
+// TxSearch allows you to search the transactions indexed by the kv event
+// store, with a query like "tx.signer = 'g1...' AND tx.height > 10".
+// The results are paginated, and ordered by height and index, either
+// ascending ("asc", the default) or descending ("desc")
+func TxSearch(_ *rpctypes.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	searcher, ok := txEventStore.(eventstore.TxSearcher)
+	if !ok {
+		return nil, errors.New("transaction search is disabled, the kv event store is not enabled")
+	}
+
+	var desc bool
+	switch orderBy {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return nil, fmt.Errorf("invalid order_by %q, expected \"asc\" or \"desc\"", orderBy)
+	}
+
+	// Only the requested page is loaded, while the index is iterated
+	perPage = validatePerPage(perPage)
+	skipCount := (max(page, 1) - 1) * perPage
+	pageHashes, totalCount, err := searcher.Search(query, skipCount, perPage, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := validatePage(page, perPage, totalCount); err != nil {
+		return nil, err
+	}
+
+	txs := make([]*ctypes.ResultTx, 0, len(pageHashes))
+	for _, hash := range pageHashes {
+		result, err := searcher.GetTx(hash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load transaction %X, %w", hash, err)
+		}
+		if result == nil {
+			continue
+		}
+
+		txs = append(txs, &ctypes.ResultTx{
+			Hash:     hash,
+			Height:   result.Height,
+			Index:    result.Index,
+			TxResult: result.Response,
+			Tx:       result.Tx,
+		})
+	}
+
+	return &ctypes.ResultTxSearch{
+		Txs:        txs,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// AccountTxs allows you to page the history of an account: the transactions
+// it signed, or receiving coins to it, as indexed by the kv event store.
+// They include the messages executed on behalf of the account (authz), and
+// the transactions signed by its session keys, as the account signs their
+// messages. The results are ordered like the ones of TxSearch
+func AccountTxs(ctx *rpctypes.Context, address string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	addr, err := crypto.AddressFromBech32(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account address %q, %w", address, err)
+	}
+
+	return TxSearch(ctx, fmt.Sprintf("%s = '%s'", kv.KeyAccount, addr), page, perPage, orderBy)
+}
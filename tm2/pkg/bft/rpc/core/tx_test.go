@@ -1,13 +1,18 @@
 package core
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -220,3 +225,109 @@ func TestTxHandler(t *testing.T) {
 		assert.ErrorContains(t, err, "unable to load block results")
 	})
 }
+
+func TestTxSearchHandler(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	t.Run("event store without search", func(t *testing.T) {
+		// Set the GLOBALLY referenced event store
+		SetTxEventStore(null.NewNullEventStore())
+
+		result, err := TxSearch(nil, "tx.height = 1", 0, 0, "")
+		require.Nil(t, result)
+
+		assert.ErrorContains(t, err, "transaction search is disabled")
+	})
+
+	t.Run("paginated and ordered results", func(t *testing.T) {
+		store := kv.NewTxEventStore(memdb.NewMemDB())
+
+		txs := make([]types.Tx, 5)
+		for i := range txs {
+			marshalledTx, err := amino.Marshal(&std.Tx{
+				Memo: fmt.Sprintf("tx %d", i),
+			})
+			require.NoError(t, err)
+
+			txs[i] = marshalledTx
+			require.NoError(t, store.Append(types.TxResult{
+				Height: int64(i + 1),
+				Tx:     txs[i],
+			}))
+		}
+
+		// Set the GLOBALLY referenced event store
+		SetTxEventStore(store)
+
+		result, err := TxSearch(nil, "tx.height >= 2", 2, 3, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, result.TotalCount)
+		require.Len(t, result.Txs, 1)
+		assert.Equal(t, txs[4], result.Txs[0].Tx)
+		assert.Equal(t, int64(5), result.Txs[0].Height)
+
+		result, err = TxSearch(nil, "tx.height >= 2", 1, 3, "desc")
+		require.NoError(t, err)
+
+		require.Len(t, result.Txs, 3)
+		assert.Equal(t, txs[4], result.Txs[0].Tx)
+		assert.Equal(t, txs[2], result.Txs[2].Tx)
+
+		_, err = TxSearch(nil, "tx.height >= 2", 1, 3, "random")
+		assert.ErrorContains(t, err, "invalid order_by")
+
+		_, err = TxSearch(nil, "tx.height >=", 1, 3, "")
+		assert.ErrorContains(t, err, "invalid query")
+	})
+}
+
+func TestAccountTxsHandler(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	var (
+		alice = crypto.AddressFromPreimage([]byte("alice"))
+		bob   = crypto.AddressFromPreimage([]byte("bob"))
+		carol = crypto.AddressFromPreimage([]byte("carol"))
+
+		store = kv.NewTxEventStore(memdb.NewMemDB())
+		txs   = make([]types.Tx, 4)
+	)
+
+	senders := []crypto.Address{alice, bob, alice, carol}
+	for i := range txs {
+		marshalledTx, err := amino.Marshal(&std.Tx{
+			Msgs: []std.Msg{bank.MsgSend{FromAddress: senders[i], ToAddress: bob}},
+		})
+		require.NoError(t, err)
+
+		txs[i] = marshalledTx
+		require.NoError(t, store.Append(types.TxResult{
+			Height: int64(i + 1),
+			Tx:     txs[i],
+		}))
+	}
+
+	// Set the GLOBALLY referenced event store
+	SetTxEventStore(store)
+
+	result, err := AccountTxs(nil, alice.String(), 1, 10, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.TotalCount)
+	require.Len(t, result.Txs, 2)
+	assert.Equal(t, txs[0], result.Txs[0].Tx)
+	assert.Equal(t, txs[2], result.Txs[1].Tx)
+
+	// The receiver of all the sends
+	result, err = AccountTxs(nil, bob.String(), 1, 3, "desc")
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.TotalCount)
+	require.Len(t, result.Txs, 3)
+	assert.Equal(t, txs[3], result.Txs[0].Tx)
+	assert.Equal(t, txs[1], result.Txs[2].Tx)
+
+	_, err = AccountTxs(nil, "invalid", 1, 3, "")
+	assert.ErrorContains(t, err, "invalid account address")
+}
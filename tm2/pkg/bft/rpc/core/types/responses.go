@@ -177,6 +177,20 @@ type ResultTxSearch struct {
 	TotalCount int         `json:"total_count"`
 }
 
+// Result of searching for blocks
+type ResultBlockSearch struct {
+	Blocks     []*ResultBlockEvents `json:"blocks"`
+	TotalCount int                  `json:"total_count"`
+}
+
+// A block found by a search, with the events of its BeginBlock and EndBlock.
+// The block meta is nil if the block is pruned
+type ResultBlockEvents struct {
+	BlockMeta        *types.BlockMeta        `json:"block_meta"`
+	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
+	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+}
+
 // List of mempool txs
 type ResultUnconfirmedTxs struct {
 	Count      int        `json:"n_txs"`
@@ -200,9 +214,23 @@ type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
 	ResultHealth             struct{}
+	ResultSubscribe          struct{}
+	ResultUnsubscribe        struct{}
 )
 
-// Event data from a subscription
+// Event data from a subscription, matching its query.
+// Type is one of EventNewBlock, EventTx or EventGno
 type ResultEvent struct {
-	Event types.TMEvent `json:"event"`
+	Query string        `json:"query"`
+	Type  string        `json:"type"`
+	Event types.TMEvent `json:"event"` // the block, or the transaction emitting the Gno event
+
+	GnoEvent abci.Event `json:"gno_event,omitempty"` // only set for EventGno
 }
+
+// Types of the subscription events
+const (
+	EventNewBlock = "NewBlock"
+	EventTx       = "Tx"
+	EventGno      = "GnoEvent"
+)
@@ -18,6 +18,18 @@ type Client interface {
 	Close() error
 }
 
+// Subscriber is the abstraction of the JSON-RPC clients
+// receiving subscription notifications, like the WS client
+type Subscriber interface {
+	// Subscribe sends the subscription request, and returns
+	// the channel on which its notifications are delivered
+	Subscribe(context.Context, types.RPCRequest) (<-chan types.RPCResponse, error)
+
+	// Unsubscribe stops the delivery of the notifications of the
+	// subscription made by the request with the given ID
+	Unsubscribe(types.JSONRPCID)
+}
+
 // Batch is the JSON-RPC batch abstraction
 type Batch interface {
 	// AddRequest adds a single request to the RPC batch
@@ -20,8 +20,49 @@ var (
 	ErrInvalidBatchResponse      = errors.New("invalid ws batch response size")
 )
 
+// notificationChanCapacity is the capacity of the notification channel of a subscription
+const notificationChanCapacity = 100
+
 type responseCh chan<- types.RPCResponses
 
+// subscription delivers the notifications of a subscription
+type subscription struct {
+	ch     chan types.RPCResponse
+	quit   chan struct{}
+	mux    sync.Mutex // guards the closing of ch
+	closed bool
+	once   sync.Once
+}
+
+// deliver delivers the notification, unless the subscription is closed
+func (s *subscription) deliver(ctx context.Context, notification types.RPCResponse) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.ch <- notification:
+	case <-s.quit:
+	case <-ctx.Done():
+	}
+}
+
+// close closes the notification channel, once any pending delivery is dropped
+func (s *subscription) close() {
+	s.once.Do(func() {
+		close(s.quit)
+
+		s.mux.Lock()
+		defer s.mux.Unlock()
+
+		s.closed = true
+		close(s.ch)
+	})
+}
+
 // Client is a WebSocket client implementation
 type Client struct {
 	ctx           context.Context
@@ -34,6 +75,9 @@ type Client struct {
 
 	requestMap    map[string]responseCh
 	requestMapMux sync.Mutex
+
+	subscriptionMap    map[string]*subscription // notification ID -> subscription
+	subscriptionMapMux sync.Mutex
 }
 
 // NewClient initializes and creates a new WS RPC client
@@ -45,10 +89,11 @@ func NewClient(rpcURL string, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		conn:       conn,
-		requestMap: make(map[string]responseCh),
-		backlog:    make(chan any, 1),
-		logger:     log.NewNoopLogger(),
+		conn:            conn,
+		requestMap:      make(map[string]responseCh),
+		subscriptionMap: make(map[string]*subscription),
+		backlog:         make(chan any, 1),
+		logger:          log.NewNoopLogger(),
 	}
 
 	ctx, cancelFn := context.WithCancelCause(context.Background())
@@ -153,6 +198,86 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 	}
 }
 
+// Subscribe sends the subscription request to the server, and returns the channel
+// on which the notifications of the subscription are delivered, in order.
+// The channel must be drained, as the notifications are delivered synchronously.
+// It is closed when the subscription is cancelled, either by Unsubscribe,
+// by an error notification of the server, or when the client is closed
+func (c *Client) Subscribe(ctx context.Context, request types.RPCRequest) (<-chan types.RPCResponse, error) {
+	var (
+		notificationID = types.NotificationID(request.ID).String()
+		sub            = &subscription{
+			ch:   make(chan types.RPCResponse, notificationChanCapacity),
+			quit: make(chan struct{}),
+		}
+	)
+
+	// Register the subscription before sending the request,
+	// as notifications can be received before the response
+	c.subscriptionMapMux.Lock()
+	if _, ok := c.subscriptionMap[notificationID]; ok {
+		c.subscriptionMapMux.Unlock()
+
+		return nil, fmt.Errorf("subscription with ID %s already exists", request.ID)
+	}
+	c.subscriptionMap[notificationID] = sub
+	c.subscriptionMapMux.Unlock()
+
+	response, err := c.SendRequest(ctx, request)
+	if err == nil && response.Error != nil {
+		err = response.Error
+	}
+
+	if err != nil {
+		c.Unsubscribe(request.ID)
+
+		return nil, err
+	}
+
+	return sub.ch, nil
+}
+
+// Unsubscribe stops the delivery of the notifications of the subscription
+// made by the request with the given ID, and closes its channel.
+// The server subscription must be cancelled separately
+func (c *Client) Unsubscribe(id types.JSONRPCID) {
+	notificationID := types.NotificationID(id).String()
+
+	c.subscriptionMapMux.Lock()
+	sub, ok := c.subscriptionMap[notificationID]
+	delete(c.subscriptionMap, notificationID)
+	c.subscriptionMapMux.Unlock()
+
+	if ok {
+		sub.close()
+	}
+}
+
+// notify delivers the notification to its subscription, if any.
+// An error notification cancels the subscription
+func (c *Client) notify(ctx context.Context, notification types.RPCResponse) bool {
+	notificationID := notification.ID.String()
+
+	c.subscriptionMapMux.Lock()
+	sub, ok := c.subscriptionMap[notificationID]
+	if ok && notification.Error != nil {
+		delete(c.subscriptionMap, notificationID)
+	}
+	c.subscriptionMapMux.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	sub.deliver(ctx, notification)
+
+	if notification.Error != nil {
+		sub.close()
+	}
+
+	return true
+}
+
 // generateIDHash generates a unique hash from the given IDs
 func generateIDHash(ids ...string) string {
 	hash := fnv.New128()
@@ -187,6 +312,9 @@ func (c *Client) runWriteRoutine(ctx context.Context) {
 
 // runReadRoutine runs the client <- server read routine
 func (c *Client) runReadRoutine(ctx context.Context) {
+	// The subscriptions end with the read routine
+	defer c.closeSubscriptions()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -231,6 +359,11 @@ func (c *Client) runReadRoutine(ctx context.Context) {
 				continue
 			}
 
+			// Check if this is a subscription notification
+			if response.ID != nil && c.notify(ctx, response) {
+				continue
+			}
+
 			// This is a single response, generate the unique ID
 			responseHash = generateIDHash(response.ID.String())
 			responses = types.RPCResponses{response}
@@ -276,6 +409,18 @@ func (c *Client) Close() error {
 	return c.closeWithCause(nil)
 }
 
+// closeSubscriptions closes the channels of all the subscriptions
+func (c *Client) closeSubscriptions() {
+	c.subscriptionMapMux.Lock()
+	subs := c.subscriptionMap
+	c.subscriptionMap = make(map[string]*subscription)
+	c.subscriptionMapMux.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
 // closeWithCause closes the client (and any open connection)
 // with the given cause
 func (c *Client) closeWithCause(err error) error {
@@ -3,6 +3,7 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -300,3 +301,93 @@ func TestClient_SendBatch(t *testing.T) {
 		assert.Equal(t, response.Error, resp[0].Error)
 	})
 }
+
+func TestClient_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader = websocket.Upgrader{}
+
+		request = types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCStringID("id"),
+			Method:  "subscribe",
+		}
+
+		notificationID = types.NotificationID(request.ID)
+
+		notifications = []types.RPCResponse{
+			types.NewRPCSuccessResponse(notificationID, "first"),
+			types.NewRPCSuccessResponse(notificationID, "second"),
+			types.RPCInternalError(notificationID, errors.New("cancelled")),
+		}
+	)
+
+	// Create the server, notifying the events before acknowledging the subscription
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		for {
+			_, message, err := c.ReadMessage()
+			if websocket.IsUnexpectedCloseError(err) {
+				return
+			}
+
+			require.NoError(t, err)
+
+			var req types.RPCRequest
+			require.NoError(t, json.Unmarshal(message, &req))
+
+			require.NoError(t, c.WriteJSON(notifications[0]))
+			require.NoError(t, c.WriteJSON(types.NewRPCSuccessResponse(req.ID, nil)))
+
+			for _, notification := range notifications[1:] {
+				require.NoError(t, c.WriteJSON(notification))
+			}
+		}
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	c, err := NewClient(url)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	ch, err := c.Subscribe(ctx, request)
+	require.NoError(t, err)
+
+	// The notifications are delivered in order,
+	// and the channel is closed on the error notification
+	var received []types.RPCResponse
+	for notification := range ch {
+		received = append(received, notification)
+	}
+
+	require.Len(t, received, len(notifications))
+
+	for index, notification := range received {
+		assert.Equal(t, notificationID, notification.ID)
+		assert.Equal(t, notifications[index].Result, notification.Result)
+	}
+	assert.NotNil(t, received[2].Error)
+
+	// The subscription can be made again, and unsubscribed
+	ch, err = c.Subscribe(ctx, request)
+	require.NoError(t, err)
+
+	c.Unsubscribe(request.ID)
+
+	for range ch {
+		// Drain the delivered notifications until the channel is closed
+	}
+}
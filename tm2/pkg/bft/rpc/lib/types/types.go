@@ -31,6 +31,12 @@ func (id JSONRPCIntID) String() string {
 	return fmt.Sprintf("%d", id)
 }
 
+// NotificationID returns the ID of the notifications sent over WS
+// for the subscription request with the given ID
+func NotificationID(id JSONRPCID) JSONRPCStringID {
+	return JSONRPCStringID(id.String() + "#event")
+}
+
 // parseID parses the given ID value
 func parseID(idValue any) (JSONRPCID, error) {
 	switch id := idValue.(type) {
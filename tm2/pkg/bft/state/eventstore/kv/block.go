@@ -0,0 +1,126 @@
+package kv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+var (
+	_ eventstore.BlockEventStore = (*TxEventStore)(nil)
+	_ eventstore.BlockSearcher   = (*TxEventStore)(nil)
+)
+
+var (
+	blockPrefix      = []byte("block/") // block/<height> -> BlockResult
+	blockIndexPrefix = []byte("bidx/")  // bidx/<len><key><len><value><height><0> -> <unix time>
+)
+
+// AppendBlock stores the block result, and indexes it by height, time,
+// proposer and the events of its BeginBlock and EndBlock
+func (t *TxEventStore) AppendBlock(result types.BlockResult) error {
+	resultRaw, err := amino.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("unable to marshal block, %w", err)
+	}
+
+	pos := txPos{result.Height, 0}
+	// The block time is indexed with each tag, so it's matched
+	// while iterating over any of them
+	unixTime := binary.BigEndian.AppendUint64(nil, uint64(result.Time.Unix()))
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(blockKey(result.Height), resultRaw)
+	// The empty height tag indexes all the blocks by height
+	batch.Set(tagKey(blockIndexPrefix, KeyBlockHeight, "", pos), unixTime)
+	for _, tag := range BlockTags(result) {
+		batch.Set(tagKey(blockIndexPrefix, tag.Key, tag.Value, pos), unixTime)
+	}
+	batch.WriteSync()
+
+	return nil
+}
+
+// GetBlock returns the result of the block at the given height,
+// or nil if it is not indexed
+func (t *TxEventStore) GetBlock(height int64) (*types.BlockResult, error) {
+	resultRaw := t.db.Get(blockKey(height))
+	if resultRaw == nil {
+		return nil, nil
+	}
+
+	var result types.BlockResult
+	if err := amino.Unmarshal(resultRaw, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal block, %w", err)
+	}
+
+	return &result, nil
+}
+
+// SearchBlocks returns the heights of the blocks matching the query,
+// ascending, or descending if desc is set. The first offset matches are
+// skipped, and at most limit heights are returned, or all of them if limit
+// is negative, along with the total count of matches.
+// See ParseBlockQuery for the query syntax
+func (t *TxEventStore) SearchBlocks(q string, offset, limit int, desc bool) ([]int64, int, error) {
+	conds, err := ParseBlockQuery(q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	untagged := map[string]matchFunc{KeyBlockTime: matchBlockTime}
+	positions, _, total := t.search(blockIndexPrefix, conds, KeyBlockHeight, untagged, offset, limit, desc)
+
+	heights := make([]int64, len(positions))
+	for i, pos := range positions {
+		heights[i] = pos.height
+	}
+
+	return heights, total, nil
+}
+
+// matchBlockTime matches the time condition against the indexed block time
+func matchBlockTime(cond query.Condition, _ txPos, value []byte) bool {
+	if len(value) != 8 {
+		return false
+	}
+
+	// The times are bounded like the heights
+	times := heightRange{min: 0, max: -1}
+	times.add(cond)
+
+	return times.contains(int64(binary.BigEndian.Uint64(value)))
+}
+
+// BlockTags returns the tags indexing the block result: its proposer, and the
+// events of its BeginBlock and EndBlock. The height and time are not tags
+func BlockTags(result types.BlockResult) []Tag {
+	var tags tagSet
+
+	if !result.ProposerAddress.IsZero() {
+		tags.add(KeyBlockProposer, result.ProposerAddress.String())
+	}
+
+	for _, events := range [][]abci.Event{result.ResultBeginBlock.Events, result.ResultEndBlock.Events} {
+		for _, ev := range events {
+			if e, ok := DecodeEvent(ev); ok {
+				tags.addEvent(e)
+			}
+		}
+	}
+
+	return tags.tags
+}
+
+func blockKey(height int64) []byte {
+	key := slices.Clone(blockPrefix)
+
+	return binary.BigEndian.AppendUint64(key, uint64(height))
+}
@@ -0,0 +1,569 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	_ eventstore.TxEventStore = (*TxEventStore)(nil)
+	_ eventstore.TxSearcher   = (*TxEventStore)(nil)
+	_ eventstore.RangeDeleter = (*TxEventStore)(nil)
+)
+
+const (
+	EventStoreType = "kv"
+
+	// MaxSearchTotal is the number of matches up to which searches count
+	// them exactly. Once their page is filled, searches stop iterating the
+	// index at this many matches, and the total they return is then a lower
+	// bound
+	MaxSearchTotal = 10_000
+)
+
+var (
+	txPrefix    = []byte("tx/")  // tx/<hash> -> TxResult
+	indexPrefix = []byte("idx/") // idx/<len><key><len><value><height><index> -> hash
+)
+
+// TxEventStore is the implementation of a transaction event store
+// indexing the transactions in a database, to search them by hash,
+// height, signer, account, message type, package path and events.
+// It also indexes the blocks, by height, time, proposer and events
+type TxEventStore struct {
+	db       dbm.DB
+	maxTotal int // see MaxSearchTotal
+}
+
+// NewTxEventStore creates a new database-backed tx event store
+func NewTxEventStore(db dbm.DB) *TxEventStore {
+	return &TxEventStore{
+		db:       db,
+		maxTotal: MaxSearchTotal,
+	}
+}
+
+// Start starts the kv transaction event store
+func (t *TxEventStore) Start() error {
+	return nil
+}
+
+// Stop stops the kv transaction event store, by closing the database
+func (t *TxEventStore) Stop() error {
+	t.db.Close()
+
+	return nil
+}
+
+// GetType returns the kv transaction event store type
+func (t *TxEventStore) GetType() string {
+	return EventStoreType
+}
+
+// Append stores the transaction result, and indexes it
+func (t *TxEventStore) Append(result types.TxResult) error {
+	resultRaw, err := amino.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	hash := result.Tx.Hash()
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(txKey(hash), resultRaw)
+	// The empty height tag indexes all the transactions by position
+	batch.Set(indexKey(KeyHeight, "", result.Height, result.Index), hash)
+	for _, tag := range TxTags(result) {
+		batch.Set(indexKey(tag.Key, tag.Value, result.Height, result.Index), hash)
+	}
+	batch.WriteSync()
+
+	return nil
+}
+
+// GetTx returns the result of the transaction with the given hash,
+// or nil if it is not indexed
+func (t *TxEventStore) GetTx(hash []byte) (*types.TxResult, error) {
+	resultRaw := t.db.Get(txKey(hash))
+	if resultRaw == nil {
+		return nil, nil
+	}
+
+	var result types.TxResult
+	if err := amino.Unmarshal(resultRaw, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeleteRange deletes the transactions and blocks of the heights from
+// startHeight to endHeight, and all their index keys. The indexes are scanned
+// whole, so that the keys of the tags which are no longer indexed are deleted
+// too
+func (t *TxEventStore) DeleteRange(startHeight, endHeight int64) error {
+	heights := heightRange{min: startHeight, max: endHeight}
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	var hashes [][]byte
+	for _, prefix := range [][]byte{indexPrefix, blockIndexPrefix} {
+		it := t.db.Iterator(prefix, prefixEnd(prefix))
+		for ; it.Valid(); it.Next() {
+			key := it.Key()
+			if len(key) < len(prefix)+txPosLen {
+				continue
+			}
+			if !heights.contains(decodeTxPos(key[len(key)-txPosLen:]).height) {
+				continue
+			}
+
+			batch.Delete(slices.Clone(key))
+			if bytes.Equal(prefix, indexPrefix) {
+				hashes = append(hashes, slices.Clone(it.Value()))
+			}
+		}
+		it.Close()
+	}
+
+	// The same transaction may be indexed at other heights
+	for _, hash := range hashes {
+		result, err := t.GetTx(hash)
+		if err != nil {
+			return err
+		}
+		if result != nil && heights.contains(result.Height) {
+			batch.Delete(txKey(hash))
+		}
+	}
+
+	for height := startHeight; height <= endHeight; height++ {
+		batch.Delete(blockKey(height))
+	}
+	batch.WriteSync()
+
+	return nil
+}
+
+// Search returns the hashes of the transactions matching the query,
+// ordered by height and index, descending if desc is set. The first offset
+// matches are skipped, and at most limit hashes are returned, or all of them
+// if limit is negative, along with the total count of matches, which is a
+// lower bound past MaxSearchTotal. See ParseQuery for the query syntax
+func (t *TxEventStore) Search(q string, offset, limit int, desc bool) ([][]byte, int, error) {
+	conds, err := ParseQuery(q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The hash conditions bound the search to the height of the transaction,
+	// instead of scanning the whole index
+	for _, cond := range conds {
+		if cond.Key != KeyHash {
+			continue
+		}
+
+		hash, err := hex.DecodeString(cond.Value)
+		if err != nil {
+			return nil, 0, nil
+		}
+
+		result, err := t.GetTx(hash)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			return nil, 0, nil
+		}
+
+		conds = append(conds, query.Condition{
+			Key:     KeyHeight,
+			Op:      query.OpEqual,
+			Value:   strconv.FormatInt(result.Height, 10),
+			Integer: true,
+		})
+	}
+
+	untagged := map[string]matchFunc{KeyHash: matchHash}
+	_, hashes, total := t.search(indexPrefix, conds, KeyHeight, untagged, offset, limit, desc)
+
+	return hashes, total, nil
+}
+
+// matchHash matches the hash condition against the indexed transaction hash
+func matchHash(cond query.Condition, _ txPos, value []byte) bool {
+	hash, err := hex.DecodeString(cond.Value)
+
+	return err == nil && bytes.Equal(hash, value)
+}
+
+// matchFunc reports whether the position, and the value indexed at it,
+// match the condition on a key which is not a tag of the index
+type matchFunc func(cond query.Condition, pos txPos, value []byte) bool
+
+// search pages through the positions matching all the conditions in the index
+// with the given prefix, and the values indexed at them. The conditions on
+// heightKey bound the height range. The first condition on a tag drives the
+// iteration over the index, in order, and the other conditions are checked at
+// each position, with the untagged match functions for the keys which are not
+// tags. Only the requested page is kept in memory, and the total count of
+// matches is returned with it. Once the page is filled, the iteration stops
+// at maxTotal matches, the total being then a lower bound
+func (t *TxEventStore) search(
+	prefix []byte,
+	conds []query.Condition,
+	heightKey string,
+	untagged map[string]matchFunc,
+	offset, limit int,
+	desc bool,
+) ([]txPos, [][]byte, int) {
+	heights := heightRange{min: 0, max: -1}
+	var (
+		driver *query.Condition
+		others []query.Condition
+	)
+	for _, cond := range conds {
+		switch {
+		case cond.Key == heightKey:
+			heights.add(cond)
+		case driver == nil && untagged[cond.Key] == nil:
+			driver = &cond
+		default:
+			others = append(others, cond)
+		}
+	}
+	if heights.empty() {
+		return nil, nil, 0
+	}
+	if driver == nil {
+		// The empty height tag indexes all the positions
+		driver = &query.Condition{Key: heightKey}
+	}
+
+	tagPrefix := tagKey(prefix, driver.Key, driver.Value, txPos{})
+	tagPrefix = tagPrefix[:len(tagPrefix)-txPosLen]
+
+	start := append(slices.Clone(tagPrefix), encodeTxPos(txPos{heights.min, 0})...)
+	var end []byte
+	if heights.max >= 0 {
+		end = append(slices.Clone(tagPrefix), encodeTxPos(txPos{heights.max + 1, 0})...)
+	} else {
+		end = prefixEnd(tagPrefix)
+	}
+
+	var it dbm.Iterator
+	if desc {
+		it = t.db.ReverseIterator(start, end)
+	} else {
+		it = t.db.Iterator(start, end)
+	}
+	defer it.Close()
+
+	var (
+		positions []txPos
+		values    [][]byte
+		total     int
+	)
+	for ; it.Valid(); it.Next() {
+		if limit >= 0 && total >= max(offset+limit, t.maxTotal) {
+			break
+		}
+
+		key := it.Key()
+		pos := decodeTxPos(key[len(key)-txPosLen:])
+		if !t.matchAll(prefix, others, untagged, pos, it.Value()) {
+			continue
+		}
+
+		if total >= offset && (limit < 0 || len(positions) < limit) {
+			positions = append(positions, pos)
+			values = append(values, slices.Clone(it.Value()))
+		}
+		total++
+	}
+
+	return positions, values, total
+}
+
+// matchAll reports whether the position, and the value indexed at it, match
+// all the conditions
+func (t *TxEventStore) matchAll(
+	prefix []byte,
+	conds []query.Condition,
+	untagged map[string]matchFunc,
+	pos txPos,
+	value []byte,
+) bool {
+	for _, cond := range conds {
+		if match, ok := untagged[cond.Key]; ok {
+			if !match(cond, pos, value) {
+				return false
+			}
+
+			continue
+		}
+
+		if !t.db.Has(tagKey(prefix, cond.Key, cond.Value, pos)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Tag is an indexed key/value pair of a transaction
+type Tag struct {
+	Key, Value string
+}
+
+// TxTags returns the tags indexing the transaction result: its signers and
+// the other accounts receiving coins, message types, package paths and
+// events. The messages executed by other messages (std.ExecMsg) are indexed
+// too, with their signers as accounts. The hash and height are not tags
+func TxTags(result types.TxResult) []Tag {
+	var tags tagSet
+
+	// The message tags are only available for std.Tx transactions
+	var tx std.Tx
+	if err := amino.Unmarshal(result.Tx, &tx); err == nil {
+		for _, msg := range tx.GetMsgs() {
+			for _, signer := range msg.GetSigners() {
+				tags.add(KeySigner, signer.String())
+			}
+			tags.addMsg(msg)
+		}
+	}
+
+	for _, ev := range result.Response.Events {
+		if e, ok := DecodeEvent(ev); ok {
+			tags.addEvent(e)
+			tags.add(KeyPkgPath, e.PkgPath)
+		}
+	}
+
+	return tags.tags
+}
+
+// tagSet collects the unique tags with a value
+type tagSet struct {
+	tags []Tag
+	seen map[Tag]bool
+}
+
+func (s *tagSet) add(key, value string) {
+	t := Tag{key, value}
+	if value == "" || s.seen[t] {
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[Tag]bool)
+	}
+
+	s.seen[t] = true
+	s.tags = append(s.tags, t)
+}
+
+// addMsg adds the tags of the message type, accounts and package path, and
+// of the messages it executes
+func (s *tagSet) addMsg(msg std.Msg) {
+	s.add(KeyMsgType, msg.Type())
+	for _, signer := range msg.GetSigners() {
+		s.add(KeyAccount, signer.String())
+	}
+	if rmsg, ok := msg.(std.ReceiversMsg); ok {
+		for _, receiver := range rmsg.GetReceivers() {
+			s.add(KeyAccount, receiver.String())
+		}
+	}
+	s.add(KeyPkgPath, msgPkgPath(msg))
+
+	if emsg, ok := msg.(std.ExecMsg); ok {
+		for _, inner := range emsg.GetMsgs() {
+			s.addMsg(inner)
+		}
+	}
+}
+
+// addEvent adds the tags of the event type and attributes
+func (s *tagSet) addEvent(e Event) {
+	s.add(KeyEvent, e.Type)
+	for _, attr := range e.Attrs {
+		s.add(eventAttrPrefix+e.Type+"."+attr.Key, attr.Value)
+	}
+}
+
+// msgPkgPath returns the path of the package called or added by the message,
+// which is the pkg_path or package.path field of its JSON representation
+func msgPkgPath(msg std.Msg) string {
+	msgRaw, err := amino.MarshalJSON(msg)
+	if err != nil {
+		return ""
+	}
+
+	var fields struct {
+		PkgPath string `json:"pkg_path"`
+		Package *struct {
+			Path string `json:"path"`
+		} `json:"package"`
+	}
+	if err := json.Unmarshal(msgRaw, &fields); err != nil {
+		return ""
+	}
+
+	if fields.Package != nil {
+		return fields.Package.Path
+	}
+
+	return fields.PkgPath
+}
+
+// Event is the JSON representation of the events emitted with std.Emit
+type Event struct {
+	Type  string `json:"type"`
+	Attrs []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"attrs"`
+	PkgPath string `json:"pkg_path"`
+}
+
+// DecodeEvent decodes the event from its JSON representation.
+// Events without a type field are not indexed
+func DecodeEvent(ev any) (Event, bool) {
+	var e Event
+
+	evRaw, err := amino.MarshalJSON(ev)
+	if err != nil {
+		return e, false
+	}
+
+	if err := json.Unmarshal(evRaw, &e); err != nil || e.Type == "" {
+		return e, false
+	}
+
+	return e, true
+}
+
+// txPos is the position of a transaction in the chain
+type txPos struct {
+	height int64
+	index  uint32
+}
+
+const txPosLen = 12
+
+func (p txPos) compare(other txPos) int {
+	switch {
+	case p.height != other.height:
+		if p.height < other.height {
+			return -1
+		}
+		return 1
+	case p.index < other.index:
+		return -1
+	case p.index > other.index:
+		return 1
+	}
+
+	return 0
+}
+
+func encodeTxPos(p txPos) []byte {
+	bz := make([]byte, txPosLen)
+	binary.BigEndian.PutUint64(bz, uint64(p.height))
+	binary.BigEndian.PutUint32(bz[8:], p.index)
+
+	return bz
+}
+
+func decodeTxPos(bz []byte) txPos {
+	return txPos{
+		height: int64(binary.BigEndian.Uint64(bz)),
+		index:  binary.BigEndian.Uint32(bz[8:]),
+	}
+}
+
+// heightRange is an inclusive range of heights, unbounded if max is negative
+type heightRange struct {
+	min, max int64
+}
+
+func (r *heightRange) add(cond query.Condition) {
+	value, _ := strconv.ParseInt(cond.Value, 10, 64)
+
+	lower, upper := value, value
+	switch cond.Op {
+	case query.OpLess:
+		lower, upper = 0, value-1
+	case query.OpLessEqual:
+		lower = 0
+	case query.OpGreater:
+		lower, upper = value+1, -1
+	case query.OpGreaterEqual:
+		upper = -1
+	}
+
+	r.min = max(r.min, lower)
+	if upper >= 0 && (r.max < 0 || upper < r.max) {
+		r.max = upper
+	}
+	if cond.Op == query.OpLess && value <= 0 {
+		// no height matches
+		r.min, r.max = 1, 0
+	}
+}
+
+func (r heightRange) empty() bool {
+	return r.max >= 0 && r.max < r.min
+}
+
+func (r heightRange) contains(height int64) bool {
+	return height >= r.min && (r.max < 0 || height <= r.max)
+}
+
+func txKey(hash []byte) []byte {
+	return append(slices.Clone(txPrefix), hash...)
+}
+
+func indexKey(key, value string, height int64, index uint32) []byte {
+	return tagKey(indexPrefix, key, value, txPos{height, index})
+}
+
+// tagKey returns the key indexing the position with the tag, in the index
+// with the given prefix. The key and value are length-prefixed, so the keys
+// of a tag never share the prefix of the keys of another tag
+func tagKey(prefix []byte, key, value string, pos txPos) []byte {
+	bz := slices.Clone(prefix)
+	bz = binary.AppendUvarint(bz, uint64(len(key)))
+	bz = append(bz, key...)
+	bz = binary.AppendUvarint(bz, uint64(len(value)))
+	bz = append(bz, value...)
+
+	return append(bz, encodeTxPos(pos)...)
+}
+
+// prefixEnd returns the end of the domain of the keys with the given prefix
+func prefixEnd(prefix []byte) []byte {
+	end := slices.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
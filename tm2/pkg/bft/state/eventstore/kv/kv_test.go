@@ -0,0 +1,639 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// testMsg is a message calling the realm at PkgPath
+type testMsg struct {
+	Caller  crypto.Address `json:"caller"`
+	PkgPath string         `json:"pkg_path"`
+}
+
+func (msg testMsg) Route() string                { return "test" }
+func (msg testMsg) Type() string                 { return "exec" }
+func (msg testMsg) ValidateBasic() error         { return nil }
+func (msg testMsg) GetSignBytes() []byte         { return nil }
+func (msg testMsg) GetSigners() []crypto.Address { return []crypto.Address{msg.Caller} }
+
+// testSendMsg is a message sending coins from From to To
+type testSendMsg struct {
+	From crypto.Address `json:"from"`
+	To   crypto.Address `json:"to"`
+}
+
+func (msg testSendMsg) Route() string                  { return "test" }
+func (msg testSendMsg) Type() string                   { return "send" }
+func (msg testSendMsg) ValidateBasic() error           { return nil }
+func (msg testSendMsg) GetSignBytes() []byte           { return nil }
+func (msg testSendMsg) GetSigners() []crypto.Address   { return []crypto.Address{msg.From} }
+func (msg testSendMsg) GetReceivers() []crypto.Address { return []crypto.Address{msg.To} }
+
+// testExecMsg is a message executing Msgs on behalf of their signers
+type testExecMsg struct {
+	Grantee crypto.Address `json:"grantee"`
+	Msgs    []std.Msg      `json:"msgs"`
+}
+
+func (msg testExecMsg) Route() string                { return "test" }
+func (msg testExecMsg) Type() string                 { return "exec_msgs" }
+func (msg testExecMsg) ValidateBasic() error         { return nil }
+func (msg testExecMsg) GetSignBytes() []byte         { return nil }
+func (msg testExecMsg) GetSigners() []crypto.Address { return []crypto.Address{msg.Grantee} }
+func (msg testExecMsg) GetMsgs() []std.Msg           { return msg.Msgs }
+
+// testEvent has the same JSON representation as the std.Emit events
+type testEvent struct {
+	Type       string          `json:"type"`
+	Attributes []testEventAttr `json:"attrs"`
+	PkgPath    string          `json:"pkg_path"`
+}
+
+type testEventAttr struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (testEvent) AssertABCIEvent() {}
+
+var _ = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv",
+	"kv",
+	amino.GetCallersDirname(),
+).
+	WithDependencies(
+		std.Package,
+		abci.Package,
+	).
+	WithTypes(
+		testMsg{},
+		testSendMsg{},
+		testExecMsg{},
+		testEvent{},
+		testEventAttr{},
+	))
+
+// newTestTxResult generates a transaction result, calling pkgPath
+// and emitting a Transfer event
+func newTestTxResult(t *testing.T, height int64, index uint32, caller crypto.Address, pkgPath, to string) types.TxResult {
+	t.Helper()
+
+	tx := std.Tx{
+		Msgs: []std.Msg{testMsg{Caller: caller, PkgPath: pkgPath}},
+		Memo: fmt.Sprintf("%d/%d", height, index),
+	}
+	txRaw, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	return types.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     txRaw,
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					testEvent{
+						Type:       "Transfer",
+						Attributes: []testEventAttr{{Key: "to", Value: to}},
+						PkgPath:    "gno.land/r/demo/wugnot",
+					},
+					abci.EventString("not indexed"),
+				},
+			},
+		},
+	}
+}
+
+func TestTxEventStore_Search(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = crypto.AddressFromPreimage([]byte("alice"))
+		bob   = crypto.AddressFromPreimage([]byte("bob"))
+
+		results = []types.TxResult{
+			newTestTxResult(t, 1, 0, alice, "gno.land/r/demo/boards", "g1bob"),
+			newTestTxResult(t, 1, 1, bob, "gno.land/r/demo/users", "g1alice"),
+			newTestTxResult(t, 2, 0, alice, "gno.land/r/demo/users", "g1alice"),
+			newTestTxResult(t, 5, 0, alice, "gno.land/r/demo/boards/v2", "g1bob"),
+		}
+	)
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	for _, result := range results {
+		require.NoError(t, store.Append(result))
+	}
+
+	// The stored results can be fetched by hash
+	for _, result := range results {
+		stored, err := store.GetTx(result.Tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, result.Height, stored.Height)
+		assert.Equal(t, result.Index, stored.Index)
+		assert.Equal(t, result.Tx, stored.Tx)
+	}
+
+	missing, err := store.GetTx([]byte("missing"))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	testTable := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		{
+			"by hash",
+			fmt.Sprintf("tx.hash = '%X'", results[2].Tx.Hash()),
+			[]int{2},
+		},
+		{
+			"by hash and other signer",
+			fmt.Sprintf("tx.hash = '%X' AND tx.signer = '%s'", results[2].Tx.Hash(), bob),
+			nil,
+		},
+		{
+			"by unknown hash",
+			"tx.hash = 'ABCD'",
+			nil,
+		},
+		{
+			"by height",
+			"tx.height = 1",
+			[]int{0, 1},
+		},
+		{
+			"by height range",
+			"tx.height > 1 AND tx.height <= 5",
+			[]int{2, 3},
+		},
+		{
+			"empty height range",
+			"tx.height > 5 AND tx.height < 3",
+			nil,
+		},
+		{
+			"by signer",
+			fmt.Sprintf("tx.signer = '%s'", alice),
+			[]int{0, 2, 3},
+		},
+		{
+			"by message type",
+			"msg.type = 'exec'",
+			[]int{0, 1, 2, 3},
+		},
+		{
+			"by package path",
+			"msg.pkg_path = 'gno.land/r/demo/boards'",
+			[]int{0},
+		},
+		{
+			"by event package path",
+			"msg.pkg_path = 'gno.land/r/demo/wugnot'",
+			[]int{0, 1, 2, 3},
+		},
+		{
+			"by event type",
+			"event.type = 'Transfer'",
+			[]int{0, 1, 2, 3},
+		},
+		{
+			"by event attribute",
+			"event.Transfer.to = 'g1alice'",
+			[]int{1, 2},
+		},
+		{
+			"combined conditions",
+			fmt.Sprintf("tx.signer = '%s' AND event.Transfer.to = 'g1alice' and tx.height >= 2", alice),
+			[]int{2},
+		},
+		{
+			"no match",
+			"msg.type = 'send'",
+			nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			hashes, total, err := store.Search(testCase.query, 0, -1, false)
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.expected), total)
+
+			if len(testCase.expected) == 0 {
+				assert.Empty(t, hashes)
+
+				return
+			}
+
+			expected := make([][]byte, len(testCase.expected))
+			for i, index := range testCase.expected {
+				expected[i] = results[index].Tx.Hash()
+			}
+			assert.Equal(t, expected, hashes)
+		})
+	}
+}
+
+func TestTxEventStore_SearchPage(t *testing.T) {
+	t.Parallel()
+
+	alice := crypto.AddressFromPreimage([]byte("alice"))
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	results := make([]types.TxResult, 0, 10)
+	for height := int64(1); height <= 10; height++ {
+		result := newTestTxResult(t, height, 0, alice, "gno.land/r/demo/boards", "g1bob")
+		require.NoError(t, store.Append(result))
+
+		results = append(results, result)
+	}
+
+	hashesOf := func(indexes ...int) [][]byte {
+		hashes := make([][]byte, 0, len(indexes))
+		for _, index := range indexes {
+			hashes = append(hashes, results[index].Tx.Hash())
+		}
+
+		return hashes
+	}
+
+	testTable := []struct {
+		name          string
+		query         string
+		offset, limit int
+		desc          bool
+		expected      [][]byte
+		expectedTotal int
+	}{
+		{"first page", "msg.type = 'exec'", 0, 3, false, hashesOf(0, 1, 2), 10},
+		{"middle page", "msg.type = 'exec'", 3, 3, false, hashesOf(3, 4, 5), 10},
+		{"last page", "msg.type = 'exec'", 9, 3, false, hashesOf(9), 10},
+		{"past the end", "msg.type = 'exec'", 12, 3, false, nil, 10},
+		{"descending", "msg.type = 'exec'", 3, 3, true, hashesOf(6, 5, 4), 10},
+		{"with height range", "msg.type = 'exec' AND tx.height > 7", 1, 3, true, hashesOf(8, 7), 3},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			hashes, total, err := store.Search(testCase.query, testCase.offset, testCase.limit, testCase.desc)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, hashes)
+			assert.Equal(t, testCase.expectedTotal, total)
+		})
+	}
+}
+
+func TestTxEventStore_SearchTotal(t *testing.T) {
+	t.Parallel()
+
+	alice := crypto.AddressFromPreimage([]byte("alice"))
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	store.maxTotal = 5
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	for height := int64(1); height <= 10; height++ {
+		require.NoError(t, store.Append(newTestTxResult(t, height, 0, alice, "gno.land/r/demo/boards", "g1bob")))
+	}
+
+	testTable := []struct {
+		name          string
+		offset, limit int
+		expectedLen   int
+		expectedTotal int
+	}{
+		{"first page", 0, 3, 3, 5},
+		{"page past the max total", 6, 3, 3, 9},
+		{"last page", 9, 3, 1, 10},
+		{"all matches", 0, -1, 10, 10},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			hashes, total, err := store.Search("msg.type = 'exec'", testCase.offset, testCase.limit, false)
+			require.NoError(t, err)
+
+			assert.Len(t, hashes, testCase.expectedLen)
+			assert.Equal(t, testCase.expectedTotal, total)
+		})
+	}
+}
+
+func TestTxEventStore_SearchValuePrefix(t *testing.T) {
+	t.Parallel()
+
+	alice := crypto.AddressFromPreimage([]byte("alice"))
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	// The value of the second tag starts with the value of the first one,
+	// followed by a NUL byte
+	exact := newTestTxResult(t, 1, 0, alice, "gno.land/r/demo/boards", "g1bob")
+	longer := newTestTxResult(t, 1, 1, alice, "gno.land/r/demo/boards", "g1bob\x00g1eve")
+	require.NoError(t, store.Append(exact))
+	require.NoError(t, store.Append(longer))
+
+	hashes, total, err := store.Search("event.Transfer.to = 'g1bob'", 0, -1, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, total)
+	assert.Equal(t, [][]byte{exact.Tx.Hash()}, hashes)
+}
+
+func TestTxEventStore_SearchAccount(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = crypto.AddressFromPreimage([]byte("alice"))
+		bob   = crypto.AddressFromPreimage([]byte("bob"))
+		carol = crypto.AddressFromPreimage([]byte("carol"))
+		dave  = crypto.AddressFromPreimage([]byte("dave"))
+		erin  = crypto.AddressFromPreimage([]byte("erin"))
+	)
+
+	newSendTxResult := func(height int64, from, to crypto.Address) types.TxResult {
+		txRaw, err := amino.Marshal(std.Tx{
+			Msgs: []std.Msg{testSendMsg{From: from, To: to}},
+		})
+		require.NoError(t, err)
+
+		return types.TxResult{Height: height, Tx: txRaw}
+	}
+
+	results := []types.TxResult{
+		newSendTxResult(1, alice, bob),
+		newTestTxResult(t, 2, 0, bob, "gno.land/r/demo/users", "g1alice"),
+		newSendTxResult(3, carol, alice),
+		newSendTxResult(4, alice, alice),
+	}
+
+	// dave sends coins of carol to erin, with an authorization of carol
+	execTxRaw, err := amino.Marshal(std.Tx{
+		Msgs: []std.Msg{testExecMsg{Grantee: dave, Msgs: []std.Msg{testSendMsg{From: carol, To: erin}}}},
+	})
+	require.NoError(t, err)
+	results = append(results, types.TxResult{Height: 5, Tx: execTxRaw})
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	for _, result := range results {
+		require.NoError(t, store.Append(result))
+	}
+
+	testTable := []struct {
+		name     string
+		account  crypto.Address
+		expected []int
+	}{
+		{"signer and receiver", alice, []int{0, 2, 3}},
+		{"receiver and caller", bob, []int{0, 1}},
+		{"signer and granter", carol, []int{2, 4}},
+		{"grantee", dave, []int{4}},
+		{"receiver of an executed message", erin, []int{4}},
+		{"no transaction", crypto.AddressFromPreimage([]byte("frank")), nil},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			hashes, _, err := store.Search(fmt.Sprintf("tx.account = '%s'", testCase.account), 0, -1, false)
+			require.NoError(t, err)
+
+			expected := make([][]byte, 0, len(testCase.expected))
+			for _, index := range testCase.expected {
+				expected = append(expected, results[index].Tx.Hash())
+			}
+			assert.ElementsMatch(t, expected, hashes)
+		})
+	}
+}
+
+func TestTxEventStore_SearchBlocks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		alice = crypto.AddressFromPreimage([]byte("alice"))
+		bob   = crypto.AddressFromPreimage([]byte("bob"))
+
+		genesisTime = time.Unix(1700000000, 0).UTC()
+	)
+
+	newBlockResult := func(height int64, proposer crypto.Address, events ...abci.Event) types.BlockResult {
+		return types.BlockResult{
+			Height:          height,
+			Time:            genesisTime.Add(time.Duration(height) * time.Minute),
+			ProposerAddress: proposer,
+			ResultEndBlock:  abci.ResponseEndBlock{Events: events},
+		}
+	}
+
+	validatorAdded := func(addr crypto.Address) abci.Event {
+		return testEvent{
+			Type:       "ValidatorAdded",
+			Attributes: []testEventAttr{{Key: "addr", Value: addr.String()}},
+			PkgPath:    "gno.land/r/sys/validators",
+		}
+	}
+
+	results := []types.BlockResult{
+		newBlockResult(1, alice),
+		newBlockResult(2, bob, validatorAdded(alice)),
+		newBlockResult(3, alice, abci.EventString("not indexed")),
+		newBlockResult(4, alice, validatorAdded(bob)),
+	}
+	results[2].ResultBeginBlock.Events = []abci.Event{testEvent{Type: "Begin"}}
+
+	store := NewTxEventStore(memdb.NewMemDB())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	for _, result := range results {
+		require.NoError(t, store.AppendBlock(result))
+	}
+
+	// The stored results can be fetched by height
+	stored, err := store.GetBlock(2)
+	require.NoError(t, err)
+	assert.Equal(t, results[1], *stored)
+
+	missing, err := store.GetBlock(5)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	testTable := []struct {
+		name     string
+		query    string
+		expected []int64
+	}{
+		{
+			"by height range",
+			"block.height > 1 AND block.height <= 3",
+			[]int64{2, 3},
+		},
+		{
+			"by time",
+			fmt.Sprintf("block.time >= %d", genesisTime.Add(3*time.Minute).Unix()),
+			[]int64{3, 4},
+		},
+		{
+			"by time range",
+			fmt.Sprintf("block.time > %d AND block.time < %d", genesisTime.Unix(), genesisTime.Add(3*time.Minute).Unix()),
+			[]int64{1, 2},
+		},
+		{
+			"by proposer",
+			fmt.Sprintf("block.proposer = '%s'", alice),
+			[]int64{1, 3, 4},
+		},
+		{
+			"by event type",
+			"event.type = 'ValidatorAdded'",
+			[]int64{2, 4},
+		},
+		{
+			"by begin block event type",
+			"event.type = 'Begin'",
+			[]int64{3},
+		},
+		{
+			"by event attribute",
+			fmt.Sprintf("event.ValidatorAdded.addr = '%s'", bob),
+			[]int64{4},
+		},
+		{
+			"combined conditions",
+			fmt.Sprintf("block.proposer = '%s' AND event.type = 'ValidatorAdded' AND block.height < 4", alice),
+			nil,
+		},
+		{
+			"empty time range",
+			"block.time < 0",
+			nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			heights, total, err := store.SearchBlocks(testCase.query, 0, -1, false)
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.expected), total)
+
+			if len(testCase.expected) == 0 {
+				assert.Empty(t, heights)
+
+				return
+			}
+
+			assert.Equal(t, testCase.expected, heights)
+		})
+	}
+
+	// The blocks are paged in the requested order
+	heights, total, err := store.SearchBlocks(fmt.Sprintf("block.proposer = '%s'", alice), 1, 1, true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []int64{3}, heights)
+
+	// The blocks are not matched by the tx searches
+	hashes, _, err := store.Search("event.type = 'ValidatorAdded'", 0, -1, false)
+	require.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestTxEventStore_DeleteRange(t *testing.T) {
+	t.Parallel()
+
+	alice := crypto.AddressFromPreimage([]byte("alice"))
+
+	db := memdb.NewMemDB()
+	store := NewTxEventStore(db)
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	results := make([]types.TxResult, 0, 4)
+	for height := int64(1); height <= 4; height++ {
+		result := newTestTxResult(t, height, 0, alice, "gno.land/r/demo/boards", "g1bob")
+		require.NoError(t, store.Append(result))
+		require.NoError(t, store.AppendBlock(types.BlockResult{Height: height, ProposerAddress: alice}))
+
+		// A tag which the transaction no longer has
+		db.Set(indexKey(KeyPkgPath, "gno.land/r/demo/stale", height, 0), result.Tx.Hash())
+
+		results = append(results, result)
+	}
+
+	require.NoError(t, store.DeleteRange(2, 3))
+
+	hashes, total, err := store.Search("msg.type = 'exec'", 0, -1, false)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{results[0].Tx.Hash(), results[3].Tx.Hash()}, hashes)
+	assert.Equal(t, 2, total)
+
+	hashes, _, err = store.Search("msg.pkg_path = 'gno.land/r/demo/stale'", 0, -1, false)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{results[0].Tx.Hash(), results[3].Tx.Hash()}, hashes)
+
+	heights, _, err := store.SearchBlocks(fmt.Sprintf("block.proposer = '%s'", alice), 0, -1, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 4}, heights)
+
+	for height := int64(1); height <= 4; height++ {
+		deleted := height == 2 || height == 3
+
+		tx, err := store.GetTx(results[height-1].Tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, deleted, tx == nil)
+
+		block, err := store.GetBlock(height)
+		require.NoError(t, err)
+		assert.Equal(t, deleted, block == nil)
+	}
+}
@@ -0,0 +1,115 @@
+package kv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+)
+
+// Query keys, besides the event attributes of the form
+// event.<event type>.<attribute key>.
+const (
+	KeyHash    = "tx.hash"      // hex encoded hash of the tx
+	KeyHeight  = "tx.height"    // height of the block including the tx
+	KeySigner  = "tx.signer"    // address of a signer of the tx
+	KeyAccount = "tx.account"   // address of a signer of the tx or of a message it executes, or of an account receiving coins
+	KeyMsgType = "msg.type"     // type of a message of the tx, ie. "exec"
+	KeyPkgPath = "msg.pkg_path" // path of a package called, added or emitting an event
+	KeyEvent   = "event.type"   // type of an event emitted by the tx
+
+	eventAttrPrefix = "event."
+)
+
+// Block query keys, besides the event type and the event attributes
+// of the BeginBlock and EndBlock events.
+const (
+	KeyBlockHeight   = "block.height"   // height of the block
+	KeyBlockTime     = "block.time"     // time of the block, in unix seconds
+	KeyBlockProposer = "block.proposer" // address of the proposer of the block
+)
+
+// ParseQuery parses a query made of conditions joined by AND, like:
+//
+//	tx.signer = 'g1...' AND msg.pkg_path = 'gno.land/r/demo/boards' AND tx.height > 10
+//
+// Values are single quoted strings, or integers for tx.height.
+// Only tx.height supports the comparison operators
+func ParseQuery(q string) ([]query.Condition, error) {
+	conds, err := query.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cond := range conds {
+		if err := validateCondition(cond); err != nil {
+			return nil, err
+		}
+	}
+
+	return conds, nil
+}
+
+func validateCondition(cond query.Condition) error {
+	switch {
+	case cond.Key == KeyHeight:
+		if !cond.Integer {
+			return fmt.Errorf("%q expects an integer value", cond.Key)
+		}
+
+		return nil
+	case cond.Key == KeyHash, cond.Key == KeySigner, cond.Key == KeyAccount, cond.Key == KeyMsgType,
+		cond.Key == KeyPkgPath, cond.Key == KeyEvent:
+	case strings.HasPrefix(cond.Key, eventAttrPrefix) && strings.Count(cond.Key, ".") >= 2:
+	default:
+		return fmt.Errorf("unknown key %q", cond.Key)
+	}
+
+	if cond.Integer {
+		return fmt.Errorf("%q expects a quoted string value", cond.Key)
+	}
+
+	return nil
+}
+
+// ParseBlockQuery parses a block query made of conditions joined by AND, like:
+//
+//	block.height > 10 AND block.time >= 1700000000 AND event.type = 'ValidatorAdded'
+//
+// Values are single quoted strings, or integers for block.height and
+// block.time, which support the comparison operators
+func ParseBlockQuery(q string) ([]query.Condition, error) {
+	conds, err := query.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cond := range conds {
+		if err := validateBlockCondition(cond); err != nil {
+			return nil, err
+		}
+	}
+
+	return conds, nil
+}
+
+func validateBlockCondition(cond query.Condition) error {
+	switch {
+	case cond.Key == KeyBlockHeight, cond.Key == KeyBlockTime:
+		if !cond.Integer {
+			return fmt.Errorf("%q expects an integer value", cond.Key)
+		}
+
+		return nil
+	case cond.Key == KeyBlockProposer, cond.Key == KeyEvent:
+	case strings.HasPrefix(cond.Key, eventAttrPrefix) && strings.Count(cond.Key, ".") >= 2:
+	default:
+		return fmt.Errorf("unknown key %q", cond.Key)
+	}
+
+	if cond.Integer {
+		return fmt.Errorf("%q expects a quoted string value", cond.Key)
+	}
+
+	return nil
+}
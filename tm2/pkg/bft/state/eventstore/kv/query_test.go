@@ -0,0 +1,80 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid queries", func(t *testing.T) {
+		t.Parallel()
+
+		conds, err := ParseQuery("tx.signer='g1abc' AND tx.height >= 10 and event.Transfer.to = 'a b'")
+		require.NoError(t, err)
+
+		assert.Equal(t, []query.Condition{
+			{Key: KeySigner, Op: query.OpEqual, Value: "g1abc"},
+			{Key: KeyHeight, Op: query.OpGreaterEqual, Value: "10", Integer: true},
+			{Key: "event.Transfer.to", Op: query.OpEqual, Value: "a b"},
+		}, conds)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, query := range []string{
+			"",
+			"tx.signer",
+			"tx.signer = g1abc",
+			"tx.signer = 'g1abc",
+			"tx.signer > 'g1abc'",
+			"tx.height = 'ten'",
+			"tx.height = '10'",
+			"tx.signer = 10",
+			"tx.height = 1 OR tx.height = 2",
+			"tx.unknown = 'value'",
+			"event.Transfer = 'value'",
+		} {
+			_, err := ParseQuery(query)
+			assert.Error(t, err, query)
+		}
+	})
+}
+
+func TestParseBlockQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid queries", func(t *testing.T) {
+		t.Parallel()
+
+		conds, err := ParseBlockQuery("block.time >= 1700000000 AND block.proposer = 'g1abc' and event.ValidatorAdded.addr = 'g1def'")
+		require.NoError(t, err)
+
+		assert.Equal(t, []query.Condition{
+			{Key: KeyBlockTime, Op: query.OpGreaterEqual, Value: "1700000000", Integer: true},
+			{Key: KeyBlockProposer, Op: query.OpEqual, Value: "g1abc"},
+			{Key: "event.ValidatorAdded.addr", Op: query.OpEqual, Value: "g1def"},
+		}, conds)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, query := range []string{
+			"block.height = '10'",
+			"block.time = 'now'",
+			"block.proposer = 10",
+			"tx.height = 1",
+			"msg.type = 'exec'",
+		} {
+			_, err := ParseBlockQuery(query)
+			assert.Error(t, err, query)
+		}
+	})
+}
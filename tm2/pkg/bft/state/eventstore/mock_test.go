@@ -54,6 +54,22 @@ func (m mockEventStore) Append(result types.TxResult) error {
 	return nil
 }
 
+type appendBlockDelegate func(types.BlockResult) error
+
+type mockBlockEventStore struct {
+	mockEventStore
+
+	appendBlockFn appendBlockDelegate
+}
+
+func (m mockBlockEventStore) AppendBlock(result types.BlockResult) error {
+	if m.appendBlockFn != nil {
+		return m.appendBlockFn(result)
+	}
+
+	return nil
+}
+
 // EventSwitch //
 
 type (
@@ -0,0 +1,190 @@
+// Package query implements the filter queries used to search the indexed
+// transactions and to subscribe to the node events, like:
+//
+//	tx.height > 100 AND msg.pkg_path = 'gno.land/r/demo/boards'
+//
+// The keys a query can use are defined by its consumer.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query operators. The comparison operators are only supported
+// for integer values.
+const (
+	OpEqual        = "="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+)
+
+// Condition is a single condition of a query, ie. tx.height >= 10.
+type Condition struct {
+	Key   string
+	Op    string
+	Value string
+
+	// Integer is set when the value is an integer, instead of a quoted string
+	Integer bool
+}
+
+// Match returns true if the value satisfies the condition.
+// Integer conditions never match values which are not integers
+func (c Condition) Match(value string) bool {
+	if !c.Integer {
+		return c.Op == OpEqual && value == c.Value
+	}
+
+	actual, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	expected, _ := strconv.ParseInt(c.Value, 10, 64)
+
+	switch c.Op {
+	case OpEqual:
+		return actual == expected
+	case OpLess:
+		return actual < expected
+	case OpLessEqual:
+		return actual <= expected
+	case OpGreater:
+		return actual > expected
+	case OpGreaterEqual:
+		return actual >= expected
+	}
+
+	return false
+}
+
+// Parse parses a query made of conditions joined by AND (case insensitive).
+// Values are single quoted strings, or integers
+func Parse(query string) ([]Condition, error) {
+	p := &parser{input: query}
+
+	var conds []Condition
+	for {
+		cond, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+
+		p.skipSpaces()
+		if p.done() {
+			return conds, nil
+		}
+		if !p.consumeKeyword("AND") {
+			return nil, p.errorf("expected AND")
+		}
+	}
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) condition() (Condition, error) {
+	var (
+		cond Condition
+		err  error
+	)
+
+	p.skipSpaces()
+	if cond.Key = p.key(); cond.Key == "" {
+		return cond, p.errorf("expected key")
+	}
+
+	p.skipSpaces()
+	if cond.Op = p.operator(); cond.Op == "" {
+		return cond, p.errorf("expected operator")
+	}
+
+	p.skipSpaces()
+	if !p.done() && p.input[p.pos] == '\'' {
+		if cond.Op != OpEqual {
+			return cond, fmt.Errorf("operator %q is not supported for %q", cond.Op, cond.Key)
+		}
+		cond.Value, err = p.string()
+	} else {
+		cond.Integer = true
+		cond.Value, err = p.integer()
+	}
+	if err != nil {
+		return cond, err
+	}
+
+	return cond, nil
+}
+
+func (p *parser) key() string {
+	start := p.pos
+	for !p.done() {
+		c := rune(p.input[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '.' && c != '_' && c != '-' {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) operator() string {
+	for _, op := range []string{OpLessEqual, OpGreaterEqual, OpEqual, OpLess, OpGreater} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *parser) integer() (string, error) {
+	start := p.pos
+	for !p.done() && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	value := p.input[start:p.pos]
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+		return "", p.errorf("expected integer or quoted string")
+	}
+	return value, nil
+}
+
+func (p *parser) string() (string, error) {
+	end := strings.IndexByte(p.input[p.pos+1:], '\'')
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+	value := p.input[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return value, nil
+}
+
+func (p *parser) consumeKeyword(kw string) bool {
+	if len(p.input)-p.pos < len(kw) || !strings.EqualFold(p.input[p.pos:p.pos+len(kw)], kw) {
+		return false
+	}
+	p.pos += len(kw)
+	return true
+}
+
+func (p *parser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid query at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
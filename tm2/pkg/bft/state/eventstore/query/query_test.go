@@ -0,0 +1,74 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid queries", func(t *testing.T) {
+		t.Parallel()
+
+		conds, err := Parse("tx.height>100 AND gno.pkg_path = 'gno.land/r/demo/boards' and gno.Transfer.to='a b'")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Condition{
+			{Key: "tx.height", Op: OpGreater, Value: "100", Integer: true},
+			{Key: "gno.pkg_path", Op: OpEqual, Value: "gno.land/r/demo/boards"},
+			{Key: "gno.Transfer.to", Op: OpEqual, Value: "a b"},
+		}, conds)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, q := range []string{
+			"",
+			"tx.signer",
+			"tx.signer = g1abc",
+			"tx.signer = 'g1abc",
+			"tx.signer > 'g1abc'",
+			"tx.height = 1 OR tx.height = 2",
+			"tx.height = 1 AND",
+			"= 1",
+		} {
+			_, err := Parse(q)
+			assert.Error(t, err, q)
+		}
+	})
+}
+
+func TestCondition_Match(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		cond     Condition
+		value    string
+		expected bool
+	}{
+		{Condition{Key: "a", Op: OpEqual, Value: "x"}, "x", true},
+		{Condition{Key: "a", Op: OpEqual, Value: "x"}, "y", false},
+		{Condition{Key: "a", Op: OpEqual, Value: "10"}, "10", true},
+		{Condition{Key: "h", Op: OpEqual, Value: "10", Integer: true}, "10", true},
+		{Condition{Key: "h", Op: OpLess, Value: "10", Integer: true}, "9", true},
+		{Condition{Key: "h", Op: OpLess, Value: "10", Integer: true}, "10", false},
+		{Condition{Key: "h", Op: OpLessEqual, Value: "10", Integer: true}, "10", true},
+		{Condition{Key: "h", Op: OpGreater, Value: "10", Integer: true}, "10", false},
+		{Condition{Key: "h", Op: OpGreater, Value: "10", Integer: true}, "11", true},
+		{Condition{Key: "h", Op: OpGreaterEqual, Value: "10", Integer: true}, "10", true},
+		{Condition{Key: "h", Op: OpGreaterEqual, Value: "10", Integer: true}, "ten", false},
+	}
+
+	for _, testCase := range testTable {
+		assert.Equal(
+			t,
+			testCase.expected,
+			testCase.cond.Match(testCase.value),
+			"%s %s %s", testCase.cond.Key, testCase.cond.Op, testCase.value,
+		)
+	}
+}
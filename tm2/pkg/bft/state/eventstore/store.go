@@ -22,3 +22,48 @@ type TxEventStore interface {
 	// to the event store
 	Append(result types.TxResult) error
 }
+
+// TxSearcher is implemented by the event stores that index
+// the transactions, so they can be searched
+type TxSearcher interface {
+	// GetTx returns the result of the transaction with the given hash,
+	// or nil if it is not indexed
+	GetTx(hash []byte) (*types.TxResult, error)
+
+	// Search returns the hashes of the transactions matching the query,
+	// ordered by height and index, descending if desc is set. The first
+	// offset matches are skipped, and at most limit hashes are returned,
+	// or all of them if limit is negative, along with the total count
+	// of matches, which the store may only count up to a bound
+	Search(query string, offset, limit int, desc bool) ([][]byte, int, error)
+}
+
+// BlockEventStore is implemented by the event stores that also store
+// the block events, emitted by BeginBlock and EndBlock
+type BlockEventStore interface {
+	// AppendBlock analyzes and appends the result of a single block
+	// to the event store
+	AppendBlock(result types.BlockResult) error
+}
+
+// BlockSearcher is implemented by the event stores that index
+// the blocks, so they can be searched
+type BlockSearcher interface {
+	// GetBlock returns the result of the block at the given height,
+	// or nil if it is not indexed
+	GetBlock(height int64) (*types.BlockResult, error)
+
+	// SearchBlocks returns the heights of the blocks matching the query,
+	// ascending, or descending if desc is set. The first offset matches
+	// are skipped, and at most limit heights are returned, or all of them
+	// if limit is negative, along with the total count of matches
+	SearchBlocks(query string, offset, limit int, desc bool) ([]int64, int, error)
+}
+
+// RangeDeleter is implemented by the event stores that can delete the events
+// of a range of heights, so they can be reindexed
+type RangeDeleter interface {
+	// DeleteRange deletes the transactions and blocks of the heights
+	// from startHeight to endHeight, inclusive, along with their indexes
+	DeleteRange(startHeight, endHeight int64) error
+}
@@ -38,8 +38,11 @@ func (is *Service) OnStart() error {
 		return fmt.Errorf("unable to start transaction event store, %w", err)
 	}
 
+	// Subscribe before returning, so no event fired after the start is missed
+	subCh := is.subscribe()
+
 	// Start the intermediary monitor service
-	go is.monitorTxEvents(ctx)
+	go is.monitorTxEvents(ctx, subCh)
 
 	return nil
 }
@@ -56,28 +59,43 @@ func (is *Service) OnStop() {
 	}
 }
 
-// monitorTxEvents acts as an intermediary feed service for the supplied
-// event store. It relays transaction events that come from the event stream
-func (is *Service) monitorTxEvents(ctx context.Context) {
-	// Create a subscription for transaction events
-	subCh := events.SubscribeToEvent(is.evsw, "tx-event-store", types.EventTx{})
+// subscribe creates a subscription for the transaction events, and the
+// block events if the event store stores them
+func (is *Service) subscribe() <-chan events.Event {
+	_, storesBlocks := is.txEventStore.(BlockEventStore)
+
+	return events.SubscribeFiltered(is.evsw, "tx-event-store", func(ev events.Event) bool {
+		switch ev.(type) {
+		case types.EventTx:
+			return true
+		case types.EventNewBlock:
+			return storesBlocks
+		default:
+			return false
+		}
+	})
+}
 
+// monitorTxEvents acts as an intermediary feed service for the supplied
+// event store. It relays transaction events that come from the event stream,
+// and the block events if the event store stores them
+func (is *Service) monitorTxEvents(ctx context.Context, subCh <-chan events.Event) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case evRaw := <-subCh:
-			// Cast the event
-			ev, ok := evRaw.(types.EventTx)
-			if !ok {
-				is.Logger.Error("invalid transaction result type cast")
-
-				continue
-			}
-
-			// Alert the actual tx event store
-			if err := is.txEventStore.Append(ev.Result); err != nil {
-				is.Logger.Error("unable to store transaction", "err", err)
+			switch ev := evRaw.(type) {
+			case types.EventTx:
+				// Alert the actual tx event store
+				if err := is.txEventStore.Append(ev.Result); err != nil {
+					is.Logger.Error("unable to store transaction", "err", err)
+				}
+			case types.EventNewBlock:
+				result := types.NewBlockResult(ev.Block.Header, ev.ResultBeginBlock, ev.ResultEndBlock)
+				if err := is.txEventStore.(BlockEventStore).AppendBlock(result); err != nil {
+					is.Logger.Error("unable to store block events", "err", err)
+				}
 			}
 		}
 	}
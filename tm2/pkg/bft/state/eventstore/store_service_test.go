@@ -6,9 +6,11 @@ import (
 	"testing"
 	"time"
 
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // generateTxEvents generates random transaction events
@@ -157,3 +159,61 @@ func TestEventStoreService_Monitor(t *testing.T) {
 		assert.Equal(t, event.Result, receivedResults[index])
 	}
 }
+
+func TestEventStoreService_MonitorBlocks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		txResults    = make(chan types.TxResult, 1)
+		blockResults = make(chan types.BlockResult, 1)
+
+		mockEventStore = &mockBlockEventStore{
+			mockEventStore: mockEventStore{
+				appendFn: func(result types.TxResult) error {
+					txResults <- result
+
+					return nil
+				},
+			},
+			appendBlockFn: func(result types.BlockResult) error {
+				blockResults <- result
+
+				return nil
+			},
+		}
+		evsw = events.NewEventSwitch()
+	)
+
+	require.NoError(t, evsw.Start())
+	t.Cleanup(func() { evsw.Stop() })
+
+	i := NewEventStoreService(mockEventStore, evsw)
+	require.NoError(t, i.OnStart())
+	t.Cleanup(i.OnStop)
+
+	header := types.Header{
+		Height:          2,
+		Time:            time.Unix(1700000000, 0).UTC(),
+		ProposerAddress: types.Address{1},
+	}
+	endBlock := abci.ResponseEndBlock{
+		Events: []abci.Event{abci.EventString("validator added")},
+	}
+
+	evsw.FireEvent(types.EventNewBlock{Block: &types.Block{Header: header}, ResultEndBlock: endBlock})
+	evsw.FireEvent(types.EventTx{Result: types.TxResult{Height: 2}})
+
+	select {
+	case result := <-blockResults:
+		assert.Equal(t, types.NewBlockResult(header, abci.ResponseBeginBlock{}, endBlock), result)
+	case <-time.After(5 * time.Second):
+		t.Fatal("block result not received")
+	}
+
+	select {
+	case result := <-txResults:
+		assert.Equal(t, int64(2), result.Height)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tx result not received")
+	}
+}
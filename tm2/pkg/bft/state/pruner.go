@@ -0,0 +1,124 @@
+package state
+
+import (
+	"sync/atomic"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+// Pruner is a service pruning, in the background, the blocks and the states
+// below the retain height.
+//
+// The retain height is the lowest of the enabled retain heights:
+//   - the node retain height, keeping its last minRetainBlocks blocks
+//   - the application retain height, returned by the ABCI Commit
+//
+// Nothing is pruned when both are disabled (zero).
+type Pruner struct {
+	service.BaseService
+
+	stateDB         dbm.DB
+	blockStore      BlockStore
+	minRetainBlocks int64
+
+	height          atomic.Int64 // latest committed height
+	appRetainHeight atomic.Int64 // latest application retain height
+
+	pruneCh chan struct{}
+}
+
+// NewPruner returns a new pruner of the given state DB and block store,
+// keeping at least the last minRetainBlocks blocks (if not zero)
+func NewPruner(stateDB dbm.DB, blockStore BlockStore, minRetainBlocks int64) *Pruner {
+	p := &Pruner{
+		stateDB:         stateDB,
+		blockStore:      blockStore,
+		minRetainBlocks: minRetainBlocks,
+		pruneCh:         make(chan struct{}, 1),
+	}
+	p.BaseService = *service.NewBaseService(nil, "Pruner", p)
+
+	return p
+}
+
+func (p *Pruner) OnStart() error {
+	go p.pruneRoutine()
+
+	return nil
+}
+
+// Committed notifies the pruner of a committed block, with the retain
+// height returned by the application. It never blocks
+func (p *Pruner) Committed(height, appRetainHeight int64) {
+	p.height.Store(height)
+	if appRetainHeight > 0 {
+		p.appRetainHeight.Store(appRetainHeight)
+	}
+
+	select {
+	case p.pruneCh <- struct{}{}:
+	default: // a pruning is already pending
+	}
+}
+
+// RetainHeight returns the height below which the blocks and states are
+// pruned, or 0 if nothing is pruned
+func (p *Pruner) RetainHeight() int64 {
+	height := p.height.Load()
+
+	var retainHeight int64
+	if p.minRetainBlocks > 0 {
+		retainHeight = max(height-p.minRetainBlocks+1, 1)
+	}
+
+	if appRetainHeight := p.appRetainHeight.Load(); appRetainHeight > 0 {
+		if retainHeight == 0 || appRetainHeight < retainHeight {
+			retainHeight = appRetainHeight
+		}
+	}
+
+	// The latest block is always kept
+	return min(retainHeight, height)
+}
+
+func (p *Pruner) pruneRoutine() {
+	for {
+		select {
+		case <-p.Quit():
+			return
+		case <-p.pruneCh:
+			retainHeight := p.RetainHeight()
+			if retainHeight <= 0 {
+				continue
+			}
+
+			if err := p.prune(retainHeight); err != nil {
+				p.Logger.Error("unable to prune", "retainHeight", retainHeight, "err", err)
+			}
+		}
+	}
+}
+
+// prune deletes the states, then the blocks below the retain height.
+// The states are pruned first, so that they are pruned again after
+// an interrupted pruning
+func (p *Pruner) prune(retainHeight int64) error {
+	base := p.blockStore.Base()
+	if base <= 0 || retainHeight <= base {
+		return nil
+	}
+
+	if err := PruneStates(p.stateDB, base, retainHeight); err != nil {
+		return err
+	}
+
+	pruned, err := p.blockStore.PruneBlocks(retainHeight)
+	if err != nil {
+		return err
+	}
+
+	p.Logger.Info("Pruned blocks", "pruned", pruned, "retainHeight", retainHeight)
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package state_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+// mockBlockStore is a block store recording the pruned heights
+type mockBlockStore struct {
+	sm.BlockStore
+
+	mux          sync.Mutex
+	base, height int64
+	pruned       chan int64
+}
+
+func (m *mockBlockStore) Base() int64 {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.base
+}
+
+func (m *mockBlockStore) Height() int64 {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.height
+}
+
+func (m *mockBlockStore) PruneBlocks(retainHeight int64) (uint64, error) {
+	m.mux.Lock()
+	pruned := uint64(retainHeight - m.base)
+	m.base = retainHeight
+	m.mux.Unlock()
+
+	m.pruned <- retainHeight
+
+	return pruned, nil
+}
+
+func TestPruner_RetainHeight(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		minRetainBlocks int64
+		height          int64
+		appRetainHeight int64
+		expected        int64
+	}{
+		{"disabled", 0, 100, 0, 0},
+		{"node", 10, 100, 0, 91},
+		{"node below first block", 200, 100, 0, 1},
+		{"app", 0, 100, 50, 50},
+		{"app beyond latest block", 0, 100, 150, 100},
+		{"lowest node", 60, 100, 50, 41},
+		{"lowest app", 10, 100, 50, 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := sm.NewPruner(memdb.NewMemDB(), &mockBlockStore{}, tc.minRetainBlocks)
+			p.Committed(tc.height, tc.appRetainHeight)
+
+			assert.Equal(t, tc.expected, p.RetainHeight())
+		})
+	}
+}
+
+func TestPruner_Prune(t *testing.T) {
+	t.Parallel()
+
+	const lastHeight = 20
+
+	var (
+		stateDB    = memdb.NewMemDB()
+		blockStore = &mockBlockStore{base: 1, height: lastHeight, pruned: make(chan int64, 1)}
+		vals, _    = types.RandValidatorSet(1, 10)
+		params     = types.DefaultConsensusParams()
+	)
+
+	for h := int64(1); h <= lastHeight; h++ {
+		sm.SaveValidatorsInfo(stateDB, h, 1, vals)
+		sm.SaveConsensusParamsInfo(stateDB, h, 1, params)
+		sm.SaveABCIResponses(stateDB, h, sm.NewABCIResponsesFromNum(0))
+	}
+
+	p := sm.NewPruner(stateDB, blockStore, 5)
+	require.NoError(t, p.Start())
+	t.Cleanup(func() { p.Stop() })
+
+	// The application retain height is ignored,
+	// as the node retains more blocks
+	p.Committed(lastHeight, 18)
+
+	select {
+	case retainHeight := <-blockStore.pruned:
+		assert.Equal(t, int64(lastHeight-4), retainHeight)
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocks not pruned")
+	}
+
+	for h := int64(1); h <= lastHeight; h++ {
+		_, err := sm.LoadABCIResponses(stateDB, h)
+		assert.Equal(t, h < lastHeight-4, err != nil, "ABCI responses at height %d", h)
+
+		_, err = sm.LoadValidators(stateDB, h)
+		assert.Equal(t, h > 1 && h < lastHeight-4, err != nil, "validators at height %d", h)
+	}
+}
@@ -0,0 +1,113 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var errNoState = errors.New("no state found")
+
+// Rollback reverts the state and the block store by one height, and returns
+// the height and app hash the application must be reverted to.
+//
+// As the block is saved before the state, the block store may be one height
+// above the state, e.g. after a crash while applying the block. Only the
+// pending block is deleted then, and the state is kept.
+func Rollback(bs BlockStore, db dbm.DB) (int64, []byte, error) {
+	invalidState := LoadState(db)
+	if invalidState.IsEmpty() {
+		return 0, nil, errNoState
+	}
+
+	height := bs.Height()
+
+	// Delete the pending block, which was never applied to the state
+	if height == invalidState.LastBlockHeight+1 {
+		if err := bs.DeleteLatestBlock(); err != nil {
+			return 0, nil, fmt.Errorf("unable to delete block %d, %w", height, err)
+		}
+
+		return invalidState.LastBlockHeight, invalidState.AppHash, nil
+	}
+
+	if height != invalidState.LastBlockHeight {
+		return 0, nil, fmt.Errorf(
+			"state height %d is not one below or equal to the block store height %d",
+			invalidState.LastBlockHeight,
+			height,
+		)
+	}
+
+	rollbackHeight := invalidState.LastBlockHeight - 1
+	if rollbackHeight < bs.Base() {
+		return 0, nil, fmt.Errorf("block %d to roll back to is not in the block store", rollbackHeight)
+	}
+
+	rollbackBlock := bs.LoadBlockMeta(rollbackHeight)
+	if rollbackBlock == nil {
+		return 0, nil, fmt.Errorf("block %d not found", rollbackHeight)
+	}
+
+	// The app hash and results hash of a block are in the header of the next one
+	latestBlock := bs.LoadBlockMeta(invalidState.LastBlockHeight)
+	if latestBlock == nil {
+		return 0, nil, fmt.Errorf("block %d not found", invalidState.LastBlockHeight)
+	}
+
+	lastValidators, err := LoadValidators(db, rollbackHeight)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	params, err := LoadConsensusParams(db, rollbackHeight+1)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The heights the validators and params last changed at are those saved
+	// with the state of the rollback height
+	valInfo := loadValidatorsInfo(db, rollbackHeight+2)
+	if valInfo == nil {
+		return 0, nil, NoValSetForHeightError{rollbackHeight + 2}
+	}
+
+	paramsInfo := loadConsensusParamsInfo(db, rollbackHeight+1)
+	if paramsInfo == nil {
+		return 0, nil, NoConsensusParamsForHeightError{rollbackHeight + 1}
+	}
+
+	rolledBackState := State{
+		SoftwareVersion: invalidState.SoftwareVersion,
+		BlockVersion:    invalidState.BlockVersion,
+		AppVersion:      invalidState.AppVersion,
+		ChainID:         invalidState.ChainID,
+
+		LastBlockHeight:  rollbackBlock.Header.Height,
+		LastBlockTotalTx: rollbackBlock.Header.TotalTxs,
+		LastBlockID:      rollbackBlock.BlockID,
+		LastBlockTime:    rollbackBlock.Header.Time,
+
+		NextValidators:              invalidState.Validators,
+		Validators:                  invalidState.LastValidators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: valInfo.LastHeightChanged,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: paramsInfo.LastHeightChanged,
+
+		LastResultsHash: latestBlock.Header.LastResultsHash,
+		AppHash:         latestBlock.Header.AppHash,
+	}
+
+	// The validators and params saved along with the state are the same
+	// as the ones already saved for these heights
+	SaveState(db, rolledBackState)
+
+	if err := bs.DeleteLatestBlock(); err != nil {
+		return 0, nil, fmt.Errorf("unable to delete block %d, %w", height, err)
+	}
+
+	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
+}
@@ -0,0 +1,128 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/abci/example/kvstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/mempool/mock"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+// makeRollbackChain saves and applies the given number of blocks, and
+// returns the state of each height.
+func makeRollbackChain(t *testing.T, height int64) (dbm.DB, *store.BlockStore, map[int64]sm.State) {
+	t.Helper()
+
+	proxyApp := appconn.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication()))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { proxyApp.Stop() })
+
+	state, stateDB, privVals := makeState(1, 1)
+	blockStore := store.NewBlockStore(memdb.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mock.Mempool{})
+
+	var (
+		states     = map[int64]sm.State{0: state}
+		lastCommit = new(types.Commit)
+	)
+
+	for h := int64(1); h <= height; h++ {
+		block, parts := state.MakeBlock(h, makeTxs(h), lastCommit, state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+
+		commit, err := makeValidCommit(h, blockID, state.Validators, privVals)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, parts, commit)
+
+		state, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+
+		states[h] = state
+		lastCommit = commit
+	}
+
+	return stateDB, blockStore, states
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	stateDB, blockStore, states := makeRollbackChain(t, 3)
+
+	height, appHash, err := sm.Rollback(blockStore, stateDB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), height)
+	assert.Equal(t, states[2].AppHash, appHash)
+
+	// The state and the block store are both at the previous height
+	assert.True(t, states[2].Equals(sm.LoadState(stateDB)))
+	assert.Equal(t, int64(2), blockStore.Height())
+	assert.Nil(t, blockStore.LoadBlock(3))
+
+	// The validators and params of the next heights can still be loaded
+	vals, err := sm.LoadValidators(stateDB, 3)
+	require.NoError(t, err)
+	assert.Equal(t, states[2].Validators.Hash(), vals.Hash())
+
+	params, err := sm.LoadConsensusParams(stateDB, 3)
+	require.NoError(t, err)
+	assert.Equal(t, states[2].ConsensusParams, params)
+}
+
+func TestRollback_PendingBlock(t *testing.T) {
+	t.Parallel()
+
+	stateDB, blockStore, states := makeRollbackChain(t, 3)
+
+	// The state was not saved after the latest block, only the block is deleted
+	sm.SaveState(stateDB, states[2])
+
+	height, appHash, err := sm.Rollback(blockStore, stateDB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), height)
+	assert.Equal(t, states[2].AppHash, appHash)
+
+	assert.True(t, states[2].Equals(sm.LoadState(stateDB)))
+	assert.Equal(t, int64(2), blockStore.Height())
+}
+
+func TestRollback_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no state", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := sm.Rollback(store.NewBlockStore(memdb.NewMemDB()), memdb.NewMemDB())
+		assert.Error(t, err)
+	})
+
+	t.Run("first block", func(t *testing.T) {
+		t.Parallel()
+
+		stateDB, blockStore, _ := makeRollbackChain(t, 1)
+
+		_, _, err := sm.Rollback(blockStore, stateDB)
+		assert.Error(t, err)
+	})
+
+	t.Run("block store behind", func(t *testing.T) {
+		t.Parallel()
+
+		stateDB, blockStore, _ := makeRollbackChain(t, 3)
+		require.NoError(t, blockStore.DeleteLatestBlock())
+		require.NoError(t, blockStore.DeleteLatestBlock())
+
+		_, _, err := sm.Rollback(blockStore, stateDB)
+		assert.ErrorContains(t, err, "is not one below or equal")
+	})
+}
@@ -17,6 +17,9 @@ const (
 	// https://github.com/tendermint/classic/pull/3438
 	// 100000 results in ~ 100ms to get 100 validators (see BenchmarkLoadValidators)
 	valSetCheckpointInterval = 100000
+
+	// number of heights pruned by a single database batch
+	pruneBatchSize = 1000
 )
 
 var errTxResultIndexCorrupted = errors.New("tx result index corrupted")
@@ -102,6 +105,20 @@ func SaveState(db dbm.DB, state State) {
 	saveState(db, state, stateKey)
 }
 
+// BootstrapState persists the State of a node bootstrapped at its last block
+// height, e.g. with state sync, with the full validator sets and consensus
+// params needed to validate the next blocks.
+func BootstrapState(db dbm.DB, state State) {
+	height := state.LastBlockHeight
+	if height > 0 && !state.LastValidators.IsNilOrEmpty() {
+		saveValidatorsInfo(db, height, height, state.LastValidators)
+	}
+	saveValidatorsInfo(db, height+1, height+1, state.Validators)
+	saveValidatorsInfo(db, height+2, height+2, state.NextValidators)
+	saveConsensusParamsInfo(db, height+1, height+1, state.ConsensusParams)
+	db.SetSync(stateKey, state.Bytes())
+}
+
 func saveState(db dbm.DB, state State, key []byte) {
 	nextHeight := state.LastBlockHeight + 1
 	// If first block, save validators for block 1.
@@ -185,6 +202,91 @@ func SaveABCIResponses(db dbm.DB, height int64, abciResponses *ABCIResponses) {
 	db.Set(CalcABCIResponsesKey(height), abciResponses.Bytes())
 }
 
+// PruneStates deletes the ABCI responses, validator sets and consensus params
+// of the heights in [from, to). The validator sets and consensus params needed
+// to load the heights from `to` on are kept, as full entries.
+// NOTE: this should only be used internally by the bft package and subpackages.
+func PruneStates(db dbm.DB, from, to int64) error {
+	if from <= 0 || to <= 0 {
+		return fmt.Errorf("from height %d and to height %d must be greater than 0", from, to)
+	}
+	if from >= to {
+		return fmt.Errorf("from height %d must be lower than to height %d", from, to)
+	}
+
+	valInfo := loadValidatorsInfo(db, to)
+	if valInfo == nil {
+		return NoValSetForHeightError{to}
+	}
+	paramsInfo := loadConsensusParamsInfo(db, to)
+	if paramsInfo == nil {
+		return NoConsensusParamsForHeightError{to}
+	}
+
+	// The heights the entries at `to` (and above) refer to
+	keepVals := make(map[int64]bool)
+	if valInfo.ValidatorSet == nil {
+		keepVals[valInfo.LastHeightChanged] = true
+		keepVals[lastStoredHeightFor(to, valInfo.LastHeightChanged)] = true
+	}
+	keepParams := make(map[int64]bool)
+	if amino.DeepEqual(abci.ConsensusParams{}, paramsInfo.ConsensusParams) {
+		keepParams[paramsInfo.LastHeightChanged] = true
+	}
+
+	batch := db.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+
+	// Delete in reverse order, so that the entries kept can still be
+	// loaded from the lower heights they refer to
+	for h := to - 1; h >= from; h-- {
+		if keepVals[h] {
+			v := loadValidatorsInfo(db, h)
+			if v != nil && v.ValidatorSet == nil {
+				vals, err := LoadValidators(db, h)
+				if err != nil {
+					return err
+				}
+				v.ValidatorSet = vals
+				v.LastHeightChanged = h
+				batch.Set(calcValidatorsKey(h), v.Bytes())
+			}
+		} else {
+			batch.Delete(calcValidatorsKey(h))
+		}
+
+		if keepParams[h] {
+			p := loadConsensusParamsInfo(db, h)
+			if p != nil && amino.DeepEqual(abci.ConsensusParams{}, p.ConsensusParams) {
+				params, err := LoadConsensusParams(db, h)
+				if err != nil {
+					return err
+				}
+				p.ConsensusParams = params
+				p.LastHeightChanged = h
+				batch.Set(calcConsensusParamsKey(h), p.Bytes())
+			}
+		} else {
+			batch.Delete(calcConsensusParamsKey(h))
+		}
+
+		batch.Delete(CalcABCIResponsesKey(h))
+
+		// Flush regularly, to keep the batches small
+		if (to-h)%pruneBatchSize == 0 {
+			batch.Write()
+			batch.Close()
+			batch = db.NewBatch()
+		}
+	}
+
+	batch.WriteSync()
+
+	return nil
+}
+
 // TxResultIndex keeps the result index information for a transaction
 type TxResultIndex struct {
 	BlockNum int64  // the block number the tx was contained in
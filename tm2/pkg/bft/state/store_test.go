@@ -3,12 +3,14 @@ package state_test
 import (
 	"fmt"
 	"os"
+	"slices"
 	"testing"
 
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -57,6 +59,99 @@ func TestStoreLoadValidators(t *testing.T) {
 	assert.NotZero(t, loadedVals.Size())
 }
 
+func TestPruneStates(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		from, to      int64
+		valsChanged   []int64 // heights the validator set changed at, besides 1
+		paramsChanged []int64 // heights the consensus params changed at, besides 1
+		expectErr     bool
+		expectVals    []int64 // pruned heights whose validators are kept
+		expectParams  []int64 // pruned heights whose consensus params are kept
+	}{
+		{"invalid from", 0, 5, nil, nil, true, nil, nil},
+		{"invalid range", 5, 5, nil, nil, true, nil, nil},
+		{"unknown height", 1, 100, nil, nil, true, nil, nil},
+		{"unchanged", 1, 10, nil, nil, false, []int64{1}, []int64{1}},
+		{"changed below", 1, 10, []int64{5}, []int64{3}, false, []int64{5}, []int64{3}},
+		{"changed at to", 1, 10, []int64{10}, []int64{10}, false, nil, nil},
+		{"partial range", 5, 15, []int64{3}, nil, false, nil, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			const lastHeight = 20
+
+			stateDB := memdb.NewMemDB()
+
+			var (
+				vals, params   = make(map[int64]*types.ValidatorSet), make(map[int64]int64)
+				valSet         *types.ValidatorSet
+				valsChangedAt  int64
+				paramsChangeAt int64
+			)
+			for h := int64(1); h <= lastHeight; h++ {
+				if h == 1 || slices.Contains(tc.valsChanged, h) {
+					valSet, _ = types.RandValidatorSet(1, 10)
+					valsChangedAt = h
+				}
+				if h == 1 || slices.Contains(tc.paramsChanged, h) {
+					paramsChangeAt = h
+				}
+
+				sm.SaveValidatorsInfo(stateDB, h, valsChangedAt, valSet)
+				sm.SaveConsensusParamsInfo(stateDB, h, paramsChangeAt, consensusParamsAt(paramsChangeAt))
+				sm.SaveABCIResponses(stateDB, h, sm.NewABCIResponsesFromNum(0))
+
+				vals[h] = valSet
+				params[h] = paramsChangeAt
+			}
+
+			err := sm.PruneStates(stateDB, tc.from, tc.to)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for h := int64(1); h <= lastHeight; h++ {
+				pruned := h >= tc.from && h < tc.to
+
+				_, err := sm.LoadABCIResponses(stateDB, h)
+				assert.Equal(t, pruned, err != nil, "ABCI responses at height %d", h)
+
+				loadedVals, err := sm.LoadValidators(stateDB, h)
+				if pruned && !slices.Contains(tc.expectVals, h) {
+					assert.Error(t, err, "validators at height %d", h)
+				} else {
+					require.NoError(t, err, "validators at height %d", h)
+					assert.Equal(t, vals[h].Hash(), loadedVals.Hash(), "validators at height %d", h)
+				}
+
+				loadedParams, err := sm.LoadConsensusParams(stateDB, h)
+				if pruned && !slices.Contains(tc.expectParams, h) {
+					assert.Error(t, err, "consensus params at height %d", h)
+				} else {
+					require.NoError(t, err, "consensus params at height %d", h)
+					assert.Equal(t, consensusParamsAt(params[h]), loadedParams, "consensus params at height %d", h)
+				}
+			}
+		})
+	}
+}
+
+// consensusParamsAt returns distinct consensus params for each height
+func consensusParamsAt(height int64) abci.ConsensusParams {
+	params := types.DefaultConsensusParams()
+	params.Block.MaxGas = height
+
+	return params
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -86,3 +181,42 @@ func BenchmarkLoadValidators(b *testing.B) {
 		})
 	}
 }
+
+func TestBootstrapState(t *testing.T) {
+	t.Parallel()
+
+	stateDB := memdb.NewMemDB()
+
+	newValidators := func() *types.ValidatorSet {
+		val, _ := types.RandValidator(true, 10)
+		return types.NewValidatorSet([]*types.Validator{val})
+	}
+
+	state := sm.State{
+		ChainID:                          "test-chain",
+		LastBlockHeight:                  150,
+		LastValidators:                   newValidators(),
+		Validators:                       newValidators(),
+		NextValidators:                   newValidators(),
+		LastHeightValidatorsChanged:      151,
+		ConsensusParams:                  types.DefaultConsensusParams(),
+		LastHeightConsensusParamsChanged: 151,
+	}
+	sm.BootstrapState(stateDB, state)
+
+	assert.True(t, state.Equals(sm.LoadState(stateDB)))
+
+	for height, expected := range map[int64]*types.ValidatorSet{
+		150: state.LastValidators,
+		151: state.Validators,
+		152: state.NextValidators,
+	} {
+		vals, err := sm.LoadValidators(stateDB, height)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Hash(), vals.Hash(), "height %d", height)
+	}
+
+	params, err := sm.LoadConsensusParams(stateDB, 151)
+	require.NoError(t, err)
+	assert.Equal(t, state.ConsensusParams, params)
+}
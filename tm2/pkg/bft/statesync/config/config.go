@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// StateSyncConfig
+
+// StateSyncConfig defines the configuration for state sync, bootstrapping a
+// new node from a snapshot of the application state instead of replaying the
+// blocks from genesis
+type StateSyncConfig struct {
+	// Bootstrap the node with state sync, if its state is empty
+	Enable bool `json:"enable" toml:"enable" comment:"Bootstrap the node with state sync, if its state is empty.\n The snapshots are discovered from the peers, and verified against the\n app hash of headers verified with the RPC servers, from the trusted height and hash"`
+
+	// RPC servers used to verify the headers of the snapshots
+	RPCServers []string `json:"rpc_servers" toml:"rpc_servers" comment:"RPC servers used to verify the headers of the snapshots, at least 2"`
+
+	// Trusted height and hash of a block, verified out of band
+	TrustHeight int64  `json:"trust_height" toml:"trust_height" comment:"Trusted height and hash of a block, verified out of band"`
+	TrustHash   string `json:"trust_hash" toml:"trust_hash"`
+
+	// Time spent discovering the snapshots of the peers
+	DiscoveryTime time.Duration `json:"discovery_time" toml:"discovery_time" comment:"Time spent discovering the snapshots of the peers"`
+
+	// Timeout of a snapshot chunk request to a peer
+	ChunkRequestTimeout time.Duration `json:"chunk_request_timeout" toml:"chunk_request_timeout" comment:"Timeout of a snapshot chunk request to a peer"`
+}
+
+// DefaultStateSyncConfig returns a default configuration for state sync
+func DefaultStateSyncConfig() *StateSyncConfig {
+	return &StateSyncConfig{
+		Enable:              false,
+		RPCServers:          []string{},
+		DiscoveryTime:       15 * time.Second,
+		ChunkRequestTimeout: 10 * time.Second,
+	}
+}
+
+// TestStateSyncConfig returns a configuration for testing state sync
+func TestStateSyncConfig() *StateSyncConfig {
+	cfg := DefaultStateSyncConfig()
+	cfg.DiscoveryTime = 500 * time.Millisecond
+	cfg.ChunkRequestTimeout = 2 * time.Second
+
+	return cfg
+}
+
+// TrustHashBytes returns the decoded trusted hash
+func (cfg *StateSyncConfig) TrustHashBytes() ([]byte, error) {
+	return hex.DecodeString(cfg.TrustHash)
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StateSyncConfig) ValidateBasic() error {
+	if cfg.DiscoveryTime < 0 {
+		return errors.New("discovery_time can't be negative")
+	}
+	if cfg.ChunkRequestTimeout < 0 {
+		return errors.New("chunk_request_timeout can't be negative")
+	}
+
+	if !cfg.Enable {
+		return nil
+	}
+
+	if len(cfg.RPCServers) < 2 {
+		return errors.New("at least 2 rpc_servers are required")
+	}
+	for _, server := range cfg.RPCServers {
+		if server == "" {
+			return errors.New("rpc_servers can't contain empty addresses")
+		}
+	}
+	if cfg.TrustHeight <= 0 {
+		return errors.New("trust_height is required")
+	}
+	if hash, err := cfg.TrustHashBytes(); err != nil || len(hash) == 0 {
+		return errors.New("trust_hash must be a valid hex hash")
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+)
+
+const (
+	// snapshotMsgSize is the maximum size of a snapshot message
+	snapshotMsgSize = 4 << 20
+
+	// chunkMsgSize is the maximum size of a chunk message, above the
+	// maximum chunk size of the application snapshots
+	chunkMsgSize = 16 << 20
+)
+
+// StateSyncMessage is a generic message for this reactor.
+type StateSyncMessage interface {
+	ValidateBasic() error
+}
+
+func decodeMsg(bz []byte) (msg StateSyncMessage, err error) {
+	if len(bz) > chunkMsgSize {
+		return msg, fmt.Errorf("msg exceeds max size (%d > %d)", len(bz), chunkMsgSize)
+	}
+	err = amino.Unmarshal(bz, &msg)
+	return
+}
+
+// -------------------------------------
+
+// snapshotsRequestMessage requests the recent snapshots of a peer
+type snapshotsRequestMessage struct{}
+
+// ValidateBasic performs basic validation.
+func (m *snapshotsRequestMessage) ValidateBasic() error {
+	return nil
+}
+
+func (m *snapshotsRequestMessage) String() string {
+	return "[snapshotsRequestMessage]"
+}
+
+// -------------------------------------
+
+// snapshotsResponseMessage advertises a snapshot of a peer
+type snapshotsResponseMessage struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *snapshotsResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("invalid height")
+	}
+	if m.Chunks == 0 {
+		return errors.New("no chunks")
+	}
+	if len(m.Hash) == 0 {
+		return errors.New("no hash")
+	}
+	return nil
+}
+
+func (m *snapshotsResponseMessage) String() string {
+	return fmt.Sprintf("[snapshotsResponseMessage %d/%d %X]", m.Height, m.Format, m.Hash)
+}
+
+func (m *snapshotsResponseMessage) snapshot() *abci.Snapshot {
+	return &abci.Snapshot{
+		Height:   m.Height,
+		Format:   m.Format,
+		Chunks:   m.Chunks,
+		Hash:     m.Hash,
+		Metadata: m.Metadata,
+	}
+}
+
+// -------------------------------------
+
+// chunkRequestMessage requests a snapshot chunk of a peer
+type chunkRequestMessage struct {
+	Height int64
+	Format uint32
+	Index  uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *chunkRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("invalid height")
+	}
+	return nil
+}
+
+func (m *chunkRequestMessage) String() string {
+	return fmt.Sprintf("[chunkRequestMessage %d/%d/%d]", m.Height, m.Format, m.Index)
+}
+
+// -------------------------------------
+
+// chunkResponseMessage returns a snapshot chunk, or that it is missing
+type chunkResponseMessage struct {
+	Height  int64
+	Format  uint32
+	Index   uint32
+	Chunk   []byte
+	Missing bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *chunkResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("invalid height")
+	}
+	if m.Missing && len(m.Chunk) > 0 {
+		return errors.New("missing chunk with contents")
+	}
+	return nil
+}
+
+func (m *chunkResponseMessage) String() string {
+	return fmt.Sprintf("[chunkResponseMessage %d/%d/%d missing=%v]", m.Height, m.Format, m.Index, m.Missing)
+}
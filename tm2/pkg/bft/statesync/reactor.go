@@ -0,0 +1,225 @@
+// Package statesync bootstraps a new node from a snapshot of the application
+// state, instead of replaying all the blocks from genesis.
+//
+// The reactor serves the snapshots of the application to the peers, and
+// discovers, fetches and restores a snapshot of the peers when syncing. The
+// restored state is verified against the app hash of a header verified by a
+// StateProvider.
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+const (
+	// SnapshotChannel is a channel for snapshot discovery
+	SnapshotChannel = byte(0x60)
+
+	// ChunkChannel is a channel for snapshot chunks
+	ChunkChannel = byte(0x61)
+
+	// recentSnapshots is the number of the recent snapshots sent to a peer
+	recentSnapshots = 10
+)
+
+var errAlreadySyncing = errors.New("state sync already in progress")
+
+// Reactor serves the snapshots of the application, and syncs the state of
+// the node from the snapshots of its peers.
+type Reactor struct {
+	p2p.BaseReactor
+
+	connSnapshot appconn.Snapshot
+	connQuery    appconn.Query
+	chunkTimeout time.Duration
+
+	mtx    sync.RWMutex
+	syncer *syncer // set while syncing
+}
+
+// NewReactor returns a new state sync reactor, using the given app connections.
+func NewReactor(connSnapshot appconn.Snapshot, connQuery appconn.Query, chunkTimeout time.Duration) *Reactor {
+	r := &Reactor{
+		connSnapshot: connSnapshot,
+		connQuery:    connQuery,
+		chunkTimeout: chunkTimeout,
+	}
+	r.BaseReactor = *p2p.NewBaseReactor("StateSyncReactor", r)
+
+	return r
+}
+
+// GetChannels implements Reactor
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  SnapshotChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: snapshotMsgSize,
+		},
+		{
+			ID:                  ChunkChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: chunkMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor by requesting the snapshots of the peer, if
+// syncing.
+func (r *Reactor) AddPeer(peer p2p.PeerConn) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.syncer != nil {
+		peer.Send(SnapshotChannel, amino.MustMarshalAny(&snapshotsRequestMessage{}))
+	}
+}
+
+// RemovePeer implements Reactor by removing the peer from the syncer.
+func (r *Reactor) RemovePeer(peer p2p.PeerConn, _ any) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.syncer != nil {
+		r.syncer.RemovePeer(peer.ID())
+	}
+}
+
+// Receive implements Reactor
+func (r *Reactor) Receive(chID byte, src p2p.PeerConn, msgBytes []byte) {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	if err = msg.ValidateBasic(); err != nil {
+		r.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	r.Logger.Debug("Receive", "src", src, "chID", chID, "msg", msg)
+
+	switch msg := msg.(type) {
+	case *snapshotsRequestMessage:
+		r.respondSnapshots(src)
+	case *snapshotsResponseMessage:
+		r.mtx.RLock()
+		if r.syncer != nil {
+			r.syncer.AddSnapshot(src.ID(), msg.snapshot())
+		}
+		r.mtx.RUnlock()
+	case *chunkRequestMessage:
+		r.respondChunk(msg, src)
+	case *chunkResponseMessage:
+		r.mtx.RLock()
+		if r.syncer != nil {
+			r.syncer.AddChunk(src.ID(), msg)
+		}
+		r.mtx.RUnlock()
+	default:
+		r.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+	}
+}
+
+// respondSnapshots sends the recent snapshots of the application to the peer
+func (r *Reactor) respondSnapshots(src p2p.PeerConn) {
+	res, err := r.connSnapshot.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err == nil && res.Error != nil {
+		err = res.Error
+	}
+	if err != nil {
+		r.Logger.Error("Unable to list snapshots", "err", err)
+		return
+	}
+
+	snapshots := res.Snapshots
+	if len(snapshots) > recentSnapshots {
+		snapshots = snapshots[:recentSnapshots]
+	}
+
+	for _, snapshot := range snapshots {
+		src.TrySend(SnapshotChannel, amino.MustMarshalAny(&snapshotsResponseMessage{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   snapshot.Chunks,
+			Hash:     snapshot.Hash,
+			Metadata: snapshot.Metadata,
+		}))
+	}
+}
+
+// respondChunk sends the requested snapshot chunk to the peer, or that the
+// chunk is missing
+func (r *Reactor) respondChunk(msg *chunkRequestMessage, src p2p.PeerConn) {
+	res, err := r.connSnapshot.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+		Height: msg.Height,
+		Format: msg.Format,
+		Chunk:  msg.Index,
+	})
+	if err != nil {
+		r.Logger.Error("Unable to load snapshot chunk", "height", msg.Height, "index", msg.Index, "err", err)
+		return
+	}
+
+	src.TrySend(ChunkChannel, amino.MustMarshalAny(&chunkResponseMessage{
+		Height:  msg.Height,
+		Format:  msg.Format,
+		Index:   msg.Index,
+		Chunk:   res.Chunk,
+		Missing: res.Chunk == nil,
+	}))
+}
+
+// requestChunk requests a snapshot chunk from the peer
+func (r *Reactor) requestChunk(peerID p2pTypes.ID, msg *chunkRequestMessage) bool {
+	peer := r.Switch.Peers().Get(peerID)
+	if peer == nil {
+		return false
+	}
+
+	return peer.Send(ChunkChannel, amino.MustMarshalAny(msg))
+}
+
+// Sync discovers the snapshots of the peers during the discovery time, and
+// restores the best one that is verified by the state provider. It returns
+// the state and the commit of the height of the restored snapshot, used to
+// bootstrap the node.
+func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
+	r.mtx.Lock()
+	if r.syncer != nil {
+		r.mtx.Unlock()
+		return sm.State{}, nil, errAlreadySyncing
+	}
+	r.syncer = newSyncer(r.Logger, r.connSnapshot, r.connQuery, stateProvider, r.chunkTimeout, r.requestChunk)
+	s := r.syncer
+	r.mtx.Unlock()
+
+	defer func() {
+		r.mtx.Lock()
+		r.syncer = nil
+		r.mtx.Unlock()
+	}()
+
+	r.Logger.Info("Discovering snapshots", "duration", discoveryTime)
+	r.Switch.Broadcast(SnapshotChannel, amino.MustMarshalAny(&snapshotsRequestMessage{}))
+
+	return s.SyncAny(discoveryTime, r.Quit())
+}
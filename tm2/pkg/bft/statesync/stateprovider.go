@@ -0,0 +1,303 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	typesver "github.com/gnolang/gno/tm2/pkg/bft/types/version"
+	tmver "github.com/gnolang/gno/tm2/pkg/bft/version"
+)
+
+var errBelowTrustHeight = errors.New("height is below the trusted height")
+
+// StateProvider provides the verified data needed to restore a snapshot, and
+// to bootstrap the node at its height.
+type StateProvider interface {
+	// AppHash returns the verified app hash after the block at the height
+	AppHash(height int64) ([]byte, error)
+
+	// Commit returns the verified commit of the block at the height
+	Commit(height int64) (*types.Commit, error)
+
+	// State returns the verified state after the block at the height
+	State(height int64) (sm.State, error)
+}
+
+// rpcClient is the RPC client used to fetch the headers
+type rpcClient interface {
+	Commit(height *int64) (*ctypes.ResultCommit, error)
+	Validators(height *int64) (*ctypes.ResultValidators, error)
+	ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error)
+}
+
+// verifiedBlock is a verified header, with the validators signing it
+type verifiedBlock struct {
+	header     *types.SignedHeader
+	validators *types.ValidatorSet
+}
+
+func (b *verifiedBlock) lightBlock() *light.LightBlock {
+	return &light.LightBlock{
+		SignedHeader: b.header,
+		ValidatorSet: b.validators,
+	}
+}
+
+// rpcStateProvider is a StateProvider verifying the headers sequentially
+// from a trusted header, fetched from a primary RPC server, and checked
+// against witness RPC servers.
+type rpcStateProvider struct {
+	chainID     string
+	primary     rpcClient
+	witnesses   []rpcClient
+	trustHeight int64
+	trustHash   []byte
+
+	mtx     sync.Mutex
+	trusted *verifiedBlock
+	last    *verifiedBlock
+	recent  map[int64]*verifiedBlock // the last verified blocks
+}
+
+// NewRPCStateProvider returns a StateProvider fetching the headers from the
+// given RPC servers, the first one being the primary and the other ones
+// witnesses. The headers are verified from the trusted height and hash.
+func NewRPCStateProvider(chainID string, servers []string, trustHeight int64, trustHash []byte) (StateProvider, error) {
+	if len(servers) < 2 {
+		return nil, errors.New("at least 2 RPC servers are required")
+	}
+
+	clients := make([]rpcClient, 0, len(servers))
+	for _, server := range servers {
+		client, err := rpcclient.NewHTTPClient(server)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create RPC client for %s, %w", server, err)
+		}
+
+		clients = append(clients, client)
+	}
+
+	return newRPCStateProvider(chainID, clients[0], clients[1:], trustHeight, trustHash), nil
+}
+
+func newRPCStateProvider(
+	chainID string,
+	primary rpcClient,
+	witnesses []rpcClient,
+	trustHeight int64,
+	trustHash []byte,
+) *rpcStateProvider {
+	return &rpcStateProvider{
+		chainID:     chainID,
+		primary:     primary,
+		witnesses:   witnesses,
+		trustHeight: trustHeight,
+		trustHash:   trustHash,
+		recent:      make(map[int64]*verifiedBlock),
+	}
+}
+
+// AppHash implements StateProvider. The app hash after the block at the
+// height is in the header of the next block.
+func (p *rpcStateProvider) AppHash(height int64) ([]byte, error) {
+	blocks, err := p.verifiedBlocks(height)
+	if err != nil {
+		return nil, err
+	}
+
+	next := blocks[1].header
+	if err := p.checkWitnesses(next); err != nil {
+		return nil, err
+	}
+
+	return next.AppHash, nil
+}
+
+// Commit implements StateProvider.
+func (p *rpcStateProvider) Commit(height int64) (*types.Commit, error) {
+	blocks, err := p.verifiedBlocks(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks[0].header.Commit, nil
+}
+
+// State implements StateProvider.
+func (p *rpcStateProvider) State(height int64) (sm.State, error) {
+	blocks, err := p.verifiedBlocks(height)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	var (
+		last = blocks[0]
+		curr = blocks[1]
+		next = blocks[2]
+	)
+
+	res, err := p.primary.ConsensusParams(&curr.header.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch consensus params at height %d, %w", curr.header.Height, err)
+	}
+	if !bytes.Equal(res.ConsensusParams.Hash(), curr.header.ConsensusHash) {
+		return sm.State{}, fmt.Errorf("consensus params hash mismatch at height %d", curr.header.Height)
+	}
+
+	// The next validators are recorded as changed at the next height, if they
+	// differ from the current ones
+	lastHeightValidatorsChanged := curr.header.Height
+	if !bytes.Equal(next.header.ValidatorsHash, curr.header.ValidatorsHash) {
+		lastHeightValidatorsChanged = next.header.Height
+	}
+
+	return sm.State{
+		SoftwareVersion: tmver.Version,
+		BlockVersion:    typesver.BlockVersion,
+		AppVersion:      curr.header.AppVersion,
+		ChainID:         p.chainID,
+
+		LastBlockHeight:  last.header.Height,
+		LastBlockTotalTx: last.header.TotalTxs,
+		LastBlockID:      last.header.Commit.BlockID,
+		LastBlockTime:    last.header.Time,
+
+		NextValidators:              next.validators,
+		Validators:                  curr.validators,
+		LastValidators:              last.validators,
+		LastHeightValidatorsChanged: lastHeightValidatorsChanged,
+
+		ConsensusParams:                  res.ConsensusParams,
+		LastHeightConsensusParamsChanged: curr.header.Height,
+
+		LastResultsHash: curr.header.LastResultsHash,
+		AppHash:         curr.header.AppHash,
+	}, nil
+}
+
+// verifiedBlocks returns the verified blocks at the height, and the 2 next
+// heights
+func (p *rpcStateProvider) verifiedBlocks(height int64) ([3]*verifiedBlock, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var blocks [3]*verifiedBlock
+
+	if height < p.trustHeight {
+		return blocks, fmt.Errorf("%w, %d < %d", errBelowTrustHeight, height, p.trustHeight)
+	}
+
+	if err := p.verifyTo(height + 2); err != nil {
+		return blocks, err
+	}
+
+	for i := range blocks {
+		block, ok := p.recent[height+int64(i)]
+		if !ok {
+			return blocks, fmt.Errorf("block %d is not verified", height+int64(i))
+		}
+		blocks[i] = block
+	}
+
+	return blocks, nil
+}
+
+// verifyTo verifies the headers up to the height, sequentially
+func (p *rpcStateProvider) verifyTo(height int64) error {
+	if p.trusted == nil {
+		trusted, err := p.fetch(p.trustHeight)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(trusted.header.Hash(), p.trustHash) {
+			return fmt.Errorf("header hash %X at trusted height %d does not match the trusted hash %X",
+				trusted.header.Hash(), p.trustHeight, p.trustHash)
+		}
+		if err := trusted.validators.VerifyCommit(p.chainID, trusted.header.Commit.BlockID, p.trustHeight, trusted.header.Commit); err != nil {
+			return fmt.Errorf("invalid commit at trusted height %d, %w", p.trustHeight, err)
+		}
+
+		p.trusted = trusted
+	}
+
+	// Restart from the trusted header to verify a lower height
+	if p.last == nil || p.last.header.Height > height {
+		p.last = p.trusted
+		p.recent = map[int64]*verifiedBlock{p.trustHeight: p.trusted}
+	}
+
+	for p.last.header.Height < height {
+		prev := p.last
+
+		block, err := p.fetch(prev.header.Height + 1)
+		if err != nil {
+			return err
+		}
+		if err := light.VerifyAdjacentHeader(p.chainID, prev.lightBlock(), block.lightBlock()); err != nil {
+			return fmt.Errorf("unable to verify header at height %d, %w", block.header.Height, err)
+		}
+
+		p.last = block
+		p.recent[block.header.Height] = block
+		delete(p.recent, block.header.Height-3)
+	}
+
+	return nil
+}
+
+// fetch returns the signed header and the validators of the height, checking
+// that they are consistent
+func (p *rpcStateProvider) fetch(height int64) (*verifiedBlock, error) {
+	commit, err := p.primary.Commit(&height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch commit at height %d, %w", height, err)
+	}
+
+	header := commit.SignedHeader
+	if err := header.ValidateBasic(p.chainID); err != nil {
+		return nil, fmt.Errorf("invalid header at height %d, %w", height, err)
+	}
+	if header.Height != height {
+		return nil, fmt.Errorf("header at height %d returned for height %d", header.Height, height)
+	}
+
+	res, err := p.primary.Validators(&height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch validators at height %d, %w", height, err)
+	}
+
+	// The proposer priorities of the validators are kept
+	validators := &types.ValidatorSet{Validators: res.Validators}
+	if !bytes.Equal(validators.Hash(), header.ValidatorsHash) {
+		return nil, fmt.Errorf("validators hash mismatch at height %d", height)
+	}
+
+	return &verifiedBlock{
+		header:     &header,
+		validators: validators,
+	}, nil
+}
+
+// checkWitnesses checks that the witnesses have the same header as the
+// primary
+func (p *rpcStateProvider) checkWitnesses(header *types.SignedHeader) error {
+	for i, witness := range p.witnesses {
+		commit, err := witness.Commit(&header.Height)
+		if err != nil {
+			return fmt.Errorf("unable to fetch commit at height %d from witness %d, %w", header.Height, i, err)
+		}
+
+		if commit.Header == nil || !bytes.Equal(commit.Header.Hash(), header.Hash()) {
+			return fmt.Errorf("witness %d has a conflicting header at height %d", i, header.Height)
+		}
+	}
+
+	return nil
+}
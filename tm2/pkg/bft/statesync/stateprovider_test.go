@@ -0,0 +1,310 @@
+package statesync
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+const testChainID = "test-chain"
+
+// testChain is a chain of signed headers, with its validator sets
+type testChain struct {
+	headers    map[int64]*types.SignedHeader
+	validators map[int64]*types.ValidatorSet
+	params     abci.ConsensusParams
+}
+
+// newTestChain generates a chain of the given height. The validators change
+// at the given height, if any.
+func newTestChain(t *testing.T, height, changeHeight int64) *testChain {
+	t.Helper()
+
+	var (
+		vals1, privVals1 = types.RandValidatorSet(4, 10)
+		vals2, privVals2 = types.RandValidatorSet(3, 10)
+
+		chain = &testChain{
+			headers:    make(map[int64]*types.SignedHeader),
+			validators: make(map[int64]*types.ValidatorSet),
+			params:     types.DefaultConsensusParams(),
+		}
+	)
+
+	validatorsAt := func(h int64) (*types.ValidatorSet, []types.PrivValidator) {
+		if changeHeight > 0 && h >= changeHeight {
+			return vals2, privVals2
+		}
+
+		return vals1, privVals1
+	}
+
+	lastBlockID := types.BlockID{}
+	for h := int64(1); h <= height+1; h++ {
+		vals, privVals := validatorsAt(h)
+		nextVals, _ := validatorsAt(h + 1)
+
+		header := &types.Header{
+			ChainID:            testChainID,
+			Height:             h,
+			Time:               time.Unix(h, 0).UTC(),
+			TotalTxs:           h,
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: nextVals.Hash(),
+			ConsensusHash:      chain.params.Hash(),
+			AppHash:            fmt.Appendf(nil, "app hash %d", h),
+			ProposerAddress:    vals.Validators[0].Address,
+		}
+
+		blockID := types.BlockID{Hash: header.Hash()}
+		voteSet := types.NewVoteSet(testChainID, h, 0, types.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, h, 0, voteSet, privVals)
+		require.NoError(t, err)
+
+		chain.headers[h] = &types.SignedHeader{Header: header, Commit: commit}
+		chain.validators[h] = vals.Copy()
+		lastBlockID = blockID
+	}
+
+	// The last header is only used to generate the commit of the previous one
+	delete(chain.headers, height+1)
+
+	return chain
+}
+
+func (c *testChain) hash(height int64) []byte {
+	return c.headers[height].Hash()
+}
+
+// mockRPCClient serves the headers of a test chain
+type mockRPCClient struct {
+	chain   *testChain
+	headers map[int64]*types.SignedHeader // overrides the chain headers
+}
+
+func (c *mockRPCClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	header, ok := c.headers[*height]
+	if !ok {
+		header, ok = c.chain.headers[*height]
+	}
+	if !ok {
+		return nil, fmt.Errorf("height %d not found", *height)
+	}
+
+	return &ctypes.ResultCommit{SignedHeader: *header, CanonicalCommit: true}, nil
+}
+
+func (c *mockRPCClient) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	vals, ok := c.chain.validators[*height]
+	if !ok {
+		return nil, fmt.Errorf("height %d not found", *height)
+	}
+
+	return &ctypes.ResultValidators{BlockHeight: *height, Validators: vals.Copy().Validators}, nil
+}
+
+func (c *mockRPCClient) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	return &ctypes.ResultConsensusParams{BlockHeight: *height, ConsensusParams: c.chain.params}, nil
+}
+
+func newMockProvider(chain *testChain, trustHeight int64, trustHash []byte) *rpcStateProvider {
+	return newRPCStateProvider(
+		testChainID,
+		&mockRPCClient{chain: chain},
+		[]rpcClient{&mockRPCClient{chain: chain}},
+		trustHeight,
+		trustHash,
+	)
+}
+
+func TestRPCStateProvider_State(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chain    = newTestChain(t, 20, 0)
+		provider = newMockProvider(chain, 5, chain.hash(5))
+	)
+
+	appHash, err := provider.AppHash(10)
+	require.NoError(t, err)
+
+	// The app hash after the block is in the next header
+	assert.Equal(t, chain.headers[11].AppHash, appHash)
+
+	commit, err := provider.Commit(10)
+	require.NoError(t, err)
+	assert.Equal(t, chain.hash(10), commit.BlockID.Hash)
+
+	state, err := provider.State(10)
+	require.NoError(t, err)
+
+	assert.Equal(t, testChainID, state.ChainID)
+	assert.Equal(t, int64(10), state.LastBlockHeight)
+	assert.Equal(t, int64(10), state.LastBlockTotalTx)
+	assert.Equal(t, chain.hash(10), state.LastBlockID.Hash)
+	assert.Equal(t, chain.headers[10].Time, state.LastBlockTime)
+	assert.Equal(t, chain.headers[11].AppHash, state.AppHash)
+	assert.Equal(t, chain.validators[10].Hash(), state.LastValidators.Hash())
+	assert.Equal(t, chain.validators[11].Hash(), state.Validators.Hash())
+	assert.Equal(t, chain.validators[12].Hash(), state.NextValidators.Hash())
+	assert.Equal(t, int64(11), state.LastHeightValidatorsChanged)
+	assert.Equal(t, chain.params, state.ConsensusParams)
+	assert.Equal(t, int64(11), state.LastHeightConsensusParamsChanged)
+
+	// A lower height is verified again from the trusted header
+	state, err = provider.State(5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), state.LastBlockHeight)
+}
+
+func TestRPCStateProvider_ValidatorsChanged(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chain    = newTestChain(t, 20, 12)
+		provider = newMockProvider(chain, 5, chain.hash(5))
+	)
+
+	// The headers are verified across the validator set change
+	state, err := provider.State(10)
+	require.NoError(t, err)
+
+	assert.Equal(t, chain.validators[11].Hash(), state.Validators.Hash())
+	assert.Equal(t, chain.validators[12].Hash(), state.NextValidators.Hash())
+	assert.Equal(t, int64(12), state.LastHeightValidatorsChanged)
+
+	state, err = provider.State(15)
+	require.NoError(t, err)
+	assert.Equal(t, chain.validators[15].Hash(), state.LastValidators.Hash())
+}
+
+func TestRPCStateProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("below trusted height", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain    = newTestChain(t, 10, 0)
+			provider = newMockProvider(chain, 5, chain.hash(5))
+		)
+
+		_, err := provider.AppHash(4)
+		assert.ErrorIs(t, err, errBelowTrustHeight)
+	})
+
+	t.Run("trusted hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain    = newTestChain(t, 10, 0)
+			provider = newMockProvider(chain, 5, chain.hash(6))
+		)
+
+		_, err := provider.AppHash(5)
+		assert.ErrorContains(t, err, "does not match the trusted hash")
+	})
+
+	t.Run("forged header", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain  = newTestChain(t, 10, 0)
+			forged = newTestChain(t, 10, 0)
+		)
+
+		// The primary serves a header signed by other validators
+		provider := newRPCStateProvider(
+			testChainID,
+			&mockRPCClient{
+				chain:   chain,
+				headers: map[int64]*types.SignedHeader{7: forged.headers[7]},
+			},
+			nil,
+			5,
+			chain.hash(5),
+		)
+
+		_, err := provider.State(5)
+		assert.ErrorContains(t, err, "validators hash mismatch at height 7")
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newTestChain(t, 10, 0)
+
+		// The primary serves a header with another app hash
+		header := *chain.headers[6].Header
+		header.AppHash = []byte("tampered")
+
+		provider := newRPCStateProvider(
+			testChainID,
+			&mockRPCClient{
+				chain: chain,
+				headers: map[int64]*types.SignedHeader{
+					6: {Header: &header, Commit: chain.headers[6].Commit},
+				},
+			},
+			nil,
+			5,
+			chain.hash(5),
+		)
+
+		_, err := provider.AppHash(5)
+		assert.ErrorContains(t, err, "invalid header at height 6")
+	})
+
+	t.Run("unavailable header", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain    = newTestChain(t, 10, 0)
+			provider = newMockProvider(chain, 5, chain.hash(5))
+		)
+
+		_, err := provider.AppHash(9)
+		assert.ErrorContains(t, err, "unable to fetch commit at height 11")
+	})
+
+	t.Run("conflicting witness", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			chain  = newTestChain(t, 10, 0)
+			forged = newTestChain(t, 10, 0)
+		)
+
+		provider := newRPCStateProvider(
+			testChainID,
+			&mockRPCClient{chain: chain},
+			[]rpcClient{
+				&mockRPCClient{chain: chain},
+				&mockRPCClient{chain: forged},
+			},
+			5,
+			chain.hash(5),
+		)
+
+		_, err := provider.AppHash(6)
+		assert.ErrorContains(t, err, "witness 1 has a conflicting header at height 7")
+	})
+}
+
+func TestNewRPCStateProvider(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRPCStateProvider(testChainID, []string{"http://127.0.0.1:26657"}, 1, []byte("hash"))
+	assert.Error(t, err)
+
+	_, err = NewRPCStateProvider(testChainID, []string{"http://127.0.0.1:26657", "http://127.0.0.1:26658"}, 1, []byte("hash"))
+	assert.NoError(t, err)
+}
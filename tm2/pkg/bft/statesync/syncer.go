@@ -0,0 +1,463 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+// minDiscoveryTime is the minimum time spent discovering snapshots, when
+// none is available
+const minDiscoveryTime = time.Second
+
+var (
+	errAbort          = errors.New("state sync aborted")
+	errRejectSnapshot = errors.New("snapshot rejected")
+	errRejectFormat   = errors.New("snapshot format rejected")
+	errNoChunkPeers   = errors.New("no peer serves the snapshot chunk")
+	errVerifyFailed   = errors.New("restored app verification failed")
+)
+
+// snapshotKey identifies a snapshot
+type snapshotKey struct {
+	height int64
+	format uint32
+	hash   string
+}
+
+func keyOf(snapshot *abci.Snapshot) snapshotKey {
+	return snapshotKey{
+		height: snapshot.Height,
+		format: snapshot.Format,
+		hash:   string(snapshot.Hash),
+	}
+}
+
+// discoveredSnapshot is a snapshot advertised by peers
+type discoveredSnapshot struct {
+	snapshot *abci.Snapshot
+	peers    []p2pTypes.ID
+}
+
+// chunkResponse is a chunk response received from a peer
+type chunkResponse struct {
+	peerID p2pTypes.ID
+	msg    *chunkResponseMessage
+}
+
+// syncer discovers the snapshots of the peers, and restores them into the
+// application, one chunk at a time.
+type syncer struct {
+	logger        *slog.Logger
+	connSnapshot  appconn.Snapshot
+	connQuery     appconn.Query
+	stateProvider StateProvider
+	chunkTimeout  time.Duration
+	requestChunk  func(p2pTypes.ID, *chunkRequestMessage) bool
+
+	mtx             sync.Mutex
+	snapshots       map[snapshotKey]*discoveredSnapshot
+	rejected        map[snapshotKey]struct{}
+	rejectedFormats map[uint32]struct{}
+	rejectedPeers   map[p2pTypes.ID]struct{}
+	current         *abci.Snapshot // snapshot being restored, if any
+
+	chunks chan chunkResponse // chunks of the current snapshot
+}
+
+func newSyncer(
+	logger *slog.Logger,
+	connSnapshot appconn.Snapshot,
+	connQuery appconn.Query,
+	stateProvider StateProvider,
+	chunkTimeout time.Duration,
+	requestChunk func(p2pTypes.ID, *chunkRequestMessage) bool,
+) *syncer {
+	return &syncer{
+		logger:          logger,
+		connSnapshot:    connSnapshot,
+		connQuery:       connQuery,
+		stateProvider:   stateProvider,
+		chunkTimeout:    chunkTimeout,
+		requestChunk:    requestChunk,
+		snapshots:       make(map[snapshotKey]*discoveredSnapshot),
+		rejected:        make(map[snapshotKey]struct{}),
+		rejectedFormats: make(map[uint32]struct{}),
+		rejectedPeers:   make(map[p2pTypes.ID]struct{}),
+		chunks:          make(chan chunkResponse, 1),
+	}
+}
+
+// AddSnapshot adds a snapshot advertised by the peer, and returns whether
+// it can be restored
+func (s *syncer) AddSnapshot(peerID p2pTypes.ID, snapshot *abci.Snapshot) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := keyOf(snapshot)
+	if _, ok := s.rejected[key]; ok {
+		return false
+	}
+	if _, ok := s.rejectedFormats[snapshot.Format]; ok {
+		return false
+	}
+	if _, ok := s.rejectedPeers[peerID]; ok {
+		return false
+	}
+
+	discovered, ok := s.snapshots[key]
+	if !ok {
+		s.logger.Info("Discovered snapshot", "height", snapshot.Height, "format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
+
+		discovered = &discoveredSnapshot{snapshot: snapshot}
+		s.snapshots[key] = discovered
+	}
+
+	for _, id := range discovered.peers {
+		if id == peerID {
+			return true
+		}
+	}
+	discovered.peers = append(discovered.peers, peerID)
+
+	return true
+}
+
+// RemovePeer removes the peer from the peers serving the snapshots
+func (s *syncer) RemovePeer(peerID p2pTypes.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.removePeer(peerID)
+}
+
+func (s *syncer) removePeer(peerID p2pTypes.ID) {
+	for key, discovered := range s.snapshots {
+		for i, id := range discovered.peers {
+			if id == peerID {
+				discovered.peers = append(discovered.peers[:i], discovered.peers[i+1:]...)
+				break
+			}
+		}
+
+		if len(discovered.peers) == 0 {
+			delete(s.snapshots, key)
+		}
+	}
+}
+
+// AddChunk adds a chunk received from the peer. The chunks which are not
+// part of the snapshot being restored are dropped
+func (s *syncer) AddChunk(peerID p2pTypes.ID, msg *chunkResponseMessage) {
+	s.mtx.Lock()
+	current := s.current
+	s.mtx.Unlock()
+
+	if current == nil || current.Height != msg.Height || current.Format != msg.Format {
+		return
+	}
+
+	select {
+	case s.chunks <- chunkResponse{peerID: peerID, msg: msg}:
+	default:
+		s.logger.Debug("Dropped unexpected chunk", "peer", peerID, "index", msg.Index)
+	}
+}
+
+// best returns the best snapshot to restore, the highest one with the most
+// peers
+func (s *syncer) best() *abci.Snapshot {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	candidates := make([]*discoveredSnapshot, 0, len(s.snapshots))
+	for _, discovered := range s.snapshots {
+		candidates = append(candidates, discovered)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch {
+		case a.snapshot.Height != b.snapshot.Height:
+			return a.snapshot.Height > b.snapshot.Height
+		case a.snapshot.Format != b.snapshot.Format:
+			return a.snapshot.Format > b.snapshot.Format
+		default:
+			return len(a.peers) > len(b.peers)
+		}
+	})
+
+	return candidates[0].snapshot
+}
+
+// reject rejects a snapshot, so that it is never restored
+func (s *syncer) reject(snapshot *abci.Snapshot) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := keyOf(snapshot)
+	s.rejected[key] = struct{}{}
+	delete(s.snapshots, key)
+}
+
+// rejectFormat rejects the snapshots of a format
+func (s *syncer) rejectFormat(format uint32) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.rejectedFormats[format] = struct{}{}
+	for key := range s.snapshots {
+		if key.format == format {
+			delete(s.snapshots, key)
+		}
+	}
+}
+
+// rejectPeer rejects the snapshots and chunks of a peer
+func (s *syncer) rejectPeer(peerID p2pTypes.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.rejectedPeers[peerID] = struct{}{}
+	s.removePeer(peerID)
+}
+
+// peersOf returns the peers serving the snapshot
+func (s *syncer) peersOf(snapshot *abci.Snapshot) []p2pTypes.ID {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	discovered, ok := s.snapshots[keyOf(snapshot)]
+	if !ok {
+		return nil
+	}
+
+	return append([]p2pTypes.ID(nil), discovered.peers...)
+}
+
+// SyncAny waits for the discovery time, and restores the best discovered
+// snapshot, moving on to the next ones as long as they are rejected
+func (s *syncer) SyncAny(discoveryTime time.Duration, quit <-chan struct{}) (sm.State, *types.Commit, error) {
+	wait := func(d time.Duration) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-quit:
+			return errAbort
+		}
+	}
+
+	if err := wait(discoveryTime); err != nil {
+		return sm.State{}, nil, err
+	}
+
+	for {
+		snapshot := s.best()
+		if snapshot == nil {
+			s.logger.Info("No snapshot available yet, discovering snapshots")
+
+			if err := wait(max(discoveryTime, minDiscoveryTime)); err != nil {
+				return sm.State{}, nil, err
+			}
+
+			continue
+		}
+
+		state, commit, err := s.Sync(snapshot, quit)
+		switch {
+		case err == nil:
+			return state, commit, nil
+		case errors.Is(err, errRejectFormat):
+			s.logger.Info("Snapshot format rejected", "format", snapshot.Format)
+			s.rejectFormat(snapshot.Format)
+		case errors.Is(err, errAbort), errors.Is(err, errVerifyFailed):
+			return sm.State{}, nil, err
+		default:
+			s.logger.Info("Snapshot rejected", "height", snapshot.Height, "format", snapshot.Format, "err", err)
+			s.reject(snapshot)
+		}
+	}
+}
+
+// Sync restores the given snapshot, and verifies the restored application.
+func (s *syncer) Sync(snapshot *abci.Snapshot, quit <-chan struct{}) (sm.State, *types.Commit, error) {
+	appHash, err := s.stateProvider.AppHash(snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("%w, unable to verify app hash: %w", errRejectSnapshot, err)
+	}
+
+	if err := s.offer(snapshot, appHash); err != nil {
+		return sm.State{}, nil, err
+	}
+
+	s.mtx.Lock()
+	s.current = snapshot
+	s.mtx.Unlock()
+
+	defer func() {
+		s.mtx.Lock()
+		s.current = nil
+		s.mtx.Unlock()
+	}()
+
+	s.logger.Info("Restoring snapshot", "height", snapshot.Height, "format", snapshot.Format, "chunks", snapshot.Chunks)
+
+	for index := uint32(0); index < snapshot.Chunks; {
+		chunk, peerID, err := s.fetchChunk(snapshot, index, quit)
+		if err != nil {
+			return sm.State{}, nil, err
+		}
+
+		res, err := s.connSnapshot.ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
+			Index:  index,
+			Chunk:  chunk,
+			Sender: string(peerID),
+		})
+		if err != nil {
+			return sm.State{}, nil, fmt.Errorf("%w, unable to apply chunk: %w", errAbort, err)
+		}
+
+		for _, sender := range res.RejectSenders {
+			s.rejectPeer(p2pTypes.ID(sender))
+		}
+
+		switch res.Result {
+		case abci.ApplySnapshotChunkAccept:
+			index++
+		case abci.ApplySnapshotChunkRetry:
+			for _, refetch := range res.RefetchChunks {
+				index = min(index, refetch)
+			}
+		case abci.ApplySnapshotChunkRejectSnapshot:
+			return sm.State{}, nil, errRejectSnapshot
+		default:
+			return sm.State{}, nil, fmt.Errorf("%w, chunk %d: %v", errAbort, index, res.Result)
+		}
+	}
+
+	if err := s.verifyApp(snapshot, appHash); err != nil {
+		return sm.State{}, nil, err
+	}
+
+	state, err := s.stateProvider.State(snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("%w, unable to build state: %w", errAbort, err)
+	}
+	commit, err := s.stateProvider.Commit(snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("%w, unable to get commit: %w", errAbort, err)
+	}
+
+	s.logger.Info("Snapshot restored", "height", snapshot.Height, "app_hash", fmt.Sprintf("%X", appHash))
+
+	return state, commit, nil
+}
+
+// offer offers the snapshot to the application
+func (s *syncer) offer(snapshot *abci.Snapshot, appHash []byte) error {
+	res, err := s.connSnapshot.OfferSnapshotSync(abci.RequestOfferSnapshot{
+		Snapshot: snapshot,
+		AppHash:  appHash,
+	})
+	if err != nil {
+		return fmt.Errorf("%w, unable to offer snapshot: %w", errAbort, err)
+	}
+
+	switch res.Result {
+	case abci.OfferSnapshotAccept:
+		return nil
+	case abci.OfferSnapshotReject:
+		return errRejectSnapshot
+	case abci.OfferSnapshotRejectFormat:
+		return errRejectFormat
+	default:
+		return fmt.Errorf("%w, snapshot offer result %v", errAbort, res.Result)
+	}
+}
+
+// fetchChunk requests the chunk from the peers serving the snapshot, in turn,
+// until one of them returns it
+func (s *syncer) fetchChunk(snapshot *abci.Snapshot, index uint32, quit <-chan struct{}) ([]byte, p2pTypes.ID, error) {
+	peers := s.peersOf(snapshot)
+
+	// Spread the requests over the peers
+	for i := range peers {
+		peerID := peers[(int(index)+i)%len(peers)]
+
+		request := &chunkRequestMessage{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Index:  index,
+		}
+		if !s.requestChunk(peerID, request) {
+			continue
+		}
+
+		chunk, err := s.waitChunk(peerID, index, quit)
+		if err != nil {
+			return nil, "", err
+		}
+		if chunk != nil {
+			return chunk, peerID, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("%w, chunk %d", errNoChunkPeers, index)
+}
+
+// waitChunk waits for the chunk from the peer, and returns nil if the peer
+// doesn't send it in time
+func (s *syncer) waitChunk(peerID p2pTypes.ID, index uint32, quit <-chan struct{}) ([]byte, error) {
+	timeout := time.NewTimer(s.chunkTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case res := <-s.chunks:
+			if res.peerID != peerID || res.msg.Index != index {
+				continue
+			}
+			if res.msg.Missing {
+				return nil, nil
+			}
+
+			return res.msg.Chunk, nil
+		case <-timeout.C:
+			s.logger.Debug("Timed out waiting for chunk", "peer", peerID, "index", index)
+			return nil, nil
+		case <-quit:
+			return nil, errAbort
+		}
+	}
+}
+
+// verifyApp verifies that the restored application is at the height and
+// app hash of the snapshot
+func (s *syncer) verifyApp(snapshot *abci.Snapshot, appHash []byte) error {
+	res, err := s.connQuery.InfoSync(abci.RequestInfo{})
+	if err != nil {
+		return fmt.Errorf("%w, unable to query app info: %w", errVerifyFailed, err)
+	}
+
+	if res.LastBlockHeight != snapshot.Height {
+		return fmt.Errorf("%w, app height %d, expected %d", errVerifyFailed, res.LastBlockHeight, snapshot.Height)
+	}
+	if !bytes.Equal(res.LastBlockAppHash, appHash) {
+		return fmt.Errorf("%w, app hash %X, expected %X", errVerifyFailed, res.LastBlockAppHash, appHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,345 @@
+package statesync
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/gnolang/gno/tm2/pkg/bft/abci/client"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+// mockApp is an application restoring the snapshot chunks in memory
+type mockApp struct {
+	abci.BaseApplication
+
+	mtx        sync.Mutex
+	offered    []*abci.Snapshot
+	rejected   map[int64]bool // offered heights to reject
+	badChunks  map[string]bool
+	restored   [][]byte
+	height     int64
+	appHash    []byte
+	reportHash []byte // app hash reported by Info, if any
+}
+
+func newMockApp() *mockApp {
+	return &mockApp{
+		rejected:  make(map[int64]bool),
+		badChunks: make(map[string]bool),
+	}
+}
+
+func (app *mockApp) Info(abci.RequestInfo) abci.ResponseInfo {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	appHash := app.appHash
+	if app.reportHash != nil {
+		appHash = app.reportHash
+	}
+
+	return abci.ResponseInfo{
+		LastBlockHeight:  app.height,
+		LastBlockAppHash: appHash,
+	}
+}
+
+func (app *mockApp) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	app.offered = append(app.offered, req.Snapshot)
+	if app.rejected[req.Snapshot.Height] {
+		return abci.ResponseOfferSnapshot{Result: abci.OfferSnapshotReject}
+	}
+
+	app.restored = nil
+	app.height = req.Snapshot.Height
+	app.appHash = req.AppHash
+
+	return abci.ResponseOfferSnapshot{Result: abci.OfferSnapshotAccept}
+}
+
+func (app *mockApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	if app.badChunks[string(req.Chunk)] {
+		return abci.ResponseApplySnapshotChunk{
+			Result:        abci.ApplySnapshotChunkRetry,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+	}
+
+	app.restored = append(app.restored, req.Chunk)
+
+	return abci.ResponseApplySnapshotChunk{Result: abci.ApplySnapshotChunkAccept}
+}
+
+// mockStateProvider returns the app hash of the height, and empty states
+type mockStateProvider struct {
+	appHashes map[int64][]byte
+}
+
+func (p *mockStateProvider) AppHash(height int64) ([]byte, error) {
+	appHash, ok := p.appHashes[height]
+	if !ok {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+
+	return appHash, nil
+}
+
+func (p *mockStateProvider) Commit(height int64) (*types.Commit, error) {
+	return &types.Commit{Precommits: []*types.CommitSig{{Height: height}}}, nil
+}
+
+func (p *mockStateProvider) State(height int64) (sm.State, error) {
+	return sm.State{LastBlockHeight: height, AppHash: p.appHashes[height]}, nil
+}
+
+// chunkServer serves the chunks of the snapshots of the peers
+type chunkServer struct {
+	mtx      sync.Mutex
+	syncer   *syncer
+	chunks   map[p2pTypes.ID]map[int64][][]byte
+	requests map[p2pTypes.ID]int
+}
+
+func (cs *chunkServer) requestChunk(peerID p2pTypes.ID, msg *chunkRequestMessage) bool {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	chunks, ok := cs.chunks[peerID]
+	if !ok {
+		return false
+	}
+	cs.requests[peerID]++
+
+	res := &chunkResponseMessage{
+		Height:  msg.Height,
+		Format:  msg.Format,
+		Index:   msg.Index,
+		Missing: true,
+	}
+	if snapshotChunks, ok := chunks[msg.Height]; ok && int(msg.Index) < len(snapshotChunks) {
+		res.Chunk = snapshotChunks[msg.Index]
+		res.Missing = false
+	}
+
+	go cs.syncer.AddChunk(peerID, res)
+
+	return true
+}
+
+func newTestSyncer(t *testing.T, app *mockApp, provider StateProvider) (*syncer, *chunkServer) {
+	t.Helper()
+
+	var (
+		mtx    sync.Mutex
+		client = abcicli.NewLocalClient(&mtx, app)
+		server = &chunkServer{
+			chunks:   make(map[p2pTypes.ID]map[int64][][]byte),
+			requests: make(map[p2pTypes.ID]int),
+		}
+	)
+
+	server.syncer = newSyncer(
+		log.NewNoopLogger(),
+		appconn.NewSnapshot(client),
+		appconn.NewQuery(client),
+		provider,
+		time.Second,
+		server.requestChunk,
+	)
+
+	return server.syncer, server
+}
+
+func TestSyncer_SyncAny(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app      = newMockApp()
+		provider = &mockStateProvider{
+			appHashes: map[int64][]byte{10: []byte("hash10"), 20: []byte("hash20")},
+		}
+		s, server = newTestSyncer(t, app, provider)
+
+		chunks10 = [][]byte{[]byte("a"), []byte("b")}
+		chunks20 = [][]byte{[]byte("c"), []byte("d"), []byte("e")}
+	)
+
+	server.chunks["peer1"] = map[int64][][]byte{10: chunks10, 20: chunks20}
+	server.chunks["peer2"] = map[int64][][]byte{20: chunks20}
+
+	assert.True(t, s.AddSnapshot("peer1", &abci.Snapshot{Height: 10, Format: 1, Chunks: 2, Hash: []byte("s10")}))
+	assert.True(t, s.AddSnapshot("peer1", &abci.Snapshot{Height: 20, Format: 1, Chunks: 3, Hash: []byte("s20")}))
+	assert.True(t, s.AddSnapshot("peer2", &abci.Snapshot{Height: 20, Format: 1, Chunks: 3, Hash: []byte("s20")}))
+
+	state, commit, err := s.SyncAny(0, make(chan struct{}))
+	require.NoError(t, err)
+
+	// The highest snapshot is restored, from both peers
+	assert.Equal(t, int64(20), state.LastBlockHeight)
+	assert.Equal(t, []byte("hash20"), state.AppHash)
+	assert.Equal(t, int64(20), commit.Height())
+
+	require.Len(t, app.offered, 1)
+	assert.Equal(t, int64(20), app.offered[0].Height)
+	assert.Equal(t, chunks20, app.restored)
+	assert.NotZero(t, server.requests["peer1"])
+	assert.NotZero(t, server.requests["peer2"])
+}
+
+func TestSyncer_SyncAny_Rejected(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app      = newMockApp()
+		provider = &mockStateProvider{
+			// The app hash of the highest snapshot can't be verified
+			appHashes: map[int64][]byte{10: []byte("hash10"), 20: []byte("hash20")},
+		}
+		s, server = newTestSyncer(t, app, provider)
+
+		chunks = [][]byte{[]byte("a"), []byte("b")}
+	)
+
+	app.rejected[20] = true
+	server.chunks["peer1"] = map[int64][][]byte{10: chunks}
+
+	s.AddSnapshot("peer1", &abci.Snapshot{Height: 10, Format: 1, Chunks: 2, Hash: []byte("s10")})
+	s.AddSnapshot("peer1", &abci.Snapshot{Height: 20, Format: 1, Chunks: 2, Hash: []byte("s20")})
+	s.AddSnapshot("peer1", &abci.Snapshot{Height: 30, Format: 1, Chunks: 2, Hash: []byte("s30")})
+
+	state, _, err := s.SyncAny(0, make(chan struct{}))
+	require.NoError(t, err)
+
+	// The unverified and rejected snapshots are skipped
+	assert.Equal(t, int64(10), state.LastBlockHeight)
+	require.Len(t, app.offered, 2)
+	assert.Equal(t, int64(20), app.offered[0].Height)
+	assert.Equal(t, int64(10), app.offered[1].Height)
+	assert.Equal(t, chunks, app.restored)
+
+	// The rejected snapshots are not discovered again
+	assert.False(t, s.AddSnapshot("peer2", &abci.Snapshot{Height: 20, Format: 1, Chunks: 2, Hash: []byte("s20")}))
+}
+
+func TestSyncer_Sync_RetryChunk(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app      = newMockApp()
+		provider = &mockStateProvider{
+			appHashes: map[int64][]byte{10: []byte("hash10")},
+		}
+		s, server = newTestSyncer(t, app, provider)
+
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 2, Hash: []byte("s10")}
+		chunks   = [][]byte{[]byte("a"), []byte("b")}
+	)
+
+	// peer1 serves a corrupted first chunk
+	app.badChunks["bad"] = true
+	server.chunks["peer1"] = map[int64][][]byte{10: {[]byte("bad"), []byte("b")}}
+	server.chunks["peer2"] = map[int64][][]byte{10: chunks}
+
+	s.AddSnapshot("peer1", snapshot)
+	s.AddSnapshot("peer2", snapshot)
+
+	_, _, err := s.Sync(snapshot, make(chan struct{}))
+	require.NoError(t, err)
+
+	// The chunk is fetched again from the other peer, and the sender rejected
+	assert.Equal(t, chunks, app.restored)
+	assert.Equal(t, []p2pTypes.ID{"peer2"}, s.peersOf(snapshot))
+	assert.False(t, s.AddSnapshot("peer1", &abci.Snapshot{Height: 20, Format: 1, Chunks: 1}))
+}
+
+func TestSyncer_Sync_NoChunkPeers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app      = newMockApp()
+		provider = &mockStateProvider{
+			appHashes: map[int64][]byte{10: []byte("hash10")},
+		}
+		s, server = newTestSyncer(t, app, provider)
+
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 2, Hash: []byte("s10")}
+	)
+
+	// The peer is missing the last chunk
+	server.chunks["peer1"] = map[int64][][]byte{10: {[]byte("a")}}
+	s.AddSnapshot("peer1", snapshot)
+
+	_, _, err := s.Sync(snapshot, make(chan struct{}))
+	assert.ErrorIs(t, err, errNoChunkPeers)
+}
+
+func TestSyncer_Sync_VerifyFailed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app      = newMockApp()
+		provider = &mockStateProvider{
+			appHashes: map[int64][]byte{10: []byte("hash10")},
+		}
+		s, server = newTestSyncer(t, app, provider)
+
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte("s10")}
+	)
+
+	app.reportHash = []byte("other hash")
+	server.chunks["peer1"] = map[int64][][]byte{10: {[]byte("a")}}
+	s.AddSnapshot("peer1", snapshot)
+
+	_, _, err := s.SyncAny(0, make(chan struct{}))
+	assert.ErrorIs(t, err, errVerifyFailed)
+}
+
+func TestSyncer_SyncAny_Quit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s, _ = newTestSyncer(t, newMockApp(), &mockStateProvider{})
+		quit = make(chan struct{})
+	)
+
+	close(quit)
+
+	_, _, err := s.SyncAny(time.Minute, quit)
+	assert.ErrorIs(t, err, errAbort)
+}
+
+func TestSyncer_RemovePeer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s, _     = newTestSyncer(t, newMockApp(), &mockStateProvider{})
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte("s10")}
+	)
+
+	s.AddSnapshot("peer1", snapshot)
+	s.AddSnapshot("peer2", snapshot)
+
+	s.RemovePeer("peer1")
+	assert.Equal(t, []p2pTypes.ID{"peer2"}, s.peersOf(snapshot))
+
+	// The snapshot is dropped with its last peer
+	s.RemovePeer("peer2")
+	assert.Nil(t, s.best())
+}
@@ -22,6 +22,9 @@ Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
 
+The store holds the contiguous range of blocks [base, height]. Blocks below
+the base have been pruned (see PruneBlocks).
+
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
 */
@@ -29,6 +32,7 @@ type BlockStore struct {
 	db dbm.DB
 
 	mtx    sync.RWMutex
+	base   int64
 	height int64
 }
 
@@ -37,11 +41,20 @@ type BlockStore struct {
 func NewBlockStore(db dbm.DB) *BlockStore {
 	bsjson := LoadBlockStoreStateJSON(db)
 	return &BlockStore{
+		base:   bsjson.Base,
 		height: bsjson.Height,
 		db:     db,
 	}
 }
 
+// Base returns the first known contiguous block height, or 0 for an empty
+// block store.
+func (bs *BlockStore) Base() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.base
+}
+
 // Height returns the last known contiguous block height.
 func (bs *BlockStore) Height() int64 {
 	bs.mtx.RLock()
@@ -61,6 +74,10 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 	buf := []byte{}
 	for i := range blockMeta.BlockID.PartsHeader.Total {
 		part := bs.LoadBlockPart(height, i)
+		if part == nil {
+			// The block has been pruned since its meta was loaded
+			return nil
+		}
 		buf = append(buf, part.Bytes...)
 	}
 	err := amino.UnmarshalSized(buf, block)
@@ -176,17 +193,130 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
 
 	// Save new BlockStoreStateJSON descriptor
-	BlockStoreStateJSON{Height: height}.Save(bs.db)
-
-	// Done!
 	bs.mtx.Lock()
+	if bs.base == 0 {
+		bs.base = height
+	}
 	bs.height = height
+	BlockStoreStateJSON{Base: bs.base, Height: height}.Save(bs.db)
 	bs.mtx.Unlock()
 
 	// Flush
 	bs.db.SetSync(nil, nil)
 }
 
+// Bootstrap initializes an empty store at the given height, e.g. after the
+// state is restored with state sync, saving the seen commit of the block at
+// the height. The next saved block is the block at height+1, which becomes
+// the base of the store.
+func (bs *BlockStore) Bootstrap(height int64, seenCommit *types.Commit) error {
+	if height <= 0 {
+		return fmt.Errorf("bootstrap height must be greater than 0, got %d", height)
+	}
+	if seenCommit == nil || seenCommit.Height() != height {
+		return fmt.Errorf("invalid seen commit for height %d", height)
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	if bs.height != 0 {
+		return fmt.Errorf("cannot bootstrap a non-empty store at height %d", bs.height)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(calcSeenCommitKey(height), amino.MustMarshal(seenCommit))
+	batch.Set(blockStoreKey, BlockStoreStateJSON{Base: height + 1, Height: height}.Bytes())
+	batch.WriteSync()
+
+	bs.base, bs.height = height+1, height
+
+	return nil
+}
+
+// PruneBlocks removes the blocks, parts, commits and seen commits below the
+// given retain height, and returns the number of pruned blocks.
+// The retain height can't be above the height of the store, so that the
+// latest block is always kept.
+func (bs *BlockStore) PruneBlocks(retainHeight int64) (uint64, error) {
+	if retainHeight <= 0 {
+		return 0, fmt.Errorf("retain height must be greater than 0, got %d", retainHeight)
+	}
+
+	bs.mtx.RLock()
+	base, height := bs.base, bs.height
+	bs.mtx.RUnlock()
+
+	if retainHeight > height {
+		return 0, fmt.Errorf("cannot prune beyond the latest height %d", height)
+	}
+	if retainHeight <= base {
+		return 0, nil
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	for h := base; h < retainHeight; h++ {
+		if meta := bs.LoadBlockMeta(h); meta != nil {
+			for i := range meta.BlockID.PartsHeader.Total {
+				batch.Delete(calcBlockPartKey(h, i))
+			}
+		}
+		batch.Delete(calcBlockMetaKey(h))
+		batch.Delete(calcBlockCommitKey(h))
+		batch.Delete(calcSeenCommitKey(h))
+	}
+
+	// The new base is saved with the deletions, so an interrupted
+	// pruning never leaves a base pointing to a pruned block
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	bs.base = retainHeight
+	batch.Set(blockStoreKey, BlockStoreStateJSON{Base: retainHeight, Height: bs.height}.Bytes())
+	batch.WriteSync()
+
+	return uint64(retainHeight - base), nil
+}
+
+// DeleteLatestBlock removes the latest block, its parts and seen commit, and
+// the commit of the previous block it holds, e.g. to roll back the node by
+// one height. The seen commit of the previous block is kept, so that the
+// node can restart from it.
+func (bs *BlockStore) DeleteLatestBlock() error {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	height := bs.height
+	if height == 0 {
+		return fmt.Errorf("no block to delete")
+	}
+	if height <= bs.base {
+		return fmt.Errorf("cannot delete the only block %d of the store", height)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	if meta := bs.LoadBlockMeta(height); meta != nil {
+		for i := range meta.BlockID.PartsHeader.Total {
+			batch.Delete(calcBlockPartKey(height, i))
+		}
+	}
+	batch.Delete(calcBlockMetaKey(height))
+	batch.Delete(calcBlockCommitKey(height - 1))
+	batch.Delete(calcSeenCommitKey(height))
+
+	bs.height = height - 1
+	batch.Set(blockStoreKey, BlockStoreStateJSON{Base: bs.base, Height: bs.height}.Bytes())
+	batch.WriteSync()
+
+	return nil
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 	if height != bs.Height()+1 {
 		panic(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", bs.Height()+1, height))
@@ -219,16 +349,22 @@ var blockStoreKey = []byte("blockStore")
 
 // BlockStoreStateJSON is the block store state JSON structure.
 type BlockStoreStateJSON struct {
+	Base   int64 `json:"base"`
 	Height int64 `json:"height"`
 }
 
-// Save persists the blockStore state to the database as JSON.
-func (bsj BlockStoreStateJSON) Save(db dbm.DB) {
+// Bytes returns the JSON encoding of the blockStore state.
+func (bsj BlockStoreStateJSON) Bytes() []byte {
 	bytes, err := amino.MarshalJSON(bsj)
 	if err != nil {
 		panic(fmt.Sprintf("Could not marshal state bytes: %v", err))
 	}
-	db.SetSync(blockStoreKey, bytes)
+	return bytes
+}
+
+// Save persists the blockStore state to the database as JSON.
+func (bsj BlockStoreStateJSON) Save(db dbm.DB) {
+	db.SetSync(blockStoreKey, bsj.Bytes())
 }
 
 // LoadBlockStoreStateJSON returns the BlockStoreStateJSON as loaded from disk.
@@ -245,5 +381,9 @@ func LoadBlockStoreStateJSON(db dbm.DB) BlockStoreStateJSON {
 	if err != nil {
 		panic(fmt.Sprintf("Could not unmarshal bytes: %X", bytes))
 	}
+	// Block stores saved before pruning was supported have no base
+	if bsj.Base == 0 && bsj.Height > 0 {
+		bsj.Base = 1
+	}
 	return bsj
 }
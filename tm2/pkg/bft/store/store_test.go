@@ -63,7 +63,7 @@ func TestLoadBlockStoreStateJSON(t *testing.T) {
 
 	db := memdb.NewMemDB()
 
-	bsj := &BlockStoreStateJSON{Height: 1000}
+	bsj := &BlockStoreStateJSON{Base: 100, Height: 1000}
 	bsj.Save(db)
 
 	retrBSJ := LoadBlockStoreStateJSON(db)
@@ -71,6 +71,18 @@ func TestLoadBlockStoreStateJSON(t *testing.T) {
 	assert.Equal(t, *bsj, retrBSJ, "expected the retrieved DBs to match")
 }
 
+func TestLoadBlockStoreStateJSON_NoBase(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	db.Set(blockStoreKey, []byte(`{"height": "1000"}`))
+
+	retrBSJ := LoadBlockStoreStateJSON(db)
+
+	assert.Equal(t, BlockStoreStateJSON{Base: 1, Height: 1000}, retrBSJ,
+		"expected the base of a non-empty store to default to 1")
+}
+
 func TestNewBlockStore(t *testing.T) {
 	t.Parallel()
 
@@ -421,6 +433,146 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	require.Nil(t, blockAtHeightPlus2, "expecting an unsuccessful load of Height()+2")
 }
 
+func TestPruneBlocks(t *testing.T) {
+	t.Parallel()
+
+	state, bs, cleanup := makeStateAndBlockStore(log.NewNoopLogger())
+	defer cleanup()
+	db := bs.db
+
+	assert.Equal(t, int64(0), bs.Base())
+	_, err := bs.PruneBlocks(1)
+	require.Error(t, err, "expecting an error on an empty store")
+
+	// Save 10 blocks
+	for h := int64(1); h <= 10; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		partSet := block.MakePartSet(2)
+		bs.SaveBlock(block, partSet, makeTestCommit(h, tmtime.Now()))
+	}
+
+	assert.Equal(t, int64(1), bs.Base())
+	assert.Equal(t, int64(10), bs.Height())
+
+	_, err = bs.PruneBlocks(0)
+	require.Error(t, err)
+	_, err = bs.PruneBlocks(11)
+	require.Error(t, err, "expecting an error when pruning the latest block")
+
+	pruned, err := bs.PruneBlocks(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), pruned)
+	assert.Equal(t, int64(5), bs.Base())
+	assert.Equal(t, int64(10), bs.Height())
+
+	for h := int64(1); h < 5; h++ {
+		assert.Nil(t, bs.LoadBlock(h), "block %d should be pruned", h)
+		assert.Nil(t, bs.LoadBlockMeta(h))
+		assert.Nil(t, bs.LoadBlockPart(h, 0))
+		assert.Nil(t, bs.LoadBlockCommit(h))
+		assert.Nil(t, bs.LoadSeenCommit(h))
+	}
+	for h := int64(5); h <= 10; h++ {
+		assert.NotNil(t, bs.LoadBlock(h), "block %d should be kept", h)
+		assert.NotNil(t, bs.LoadSeenCommit(h))
+	}
+
+	// Pruning below the base is a no-op
+	pruned, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), pruned)
+
+	// The base is persisted
+	assert.Equal(t, BlockStoreStateJSON{Base: 5, Height: 10}, LoadBlockStoreStateJSON(db))
+	bs = NewBlockStore(db)
+	assert.Equal(t, int64(5), bs.Base())
+
+	// Pruning up to the latest height keeps the latest block
+	pruned, err = bs.PruneBlocks(10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), pruned)
+	assert.NotNil(t, bs.LoadBlock(10))
+
+	// New blocks are saved after the pruned ones
+	block := makeBlock(11, state, new(types.Commit))
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(11, tmtime.Now()))
+	assert.Equal(t, int64(10), bs.Base())
+	assert.Equal(t, int64(11), bs.Height())
+}
+
+func TestBootstrap(t *testing.T) {
+	t.Parallel()
+
+	state, bs, cleanup := makeStateAndBlockStore(log.NewNoopLogger())
+	defer cleanup()
+
+	require.Error(t, bs.Bootstrap(0, makeTestCommit(0, tmtime.Now())))
+	require.Error(t, bs.Bootstrap(20, makeTestCommit(19, tmtime.Now())))
+
+	seenCommit := makeTestCommit(20, tmtime.Now())
+	require.NoError(t, bs.Bootstrap(20, seenCommit))
+
+	assert.Equal(t, int64(21), bs.Base())
+	assert.Equal(t, int64(20), bs.Height())
+	assert.Equal(t, seenCommit.Hash(), bs.LoadSeenCommit(20).Hash())
+	assert.Nil(t, bs.LoadBlock(20))
+
+	// The store can only be bootstrapped once
+	require.Error(t, bs.Bootstrap(30, makeTestCommit(30, tmtime.Now())))
+
+	// The next blocks are saved from the bootstrap height
+	block := makeBlock(21, state, new(types.Commit))
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(21, tmtime.Now()))
+	assert.Equal(t, int64(21), bs.Base())
+	assert.Equal(t, int64(21), bs.Height())
+
+	// The bootstrapped store is persisted
+	bs = NewBlockStore(bs.db)
+	assert.Equal(t, int64(21), bs.Base())
+	assert.Equal(t, int64(21), bs.Height())
+}
+
+func TestDeleteLatestBlock(t *testing.T) {
+	t.Parallel()
+
+	state, bs, cleanup := makeStateAndBlockStore(log.NewNoopLogger())
+	defer cleanup()
+
+	require.Error(t, bs.DeleteLatestBlock(), "expecting an error on an empty store")
+
+	// Save 3 blocks
+	for h := int64(1); h <= 3; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+
+	require.NoError(t, bs.DeleteLatestBlock())
+	assert.Equal(t, int64(1), bs.Base())
+	assert.Equal(t, int64(2), bs.Height())
+
+	assert.Nil(t, bs.LoadBlock(3))
+	assert.Nil(t, bs.LoadBlockMeta(3))
+	assert.Nil(t, bs.LoadBlockPart(3, 0))
+	assert.Nil(t, bs.LoadSeenCommit(3))
+
+	// The new latest block can be restarted from its seen commit
+	assert.NotNil(t, bs.LoadBlock(2))
+	assert.NotNil(t, bs.LoadSeenCommit(2))
+
+	// The deletion is persisted
+	assert.Equal(t, BlockStoreStateJSON{Base: 1, Height: 2}, LoadBlockStoreStateJSON(bs.db))
+
+	// The deleted block can be saved again
+	block := makeBlock(3, state, new(types.Commit))
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(3, tmtime.Now()))
+	assert.Equal(t, int64(3), bs.Height())
+
+	// The only block of the store can't be deleted
+	_, err := bs.PruneBlocks(3)
+	require.NoError(t, err)
+	require.Error(t, bs.DeleteLatestBlock())
+}
+
 func doFn(fn func() (any, error)) (res any, err error, panicErr error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -57,6 +57,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 
 		// Misc.
 		TxResult{},
+		BlockResult{},
 		MockAppState{},
 		VoteSet{},
 	))
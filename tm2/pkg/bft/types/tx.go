@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
@@ -121,3 +122,24 @@ type TxResult struct {
 func (tx *TxResult) Bytes() []byte {
 	return amino.MustMarshal(tx)
 }
+
+// BlockResult contains the results of BeginBlock and EndBlock for a block,
+// along with the header fields it is indexed by.
+type BlockResult struct {
+	Height           int64                   `json:"height"`
+	Time             time.Time               `json:"time"`
+	ProposerAddress  Address                 `json:"proposer_address"`
+	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
+	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+}
+
+// NewBlockResult returns the BlockResult of the executed block.
+func NewBlockResult(header Header, beginBlock abci.ResponseBeginBlock, endBlock abci.ResponseEndBlock) BlockResult {
+	return BlockResult{
+		Height:           header.Height,
+		Time:             header.Time,
+		ProposerAddress:  header.ProposerAddress,
+		ResultBeginBlock: beginBlock,
+		ResultEndBlock:   endBlock,
+	}
+}
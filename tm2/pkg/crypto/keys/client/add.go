@@ -18,6 +18,7 @@ import (
 var (
 	errInvalidMnemonic       = errors.New("invalid bip39 mnemonic")
 	errInvalidDerivationPath = errors.New("invalid derivation path")
+	errUnsupportedAlgo       = errors.New("unsupported signing algo")
 )
 
 var reDerivationPath = regexp.MustCompile(`^44'\/118'\/\d+'\/0\/\d+$`)
@@ -29,6 +30,7 @@ type AddCfg struct {
 	NoBackup bool
 	Account  uint64
 	Index    uint64
+	Algo     string
 
 	DerivationPath commands.StringArr
 }
@@ -88,6 +90,13 @@ func (c *AddCfg) RegisterFlags(fs *flag.FlagSet) {
 		"address index number for HD derivation",
 	)
 
+	fs.StringVar(
+		&c.Algo,
+		"algo",
+		string(keys.Secp256k1),
+		"signing algo of the key (secp256k1, ed25519 or secp256r1)",
+	)
+
 	fs.Var(
 		&c.DerivationPath,
 		"derivation-path",
@@ -118,6 +127,12 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 		}
 	}
 
+	// Validate the signing algo
+	algo := keys.SigningAlgo(cfg.Algo)
+	if !algo.IsSupported() {
+		return fmt.Errorf("%w: %s", errUnsupportedAlgo, cfg.Algo)
+	}
+
 	name := args[0]
 
 	// Read the keybase from the home directory
@@ -176,13 +191,13 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 	}
 
 	// Save the account
-	info, err := kb.CreateAccount(
+	info, err := kb.CreateAccountWithAlgo(
 		name,
 		mnemonic,
 		"",
 		encryptPassword,
-		uint32(cfg.Account),
-		uint32(cfg.Index),
+		*hd.NewFundraiserParams(uint32(cfg.Account), crypto.CoinType, uint32(cfg.Index)),
+		algo,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to save account to keybase, %w", err)
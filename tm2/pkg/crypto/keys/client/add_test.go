@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -219,6 +221,93 @@ func generateDerivationPaths(count int) []string {
 	return paths
 }
 
+func TestAdd_Algo(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		algo    string
+		pubType any
+	}{
+		{"ed25519", ed25519.PubKeyEd25519{}},
+		{"secp256r1", secp256r1.PubKeySecp256r1{}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.algo, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				kbHome      = t.TempDir()
+				baseOptions = BaseOptions{
+					InsecurePasswordStdin: true,
+					Home:                  kbHome,
+				}
+
+				keyName = "key-name"
+			)
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFn()
+
+			io := commands.NewTestIO()
+			io.SetIn(strings.NewReader("test1234\ntest1234\n"))
+
+			// Create the command
+			cmd := NewRootCmdWithBaseConfig(io, baseOptions)
+
+			args := []string{
+				"add",
+				"--insecure-password-stdin",
+				"--home",
+				kbHome,
+				"--algo",
+				testCase.algo,
+				keyName,
+			}
+
+			require.NoError(t, cmd.ParseAndRun(ctx, args))
+
+			// Check the keybase
+			kb, err := keys.NewKeyBaseFromDir(kbHome)
+			require.NoError(t, err)
+
+			key, err := kb.GetByName(keyName)
+			require.NoError(t, err)
+			assert.IsType(t, testCase.pubType, key.GetPubKey())
+		})
+	}
+
+	t.Run("unsupported algo", func(t *testing.T) {
+		t.Parallel()
+
+		kbHome := t.TempDir()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		io := commands.NewTestIO()
+		io.SetIn(strings.NewReader("test1234\ntest1234\n"))
+
+		// Create the command
+		cmd := NewRootCmdWithBaseConfig(io, BaseOptions{
+			InsecurePasswordStdin: true,
+			Home:                  kbHome,
+		})
+
+		args := []string{
+			"add",
+			"--insecure-password-stdin",
+			"--home",
+			kbHome,
+			"--algo",
+			"rsa",
+			"key-name",
+		}
+
+		assert.ErrorIs(t, cmd.ParseAndRun(ctx, args), errUnsupportedAlgo)
+	})
+}
+
 func TestAdd_Derive(t *testing.T) {
 	t.Parallel()
 
@@ -20,6 +20,9 @@ type MakeTxCfg struct {
 	GasFee    string
 	Memo      string
 
+	TimeoutHeight    int64
+	TimeoutTimestamp int64
+
 	Broadcast bool
 	// Valid options are SimulateTest, SimulateSkip or SimulateOnly.
 	Simulate string
@@ -86,6 +89,20 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		"any descriptive text",
 	)
 
+	fs.Int64Var(
+		&c.TimeoutHeight,
+		"timeout-height",
+		0,
+		"block height after which the tx is no longer valid (0 for no timeout)",
+	)
+
+	fs.Int64Var(
+		&c.TimeoutTimestamp,
+		"timeout-timestamp",
+		0,
+		"unix timestamp, in seconds, after which the tx is no longer valid (0 for no timeout)",
+	)
+
 	fs.BoolVar(
 		&c.Broadcast,
 		"broadcast",
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	errNotMultisigKey          = errors.New("key is not a multisig key")
+	errNotMultisigMember       = errors.New("key is not part of the multisig")
+	errInvalidPartialSignature = errors.New("invalid partial signature")
+	errDuplicateSignature      = errors.New("duplicate partial signature")
+	errInsufficientSignatures  = errors.New("insufficient signatures")
+)
+
+type MultisignCfg struct {
+	RootCfg *BaseCfg
+
+	TxPath        string
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+func NewMultisignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &MultisignCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "multisign",
+			ShortUsage: "multisign [flags] <multisig key-name or address> <signature-file> [<signature-file>...]",
+			ShortHelp:  "combines partial signatures into a multisig signature of the given tx document",
+			LongHelp: "Verifies the partial signatures produced by `sign --multisig`, and combines them " +
+				"into the signature of the multisig. The signed tx document is saved to disk, ready to be broadcast.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMultisign(cfg, args, io)
+		},
+	)
+}
+
+func (c *MultisignCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.TxPath,
+		"tx-path",
+		"",
+		"path to the Amino JSON-encoded tx (file) to sign",
+	)
+
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"the ID of the chain",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"account-number",
+		0,
+		"account number of the multisig",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"account-sequence",
+		0,
+		"account sequence of the multisig",
+	)
+}
+
+func execMultisign(cfg *MultisignCfg, args []string, io commands.IO) error {
+	// Make sure the multisig key and at least one signature are provided
+	if len(args) < 2 {
+		return flag.ErrHelp
+	}
+
+	// Load the keybase
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return fmt.Errorf("unable to load keybase, %w", err)
+	}
+
+	multisigPub, err := getMultisigPubKey(kb, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Get the transaction
+	txRaw, err := os.ReadFile(cfg.TxPath)
+	if err != nil {
+		return fmt.Errorf("unable to read transaction file")
+	}
+
+	if len(txRaw) == 0 {
+		return errInvalidTxFile
+	}
+
+	var tx std.Tx
+	if err := amino.UnmarshalJSON(txRaw, &tx); err != nil {
+		return fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	signBytes, err := tx.GetSignBytes(cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+	if err != nil {
+		return fmt.Errorf("unable to get signature bytes, %w", err)
+	}
+
+	// Verify and combine the partial signatures
+	sigs := make([]std.Signature, 0, len(args)-1)
+	for _, path := range args[1:] {
+		sigRaw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read signature file %s, %w", path, err)
+		}
+
+		var sig std.Signature
+		if err := amino.UnmarshalJSON(sigRaw, &sig); err != nil {
+			return fmt.Errorf("unable to unmarshal signature %s, %w", path, err)
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	multisignature, err := combineSignatures(multisigPub, signBytes, sigs)
+	if err != nil {
+		return err
+	}
+
+	// Save the multisig signature, overwriting the previous one if any
+	signature := std.Signature{
+		PubKey:    multisigPub,
+		Signature: multisignature,
+	}
+
+	replaced := false
+	for index, sig := range tx.Signatures {
+		if sig.PubKey != nil && sig.PubKey.Equals(multisigPub) {
+			tx.Signatures[index] = signature
+			replaced = true
+
+			break
+		}
+	}
+
+	if !replaced {
+		tx.Signatures = append(tx.Signatures, signature)
+	}
+
+	// Validate the tx after signing
+	if err := tx.ValidateBasic(); err != nil {
+		return fmt.Errorf("unable to validate transaction, %w", err)
+	}
+
+	encodedTx, err := amino.MarshalJSON(tx)
+	if err != nil {
+		return fmt.Errorf("unable to marshal tx to JSON, %w", err)
+	}
+
+	if err := os.WriteFile(cfg.TxPath, encodedTx, 0o644); err != nil {
+		return fmt.Errorf("unable to write tx to %s, %w", cfg.TxPath, err)
+	}
+
+	io.Printf("\nTx successfully signed by the multisig and saved to %s\n", cfg.TxPath)
+
+	return nil
+}
+
+// combineSignatures verifies the partial signatures of signBytes, and
+// combines them into an Amino-encoded multisig.Multisignature
+func combineSignatures(
+	multisigPub multisig.PubKeyMultisigThreshold,
+	signBytes []byte,
+	sigs []std.Signature,
+) ([]byte, error) {
+	multisignature := multisig.NewMultisig(len(multisigPub.PubKeys))
+
+	for _, sig := range sigs {
+		if sig.PubKey == nil {
+			return nil, fmt.Errorf("%w: missing public key", errInvalidPartialSignature)
+		}
+
+		address := sig.PubKey.Address()
+
+		if !isMultisigMember(multisigPub, sig.PubKey) {
+			return nil, fmt.Errorf("%w: %s", errNotMultisigMember, address)
+		}
+
+		if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return nil, fmt.Errorf(
+				"%w: signature of %s does not match the tx, chain ID, account number and sequence",
+				errInvalidPartialSignature,
+				address,
+			)
+		}
+
+		for index, pub := range multisigPub.PubKeys {
+			if !pub.Equals(sig.PubKey) {
+				continue
+			}
+
+			if multisignature.BitArray.GetIndex(index) {
+				return nil, fmt.Errorf("%w: %s", errDuplicateSignature, address)
+			}
+
+			multisignature.AddSignature(sig.Signature, index)
+		}
+	}
+
+	if len(multisignature.Sigs) < int(multisigPub.K) {
+		return nil, fmt.Errorf(
+			"%w: got %d, the multisig threshold is %d",
+			errInsufficientSignatures,
+			len(multisignature.Sigs),
+			multisigPub.K,
+		)
+	}
+
+	return multisignature.Marshal(), nil
+}
+
+// getMultisigPubKey fetches the public key of the given multisig key
+func getMultisigPubKey(kb keys.Keybase, nameOrBech32 string) (multisig.PubKeyMultisigThreshold, error) {
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return multisig.PubKeyMultisigThreshold{}, fmt.Errorf("unable to get multisig key from keybase, %w", err)
+	}
+
+	multisigPub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return multisig.PubKeyMultisigThreshold{}, fmt.Errorf("%w: %s", errNotMultisigKey, nameOrBech32)
+	}
+
+	return multisigPub, nil
+}
+
+// isMultisigMember returns whether pub is one of the keys of the multisig
+func isMultisigMember(multisigPub multisig.PubKeyMultisigThreshold, pub crypto.PubKey) bool {
+	for _, key := range multisigPub.PubKeys {
+		if key.Equals(pub) {
+			return true
+		}
+	}
+
+	return false
+}
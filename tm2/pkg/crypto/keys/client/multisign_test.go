@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	multisigTestPassword = "encrypt"
+	multisigTestChainID  = "dev"
+)
+
+// multisigTestEnv is a keybase with the keys key1, key2 and key3, and
+// the 2 out of 3 multisig key msig, along with a tx document sent from msig.
+type multisigTestEnv struct {
+	home   string
+	txPath string
+	pub    multisig.PubKeyMultisigThreshold
+}
+
+func newMultisigTestEnv(t *testing.T) multisigTestEnv {
+	t.Helper()
+
+	home := t.TempDir()
+
+	kb, err := keys.NewKeyBaseFromDir(home)
+	require.NoError(t, err)
+
+	pubs := make([]crypto.PubKey, 0, 3)
+	for _, name := range []string{"key1", "key2", "key3"} {
+		info, err := kb.CreateAccount(name, generateTestMnemonic(t), "", multisigTestPassword, 0, 0)
+		require.NoError(t, err)
+
+		pubs = append(pubs, info.GetPubKey())
+	}
+
+	// A key which is not part of the multisig
+	_, err = kb.CreateAccount("outsider", generateTestMnemonic(t), "", multisigTestPassword, 0, 0)
+	require.NoError(t, err)
+
+	pub := multisig.NewPubKeyMultisigThreshold(2, pubs).(multisig.PubKeyMultisigThreshold)
+	_, err = kb.CreateMulti("msig", pub)
+	require.NoError(t, err)
+
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: pub.Address(),
+				ToAddress:   pubs[0].Address(),
+				Amount:      std.NewCoins(std.NewCoin("ugnot", 10)),
+			},
+		},
+		Fee: std.NewFee(10, std.NewCoin("ugnot", 10)),
+	}
+
+	txPath := filepath.Join(home, "tx.json")
+	require.NoError(t, os.WriteFile(txPath, amino.MustMarshalJSON(tx), 0o644))
+
+	return multisigTestEnv{home: home, txPath: txPath, pub: pub}
+}
+
+// run runs the gnokey command with the given args, giving it the keybase
+// password if needed
+func (env multisigTestEnv) run(t *testing.T, args ...string) error {
+	t.Helper()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	io := commands.NewTestIO()
+	io.SetIn(strings.NewReader(multisigTestPassword + "\n"))
+
+	cmd := NewRootCmdWithBaseConfig(io, BaseOptions{
+		InsecurePasswordStdin: true,
+		Home:                  env.home,
+		Quiet:                 true,
+	})
+
+	return cmd.ParseAndRun(ctx, append([]string{"--home", env.home, "--insecure-password-stdin"}, args...))
+}
+
+// partialSign produces the partial signature of the tx by the given key
+func (env multisigTestEnv) partialSign(t *testing.T, key string, chainID string) string {
+	t.Helper()
+
+	sigPath := filepath.Join(env.home, key+"-"+chainID+".sig")
+	require.NoError(t, env.run(t,
+		"sign",
+		"--tx-path", env.txPath,
+		"--chainid", chainID,
+		"--multisig", "msig",
+		"--output-document", sigPath,
+		key,
+	))
+
+	return sigPath
+}
+
+func TestMultisign(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no signature provided", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		assert.ErrorIs(t, env.run(t, "multisign", "--tx-path", env.txPath, "msig"), flag.ErrHelp)
+	})
+
+	t.Run("partial signature by a non-member key", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		err := env.run(t,
+			"sign",
+			"--tx-path", env.txPath,
+			"--multisig", "msig",
+			"--output-document", filepath.Join(env.home, "outsider.sig"),
+			"outsider",
+		)
+		assert.ErrorIs(t, err, errNotMultisigMember)
+	})
+
+	t.Run("not a multisig key", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+		sig1 := env.partialSign(t, "key1", multisigTestChainID)
+
+		err := env.run(t, "multisign", "--tx-path", env.txPath, "key2", sig1)
+		assert.ErrorIs(t, err, errNotMultisigKey)
+	})
+
+	t.Run("insufficient signatures", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+		sig1 := env.partialSign(t, "key1", multisigTestChainID)
+
+		err := env.run(t, "multisign", "--tx-path", env.txPath, "msig", sig1)
+		require.ErrorIs(t, err, errInsufficientSignatures)
+		assert.ErrorContains(t, err, "got 1, the multisig threshold is 2")
+	})
+
+	t.Run("duplicate signature", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+		sig1 := env.partialSign(t, "key1", multisigTestChainID)
+
+		err := env.run(t, "multisign", "--tx-path", env.txPath, "msig", sig1, sig1)
+		assert.ErrorIs(t, err, errDuplicateSignature)
+	})
+
+	t.Run("signature for another chain", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+		sig1 := env.partialSign(t, "key1", multisigTestChainID)
+		sig2 := env.partialSign(t, "key2", "other")
+
+		err := env.run(t, "multisign", "--tx-path", env.txPath, "msig", sig1, sig2)
+		assert.ErrorIs(t, err, errInvalidPartialSignature)
+	})
+
+	t.Run("valid signatures", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+		sig1 := env.partialSign(t, "key1", multisigTestChainID)
+		sig3 := env.partialSign(t, "key3", multisigTestChainID)
+
+		// The tx document is left untouched by partial signatures
+		var tx std.Tx
+		require.NoError(t, amino.UnmarshalJSON(mustReadFile(t, env.txPath), &tx))
+		require.Empty(t, tx.Signatures)
+
+		require.NoError(t, env.run(t, "multisign", "--tx-path", env.txPath, "msig", sig3, sig1))
+
+		require.NoError(t, amino.UnmarshalJSON(mustReadFile(t, env.txPath), &tx))
+		require.Len(t, tx.Signatures, 1)
+		assert.True(t, tx.Signatures[0].PubKey.Equals(env.pub))
+
+		signBytes, err := tx.GetSignBytes(multisigTestChainID, 0, 0)
+		require.NoError(t, err)
+		assert.True(t, env.pub.VerifyBytes(signBytes, tx.Signatures[0].Signature))
+	})
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return content
+}
@@ -45,6 +45,7 @@ func NewRootCmdWithBaseConfig(io commands.IO, base BaseOptions) *commands.Comman
 		NewListCmd(cfg, io),
 		NewRotateCmd(cfg, io),
 		NewSignCmd(cfg, io),
+		NewMultisignCmd(cfg, io),
 		NewVerifyCmd(cfg, io),
 		NewQueryCmd(cfg, io),
 		NewBroadcastCmd(cfg, io),
@@ -115,6 +115,9 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 		Fee:        std.NewFee(gaswanted, gasfee),
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
+
+		TimeoutHeight:    cfg.RootCfg.TimeoutHeight,
+		TimeoutTimestamp: cfg.RootCfg.TimeoutTimestamp,
 	}
 
 	if cfg.RootCfg.Broadcast {
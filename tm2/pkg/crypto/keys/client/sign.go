@@ -9,6 +9,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -34,6 +35,9 @@ type SignCfg struct {
 	AccountNumber uint64
 	Sequence      uint64
 	NameOrBech32  string
+
+	Multisig       string
+	OutputDocument string
 }
 
 func NewSignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
@@ -82,6 +86,20 @@ func (c *SignCfg) RegisterFlags(fs *flag.FlagSet) {
 		0,
 		"account sequence to sign with",
 	)
+
+	fs.StringVar(
+		&c.Multisig,
+		"multisig",
+		"",
+		"name or address of the multisig key to produce a partial signature for, instead of signing the tx",
+	)
+
+	fs.StringVar(
+		&c.OutputDocument,
+		"output-document",
+		"",
+		"path to save the partial signature to (only useful with --multisig, defaults to stdout)",
+	)
 }
 
 func execSign(cfg *SignCfg, args []string, io commands.IO) error {
@@ -137,6 +155,20 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		return fmt.Errorf("unable to unmarshal transaction, %w", err)
 	}
 
+	// Fetch the multisig key info from the keybase,
+	// if the tx is signed on behalf of a multisig
+	var multisigPub multisig.PubKeyMultisigThreshold
+	if cfg.Multisig != "" {
+		multisigPub, err = getMultisigPubKey(kb, cfg.Multisig)
+		if err != nil {
+			return err
+		}
+
+		if !isMultisigMember(multisigPub, info.GetPubKey()) {
+			return fmt.Errorf("%w: %s", errNotMultisigMember, info.GetAddress())
+		}
+	}
+
 	var password string
 
 	// Check if we need to get a decryption password.
@@ -169,6 +201,16 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		decryptPass: password,
 	}
 
+	// Produce a partial signature for the multisig
+	if cfg.Multisig != "" {
+		sig, err := signMultisigTx(&tx, kb, sOpts, kOpts)
+		if err != nil {
+			return fmt.Errorf("unable to sign transaction, %w", err)
+		}
+
+		return savePartialSignature(sig, cfg.OutputDocument, io)
+	}
+
 	// Sign the transaction
 	if err := signTx(&tx, kb, sOpts, kOpts); err != nil {
 		return fmt.Errorf("unable to sign transaction, %w", err)
@@ -240,3 +282,59 @@ func signTx(
 
 	return nil
 }
+
+// signMultisigTx generates the signature of the transaction by one of the
+// keys of a multisig, to be combined with the others using multisign
+func signMultisigTx(
+	tx *std.Tx,
+	kb keys.Keybase,
+	signOpts signOpts,
+	keyOpts keyOpts,
+) (std.Signature, error) {
+	signBytes, err := tx.GetSignBytes(
+		signOpts.chainID,
+		signOpts.accountNumber,
+		signOpts.accountSequence,
+	)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to get signature bytes, %w", err)
+	}
+
+	// Sign the transaction data
+	sig, pub, err := kb.Sign(
+		keyOpts.keyName,
+		keyOpts.decryptPass,
+		signBytes,
+	)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to sign transaction bytes, %w", err)
+	}
+
+	return std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}, nil
+}
+
+// savePartialSignature saves the given signature to the given path
+// (Amino-encoded JSON), or prints it if the path is empty
+func savePartialSignature(sig std.Signature, path string, io commands.IO) error {
+	encodedSig, err := amino.MarshalJSON(sig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal signature to JSON, %w", err)
+	}
+
+	if path == "" {
+		io.Println(string(encodedSig))
+
+		return nil
+	}
+
+	if err := os.WriteFile(path, encodedSig, 0o644); err != nil {
+		return fmt.Errorf("unable to write signature to %s, %w", path, err)
+	}
+
+	io.Printf("\nPartial signature successfully saved to %s\n", path)
+
+	return nil
+}
@@ -7,11 +7,13 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ledger"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -56,9 +58,10 @@ const (
 )
 
 var (
-	// ErrUnsupportedSigningAlgo is raised when the caller tries to use a
-	// different signing scheme than secp256k1.
-	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo: only secp256k1 is supported")
+	// ErrUnsupportedSigningAlgo is raised when the caller tries to use an
+	// unknown signing scheme, or a different signing scheme than secp256k1
+	// for ledger keys.
+	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo")
 
 	// ErrUnsupportedLanguage is raised when the caller tries to use a
 	// different language than english for creating a mnemonic sentence.
@@ -95,12 +98,22 @@ func (kb dbKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd str
 }
 
 func (kb dbKeybase) CreateAccountBip44(name, mnemonic, bip39Passphrase, encryptPasswd string, params hd.BIP44Params) (info Info, err error) {
+	return kb.CreateAccountWithAlgo(name, mnemonic, bip39Passphrase, encryptPasswd, params, Secp256k1)
+}
+
+// CreateAccountWithAlgo converts a mnemonic to a private key of the given
+// signing algo and persists it, encrypted with the given password.
+func (kb dbKeybase) CreateAccountWithAlgo(name, mnemonic, bip39Passphrase, encryptPasswd string, params hd.BIP44Params, algo SigningAlgo) (info Info, err error) {
+	if !algo.IsSupported() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgo, algo)
+	}
+
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
 	if err != nil {
 		return
 	}
 
-	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String())
+	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String(), algo)
 	return
 }
 
@@ -108,7 +121,7 @@ func (kb dbKeybase) CreateAccountBip44(name, mnemonic, bip39Passphrase, encryptP
 // It returns the created key info and an error if the Ledger could not be queried
 func (kb dbKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (Info, error) {
 	if algo != Secp256k1 {
-		return nil, ErrUnsupportedSigningAlgo
+		return nil, fmt.Errorf("%w: only secp256k1 is supported", ErrUnsupportedSigningAlgo)
 	}
 
 	coinType := crypto.CoinType
@@ -135,7 +148,7 @@ func (kb dbKeybase) CreateMulti(name string, pub crypto.PubKey) (Info, error) {
 	return kb.writeMultisigKey(name, pub)
 }
 
-func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (Info, error) {
+func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string, algo SigningAlgo) (Info, error) {
 	// create master key and derive first key:
 	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
 	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
@@ -143,9 +156,23 @@ func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath str
 		return nil, err
 	}
 
+	// The BIP 32 derivation is defined over secp256k1: keys of other
+	// signing algos are generated from the derived secret.
+	var priv crypto.PrivKey
+	switch algo {
+	case Secp256k1:
+		priv = secp256k1.PrivKeySecp256k1(derivedPriv)
+	case Ed25519:
+		priv = ed25519.GenPrivKeyFromSecret(derivedPriv[:])
+	case Secp256r1:
+		priv = secp256r1.GenPrivKeySecp256r1(derivedPriv[:])
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigningAlgo, algo)
+	}
+
 	// use possibly blank password to encrypt the private
 	// key and store it. User must enforce good passwords.
-	return kb.writeLocalKey(name, secp256k1.PrivKeySecp256k1(derivedPriv), passwd)
+	return kb.writeLocalKey(name, priv, passwd)
 }
 
 // List returns the keys from storage in alphabetical order.
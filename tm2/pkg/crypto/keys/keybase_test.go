@@ -9,7 +9,10 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 )
 
 func TestCreateAccountInvalidMnemonic(t *testing.T) {
@@ -210,6 +213,71 @@ func TestSignVerify(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestCreateAccountWithAlgo(t *testing.T) {
+	t.Parallel()
+
+	mn := `lounge napkin all odor tilt dove win inject sleep jazz uncover traffic hint require cargo arm rocket round scan bread report squirrel step lake`
+	params := *hd.NewFundraiserParams(0, crypto.CoinType, 0)
+	pass := "1234"
+	msg := []byte("my first message")
+
+	testTable := []struct {
+		algo    SigningAlgo
+		pubType crypto.PubKey
+	}{
+		{Secp256k1, secp256k1.PubKeySecp256k1{}},
+		{Ed25519, ed25519.PubKeyEd25519{}},
+		{Secp256r1, secp256r1.PubKeySecp256r1{}},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(string(testCase.algo), func(t *testing.T) {
+			t.Parallel()
+
+			cstore := NewInMemory()
+
+			info, err := cstore.CreateAccountWithAlgo("key", mn, "", pass, params, testCase.algo)
+			require.NoError(t, err)
+			assert.IsType(t, testCase.pubType, info.GetPubKey())
+
+			// The derivation is deterministic
+			other, err := NewInMemory().CreateAccountWithAlgo("key", mn, "", pass, params, testCase.algo)
+			require.NoError(t, err)
+			assert.Equal(t, info.GetAddress(), other.GetAddress())
+
+			sig, pub, err := cstore.Sign("key", pass, msg)
+			require.NoError(t, err)
+			assert.True(t, info.GetPubKey().Equals(pub))
+			assert.NoError(t, cstore.Verify("key", msg, sig))
+
+			// The private key survives the armor round trip
+			priv, err := cstore.ExportPrivKey("key", pass)
+			require.NoError(t, err)
+			assert.True(t, info.GetPubKey().Equals(priv.PubKey()))
+		})
+	}
+
+	t.Run("unsupported algo", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInMemory().CreateAccountWithAlgo("key", mn, "", pass, params, SigningAlgo("rsa"))
+		assert.ErrorIs(t, err, ErrUnsupportedSigningAlgo)
+	})
+
+	t.Run("different algos derive different addresses", func(t *testing.T) {
+		t.Parallel()
+
+		cstore := NewInMemory()
+
+		k1, err := cstore.CreateAccountWithAlgo("k1", mn, "", pass, params, Secp256k1)
+		require.NoError(t, err)
+		r1, err := cstore.CreateAccountWithAlgo("r1", mn, "", pass, params, Secp256r1)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, k1.GetAddress(), r1.GetAddress())
+	})
+}
+
 func assertPassword(t *testing.T, cstore Keybase, name, pass, badpass string) {
 	t.Helper()
 
@@ -7,6 +7,21 @@ const (
 	// Secp256k1 uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1 = SigningAlgo("secp256k1")
 	// Ed25519 represents the Ed25519 signature system.
-	// It is currently not supported for end-user keys (wallets/ledgers).
+	// It is currently not supported for ledgers.
 	Ed25519 = SigningAlgo("ed25519")
+	// Secp256r1 uses the NIST P-256 ECDSA parameters, used by WebAuthn
+	// authenticators (passkeys).
+	// It is currently not supported for ledgers.
+	Secp256r1 = SigningAlgo("secp256r1")
 )
+
+// IsSupported returns whether keys of the signing algo can be created in a
+// keybase.
+func (algo SigningAlgo) IsSupported() bool {
+	switch algo {
+	case Secp256k1, Ed25519, Secp256r1:
+		return true
+	default:
+		return false
+	}
+}
@@ -149,6 +149,16 @@ func (lkb lazyKeybase) CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPa
 	return NewDBKeybase(db).CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd, params)
 }
 
+func (lkb lazyKeybase) CreateAccountWithAlgo(name, mnemonic, bip39Passwd, encryptPasswd string, params hd.BIP44Params, algo SigningAlgo) (Info, error) {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db).CreateAccountWithAlgo(name, mnemonic, bip39Passwd, encryptPasswd, params, algo)
+}
+
 func (lkb lazyKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (info Info, err error) {
 	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
 	if err != nil {
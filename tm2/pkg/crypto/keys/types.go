@@ -35,6 +35,10 @@ type Keybase interface {
 	// If an account exists with the same address but a different name, it is replaced by the new name.
 	CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd string, params hd.BIP44Params) (Info, error)
 
+	// Like CreateAccountBip44 but derives a key of the given signing algo.
+	// If an account exists with the same address but a different name, it is replaced by the new name.
+	CreateAccountWithAlgo(name, mnemonic, bip39Passwd, encryptPasswd string, params hd.BIP44Params, algo SigningAlgo) (Info, error)
+
 	// CreateLedger creates, stores, and returns a new Ledger key reference
 	CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (info Info, err error)
 
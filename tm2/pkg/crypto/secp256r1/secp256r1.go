@@ -0,0 +1,222 @@
+package secp256r1
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+)
+
+// SignatureSize is the size of a raw signature: R || S, both 32 bytes.
+const SignatureSize = 64
+
+var (
+	errInvalidPubKey = errors.New("invalid secp256r1 public key")
+
+	curveOrder     = elliptic.P256().Params().N
+	halfCurveOrder = new(big.Int).Rsh(curveOrder, 1)
+	one            = big.NewInt(1)
+)
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKeySecp256r1{}
+
+// PrivKeySecp256r1 implements PrivKey for the NIST P-256 curve.
+// It is the big-endian encoding of the private scalar.
+type PrivKeySecp256r1 [32]byte
+
+// Bytes marshalls the private key using amino encoding.
+func (privKey PrivKeySecp256r1) Bytes() []byte {
+	return amino.MustMarshalAny(privKey)
+}
+
+// Sign creates an ECDSA signature on curve P-256, using SHA256 on the msg.
+// The returned signature will be of the form R || S (in lower-S form).
+func (privKey PrivKeySecp256r1) Sign(msg []byte) ([]byte, error) {
+	priv, err := privKey.ecdsa()
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, err := ecdsa.Sign(crypto.CReader(), priv, crypto.Sha256(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize the signature to lower-S form, as required by VerifyBytes.
+	if s.Cmp(halfCurveOrder) > 0 {
+		s.Sub(curveOrder, s)
+	}
+
+	sig := make([]byte, SignatureSize)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	return sig, nil
+}
+
+// PubKey returns the compressed public key of the private key.
+func (privKey PrivKeySecp256r1) PubKey() crypto.PubKey {
+	priv, err := ecdh.P256().NewPrivateKey(privKey[:])
+	if err != nil {
+		panic(err)
+	}
+
+	// The uncompressed point is 0x04 || X || Y
+	point := priv.PublicKey().Bytes()
+
+	var pubKey PubKeySecp256r1
+	pubKey[0] = 0x02 | point[64]&1
+	copy(pubKey[1:], point[1:33])
+
+	return pubKey
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKeySecp256r1) Equals(other crypto.PrivKey) bool {
+	if otherSecp, ok := other.(PrivKeySecp256r1); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherSecp[:]) == 1
+	}
+	return false
+}
+
+func (privKey PrivKeySecp256r1) ecdsa() (*ecdsa.PrivateKey, error) {
+	pub, err := privKey.PubKey().(PubKeySecp256r1).ecdsa()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).SetBytes(privKey[:]),
+	}, nil
+}
+
+// GenPrivKey generates a new ECDSA private key on curve P-256.
+// It uses OS randomness to generate the private key.
+func GenPrivKey() PrivKeySecp256r1 {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new secp256r1 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKeySecp256r1 {
+	var privKeyBytes [32]byte
+	d := new(big.Int)
+	for {
+		privKeyBytes = [32]byte{}
+		_, err := io.ReadFull(rand, privKeyBytes[:])
+		if err != nil {
+			panic(err)
+		}
+
+		d.SetBytes(privKeyBytes[:])
+		// break if we found a valid point (i.e. > 0 and < N == curverOrder)
+		if 0 < d.Sign() && d.Cmp(curveOrder) < 0 {
+			break
+		}
+	}
+
+	return PrivKeySecp256r1(privKeyBytes)
+}
+
+// GenPrivKeySecp256r1 hashes the secret with SHA2, and uses
+// that 32 byte output to create the private key.
+//
+// It makes sure the private key is a valid field element by setting:
+//
+// c = sha256(secret)
+// k = (c mod (n − 1)) + 1, where n = curve order.
+//
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeySecp256r1(secret []byte) PrivKeySecp256r1 {
+	secHash := sha256.Sum256(secret)
+
+	fe := new(big.Int).SetBytes(secHash[:])
+	n := new(big.Int).Sub(curveOrder, one)
+	fe.Mod(fe, n)
+	fe.Add(fe, one)
+
+	var privKey PrivKeySecp256r1
+	fe.FillBytes(privKey[:])
+
+	return privKey
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKeySecp256r1{}
+
+// PubKeySecp256r1Size is comprised of 32 bytes for one field element
+// (the x-coordinate), plus one byte for the parity of the y-coordinate.
+const PubKeySecp256r1Size = 33
+
+// PubKeySecp256r1 implements crypto.PubKey for the NIST P-256 curve.
+// It is the compressed form of the pubkey: a 0x02 or 0x03 byte depending on
+// the parity of the y-coordinate, followed by the x-coordinate.
+type PubKeySecp256r1 [PubKeySecp256r1Size]byte
+
+// Address is the SHA256-20 of the compressed pubkey bytes.
+func (pubKey PubKeySecp256r1) Address() crypto.Address {
+	return crypto.AddressFromBytes(tmhash.SumTruncated(pubKey[:]))
+}
+
+// Bytes returns the pubkey marshalled with amino encoding.
+func (pubKey PubKeySecp256r1) Bytes() []byte {
+	return amino.MustMarshalAny(pubKey)
+}
+
+// VerifyBytes verifies a signature of msg, either of the form R || S, as
+// produced by PrivKeySecp256r1, or an amino-encoded WebAuthnSignature.
+// Raw signatures which are not in lower-S form are rejected.
+func (pubKey PubKeySecp256r1) VerifyBytes(msg []byte, sig []byte) bool {
+	pub, err := pubKey.ecdsa()
+	if err != nil {
+		return false
+	}
+
+	if len(sig) != SignatureSize {
+		return verifyWebAuthn(pub, msg, sig)
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+
+	// Reject malleable signatures
+	if s.Cmp(halfCurveOrder) > 0 {
+		return false
+	}
+
+	return ecdsa.Verify(pub, crypto.Sha256(msg), r, s)
+}
+
+func (pubKey PubKeySecp256r1) String() string {
+	return crypto.PubKeyToBech32(pubKey)
+}
+
+func (pubKey PubKeySecp256r1) Equals(other crypto.PubKey) bool {
+	if otherSecp, ok := other.(PubKeySecp256r1); ok {
+		return bytes.Equal(pubKey[:], otherSecp[:])
+	}
+	return false
+}
+
+func (pubKey PubKeySecp256r1) ecdsa() (*ecdsa.PublicKey, error) {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), pubKey[:])
+	if x == nil {
+		return nil, errInvalidPubKey
+	}
+
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
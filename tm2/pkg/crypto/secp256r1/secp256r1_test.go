@@ -0,0 +1,259 @@
+package secp256r1
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+func TestSignAndValidateSecp256r1(t *testing.T) {
+	t.Parallel()
+
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+
+	assert.True(t, pubKey.VerifyBytes(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[3] ^= byte(0x01)
+	assert.False(t, pubKey.VerifyBytes(msg, sig))
+
+	// A signature by another key is rejected
+	otherSig, err := GenPrivKey().Sign(msg)
+	require.NoError(t, err)
+	assert.False(t, pubKey.VerifyBytes(msg, otherSig))
+}
+
+func TestRejectHighSSignature(t *testing.T) {
+	t.Parallel()
+
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	msg := []byte("hello world")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	// Flip S to its high form, which is an otherwise valid ECDSA signature
+	s := new(big.Int).SetBytes(sig[32:])
+	s.Sub(curveOrder, s)
+	s.FillBytes(sig[32:])
+
+	pub, err := pubKey.(PubKeySecp256r1).ecdsa()
+	require.NoError(t, err)
+	require.True(t, ecdsa.Verify(pub, crypto.Sha256(msg), new(big.Int).SetBytes(sig[:32]), s))
+
+	assert.False(t, pubKey.VerifyBytes(msg, sig))
+}
+
+func TestGenPrivKeySecp256r1(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+
+	privKey := GenPrivKeySecp256r1(secret)
+	assert.Equal(t, privKey, GenPrivKeySecp256r1(secret))
+	assert.NotEqual(t, privKey, GenPrivKeySecp256r1([]byte("other secret")))
+
+	d := new(big.Int).SetBytes(privKey[:])
+	assert.True(t, d.Sign() > 0 && d.Cmp(curveOrder) < 0)
+}
+
+func TestPubKeyAminoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	decodedPub, err := crypto.PubKeyFromBytes(pubKey.Bytes())
+	require.NoError(t, err)
+	assert.True(t, pubKey.Equals(decodedPub))
+
+	decodedPriv, err := crypto.PrivKeyFromBytes(privKey.Bytes())
+	require.NoError(t, err)
+	assert.True(t, privKey.Equals(decodedPriv))
+
+	bech32Pub, err := crypto.PubKeyFromBech32(pubKey.String())
+	require.NoError(t, err)
+	assert.True(t, pubKey.Equals(bech32Pub))
+}
+
+// webAuthnSign signs msg with privKey the way a WebAuthn authenticator does,
+// and normalizes the signature to lower-S form, as clients must.
+func webAuthnSign(t *testing.T, privKey PrivKeySecp256r1, challenge []byte, typ string, flags byte) WebAuthnSignature {
+	t.Helper()
+
+	authData := make([]byte, authenticatorDataMinSize)
+	copy(authData, crypto.Sha256([]byte("gno.land")))
+	authData[32] = flags
+
+	clientDataJSON := fmt.Appendf(nil,
+		`{"type":%q,"challenge":%q,"origin":"https://gno.land","crossOrigin":false}`,
+		typ,
+		base64.RawURLEncoding.EncodeToString(challenge),
+	)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	priv, err := privKey.ecdsa()
+	require.NoError(t, err)
+
+	r, s, err := ecdsa.Sign(crypto.CReader(), priv, signed[:])
+	require.NoError(t, err)
+	if s.Cmp(halfCurveOrder) > 0 {
+		s.Sub(curveOrder, s)
+	}
+
+	return WebAuthnSignature{
+		AuthenticatorData: authData,
+		ClientDataJSON:    clientDataJSON,
+		Signature:         derSign(t, r, s),
+	}
+}
+
+func derSign(t *testing.T, r, s *big.Int) []byte {
+	t.Helper()
+
+	sig, err := asn1.Marshal(derSignature{R: r, S: s})
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestVerifyWebAuthnSignature(t *testing.T) {
+	t.Parallel()
+
+	privKey := GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	msg := []byte(`{"chain_id":"dev"}`)
+
+	testTable := []struct {
+		name  string
+		sig   func(t *testing.T) []byte
+		valid bool
+	}{
+		{
+			"valid assertion",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return webAuthnSign(t, privKey, crypto.Sha256(msg), webAuthnGetType, flagUserPresent).Bytes()
+			},
+			true,
+		},
+		{
+			"wrong challenge",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return webAuthnSign(t, privKey, crypto.Sha256([]byte("other")), webAuthnGetType, flagUserPresent).Bytes()
+			},
+			false,
+		},
+		{
+			"unhashed challenge",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return webAuthnSign(t, privKey, msg, webAuthnGetType, flagUserPresent).Bytes()
+			},
+			false,
+		},
+		{
+			"registration ceremony",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return webAuthnSign(t, privKey, crypto.Sha256(msg), "webauthn.create", flagUserPresent).Bytes()
+			},
+			false,
+		},
+		{
+			"user not present",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return webAuthnSign(t, privKey, crypto.Sha256(msg), webAuthnGetType, 0).Bytes()
+			},
+			false,
+		},
+		{
+			"truncated authenticator data",
+			func(t *testing.T) []byte {
+				t.Helper()
+				sig := webAuthnSign(t, privKey, crypto.Sha256(msg), webAuthnGetType, flagUserPresent)
+				sig.AuthenticatorData = sig.AuthenticatorData[:authenticatorDataMinSize-1]
+				return sig.Bytes()
+			},
+			false,
+		},
+		{
+			"tampered client data",
+			func(t *testing.T) []byte {
+				t.Helper()
+				sig := webAuthnSign(t, privKey, crypto.Sha256(msg), webAuthnGetType, flagUserPresent)
+				sig.ClientDataJSON = append(sig.ClientDataJSON[:len(sig.ClientDataJSON)-1], []byte(`,"x":1}`)...)
+				return sig.Bytes()
+			},
+			false,
+		},
+		{
+			"signed by another key",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return webAuthnSign(t, GenPrivKey(), crypto.Sha256(msg), webAuthnGetType, flagUserPresent).Bytes()
+			},
+			false,
+		},
+		{
+			"high S",
+			func(t *testing.T) []byte {
+				t.Helper()
+				sig := webAuthnSign(t, privKey, crypto.Sha256(msg), webAuthnGetType, flagUserPresent)
+				var der derSignature
+				_, err := asn1.Unmarshal(sig.Signature, &der)
+				require.NoError(t, err)
+				sig.Signature = derSign(t, der.R, new(big.Int).Sub(curveOrder, der.S))
+				return sig.Bytes()
+			},
+			false,
+		},
+		{
+			"trailing data after the signature",
+			func(t *testing.T) []byte {
+				t.Helper()
+				sig := webAuthnSign(t, privKey, crypto.Sha256(msg), webAuthnGetType, flagUserPresent)
+				sig.Signature = append(sig.Signature, 0)
+				return sig.Bytes()
+			},
+			false,
+		},
+		{
+			"not a webauthn signature",
+			func(t *testing.T) []byte {
+				t.Helper()
+				return []byte("invalid")
+			},
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.valid, pubKey.VerifyBytes(msg, testCase.sig(t)))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package secp256r1
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// webAuthnGetType is the type of the client data of WebAuthn assertions.
+	webAuthnGetType = "webauthn.get"
+
+	// authenticatorDataMinSize is the size of the authenticator data without
+	// extensions: the RP ID hash (32 bytes), the flags (1 byte) and the
+	// signature counter (4 bytes).
+	authenticatorDataMinSize = 37
+
+	// flagUserPresent is the authenticator data flag set when the user was
+	// present during the assertion.
+	flagUserPresent = 0x01
+)
+
+// WebAuthnSignature is a signature produced by a WebAuthn authenticator, such
+// as a browser passkey, for the assertion of a message.
+//
+// The challenge of the assertion is the SHA256 of the message. The
+// authenticator signs AuthenticatorData || SHA256(ClientDataJSON), and the
+// signature is ASN.1 DER encoded. Authenticators may return an S in either
+// form, which clients normalize to the lower-S form, as the signatures with a
+// high S are rejected. A WebAuthnSignature is used as the signature
+// of a PubKeySecp256r1 in its amino binary encoding.
+type WebAuthnSignature struct {
+	AuthenticatorData []byte `json:"authenticator_data" yaml:"authenticator_data"`
+	ClientDataJSON    []byte `json:"client_data_json" yaml:"client_data_json"`
+	Signature         []byte `json:"signature" yaml:"signature"`
+}
+
+// Bytes returns the amino binary encoding of the signature, to be used as the
+// signature of a transaction.
+func (sig WebAuthnSignature) Bytes() []byte {
+	return amino.MustMarshal(sig)
+}
+
+// derSignature is the ASN.1 structure of an ECDSA signature.
+type derSignature struct {
+	R, S *big.Int
+}
+
+// clientData holds the fields of the WebAuthn client data that are verified.
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+// verifyWebAuthn verifies that sigBytes is an amino-encoded WebAuthnSignature
+// of msg by pub.
+func verifyWebAuthn(pub *ecdsa.PublicKey, msg []byte, sigBytes []byte) bool {
+	var sig WebAuthnSignature
+	if err := amino.Unmarshal(sigBytes, &sig); err != nil {
+		return false
+	}
+
+	if len(sig.AuthenticatorData) < authenticatorDataMinSize ||
+		sig.AuthenticatorData[32]&flagUserPresent == 0 {
+		return false
+	}
+
+	var data clientData
+	if err := json.Unmarshal(sig.ClientDataJSON, &data); err != nil {
+		return false
+	}
+
+	if data.Type != webAuthnGetType {
+		return false
+	}
+
+	// Browsers encode the challenge in unpadded base64url
+	challenge, err := base64.RawURLEncoding.DecodeString(data.Challenge)
+	if err != nil || !bytes.Equal(challenge, crypto.Sha256(msg)) {
+		return false
+	}
+
+	var der derSignature
+	rest, err := asn1.Unmarshal(sig.Signature, &der)
+	if err != nil || len(rest) > 0 || der.R.Sign() <= 0 || der.S.Sign() <= 0 {
+		return false
+	}
+
+	// Reject malleable signatures, as for raw signatures
+	if der.S.Cmp(halfCurveOrder) > 0 {
+		return false
+	}
+
+	signed := append(append([]byte{}, sig.AuthenticatorData...), crypto.Sha256(sig.ClientDataJSON)...)
+
+	return ecdsa.Verify(pub, crypto.Sha256(signed), der.R, der.S)
+}
@@ -0,0 +1,180 @@
+package iavl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+// importBatchSize is the number of nodes written in a single batch by the
+// Importer.
+const importBatchSize = 10000
+
+// ErrNotEmpty is returned when importing into a tree having saved versions.
+var ErrNotEmpty = errors.New("tree is not empty")
+
+// ExportNode is a node of an exported tree. It contains the data required
+// to rebuild the node, and its hash, with an Importer.
+type ExportNode struct {
+	Key     []byte
+	Value   []byte // leaf nodes only
+	Version int64
+	Height  int8
+}
+
+// Export calls fn for each node of the tree in post-order, ie. the
+// children of an inner node before the inner node itself, stopping at the
+// first error returned by fn. The nodes can be imported with an Importer
+// to rebuild an identical tree.
+func (t *ImmutableTree) Export(fn func(ExportNode) error) error {
+	if t.root == nil {
+		return nil
+	}
+
+	return t.root.export(t, fn)
+}
+
+func (node *Node) export(t *ImmutableTree, fn func(ExportNode) error) error {
+	if !node.isLeaf() {
+		if err := node.getLeftNode(t).export(t, fn); err != nil {
+			return err
+		}
+		if err := node.getRightNode(t).export(t, fn); err != nil {
+			return err
+		}
+	}
+
+	return fn(ExportNode{
+		Key:     node.key,
+		Value:   node.value,
+		Version: node.version,
+		Height:  node.height,
+	})
+}
+
+// Importer rebuilds a tree version from the nodes exported by Export,
+// in the same order. The imported version is saved on Commit.
+type Importer struct {
+	tree    *MutableTree
+	version int64
+	batch   dbm.Batch
+	pending int     // nodes written to the batch
+	stack   []*Node // subtrees waiting for their parent
+}
+
+// Import returns an importer of the given tree version.
+// The tree must not have any saved version.
+func (tree *MutableTree) Import(version int64) (*Importer, error) {
+	if version <= 0 {
+		return nil, fmt.Errorf("invalid import version %d", version)
+	}
+	if tree.ndb.getLatestVersion() > 0 {
+		return nil, ErrNotEmpty
+	}
+
+	return &Importer{
+		tree:    tree,
+		version: version,
+		batch:   tree.ndb.db.NewBatch(),
+	}, nil
+}
+
+// Add adds the next exported node to the tree. An inner node is built
+// from the two last added subtrees, which are its children.
+func (i *Importer) Add(exportNode ExportNode) error {
+	if i.tree == nil {
+		return errors.New("importer is closed")
+	}
+	if exportNode.Version <= 0 || exportNode.Version > i.version {
+		return fmt.Errorf("node version %d is not within the import version %d", exportNode.Version, i.version)
+	}
+
+	node := &Node{
+		key:     exportNode.Key,
+		value:   exportNode.Value,
+		version: exportNode.Version,
+		height:  exportNode.Height,
+		size:    1,
+	}
+
+	switch {
+	case node.height < 0:
+		return fmt.Errorf("invalid node height %d", node.height)
+	case node.height > 0:
+		if len(i.stack) < 2 {
+			return fmt.Errorf("inner node at height %d is missing its children", node.height)
+		}
+
+		left, right := i.stack[len(i.stack)-2], i.stack[len(i.stack)-1]
+		if node.height != max(left.height, right.height)+1 {
+			return fmt.Errorf("inner node at height %d has children at heights %d and %d",
+				node.height, left.height, right.height)
+		}
+
+		node.leftHash = left.hash
+		node.rightHash = right.hash
+		node.size = left.size + right.size
+		i.stack = i.stack[:len(i.stack)-2]
+	}
+
+	node._hash()
+
+	buf := new(bytes.Buffer)
+	if err := node.writeBytes(buf); err != nil {
+		return err
+	}
+	i.batch.Set(i.tree.ndb.nodeKey(node.hash), buf.Bytes())
+
+	i.pending++
+	if i.pending >= importBatchSize {
+		i.batch.Write()
+		i.batch.Close()
+		i.batch = i.tree.ndb.db.NewBatch()
+		i.pending = 0
+	}
+
+	i.stack = append(i.stack, node)
+
+	return nil
+}
+
+// Commit saves the imported version, and loads it in the tree.
+// The importer can no longer be used afterwards.
+func (i *Importer) Commit() error {
+	if i.tree == nil {
+		return errors.New("importer is closed")
+	}
+
+	switch len(i.stack) {
+	case 0:
+		i.batch.Set(i.tree.ndb.rootKey(i.version), []byte{})
+	case 1:
+		i.batch.Set(i.tree.ndb.rootKey(i.version), i.stack[0].hash)
+	default:
+		return fmt.Errorf("invalid import, %d subtrees are not attached to a root", len(i.stack))
+	}
+
+	i.batch.WriteSync()
+	i.tree.ndb.resetLatestVersion(i.version)
+
+	tree := i.tree
+	i.Close()
+
+	_, err := tree.LoadVersion(i.version)
+
+	return err
+}
+
+// Close discards the nodes not yet written. It is a no-op after Commit.
+func (i *Importer) Close() {
+	if i.tree == nil {
+		return
+	}
+
+	i.batch.Close()
+	i.batch = nil
+	i.stack = nil
+	i.tree = nil
+}
@@ -0,0 +1,163 @@
+package iavl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+// setupExportTree returns a tree with a few versions of sets and removals
+func setupExportTree(t *testing.T) *MutableTree {
+	t.Helper()
+
+	tree := NewMutableTree(memdb.NewMemDB(), 0)
+	for v := 0; v < 5; v++ {
+		for i := 0; i < 50; i++ {
+			tree.Set([]byte(fmt.Sprintf("key%03d", (i*7+v)%100)), []byte(fmt.Sprintf("value%d-%d", v, i)))
+		}
+		for i := 0; i < 10; i++ {
+			tree.Remove([]byte(fmt.Sprintf("key%03d", (i*13+v)%100)))
+		}
+
+		_, _, err := tree.SaveVersion()
+		require.NoError(t, err)
+	}
+
+	return tree
+}
+
+func exportNodes(t *testing.T, tree *MutableTree, version int64) []ExportNode {
+	t.Helper()
+
+	itree, err := tree.GetImmutable(version)
+	require.NoError(t, err)
+
+	var nodes []ExportNode
+	require.NoError(t, itree.Export(func(node ExportNode) error {
+		nodes = append(nodes, node)
+		return nil
+	}))
+
+	return nodes
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tree    = setupExportTree(t)
+		version = int64(4)
+		nodes   = exportNodes(t, tree, version)
+	)
+
+	itree, err := tree.GetImmutable(version)
+	require.NoError(t, err)
+	assert.Len(t, nodes, itree.nodeSize())
+
+	// Import the version into an empty tree
+	newTree := NewMutableTree(memdb.NewMemDB(), 0)
+	importer, err := newTree.Import(version)
+	require.NoError(t, err)
+
+	for _, node := range nodes {
+		require.NoError(t, importer.Add(node))
+	}
+	require.NoError(t, importer.Commit())
+
+	assert.Equal(t, version, newTree.Version())
+	assert.Equal(t, itree.Hash(), newTree.Hash())
+	assert.Equal(t, itree.Size(), newTree.Size())
+
+	itree.Iterate(func(key, value []byte) bool {
+		_, newValue := newTree.Get(key)
+		assert.Equal(t, value, newValue)
+
+		return false
+	})
+
+	// Both trees keep the same hashes on new versions
+	_, err = tree.LoadVersionForOverwriting(version)
+	require.NoError(t, err)
+
+	for _, tr := range []*MutableTree{tree, newTree} {
+		tr.Set([]byte("key001"), []byte("updated"))
+		tr.Remove([]byte("key002"))
+	}
+
+	hash, _, err := tree.SaveVersion()
+	require.NoError(t, err)
+	newHash, _, err := newTree.SaveVersion()
+	require.NoError(t, err)
+	assert.Equal(t, hash, newHash)
+}
+
+func TestImport_EmptyTree(t *testing.T) {
+	t.Parallel()
+
+	tree := NewMutableTree(memdb.NewMemDB(), 0)
+	importer, err := tree.Import(3)
+	require.NoError(t, err)
+	require.NoError(t, importer.Commit())
+
+	assert.Equal(t, int64(3), tree.Version())
+	assert.True(t, tree.VersionExists(3))
+	assert.Nil(t, tree.root)
+}
+
+func TestImport_Errors(t *testing.T) {
+	t.Parallel()
+
+	nodes := exportNodes(t, setupExportTree(t), 5)
+
+	t.Run("not empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := setupExportTree(t).Import(10)
+		assert.ErrorIs(t, err, ErrNotEmpty)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewMutableTree(memdb.NewMemDB(), 0).Import(0)
+		assert.Error(t, err)
+	})
+
+	t.Run("node above the import version", func(t *testing.T) {
+		t.Parallel()
+
+		importer, err := NewMutableTree(memdb.NewMemDB(), 0).Import(2)
+		require.NoError(t, err)
+		defer importer.Close()
+
+		assert.Error(t, importer.Add(ExportNode{Key: []byte("a"), Value: []byte("a"), Version: 3}))
+	})
+
+	t.Run("missing children", func(t *testing.T) {
+		t.Parallel()
+
+		importer, err := NewMutableTree(memdb.NewMemDB(), 0).Import(5)
+		require.NoError(t, err)
+		defer importer.Close()
+
+		require.NoError(t, importer.Add(nodes[0]))
+		assert.Error(t, importer.Add(ExportNode{Key: []byte("a"), Version: 1, Height: 1}))
+	})
+
+	t.Run("unattached subtrees", func(t *testing.T) {
+		t.Parallel()
+
+		importer, err := NewMutableTree(memdb.NewMemDB(), 0).Import(5)
+		require.NoError(t, err)
+		defer importer.Close()
+
+		for _, node := range nodes[:len(nodes)-1] {
+			require.NoError(t, importer.Add(node))
+		}
+		assert.Error(t, importer.Commit())
+	})
+}
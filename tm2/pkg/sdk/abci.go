@@ -1,6 +1,9 @@
 package sdk
 
-import abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+import (
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
 
 // InitChainer initializes application state at genesis
 type InitChainer func(ctx Context, req abci.RequestInitChain) abci.ResponseInitChain
@@ -25,3 +28,8 @@ type BeginTxHook func(ctx Context) Context
 // EndTxHook is a BaseApp-specific hook, called after all the messages in a
 // transaction have terminated.
 type EndTxHook func(ctx Context, result Result)
+
+// RestoreHook is a BaseApp-specific hook, called after the state is restored
+// from a snapshot, to initialize the application on the restored state. The
+// changes written to the given cache-wrapped store are persisted.
+type RestoreHook func(ms store.MultiStore)
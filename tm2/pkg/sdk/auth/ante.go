@@ -3,6 +3,7 @@ package auth
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -10,6 +11,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
@@ -28,6 +30,22 @@ func init() {
 // and also to accept or reject different types of PubKey's. This is where apps can define their own PubKey
 type SignatureVerificationGasConsumer = func(meter store.GasMeter, sig []byte, pubkey crypto.PubKey, params Params) sdk.Result
 
+const (
+	// MaxReplacedSequences is the number of sequences preceding the sequence of
+	// an account which a tx checked for the mempool may be signed with, to
+	// replace the pending tx of the account which used it.
+	MaxReplacedSequences = 16
+
+	// PriorityGas is the amount of gas whose price is the priority of a tx in
+	// the mempool.
+	PriorityGas = 1_000_000
+)
+
+// SessionKeysContextKey is the context key of the session keys which signed the
+// tx for their accounts, a map[crypto.Address]crypto.PubKey set by the ante
+// handler.
+type SessionKeysContextKey struct{}
+
 type AnteOptions struct {
 	// If verifyGenesisSignatures is false, does not check signatures when Height==0.
 	// This is useful for development, and maybe production chains.
@@ -37,6 +55,7 @@ type AnteOptions struct {
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
+// signer, or from the fee granter within the allowance it granted to the first
 // signer.
 func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer SignatureVerificationGasConsumer, opts AnteOptions) sdk.AnteHandler {
 	return func(
@@ -65,6 +84,13 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			if !res.IsOK() {
 				return ctx, res, true
 			}
+
+			// the priority of txs in the mempool compares their gas prices
+			if denom := GasPriceDenom(ctx); denom != "" && !tx.Fee.GasFee.IsZero() && tx.Fee.GasFee.Denom != denom {
+				return ctx, abciResult(std.ErrInsufficientFee(fmt.Sprintf(
+					"fee denomination %s is not the gas price denomination %s", tx.Fee.GasFee.Denom, denom,
+				))), true
+			}
 		}
 
 		newCtx = SetGasMeter(ctx, tx.Fee.GasWanted)
@@ -108,6 +134,10 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			return newCtx, res, true
 		}
 
+		if res := ValidateTimeout(ctx, tx); !res.IsOK() {
+			return newCtx, res, true
+		}
+
 		// stdSigs contains the sequence number, account number, and signatures.
 		// When simulating, this would just be a 0-length slice.
 		signerAddrs := tx.GetSigners()
@@ -120,21 +150,45 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			return newCtx, res, true
 		}
 
-		// deduct the fees
+		// deduct the fees, which are only written once the tx is known not to
+		// replace a pending tx in the mempool: the fees of the replaced tx
+		// were deducted from the check state already.
+		feeMS := newCtx.MultiStore().MultiCacheWrap()
 		if !tx.Fee.GasFee.IsZero() {
-			res = DeductFees(bank, newCtx, signerAccs[0], ak.FeeCollectorAddress(ctx), std.Coins{tx.Fee.GasFee})
+			feeCtx := newCtx.WithMultiStore(feeMS)
+			payer := signerAccs[0]
+			if granter, ok := tx.Fee.Granter(); ok {
+				// the granter pays the fees, within the allowance granted to the first signer
+				if err := ak.UseFeeGrant(feeCtx, granter, signerAddrs[0], std.Coins{tx.Fee.GasFee}, tx.GetMsgs()); err != nil {
+					return newCtx, abciResult(err), true
+				}
+				payer, res = GetSignerAcc(feeCtx, ak, granter)
+				if !res.IsOK() {
+					return newCtx, res, true
+				}
+			}
+
+			res = DeductFees(bank, feeCtx, payer, ak.FeeCollectorAddress(ctx), std.Coins{tx.Fee.GasFee})
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
 
 			// reload the account as fees have been deducted
-			signerAccs[0] = ak.GetAccount(newCtx, signerAccs[0].GetAddress())
+			signerAccs[0] = ak.GetAccount(feeCtx, signerAccs[0].GetAddress())
+		}
+		if len(tx.GetSignatures()) == 0 {
+			feeMS.MultiWrite()
 		}
 
 		// stdSigs contains the sequence number, account number, and signatures.
 		// When simulating, this would just be a 0-length slice.
 		stdSigs := tx.GetSignatures()
 
+		// the sequence of the first signer, which orders its txs in the mempool
+		sequence := signerAccs[0].GetSequence()
+		replacement := false
+		sessionKeys := map[crypto.Address]crypto.PubKey{}
+
 		for i := range stdSigs {
 			// skip the fee payer, account is cached and fees were deducted already
 			if i != 0 {
@@ -154,16 +208,39 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 				if err != nil {
 					return newCtx, res, true
 				}
-				signerAccs[i], res = processSig(newCtx, sacc, stdSigs[i], signBytes, simulate, params, sigGasConsumer)
+				signerAccs[i], res = processSig(newCtx, sacc, tx, stdSigs[i], signBytes, simulate, params, sigGasConsumer)
+				if !res.IsOK() && i == 0 && ctx.IsCheckTx() && !simulate {
+					// the tx may replace a pending tx of the first signer in the mempool
+					signerAccs[i], sequence, res = processReplacementSig(newCtx, ak, tx, params, sigGasConsumer, res)
+					replacement = res.IsOK()
+				}
 				if !res.IsOK() {
 					return newCtx, res, true
 				}
+				if _, ok := sacc.(std.SessionKeyAccount); ok && stdSigs[i].PubKey != nil &&
+					stdSigs[i].PubKey.Address() != signerAddrs[i] {
+					sessionKeys[signerAddrs[i]] = stdSigs[i].PubKey
+				}
+			}
+			if i == 0 && !replacement {
+				// before the account of the first signer, which has them deducted
+				feeMS.MultiWrite()
 			}
 			ak.SetAccount(newCtx, signerAccs[i])
 		}
+		if len(sessionKeys) > 0 {
+			// the keepers charge the session keys what the msgs spend
+			newCtx = newCtx.WithValue(SessionKeysContextKey{}, sessionKeys)
+		}
 
 		// TODO: tx tags (?)
-		return newCtx, sdk.Result{GasWanted: tx.Fee.GasWanted}, false // continue...
+		return newCtx, sdk.Result{
+			GasWanted:   tx.Fee.GasWanted,
+			Priority:    TxPriority(tx.Fee, GasPriceDenom(ctx)),
+			Sender:      signerAddrs[0],
+			Sequence:    sequence,
+			Replacement: replacement,
+		}, false // continue...
 	}
 }
 
@@ -209,20 +286,60 @@ func ValidateMemo(tx std.Tx, params Params) sdk.Result {
 	return sdk.Result{}
 }
 
+// inclusionHeight returns the height of the block the transaction being
+// processed is included in.
+func inclusionHeight(ctx sdk.Context) int64 {
+	if ctx.IsCheckTx() {
+		// the check state is at the last committed block; the tx can at best be
+		// included in the next one.
+		return ctx.BlockHeight() + 1
+	}
+	return ctx.BlockHeight()
+}
+
+// ValidateTimeout validates that the transaction timeout is not past.
+func ValidateTimeout(ctx sdk.Context, tx std.Tx) sdk.Result {
+	height := inclusionHeight(ctx)
+
+	if tx.IsExpired(height, ctx.BlockTime()) {
+		return abciResult(std.ErrTxTimeout(
+			fmt.Sprintf(
+				"tx timed out; timeout height: %d, timeout timestamp: %d, block height: %d",
+				tx.TimeoutHeight, tx.TimeoutTimestamp, height,
+			),
+		))
+	}
+
+	return sdk.Result{}
+}
+
 // verify the signature and increment the sequence. If the account doesn't
 // have a pubkey, set it.
+//
+// Signatures by another key than the account's are accepted from the session
+// keys of accounts implementing std.SessionKeyAccount, within their scope.
 func processSig(
-	ctx sdk.Context, acc std.Account, sig std.Signature, signBytes []byte, simulate bool, params Params,
+	ctx sdk.Context, acc std.Account, tx std.Tx, sig std.Signature, signBytes []byte, simulate bool, params Params,
 	sigGasConsumer SignatureVerificationGasConsumer,
 ) (updatedAcc std.Account, res sdk.Result) {
-	pubKey, res := ProcessPubKey(acc, sig)
-	if !res.IsOK() {
-		return nil, res
-	}
+	var pubKey crypto.PubKey
+	skacc, ok := acc.(std.SessionKeyAccount)
+	if ok && sig.PubKey != nil && sig.PubKey.Address() != acc.GetAddress() {
+		// the signature is by a session key of the account
+		pubKey = sig.PubKey
+		if err := skacc.UseSessionKey(pubKey, tx, inclusionHeight(ctx)); err != nil {
+			return nil, abciResult(err)
+		}
+	} else {
+		pubKey, res = ProcessPubKey(acc, sig)
+		if !res.IsOK() {
+			return nil, res
+		}
 
-	err := acc.SetPubKey(pubKey)
-	if err != nil {
-		return nil, abciResult(std.ErrInternal("setting PubKey on signer's account"))
+		err := acc.SetPubKey(pubKey)
+		if err != nil {
+			return nil, abciResult(std.ErrInternal("setting PubKey on signer's account"))
+		}
 	}
 
 	if res := sigGasConsumer(ctx.GasMeter(), sig.Signature, pubKey, params); !res.IsOK() {
@@ -240,6 +357,80 @@ func processSig(
 	return acc, res
 }
 
+// processReplacementSig checks the signature of the first signer of a tx
+// against the sequences of its txs pending in the mempool, up to the last
+// MaxReplacedSequences ones, after it failed to verify with the account
+// sequence. The pending sequences are those used since the last committed
+// state; each sequence tried costs the gas of a signature verification. The
+// sequence of the account is not incremented, as the tx replaces the pending
+// tx which used the sequence in the mempool. It returns res if no sequence
+// matches. A pending tx rechecked after its sequence was committed doesn't
+// match, as the sequence is no longer pending.
+func processReplacementSig(
+	ctx sdk.Context, ak AccountKeeper, tx std.Tx, params Params,
+	sigGasConsumer SignatureVerificationGasConsumer, res sdk.Result,
+) (std.Account, uint64, sdk.Result) {
+	// reload the account, processSig may have modified it
+	acc := ak.GetAccount(ctx, tx.GetSigners()[0])
+	sig := tx.Signatures[0]
+
+	pubKey := sig.PubKey
+	if pubKey == nil {
+		pubKey = acc.GetPubKey()
+	}
+	if pubKey == nil {
+		return nil, 0, res
+	}
+
+	committed, ok := committedSequence(ctx, ak, acc.GetAddress())
+	if !ok {
+		return nil, 0, res
+	}
+
+	accSequence := acc.GetSequence()
+	for seq := accSequence; seq > committed && accSequence-seq < MaxReplacedSequences; {
+		seq--
+		signBytes, err := getSignBytes(ctx.ChainID(), tx, acc.GetAccountNumber(), seq)
+		if err != nil {
+			continue
+		}
+		if !pubKey.VerifyBytes(signBytes, sig.Signature) {
+			if sigRes := sigGasConsumer(ctx.GasMeter(), sig.Signature, pubKey, params); !sigRes.IsOK() {
+				return nil, 0, sigRes
+			}
+			continue
+		}
+
+		// the checks other than the signature itself are done by processSig
+		updatedAcc, sigRes := processSig(ctx, acc, tx, sig, signBytes, false, params, sigGasConsumer)
+		if !sigRes.IsOK() {
+			return nil, 0, sigRes
+		}
+		if err := updatedAcc.SetSequence(accSequence); err != nil {
+			panic(err)
+		}
+		return updatedAcc, seq, sigRes
+	}
+
+	return nil, 0, res
+}
+
+// committedSequence returns the sequence of the account at addr in the last
+// committed state, from which its txs pending in the mempool are sequenced.
+// It returns false if the context has no committed state, outside of CheckTx.
+func committedSequence(ctx sdk.Context, ak AccountKeeper, addr crypto.Address) (uint64, bool) {
+	committed, ok := ctx.Value(sdk.CommittedMultiStoreContextKey{}).(store.MultiStore)
+	if !ok {
+		return 0, false
+	}
+
+	acc := ak.GetAccount(ctx.WithMultiStore(committed), addr)
+	if acc == nil {
+		return 0, true
+	}
+	return acc.GetSequence(), true
+}
+
 // ProcessPubKey verifies that the given account address matches that of the
 // std.Signature. In addition, it will set the public key of the account if it
 // has not been set.
@@ -277,6 +468,16 @@ func DefaultSigVerificationGasConsumer(
 		meter.ConsumeGas(params.SigVerifyCostSecp256k1, "ante verify: secp256k1")
 		return sdk.Result{}
 
+	case secp256r1.PubKeySecp256r1:
+		// WebAuthn assertions are longer than raw signatures, and cost more
+		// to verify: their client data is parsed and hashed too
+		if len(sig) > secp256r1.SignatureSize {
+			meter.ConsumeGas(params.SigVerifyCostWebAuthn, "ante verify: secp256r1 webauthn")
+			return sdk.Result{}
+		}
+		meter.ConsumeGas(params.SigVerifyCostSecp256r1, "ante verify: secp256r1")
+		return sdk.Result{}
+
 	case multisig.PubKeyMultisigThreshold:
 		var multisignature multisig.Multisignature
 		amino.MustUnmarshal(sig, &multisignature)
@@ -406,6 +607,34 @@ func EnsureSufficientMempoolFees(ctx sdk.Context, fee std.Fee) sdk.Result {
 	))
 }
 
+// TxPriority returns the priority of a tx in the mempool: the price of
+// PriorityGas gas given its fee, if it is paid in the gas price denomination.
+func TxPriority(fee std.Fee, denom string) int64 {
+	if fee.GasWanted <= 0 || fee.GasFee.Amount <= 0 || fee.GasFee.Denom != denom {
+		return 0
+	}
+
+	priority := new(big.Int).Mul(big.NewInt(fee.GasFee.Amount), big.NewInt(PriorityGas))
+	priority.Quo(priority, big.NewInt(fee.GasWanted))
+	if !priority.IsInt64() {
+		return math.MaxInt64
+	}
+	return priority.Int64()
+}
+
+// GasPriceDenom returns the denomination the gas prices of txs are compared
+// in: the one of the block gas price, or else of the first minimum gas price
+// of the node. It is empty if there is no gas price.
+func GasPriceDenom(ctx sdk.Context) string {
+	if gp, ok := ctx.Value(GasPriceContextKey{}).(std.GasPrice); ok && gp.Price.IsValid() && !gp.Price.IsZero() {
+		return gp.Price.Denom
+	}
+	if minGasPrices := ctx.MinGasPrices(); len(minGasPrices) > 0 {
+		return minGasPrices[0].Price.Denom
+	}
+	return ""
+}
+
 // SetGasMeter returns a new context with a gas meter set from a given context.
 func SetGasMeter(ctx sdk.Context, gasLimit int64) sdk.Context {
 	// In various cases such as simulation and during the genesis block, we do not
@@ -429,6 +658,10 @@ func GetSignBytes(chainID string, tx std.Tx, acc std.Account, genesis bool) ([]b
 		accSequence = acc.GetSequence()
 	}
 
+	return getSignBytes(chainID, tx, accNum, accSequence)
+}
+
+func getSignBytes(chainID string, tx std.Tx, accNum, accSequence uint64) ([]byte, error) {
 	return std.GetSignaturePayload(
 		std.SignDoc{
 			ChainID:       chainID,
@@ -437,6 +670,9 @@ func GetSignBytes(chainID string, tx std.Tx, acc std.Account, genesis bool) ([]b
 			Fee:           tx.Fee,
 			Msgs:          tx.Msgs,
 			Memo:          tx.Memo,
+
+			TimeoutHeight:    tx.TimeoutHeight,
+			TimeoutTimestamp: tx.TimeoutTimestamp,
 		},
 	)
 }
@@ -2,10 +2,12 @@ package auth
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +19,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -228,6 +231,47 @@ func TestAnteHandlerAccountNumbersAtBlockHeightZero(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test that ed25519 and secp256r1 keys can sign transactions.
+func TestAnteHandlerSigningAlgos(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	ctx := env.ctx
+
+	// keys and addresses
+	priv1 := ed25519.GenPrivKey()
+	addr1 := priv1.PubKey().Address()
+	priv2 := secp256r1.GenPrivKey()
+	addr2 := priv2.PubKey().Address()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(ctx, acc1)
+	acc2 := env.acck.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(ctx, acc2)
+
+	// msg and signatures
+	msgs := []std.Msg{tu.NewTestMsg(addr1, addr2)}
+	fee := tu.NewTestFee()
+
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2}, []uint64{0, 1}, []uint64{0, 0}
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	// the public keys are set on the accounts
+	assert.True(t, priv1.PubKey().Equals(env.acck.GetAccount(ctx, addr1).GetPubKey()))
+	assert.True(t, priv2.PubKey().Equals(env.acck.GetAccount(ctx, addr2).GetPubKey()))
+
+	// a signature by the wrong key is rejected
+	privs, seqs = []crypto.PrivKey{priv1, secp256r1.GenPrivKey()}, []uint64{1, 1}
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+}
+
 // Test logic around sequence checking with one signer and many signers.
 func TestAnteHandlerSequences(t *testing.T) {
 	t.Parallel()
@@ -307,6 +351,103 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test the mempool ordering of txs checked by CheckTx, and the replacement of
+// pending txs.
+func TestAnteHandlerReplacement(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	acc1 := env.acck.NewAccountWithAddress(env.ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(env.ctx, acc1)
+
+	// the check state caches the committed state, like in CheckTx
+	committed := env.ctx.MultiStore()
+	ctx := env.ctx.WithMode(sdk.RunTxModeCheck).
+		WithMultiStore(committed.MultiCacheWrap()).
+		WithValue(sdk.CommittedMultiStoreContextKey{}, committed).
+		WithValue(GasPriceContextKey{}, std.GasPrice{Gas: 1000, Price: std.NewCoin("atom", 1)})
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+	fee := std.NewFee(50000, std.NewCoin("atom", 150))
+
+	// no sequence is pending yet
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	for seq := range uint64(3) {
+		tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{seq}, fee)
+		_, res, abort := anteHandler(ctx, tx, false)
+		require.False(t, abort, res.Log)
+		assert.Equal(t, addr1, res.Sender)
+		assert.Equal(t, seq, res.Sequence)
+		assert.Equal(t, int64(3000), res.Priority)
+		assert.False(t, res.Replacement)
+	}
+
+	// a tx signed with a pending sequence is checked as a replacement, and
+	// the fees of the replaced tx stand for its own
+	coins := env.acck.GetAccount(ctx, addr1).GetCoins()
+	replacingFee := std.NewFee(50000, std.NewCoin("atom", 300))
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, replacingFee)
+	_, res, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort, res.Log)
+	assert.Equal(t, uint64(1), res.Sequence)
+	assert.Equal(t, int64(6000), res.Priority)
+	assert.True(t, res.Replacement)
+	assert.Equal(t, uint64(3), env.acck.GetAccount(ctx, addr1).GetSequence())
+	assert.Equal(t, coins, env.acck.GetAccount(ctx, addr1).GetCoins())
+
+	// fees are only compared in the gas price denomination
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, std.NewFee(50000, std.NewCoin("foocoin", 300)))
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InsufficientFeeError{})
+
+	// the next tx still uses the account sequence
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{3}, fee)
+	_, res, abort = anteHandler(ctx, tx, false)
+	require.False(t, abort, res.Log)
+	assert.Equal(t, uint64(3), res.Sequence)
+	assert.False(t, res.Replacement)
+	assert.Equal(t, coins.Sub(std.Coins{fee.GasFee}), env.acck.GetAccount(ctx, addr1).GetCoins())
+
+	// a tx signed with a future sequence, or by another key, is rejected
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{10}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+	priv2, _, _ := tu.KeyTestPubAddr()
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{priv2}, accnums, []uint64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// txs are never replaced in DeliverTx
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, replacingFee)
+	checkInvalidTx(t, anteHandler, ctx.WithMode(sdk.RunTxModeDeliver), tx, false, std.UnauthorizedError{})
+}
+
+func TestTxPriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fee      std.Fee
+		priority int64
+	}{
+		{std.NewFee(1000, std.NewCoin("ugnot", 1)), 1000},
+		{std.NewFee(50000, std.NewCoin("ugnot", 1000)), 20_000},
+		{std.NewFee(3_000_000, std.NewCoin("ugnot", 1)), 0},
+		{std.NewFee(0, std.NewCoin("ugnot", 1)), 0},
+		{std.NewFee(1000, std.NewCoin("ugnot", 0)), 0},
+		{std.NewFee(1, std.NewCoin("ugnot", math.MaxInt64)), math.MaxInt64},
+		{std.NewFee(1000, std.NewCoin("atom", 1)), 0},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.priority, TxPriority(tc.fee, "ugnot"), "fee: %+v", tc.fee)
+	}
+}
+
 // Test logic around fee deduction.
 func TestAnteHandlerFees(t *testing.T) {
 	t.Parallel()
@@ -352,6 +493,76 @@ func TestAnteHandlerFees(t *testing.T) {
 	require.Equal(t, env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom"), int64(0))
 }
 
+func TestAnteHandlerFeeGranter(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	_, _, granter := tu.KeyTestPubAddr()
+
+	// set the accounts; the grantee has no funds
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	env.acck.SetAccount(ctx, acc1)
+	accGranter := env.acck.NewAccountWithAddress(ctx, granter)
+	accGranter.SetCoins(std.NewCoins(std.NewCoin("atom", 1000)))
+	env.acck.SetAccount(ctx, accGranter)
+
+	// msg and signatures
+	var tx std.Tx
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+	fee := tu.NewTestFee()
+	fee.FeeGranter = granter.Bech32()
+
+	// no allowance granted
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{0}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// allowance restricted to other messages
+	env.acck.SetFeeGrant(ctx, FeeGrant{
+		Granter:   granter,
+		Grantee:   addr1,
+		Allowance: FeeAllowance{AllowedMsgs: []string{"bank/send"}},
+	})
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// expired allowance
+	env.acck.SetFeeGrant(ctx, FeeGrant{
+		Granter:   granter,
+		Grantee:   addr1,
+		Allowance: FeeAllowance{Expiration: ctx.BlockTime().Unix() - 1},
+	})
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// allowance covering two transactions
+	env.acck.SetFeeGrant(ctx, FeeGrant{
+		Granter: granter,
+		Grantee: addr1,
+		Allowance: FeeAllowance{
+			SpendLimit:  std.NewCoins(std.NewCoin("atom", 300)),
+			AllowedMsgs: []string{MsgTypeURL(msgs[0])},
+		},
+	})
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, int64(850), env.acck.GetAccount(ctx, granter).GetCoins().AmountOf("atom"))
+	require.Equal(t, "150atom", env.acck.GetFeeGrant(ctx, granter, addr1).Allowance.SpendLimit.String())
+
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, int64(700), env.acck.GetAccount(ctx, granter).GetCoins().AmountOf("atom"))
+	require.True(t, env.acck.GetAccount(ctx, addr1).GetCoins().IsZero())
+
+	// exhausted allowance is removed
+	require.Nil(t, env.acck.GetFeeGrant(ctx, granter, addr1))
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{2}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+}
+
 // Test logic around memo gas consumption.
 func TestAnteHandlerMemoGas(t *testing.T) {
 	t.Parallel()
@@ -395,6 +606,62 @@ func TestAnteHandlerMemoGas(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test logic around transaction timeouts.
+func TestAnteHandlerTimeout(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	ctx := env.ctx.WithBlockHeader(&bft.Header{Height: 10, ChainID: "test-chain-id", Time: time.Unix(1000, 0)})
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	fee := tu.NewTestFee()
+	seq := uint64(0)
+	newTx := func(timeoutHeight, timeoutTimestamp int64) std.Tx {
+		tx := std.NewTx(msgs, fee, nil, "")
+		tx.TimeoutHeight, tx.TimeoutTimestamp = timeoutHeight, timeoutTimestamp
+		signBytes, err := tx.GetSignBytes(ctx.ChainID(), 0, seq)
+		require.NoError(t, err)
+		sig, err := priv1.Sign(signBytes)
+		require.NoError(t, err)
+		tx.Signatures = []std.Signature{{PubKey: priv1.PubKey(), Signature: sig}}
+		return tx
+	}
+
+	// negative timeout
+	checkInvalidTx(t, anteHandler, ctx, newTx(-1, 0), false, std.TxTimeoutError{})
+
+	// timeout height is past
+	checkInvalidTx(t, anteHandler, ctx, newTx(9, 0), false, std.TxTimeoutError{})
+
+	// timeout timestamp is past
+	checkInvalidTx(t, anteHandler, ctx, newTx(0, 999), false, std.TxTimeoutError{})
+
+	// timeout height is the current block
+	checkValidTx(t, anteHandler, ctx, newTx(10, 1000), false)
+	seq++
+
+	// on CheckTx, the tx can at best be included in the next block
+	checkCtx := ctx.WithMode(sdk.RunTxModeCheck).WithValue(GasPriceContextKey{}, std.GasPrice{})
+	checkInvalidTx(t, anteHandler, checkCtx, newTx(10, 0), false, std.TxTimeoutError{})
+	checkValidTx(t, anteHandler, checkCtx, newTx(11, 0), false)
+
+	// the timeout is covered by the signature
+	tx := newTx(20, 0)
+	tx.TimeoutHeight = 30
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+}
+
 func TestAnteHandlerMultiSigner(t *testing.T) {
 	t.Parallel()
 
@@ -659,6 +926,8 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 	}{
 		{"PubKeyEd25519", args{store.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostED25519, false},
 		{"PubKeySecp256k1", args{store.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostSecp256k1, false},
+		{"PubKeySecp256r1", args{store.NewInfiniteGasMeter(), make([]byte, secp256r1.SignatureSize), secp256r1.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostSecp256r1, false},
+		{"PubKeySecp256r1 WebAuthn", args{store.NewInfiniteGasMeter(), secp256r1.WebAuthnSignature{Signature: make([]byte, 70)}.Bytes(), secp256r1.GenPrivKey().PubKey(), params}, DefaultSigVerifyCostWebAuthn, false},
 		{"Multisig", args{store.NewInfiniteGasMeter(), amino.MustMarshal(multisignature1), multisigKey1, params}, expectedCost1, false},
 		{"unknown key", args{store.NewInfiniteGasMeter(), nil, nil, params}, 0, true},
 	}
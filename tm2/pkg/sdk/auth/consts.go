@@ -16,6 +16,8 @@ const (
 
 	// AddressStoreKeyPrefix prefix for account-by-address store
 	AddressStoreKeyPrefix = "/a/"
+	// FeeGrantStoreKeyPrefix prefix for fee-grant-by-granter-and-grantee store
+	FeeGrantStoreKeyPrefix = "/g/"
 	// key for gas price
 	GasPriceKey = "gasPrice"
 	// param key for global account number
@@ -26,3 +28,15 @@ const (
 func AddressStoreKey(addr crypto.Address) []byte {
 	return append([]byte(AddressStoreKeyPrefix), addr.Bytes()...)
 }
+
+// FeeGrantStoreKey turns a granter and a grantee to the key used to get their
+// fee grant from the account store
+func FeeGrantStoreKey(granter, grantee crypto.Address) []byte {
+	return append(FeeGrantsByGranterKey(granter), grantee.Bytes()...)
+}
+
+// FeeGrantsByGranterKey returns the prefix of the keys of all the fee grants
+// of a granter
+func FeeGrantsByGranterKey(granter crypto.Address) []byte {
+	return append([]byte(FeeGrantStoreKeyPrefix), granter.Bytes()...)
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// FeeAllowance defines how much and for what a granter pays the transaction
+// fees of a grantee.
+type FeeAllowance struct {
+	// SpendLimit is the total amount of fees the granter pays; it is decreased
+	// every time the allowance is used. Empty means no limit.
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+	// Expiration is the unix timestamp, in seconds, after which the allowance
+	// can no longer be used. Zero means no expiration.
+	Expiration int64 `json:"expiration" yaml:"expiration"`
+	// AllowedMsgs restricts the allowance to transactions only containing the
+	// listed messages, in the "<route>/<type>" form (ie. "bank/send" or
+	// "vm/exec"). Empty means all messages are allowed.
+	AllowedMsgs []string `json:"allowed_msgs" yaml:"allowed_msgs"`
+}
+
+// ValidateBasic does a simple validation check that doesn't require access to
+// any other information.
+func (fa FeeAllowance) ValidateBasic() error {
+	if !fa.SpendLimit.IsValid() {
+		return std.ErrInvalidCoins(fa.SpendLimit.String())
+	}
+	if fa.Expiration < 0 {
+		return std.ErrUnknownRequest("fee allowance expiration must not be negative")
+	}
+	for _, msgType := range fa.AllowedMsgs {
+		if msgType == "" {
+			return std.ErrUnknownRequest("fee allowance allowed message must not be empty")
+		}
+	}
+	return nil
+}
+
+// Accept checks that the allowance covers paying fees for a transaction
+// containing msgs at blockTime. It returns the allowance left after paying the
+// fees, and whether the allowance is exhausted and must be removed.
+func (fa FeeAllowance) Accept(blockTime time.Time, fees std.Coins, msgs []std.Msg) (left FeeAllowance, remove bool, err error) {
+	if fa.Expiration != 0 && blockTime.Unix() > fa.Expiration {
+		return fa, false, std.ErrUnauthorized("fee allowance expired")
+	}
+
+	if len(fa.AllowedMsgs) != 0 {
+		for _, msg := range msgs {
+			if msgType := MsgTypeURL(msg); !slices.Contains(fa.AllowedMsgs, msgType) {
+				return fa, false, std.ErrUnauthorized(
+					fmt.Sprintf("fee allowance does not allow message %q", msgType))
+			}
+		}
+	}
+
+	if len(fa.SpendLimit) == 0 {
+		return fa, false, nil
+	}
+	if !fa.SpendLimit.IsAllGTE(fees) {
+		return fa, false, std.ErrInsufficientFunds(
+			fmt.Sprintf("fee allowance spend limit exceeded; %s < %s", fa.SpendLimit, fees))
+	}
+	fa.SpendLimit = fa.SpendLimit.Sub(fees)
+	return fa, len(fa.SpendLimit) == 0, nil
+}
+
+// MsgTypeURL returns the "<route>/<type>" identifier of msg used by fee
+// allowances.
+func MsgTypeURL(msg std.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
+
+// FeeGrant is a fee allowance given by a granter to a grantee.
+type FeeGrant struct {
+	Granter   crypto.Address `json:"granter" yaml:"granter"`
+	Grantee   crypto.Address `json:"grantee" yaml:"grantee"`
+	Allowance FeeAllowance   `json:"allowance" yaml:"allowance"`
+}
+
+// GetFeeGrant returns the fee grant given by granter to grantee, or nil.
+func (ak AccountKeeper) GetFeeGrant(ctx sdk.Context, granter, grantee crypto.Address) *FeeGrant {
+	stor := ctx.GasStore(ak.key)
+	bz := stor.Get(FeeGrantStoreKey(granter, grantee))
+	if bz == nil {
+		return nil
+	}
+	grant := new(FeeGrant)
+	amino.MustUnmarshal(bz, grant)
+	return grant
+}
+
+// SetFeeGrant stores a fee grant, replacing any previous grant given by the
+// same granter to the same grantee.
+func (ak AccountKeeper) SetFeeGrant(ctx sdk.Context, grant FeeGrant) {
+	stor := ctx.GasStore(ak.key)
+	stor.Set(FeeGrantStoreKey(grant.Granter, grant.Grantee), amino.MustMarshal(grant))
+}
+
+// RemoveFeeGrant removes the fee grant given by granter to grantee.
+func (ak AccountKeeper) RemoveFeeGrant(ctx sdk.Context, granter, grantee crypto.Address) {
+	stor := ctx.GasStore(ak.key)
+	stor.Delete(FeeGrantStoreKey(granter, grantee))
+}
+
+// IterateFeeGrants iterates over the fee grants given by granter, ordered by
+// grantee.
+func (ak AccountKeeper) IterateFeeGrants(ctx sdk.Context, granter crypto.Address, process func(FeeGrant) (stop bool)) {
+	stor := ctx.GasStore(ak.key)
+	iter := store.PrefixIterator(stor, FeeGrantsByGranterKey(granter))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var grant FeeGrant
+		amino.MustUnmarshal(iter.Value(), &grant)
+		if process(grant) {
+			return
+		}
+	}
+}
+
+// UseFeeGrant checks that granter granted grantee an allowance covering fees
+// for msgs, and consumes it.
+func (ak AccountKeeper) UseFeeGrant(ctx sdk.Context, granter, grantee crypto.Address, fees std.Coins, msgs []std.Msg) error {
+	grant := ak.GetFeeGrant(ctx, granter, grantee)
+	if grant == nil {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("%s has no fee allowance from %s", grantee, granter))
+	}
+
+	left, remove, err := grant.Allowance.Accept(ctx.BlockTime(), fees, msgs)
+	if err != nil {
+		return err
+	}
+	if remove {
+		ak.RemoveFeeGrant(ctx, granter, grantee)
+	} else {
+		grant.Allowance = left
+		ak.SetFeeGrant(ctx, *grant)
+	}
+	return nil
+}
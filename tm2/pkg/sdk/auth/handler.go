@@ -25,9 +25,40 @@ func NewHandler(acck AccountKeeper, gpKpr GasPriceKeeper) authHandler {
 }
 
 func (ah authHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
-	// no messages supported yet.
-	errMsg := fmt.Sprintf("unrecognized auth message type: %T", msg)
-	return abciResult(std.ErrUnknownRequest(errMsg))
+	switch msg := msg.(type) {
+	case MsgGrantAllowance:
+		return ah.handleMsgGrantAllowance(ctx, msg)
+	case MsgRevokeAllowance:
+		return ah.handleMsgRevokeAllowance(ctx, msg)
+	default:
+		errMsg := fmt.Sprintf("unrecognized auth message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgGrantAllowance.
+func (ah authHandler) handleMsgGrantAllowance(ctx sdk.Context, msg MsgGrantAllowance) sdk.Result {
+	// Create the grantee account if needed, so that it can sign transactions
+	// before it received any coins.
+	if ah.acck.GetAccount(ctx, msg.Grantee) == nil {
+		ah.acck.SetAccount(ctx, ah.acck.NewAccountWithAddress(ctx, msg.Grantee))
+	}
+	ah.acck.SetFeeGrant(ctx, FeeGrant{
+		Granter:   msg.Granter,
+		Grantee:   msg.Grantee,
+		Allowance: msg.Allowance,
+	})
+	return sdk.Result{}
+}
+
+// Handle MsgRevokeAllowance.
+func (ah authHandler) handleMsgRevokeAllowance(ctx sdk.Context, msg MsgRevokeAllowance) sdk.Result {
+	if ah.acck.GetFeeGrant(ctx, msg.Granter, msg.Grantee) == nil {
+		return abciResult(std.ErrUnknownRequest(
+			fmt.Sprintf("%s has no fee allowance from %s", msg.Grantee, msg.Granter)))
+	}
+	ah.acck.RemoveFeeGrant(ctx, msg.Granter, msg.Grantee)
+	return sdk.Result{}
 }
 
 //----------------------------------------
@@ -37,6 +68,7 @@ func (ah authHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 const (
 	QueryAccount  = "accounts"
 	QueryGasPrice = "gasprice"
+	QueryGrants   = "grants"
 )
 
 func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -45,6 +77,8 @@ func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Re
 		return ah.queryAccount(ctx, req)
 	case QueryGasPrice:
 		return ah.queryGasPrice(ctx, req)
+	case QueryGrants:
+		return ah.queryGrants(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown auth query endpoint"))
@@ -95,6 +129,49 @@ func (ah authHandler) queryGasPrice(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// queryGrants fetch the fee grants given by an address, or the one given to a
+// grantee if it is also passed as path component.
+func (ah authHandler) queryGrants(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addresses from path.
+	b32granter := thirdPart(req.Path)
+	granter, err := crypto.AddressFromBech32(b32granter)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress(
+				"invalid query address " + b32granter))
+		return
+	}
+
+	var result any
+	if b32grantee := fourthPart(req.Path); b32grantee != "" {
+		grantee, err := crypto.AddressFromBech32(b32grantee)
+		if err != nil {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrInvalidAddress(
+					"invalid query address " + b32grantee))
+			return
+		}
+		result = ah.acck.GetFeeGrant(ctx, granter, grantee)
+	} else {
+		grants := []FeeGrant{}
+		ah.acck.IterateFeeGrants(ctx, granter, func(grant FeeGrant) bool {
+			grants = append(grants, grant)
+			return false
+		})
+		result = grants
+	}
+
+	bz, err := amino.MarshalJSONIndent(result, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
@@ -117,3 +194,13 @@ func thirdPart(path string) string {
 		return parts[2]
 	}
 }
+
+// returns the fourth component of a path.
+func fourthPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return ""
+	} else {
+		return parts[3]
+	}
+}
@@ -89,6 +89,53 @@ func TestQueryGasPrice(t *testing.T) {
 	require.True(t, gp == gp2)
 }
 
+func TestFeeGrants(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.acck, env.gk)
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, grantee := tu.KeyTestPubAddr()
+
+	allowance := FeeAllowance{
+		SpendLimit:  std.NewCoins(std.NewCoin("foo", 10)),
+		AllowedMsgs: []string{"bank/send"},
+	}
+	grant := NewMsgGrantAllowance(granter, grantee, allowance)
+	require.NoError(t, grant.ValidateBasic())
+	require.Error(t, NewMsgGrantAllowance(granter, granter, allowance).ValidateBasic())
+
+	// the grantee account is created
+	res := h.Process(env.ctx, grant)
+	require.True(t, res.IsOK(), res.Log)
+	require.NotNil(t, env.acck.GetAccount(env.ctx, grantee))
+
+	// query the grants of granter
+	res2 := h.Query(env.ctx, abci.RequestQuery{Path: fmt.Sprintf("auth/%s/%s", QueryGrants, granter)})
+	require.Nil(t, res2.Error)
+	var grants []FeeGrant
+	require.NoError(t, amino.UnmarshalJSON(res2.Data, &grants))
+	require.Equal(t, []FeeGrant{{Granter: granter, Grantee: grantee, Allowance: allowance}}, grants)
+
+	// query a single grant
+	res2 = h.Query(env.ctx, abci.RequestQuery{Path: fmt.Sprintf("auth/%s/%s/%s", QueryGrants, granter, grantee)})
+	require.Nil(t, res2.Error)
+	var single FeeGrant
+	require.NoError(t, amino.UnmarshalJSON(res2.Data, &single))
+	require.Equal(t, grants[0], single)
+
+	// revoke
+	res = h.Process(env.ctx, NewMsgRevokeAllowance(granter, grantee))
+	require.True(t, res.IsOK(), res.Log)
+	require.Nil(t, env.acck.GetFeeGrant(env.ctx, granter, grantee))
+	res = h.Process(env.ctx, NewMsgRevokeAllowance(granter, grantee))
+	require.False(t, res.IsOK())
+
+	res2 = h.Query(env.ctx, abci.RequestQuery{Path: fmt.Sprintf("auth/%s/%s/%s", QueryGrants, granter, grantee)})
+	require.Nil(t, res2.Error)
+	require.Equal(t, "null", string(res2.Data))
+}
+
 func TestQuerierRouteNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is the name of the auth module
+const RouterKey = ModuleName
+
+// MsgGrantAllowance - grants a fee allowance to a grantee, replacing any
+// previous one
+type MsgGrantAllowance struct {
+	Granter   crypto.Address `json:"granter" yaml:"granter"`
+	Grantee   crypto.Address `json:"grantee" yaml:"grantee"`
+	Allowance FeeAllowance   `json:"allowance" yaml:"allowance"`
+}
+
+var _ std.Msg = MsgGrantAllowance{}
+
+// NewMsgGrantAllowance - construct a fee allowance grant msg.
+func NewMsgGrantAllowance(granter, grantee crypto.Address, allowance FeeAllowance) MsgGrantAllowance {
+	return MsgGrantAllowance{Granter: granter, Grantee: grantee, Allowance: allowance}
+}
+
+// Route Implements Msg.
+func (msg MsgGrantAllowance) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgGrantAllowance) Type() string { return "grant_allowance" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgGrantAllowance) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.Granter == msg.Grantee {
+		return std.ErrInvalidAddress("cannot grant a fee allowance to self")
+	}
+	return msg.Allowance.ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgGrantAllowance) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgGrantAllowance) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+// MsgRevokeAllowance - revokes the fee allowance granted to a grantee
+type MsgRevokeAllowance struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+}
+
+var _ std.Msg = MsgRevokeAllowance{}
+
+// NewMsgRevokeAllowance - construct a fee allowance revocation msg.
+func NewMsgRevokeAllowance(granter, grantee crypto.Address) MsgRevokeAllowance {
+	return MsgRevokeAllowance{Granter: granter, Grantee: grantee}
+}
+
+// Route Implements Msg.
+func (msg MsgRevokeAllowance) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevokeAllowance) Type() string { return "revoke_allowance" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevokeAllowance) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevokeAllowance) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevokeAllowance) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
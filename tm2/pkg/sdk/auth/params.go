@@ -19,9 +19,12 @@ const (
 	DefaultTxSizeCostPerByte      int64 = 10
 	DefaultSigVerifyCostED25519   int64 = 590
 	DefaultSigVerifyCostSecp256k1 int64 = 1000
+	DefaultSigVerifyCostSecp256r1 int64 = 1000
+	DefaultSigVerifyCostWebAuthn  int64 = 2000
 
 	DefaultGasPricesChangeCompressor int64 = 10
 	DefaultTargetGasRatio            int64 = 70 //  70% of the MaxGas in a block
+	DefaultFeeRefundRatio            int64 = 0  //  unused gas fees are not refunded
 
 	DefaultFeeCollectorName string = "fee_collector"
 )
@@ -33,11 +36,14 @@ type Params struct {
 	TxSizeCostPerByte         int64            `json:"tx_size_cost_per_byte" yaml:"tx_size_cost_per_byte"`
 	SigVerifyCostED25519      int64            `json:"sig_verify_cost_ed25519" yaml:"sig_verify_cost_ed25519"`
 	SigVerifyCostSecp256k1    int64            `json:"sig_verify_cost_secp256k1" yaml:"sig_verify_cost_secp256k1"`
+	SigVerifyCostSecp256r1    int64            `json:"sig_verify_cost_secp256r1" yaml:"sig_verify_cost_secp256r1"`
+	SigVerifyCostWebAuthn     int64            `json:"sig_verify_cost_webauthn" yaml:"sig_verify_cost_webauthn"` // secp256r1 WebAuthn assertions
 	GasPricesChangeCompressor int64            `json:"gas_price_change_compressor" yaml:"gas_price_change_compressor"`
 	TargetGasRatio            int64            `json:"target_gas_ratio" yaml:"target_gas_ratio"`
 	InitialGasPrice           std.GasPrice     `json:"initial_gasprice"`
 	UnrestrictedAddrs         []crypto.Address `json:"unrestricted_addrs" yaml:"unrestricted_addrs"`
 	FeeCollector              crypto.Address   `json:"fee_collector" yaml:"fee_collector"`
+	FeeRefundRatio            int64            `json:"fee_refund_ratio" yaml:"fee_refund_ratio"` // % of the unused gas fees refunded
 }
 
 // NewParams creates a new Params object
@@ -51,9 +57,12 @@ func NewParams(maxMemoBytes, txSigLimit, txSizeCostPerByte,
 		TxSizeCostPerByte:         txSizeCostPerByte,
 		SigVerifyCostED25519:      sigVerifyCostED25519,
 		SigVerifyCostSecp256k1:    sigVerifyCostSecp256k1,
+		SigVerifyCostSecp256r1:    DefaultSigVerifyCostSecp256r1,
+		SigVerifyCostWebAuthn:     DefaultSigVerifyCostWebAuthn,
 		GasPricesChangeCompressor: gasPricesChangeCompressor,
 		TargetGasRatio:            targetGasRatio,
 		FeeCollector:              feeCollector,
+		FeeRefundRatio:            DefaultFeeRefundRatio,
 	}
 }
 
@@ -86,12 +95,27 @@ func (p Params) String() string {
 	fmt.Fprintf(sb, "TxSizeCostPerByte: %d\n", p.TxSizeCostPerByte)
 	fmt.Fprintf(sb, "SigVerifyCostED25519: %d\n", p.SigVerifyCostED25519)
 	fmt.Fprintf(sb, "SigVerifyCostSecp256k1: %d\n", p.SigVerifyCostSecp256k1)
+	fmt.Fprintf(sb, "SigVerifyCostSecp256r1: %d\n", p.SigVerifyCostSecp256r1)
+	fmt.Fprintf(sb, "SigVerifyCostWebAuthn: %d\n", p.SigVerifyCostWebAuthn)
 	fmt.Fprintf(sb, "GasPricesChangeCompressor: %d\n", p.GasPricesChangeCompressor)
 	fmt.Fprintf(sb, "TargetGasRatio: %d\n", p.TargetGasRatio)
 	fmt.Fprintf(sb, "FeeCollector: %s\n", p.FeeCollector.String())
+	fmt.Fprintf(sb, "FeeRefundRatio: %d\n", p.FeeRefundRatio)
 	return sb.String()
 }
 
+// withDefaults returns the params with the default value of the params added
+// after the chains started, which are zero in their genesis and store.
+func (p Params) withDefaults() Params {
+	if p.SigVerifyCostSecp256r1 == 0 {
+		p.SigVerifyCostSecp256r1 = DefaultSigVerifyCostSecp256r1
+	}
+	if p.SigVerifyCostWebAuthn == 0 {
+		p.SigVerifyCostWebAuthn = DefaultSigVerifyCostWebAuthn
+	}
+	return p
+}
+
 func (p Params) Validate() error {
 	if p.MaxMemoBytes <= 0 {
 		return fmt.Errorf("invalid max memo bytes: %d", p.MaxMemoBytes)
@@ -105,6 +129,12 @@ func (p Params) Validate() error {
 	if p.SigVerifyCostSecp256k1 <= 0 {
 		return fmt.Errorf("invalid SECK256k1 signature verification cost: %d", p.SigVerifyCostSecp256k1)
 	}
+	if p.SigVerifyCostSecp256r1 <= 0 {
+		return fmt.Errorf("invalid SECP256r1 signature verification cost: %d", p.SigVerifyCostSecp256r1)
+	}
+	if p.SigVerifyCostWebAuthn <= 0 {
+		return fmt.Errorf("invalid WebAuthn signature verification cost: %d", p.SigVerifyCostWebAuthn)
+	}
 	if p.TxSizeCostPerByte <= 0 {
 		return fmt.Errorf("invalid tx size cost per byte: %d", p.TxSizeCostPerByte)
 	}
@@ -114,6 +144,9 @@ func (p Params) Validate() error {
 	if p.TargetGasRatio < 0 || p.TargetGasRatio > 100 {
 		return fmt.Errorf("invalid target block gas ratio: %d, it should be between 0 and 100, 0 is unlimited", p.TargetGasRatio)
 	}
+	if p.FeeRefundRatio < 0 || p.FeeRefundRatio > 100 {
+		return fmt.Errorf("invalid fee refund ratio: %d, it should be between 0 and 100", p.FeeRefundRatio)
+	}
 	if p.FeeCollector.IsZero() {
 		return fmt.Errorf("invalid fee collector, cannot be empty")
 	}
@@ -148,7 +181,7 @@ func (ak AccountKeeper) SetParams(ctx sdk.Context, params Params) error {
 func (ak AccountKeeper) GetParams(ctx sdk.Context) Params {
 	params := Params{}
 	ak.prmk.GetStruct(ctx, "p", &params)
-	return params
+	return params.withDefaults()
 }
 
 func (ak AccountKeeper) WillSetParam(ctx sdk.Context, key string, value any) {
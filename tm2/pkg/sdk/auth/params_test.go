@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,6 +25,8 @@ func TestValidate(t *testing.T) {
 				TxSizeCostPerByte:         1,
 				SigVerifyCostED25519:      100,
 				SigVerifyCostSecp256k1:    200,
+				SigVerifyCostSecp256r1:    300,
+				SigVerifyCostWebAuthn:     400,
 				GasPricesChangeCompressor: 1,
 				TargetGasRatio:            50,
 				FeeCollector:              crypto.AddressFromPreimage([]byte("test_collector")),
@@ -43,6 +47,35 @@ func TestValidate(t *testing.T) {
 			},
 			expectsError: true,
 		},
+		{
+			name: "Invalid SigVerifyCostSecp256r1",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				GasPricesChangeCompressor: 1,
+				TargetGasRatio:            50,
+				FeeCollector:              crypto.AddressFromPreimage([]byte("test_collector")),
+			},
+			expectsError: true,
+		},
+		{
+			name: "Invalid SigVerifyCostWebAuthn",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				SigVerifyCostSecp256r1:    300,
+				GasPricesChangeCompressor: 1,
+				TargetGasRatio:            50,
+				FeeCollector:              crypto.AddressFromPreimage([]byte("test_collector")),
+			},
+			expectsError: true,
+		},
 		{
 			name: "Invalid GasPricesChangeCompressor",
 			params: Params{
@@ -57,6 +90,21 @@ func TestValidate(t *testing.T) {
 			},
 			expectsError: true,
 		},
+		{
+			name: "Invalid FeeRefundRatio",
+			params: Params{
+				MaxMemoBytes:              256,
+				TxSigLimit:                10,
+				TxSizeCostPerByte:         1,
+				SigVerifyCostED25519:      100,
+				SigVerifyCostSecp256k1:    200,
+				GasPricesChangeCompressor: 1,
+				TargetGasRatio:            50,
+				FeeCollector:              crypto.AddressFromPreimage([]byte("test_collector")),
+				FeeRefundRatio:            101,
+			},
+			expectsError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -101,6 +149,8 @@ func TestNewParams(t *testing.T) {
 		TxSizeCostPerByte:         txSizeCostPerByte,
 		SigVerifyCostED25519:      sigVerifyCostED25519,
 		SigVerifyCostSecp256k1:    sigVerifyCostSecp256k1,
+		SigVerifyCostSecp256r1:    DefaultSigVerifyCostSecp256r1,
+		SigVerifyCostWebAuthn:     DefaultSigVerifyCostWebAuthn,
 		GasPricesChangeCompressor: gasPricesChangeCompressor,
 		TargetGasRatio:            targetGasRatio,
 		FeeCollector:              feeCollector,
@@ -118,11 +168,11 @@ func TestParamsString(t *testing.T) {
 		params Params
 		want   string
 	}{
-		{"blank params", Params{}, "Params: \nMaxMemoBytes: 0\nTxSigLimit: 0\nTxSizeCostPerByte: 0\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\nFeeCollector: g1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqluuxe\n"},
+		{"blank params", Params{}, "Params: \nMaxMemoBytes: 0\nTxSigLimit: 0\nTxSizeCostPerByte: 0\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nSigVerifyCostSecp256r1: 0\nSigVerifyCostWebAuthn: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\nFeeCollector: g1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqluuxe\nFeeRefundRatio: 0\n"},
 		{"some values", Params{
 			MaxMemoBytes:      1_000_000,
 			TxSizeCostPerByte: 8192,
-		}, "Params: \nMaxMemoBytes: 1000000\nTxSigLimit: 0\nTxSizeCostPerByte: 8192\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\nFeeCollector: g1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqluuxe\n"},
+		}, "Params: \nMaxMemoBytes: 1000000\nTxSigLimit: 0\nTxSizeCostPerByte: 8192\nSigVerifyCostED25519: 0\nSigVerifyCostSecp256k1: 0\nSigVerifyCostSecp256r1: 0\nSigVerifyCostWebAuthn: 0\nGasPricesChangeCompressor: 0\nTargetGasRatio: 0\nFeeCollector: g1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqluuxe\nFeeRefundRatio: 0\n"},
 	}
 
 	for _, tt := range cases {
@@ -132,3 +182,29 @@ func TestParamsString(t *testing.T) {
 		})
 	}
 }
+
+func TestParamsSecp256r1Default(t *testing.T) {
+	// The genesis of a chain created before the secp256r1 keys were supported.
+	bz, err := amino.MarshalJSON(DefaultGenesisState())
+	require.NoError(t, err)
+	var raw map[string]map[string]any
+	require.NoError(t, json.Unmarshal(bz, &raw))
+	delete(raw["params"], "sig_verify_cost_secp256r1")
+	delete(raw["params"], "sig_verify_cost_webauthn")
+	bz, err = json.Marshal(raw)
+	require.NoError(t, err)
+
+	var genesis GenesisState
+	require.NoError(t, amino.UnmarshalJSON(bz, &genesis))
+	require.Zero(t, genesis.Params.SigVerifyCostSecp256r1)
+	require.NoError(t, ValidateGenesis(genesis))
+
+	env := setupTestEnv()
+	env.acck.InitGenesis(env.ctx, genesis)
+	assert.Equal(t, DefaultSigVerifyCostSecp256r1, env.acck.GetParams(env.ctx).SigVerifyCostSecp256r1)
+	assert.Equal(t, DefaultSigVerifyCostWebAuthn, env.acck.GetParams(env.ctx).SigVerifyCostWebAuthn)
+
+	// The params stored by such a chain.
+	env.acck.prmk.SetStruct(env.ctx, "p", genesis.Params)
+	assert.Equal(t, DefaultParams(), env.acck.GetParams(env.ctx))
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"math/big"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// FeeRefundEvent is emitted when the fees paid for the unused gas of a
+// transaction are refunded.
+type FeeRefundEvent struct {
+	Payer  crypto.Address `json:"payer" yaml:"payer"`
+	Refund std.Coins      `json:"refund" yaml:"refund"`
+}
+
+func (FeeRefundEvent) AssertABCIEvent() {}
+
+// NewFeeRefundHandler returns a PostHandler refunding the FeeRefundRatio
+// percent of the fees paid for the unused gas of a transaction to its fee
+// payer: the fee granter if set, or the first signer.
+//
+// NOTE: refunds to a fee granter don't restore the allowance it granted.
+func NewFeeRefundHandler(ak AccountKeeper, bank BankKeeperI) sdk.PostHandler {
+	return func(ctx sdk.Context, tx std.Tx, gasUsed int64) sdk.Result {
+		refund := FeeRefund(tx.Fee, gasUsed, ak.GetParams(ctx).FeeRefundRatio)
+		if refund.IsZero() {
+			return sdk.Result{}
+		}
+
+		payer := tx.GetSigners()[0]
+		if granter, ok := tx.Fee.Granter(); ok {
+			payer = granter
+		}
+
+		// Sending coins is unrestricted to refund gas fees
+		err := bank.SendCoinsUnrestricted(ctx, ak.FeeCollectorAddress(ctx), payer, refund)
+		if err != nil {
+			return abciResult(err)
+		}
+
+		return sdk.Result{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{FeeRefundEvent{Payer: payer, Refund: refund}},
+			},
+		}
+	}
+}
+
+// FeeRefund returns the ratio percent of the fees paid for the gas wanted but
+// not used by a transaction.
+func FeeRefund(fee std.Fee, gasUsed int64, ratio int64) std.Coins {
+	unused := fee.GasWanted - gasUsed
+	if unused <= 0 || ratio <= 0 || fee.GasFee.Amount <= 0 {
+		return nil
+	}
+
+	// refund = amount * unused / wanted * ratio / 100, rounded down.
+	amt := new(big.Int).Mul(big.NewInt(fee.GasFee.Amount), big.NewInt(unused))
+	amt.Mul(amt, big.NewInt(ratio))
+	amt.Quo(amt, new(big.Int).Mul(big.NewInt(fee.GasWanted), big.NewInt(100)))
+	if amt.Sign() <= 0 {
+		return nil
+	}
+	return std.Coins{std.NewCoin(fee.GasFee.Denom, amt.Int64())}
+}
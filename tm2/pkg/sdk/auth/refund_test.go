@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestFeeRefund(t *testing.T) {
+	t.Parallel()
+
+	fee := std.NewFee(1000, std.NewCoin("ugnot", 500))
+
+	testTable := []struct {
+		name     string
+		fee      std.Fee
+		gasUsed  int64
+		ratio    int64
+		expected string
+	}{
+		{"full refund of unused gas", fee, 400, 100, "300ugnot"},
+		{"partial refund of unused gas", fee, 400, 50, "150ugnot"},
+		{"rounded down", fee, 999, 50, ""},
+		{"refunds disabled", fee, 400, 0, ""},
+		{"all gas used", fee, 1000, 100, ""},
+		{"out of gas", fee, 1200, 100, ""},
+		{"no fee", std.NewFee(1000, std.NewCoin("ugnot", 0)), 0, 100, ""},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			refund := FeeRefund(testCase.fee, testCase.gasUsed, testCase.ratio)
+			assert.Equal(t, testCase.expected, refund.String())
+		})
+	}
+}
+
+func TestFeeRefundHandler(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+	postHandler := NewFeeRefundHandler(env.acck, env.bankk)
+
+	_, _, addr1 := tu.KeyTestPubAddr()
+	_, _, granter := tu.KeyTestPubAddr()
+	collector := env.acck.FeeCollectorAddress(ctx)
+
+	acc := env.acck.NewAccountWithAddress(ctx, collector)
+	acc.SetCoins(std.NewCoins(std.NewCoin("atom", 1000)))
+	env.acck.SetAccount(ctx, acc)
+
+	tx := std.NewTx([]std.Msg{tu.NewTestMsg(addr1)}, std.NewFee(1000, std.NewCoin("atom", 100)), nil, "")
+
+	// refunds are disabled by default
+	res := postHandler(ctx, tx, 200)
+	require.True(t, res.IsOK())
+	assert.Empty(t, res.Events)
+	assert.Nil(t, env.acck.GetAccount(ctx, addr1))
+
+	params := DefaultParams()
+	params.FeeRefundRatio = 50
+	require.NoError(t, env.acck.SetParams(ctx, params))
+
+	// the first signer is refunded
+	res = postHandler(ctx, tx, 200)
+	require.True(t, res.IsOK())
+	assert.Equal(t, []abci.Event{FeeRefundEvent{Payer: addr1, Refund: std.NewCoins(std.NewCoin("atom", 40))}}, res.Events)
+	assert.Equal(t, "40atom", env.acck.GetAccount(ctx, addr1).GetCoins().String())
+	assert.Equal(t, "960atom", env.acck.GetAccount(ctx, collector).GetCoins().String())
+
+	// the fee granter is refunded
+	tx.Fee.FeeGranter = granter.Bech32()
+	res = postHandler(ctx, tx, 600)
+	require.True(t, res.IsOK())
+	assert.Equal(t, []abci.Event{FeeRefundEvent{Payer: granter, Refund: std.NewCoins(std.NewCoin("atom", 20))}}, res.Events)
+	assert.Equal(t, "20atom", env.acck.GetAccount(ctx, granter).GetCoins().String())
+}
@@ -0,0 +1,87 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Authorization allows a grantee to execute messages of a given type on
+// behalf of a granter. Applications can define their own authorizations, to
+// restrict further what the grantee can do.
+type Authorization interface {
+	// MsgType returns the type of the authorized messages, in the
+	// "<route>/<type>" form (ie. "bank/send" or "vm/exec").
+	MsgType() string
+
+	// ValidateBasic does a simple validation check that doesn't require
+	// access to any other information.
+	ValidateBasic() error
+
+	// Accept checks that msg, of type MsgType, is authorized. It returns the
+	// authorization left after executing msg, and whether it is exhausted and
+	// must be removed.
+	Accept(ctx sdk.Context, msg std.Msg) (left Authorization, remove bool, err error)
+}
+
+var (
+	_ Authorization = GenericAuthorization{}
+	_ Authorization = SendAuthorization{}
+)
+
+// GenericAuthorization authorizes any message of type Msg, without further
+// restrictions.
+type GenericAuthorization struct {
+	Msg string `json:"msg" yaml:"msg"`
+}
+
+// MsgType implements Authorization.
+func (ga GenericAuthorization) MsgType() string { return ga.Msg }
+
+// ValidateBasic implements Authorization.
+func (ga GenericAuthorization) ValidateBasic() error {
+	if ga.Msg == "" {
+		return std.ErrUnknownRequest("authorization message type must not be empty")
+	}
+	return nil
+}
+
+// Accept implements Authorization.
+func (ga GenericAuthorization) Accept(ctx sdk.Context, msg std.Msg) (Authorization, bool, error) {
+	return ga, false, nil
+}
+
+// SendAuthorization authorizes bank sends, up to a total of SpendLimit.
+type SendAuthorization struct {
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// MsgType implements Authorization.
+func (sa SendAuthorization) MsgType() string {
+	return auth.MsgTypeURL(bank.MsgSend{})
+}
+
+// ValidateBasic implements Authorization.
+func (sa SendAuthorization) ValidateBasic() error {
+	if !sa.SpendLimit.IsValid() || !sa.SpendLimit.IsAllPositive() {
+		return std.ErrInvalidCoins("invalid spend limit " + sa.SpendLimit.String())
+	}
+	return nil
+}
+
+// Accept implements Authorization.
+func (sa SendAuthorization) Accept(ctx sdk.Context, msg std.Msg) (Authorization, bool, error) {
+	send, ok := msg.(bank.MsgSend)
+	if !ok {
+		return sa, false, std.ErrUnauthorized(fmt.Sprintf("send authorization does not allow %T", msg))
+	}
+	if !sa.SpendLimit.IsAllGTE(send.Amount) {
+		return sa, false, std.ErrInsufficientFunds(
+			fmt.Sprintf("send authorization spend limit exceeded; %s < %s", sa.SpendLimit, send.Amount))
+	}
+	left := SendAuthorization{SpendLimit: sa.SpendLimit.Sub(send.Amount)}
+	return left, len(left.SpendLimit) == 0, nil
+}
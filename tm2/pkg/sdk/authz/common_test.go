@@ -0,0 +1,51 @@
+package authz
+
+// DONTCOVER
+
+import (
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+type testEnv struct {
+	ctx   sdk.Context
+	azk   AuthzKeeper
+	bankk bank.BankKeeper
+	acck  auth.AccountKeeper
+	h     authzHandler
+}
+
+func setupTestEnv() testEnv {
+	db := memdb.NewMemDB()
+
+	authCapKey := store.NewStoreKey("authCapKey")
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{ChainID: "test-chain-id"}, log.NewNoopLogger())
+
+	prmk := params.NewParamsKeeper(authCapKey)
+	acck := auth.NewAccountKeeper(authCapKey, prmk.ForModule(auth.ModuleName), std.ProtoBaseAccount)
+	bankk := bank.NewBankKeeper(authCapKey, acck, prmk.ForModule(bank.ModuleName))
+	azk := NewAuthzKeeper(authCapKey)
+
+	prmk.Register(auth.ModuleName, acck)
+	prmk.Register(bank.ModuleName, bankk)
+
+	router := sdk.NewRouter()
+	router.AddRoute(bank.ModuleName, bank.NewHandler(bankk))
+	h := NewHandler(azk, router)
+	router.AddRoute(ModuleName, h)
+
+	return testEnv{ctx: ctx, azk: azk, bankk: bankk, acck: acck, h: h}
+}
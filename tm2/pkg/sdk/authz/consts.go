@@ -0,0 +1,27 @@
+package authz
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// module name
+	ModuleName = "authz"
+
+	// GrantStoreKeyPrefix prefix for the grant-by-granter-grantee-and-msg-type store
+	GrantStoreKeyPrefix = "/authz/"
+)
+
+// GrantStoreKey turns a granter, a grantee and a message type to the key used
+// to get their grant from the store
+func GrantStoreKey(granter, grantee crypto.Address, msgType string) []byte {
+	key := GrantsByGranterKey(granter)
+	key = append(key, grantee.Bytes()...)
+	return append(key, msgType...)
+}
+
+// GrantsByGranterKey returns the prefix of the keys of all the grants of a
+// granter
+func GrantsByGranterKey(granter crypto.Address) []byte {
+	return append([]byte(GrantStoreKeyPrefix), granter.Bytes()...)
+}
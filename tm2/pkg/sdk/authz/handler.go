@@ -0,0 +1,182 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type authzHandler struct {
+	authz  AuthzKeeper
+	router sdk.Router
+}
+
+// NewHandler returns a handler for "authz" type messages. The messages
+// executed by MsgExec are dispatched through router.
+func NewHandler(authz AuthzKeeper, router sdk.Router) authzHandler {
+	return authzHandler{
+		authz:  authz,
+		router: router,
+	}
+}
+
+func (ah authzHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgGrant:
+		return ah.handleMsgGrant(ctx, msg)
+	case MsgRevoke:
+		return ah.handleMsgRevoke(ctx, msg)
+	case MsgExec:
+		return ah.handleMsgExec(ctx, msg)
+	default:
+		errMsg := fmt.Sprintf("unrecognized authz message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgGrant.
+func (ah authzHandler) handleMsgGrant(ctx sdk.Context, msg MsgGrant) sdk.Result {
+	ah.authz.SetGrant(ctx, Grant{
+		Granter:       msg.Granter,
+		Grantee:       msg.Grantee,
+		Authorization: msg.Authorization,
+		Expiration:    msg.Expiration,
+	})
+	return sdk.Result{}
+}
+
+// Handle MsgRevoke.
+func (ah authzHandler) handleMsgRevoke(ctx sdk.Context, msg MsgRevoke) sdk.Result {
+	if ah.authz.GetGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType) == nil {
+		return abciResult(std.ErrUnknownRequest(
+			fmt.Sprintf("%s has no authorization for %q from %s", msg.Grantee, msg.MsgType, msg.Granter)))
+	}
+	ah.authz.RemoveGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType)
+	return sdk.Result{}
+}
+
+// Handle MsgExec.
+func (ah authzHandler) handleMsgExec(ctx sdk.Context, msg MsgExec) (res sdk.Result) {
+	infos := make([]string, 0, len(msg.Msgs))
+	for _, inner := range msg.Msgs {
+		if err := ah.authz.Authorize(ctx, msg.Grantee, inner); err != nil {
+			return abciResult(err)
+		}
+
+		handler := ah.router.Route(inner.Route())
+		if handler == nil {
+			return abciResult(std.ErrUnknownRequest("unrecognized message type: " + inner.Route()))
+		}
+		innerRes := handler.Process(ctx, inner)
+		if !innerRes.IsOK() {
+			return innerRes
+		}
+
+		res.Data = append(res.Data, innerRes.Data...)
+		res.Events = append(res.Events, innerRes.Events...)
+		infos = append(infos, innerRes.Info)
+	}
+	res.Info = strings.Join(infos, "\n")
+	return res
+}
+
+//----------------------------------------
+// Query
+
+// query paths
+const (
+	QueryGrants = "grants"
+)
+
+func (ah authzHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	switch secondPart(req.Path) {
+	case QueryGrants:
+		return ah.queryGrants(ctx, req)
+	default:
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("unknown authz query endpoint"))
+		return
+	}
+}
+
+// queryGrants fetches the grants given by an address, optionally restricted
+// to those given to the grantee passed as next path component.
+func (ah authzHandler) queryGrants(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	b32granter := thirdPart(req.Path)
+	granter, err := crypto.AddressFromBech32(b32granter)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32granter))
+		return
+	}
+
+	var grantee crypto.Address
+	if b32grantee := fourthPart(req.Path); b32grantee != "" {
+		grantee, err = crypto.AddressFromBech32(b32grantee)
+		if err != nil {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrInvalidAddress("invalid query address " + b32grantee))
+			return
+		}
+	}
+
+	grants := []Grant{}
+	ah.authz.IterateGrants(ctx, granter, func(grant Grant) bool {
+		if grantee.IsZero() || grant.Grantee == grantee {
+			grants = append(grants, grant)
+		}
+		return false
+	})
+
+	bz, err := amino.MarshalJSONIndent(grants, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
+
+// returns the third component of a path.
+func thirdPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	} else {
+		return parts[2]
+	}
+}
+
+// returns the fourth component of a path.
+func fourthPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return ""
+	} else {
+		return parts[3]
+	}
+}
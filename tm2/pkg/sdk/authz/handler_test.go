@@ -0,0 +1,132 @@
+package authz
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	granter = crypto.AddressFromPreimage([]byte("granter"))
+	grantee = crypto.AddressFromPreimage([]byte("grantee"))
+	other   = crypto.AddressFromPreimage([]byte("other"))
+)
+
+func TestMsgExecValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	send := bank.NewMsgSend(granter, other, std.NewCoins(std.NewCoin("foo", 10)))
+
+	assert.NoError(t, NewMsgExec(grantee, []std.Msg{send}).ValidateBasic())
+	assert.Error(t, NewMsgExec(crypto.Address{}, []std.Msg{send}).ValidateBasic())
+	assert.Error(t, NewMsgExec(grantee, nil).ValidateBasic())
+	assert.Error(t, NewMsgExec(grantee, []std.Msg{NewMsgExec(grantee, []std.Msg{send})}).ValidateBasic())
+	assert.Error(t, NewMsgExec(grantee, []std.Msg{bank.NewMsgSend(granter, other, nil)}).ValidateBasic())
+}
+
+func TestExecSend(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+	require.NoError(t, env.bankk.SetCoins(ctx, granter, std.NewCoins(std.NewCoin("foo", 100))))
+
+	send := bank.NewMsgSend(granter, other, std.NewCoins(std.NewCoin("foo", 30)))
+	exec := NewMsgExec(grantee, []std.Msg{send})
+
+	// no authorization
+	res := env.h.Process(ctx, exec)
+	require.False(t, res.IsOK())
+	assert.Contains(t, res.Log, "is not authorized to execute \"bank/send\"")
+	assert.Error(t, env.azk.CheckExecs(ctx, std.Tx{Msgs: []std.Msg{exec}}))
+
+	// authorization up to 50foo
+	grant := NewMsgGrant(granter, grantee, SendAuthorization{SpendLimit: std.NewCoins(std.NewCoin("foo", 50))}, 0)
+	require.NoError(t, grant.ValidateBasic())
+	res = env.h.Process(ctx, grant)
+	require.True(t, res.IsOK(), res.Log)
+	require.NoError(t, env.azk.CheckExecs(ctx, std.Tx{Msgs: []std.Msg{exec}}))
+
+	res = env.h.Process(ctx, exec)
+	require.True(t, res.IsOK(), res.Log)
+	assert.Equal(t, "70foo", env.bankk.GetCoins(ctx, granter).String())
+	assert.Equal(t, "30foo", env.bankk.GetCoins(ctx, other).String())
+	left := env.azk.GetGrant(ctx, granter, grantee, "bank/send").Authorization
+	assert.Equal(t, SendAuthorization{SpendLimit: std.NewCoins(std.NewCoin("foo", 20))}, left)
+
+	// spend limit exceeded
+	res = env.h.Process(ctx, exec)
+	require.False(t, res.IsOK())
+	assert.Contains(t, res.Log, "spend limit exceeded")
+
+	// exhausted authorizations are removed
+	res = env.h.Process(ctx, NewMsgExec(grantee, []std.Msg{
+		bank.NewMsgSend(granter, other, std.NewCoins(std.NewCoin("foo", 20))),
+	}))
+	require.True(t, res.IsOK(), res.Log)
+	assert.Nil(t, env.azk.GetGrant(ctx, granter, grantee, "bank/send"))
+}
+
+func TestExecExpiration(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+	require.NoError(t, env.bankk.SetCoins(ctx, granter, std.NewCoins(std.NewCoin("foo", 100))))
+
+	res := env.h.Process(ctx, NewMsgGrant(granter, grantee, GenericAuthorization{Msg: "bank/send"}, 1000))
+	require.True(t, res.IsOK(), res.Log)
+
+	exec := NewMsgExec(grantee, []std.Msg{bank.NewMsgSend(granter, other, std.NewCoins(std.NewCoin("foo", 10)))})
+	res = env.h.Process(ctx.WithBlockHeader(&bft.Header{Time: time.Unix(1000, 0)}), exec)
+	require.True(t, res.IsOK(), res.Log)
+	res = env.h.Process(ctx.WithBlockHeader(&bft.Header{Time: time.Unix(1001, 0)}), exec)
+	require.False(t, res.IsOK())
+	assert.Contains(t, res.Log, "expired")
+}
+
+func TestRevokeAndQueryGrants(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	for _, msg := range []MsgGrant{
+		NewMsgGrant(granter, grantee, GenericAuthorization{Msg: "vm/exec"}, 0),
+		NewMsgGrant(granter, other, SendAuthorization{SpendLimit: std.NewCoins(std.NewCoin("foo", 1))}, 0),
+	} {
+		res := env.h.Process(ctx, msg)
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	query := func(path string) []Grant {
+		t.Helper()
+
+		res := env.h.Query(ctx, abci.RequestQuery{Path: path})
+		require.Nil(t, res.Error)
+		var grants []Grant
+		require.NoError(t, amino.UnmarshalJSON(res.Data, &grants))
+		return grants
+	}
+	assert.Len(t, query(fmt.Sprintf("authz/%s/%s", QueryGrants, granter)), 2)
+	grants := query(fmt.Sprintf("authz/%s/%s/%s", QueryGrants, granter, grantee))
+	require.Len(t, grants, 1)
+	assert.Equal(t, GenericAuthorization{Msg: "vm/exec"}, grants[0].Authorization)
+
+	res := env.h.Process(ctx, NewMsgRevoke(granter, grantee, "vm/exec"))
+	require.True(t, res.IsOK(), res.Log)
+	assert.Empty(t, query(fmt.Sprintf("authz/%s/%s/%s", QueryGrants, granter, grantee)))
+
+	res = env.h.Process(ctx, NewMsgRevoke(granter, grantee, "vm/exec"))
+	require.False(t, res.IsOK())
+}
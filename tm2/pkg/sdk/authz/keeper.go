@@ -0,0 +1,150 @@
+package authz
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// Grant is an authorization given by a granter to a grantee.
+type Grant struct {
+	Granter       crypto.Address `json:"granter" yaml:"granter"`
+	Grantee       crypto.Address `json:"grantee" yaml:"grantee"`
+	Authorization Authorization  `json:"authorization" yaml:"authorization"`
+	// Expiration is the unix timestamp, in seconds, after which the grant
+	// can no longer be used. Zero means no expiration.
+	Expiration int64 `json:"expiration" yaml:"expiration"`
+}
+
+// AuthzKeeperI manages the authorizations given by accounts to execute
+// messages on their behalf.
+type AuthzKeeperI interface {
+	GetGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType string) *Grant
+	SetGrant(ctx sdk.Context, grant Grant)
+	RemoveGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType string)
+	IterateGrants(ctx sdk.Context, granter crypto.Address, process func(Grant) (stop bool))
+	Authorize(ctx sdk.Context, grantee crypto.Address, msg std.Msg) error
+	CheckExecs(ctx sdk.Context, tx std.Tx) error
+}
+
+var _ AuthzKeeperI = AuthzKeeper{}
+
+// AuthzKeeper is the concrete implementation of AuthzKeeperI.
+type AuthzKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key store.StoreKey
+}
+
+// NewAuthzKeeper returns a new AuthzKeeper.
+func NewAuthzKeeper(key store.StoreKey) AuthzKeeper {
+	return AuthzKeeper{
+		key: key,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (ak AuthzKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// GetGrant returns the grant given by granter to grantee for msgType, or nil.
+func (ak AuthzKeeper) GetGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType string) *Grant {
+	stor := ctx.GasStore(ak.key)
+	bz := stor.Get(GrantStoreKey(granter, grantee, msgType))
+	if bz == nil {
+		return nil
+	}
+	grant := new(Grant)
+	amino.MustUnmarshal(bz, grant)
+	return grant
+}
+
+// SetGrant stores a grant, replacing any previous grant given by the same
+// granter to the same grantee for the same message type.
+func (ak AuthzKeeper) SetGrant(ctx sdk.Context, grant Grant) {
+	stor := ctx.GasStore(ak.key)
+	key := GrantStoreKey(grant.Granter, grant.Grantee, grant.Authorization.MsgType())
+	stor.Set(key, amino.MustMarshal(grant))
+}
+
+// RemoveGrant removes the grant given by granter to grantee for msgType.
+func (ak AuthzKeeper) RemoveGrant(ctx sdk.Context, granter, grantee crypto.Address, msgType string) {
+	stor := ctx.GasStore(ak.key)
+	stor.Delete(GrantStoreKey(granter, grantee, msgType))
+}
+
+// IterateGrants iterates over the grants given by granter, ordered by grantee
+// and message type.
+func (ak AuthzKeeper) IterateGrants(ctx sdk.Context, granter crypto.Address, process func(Grant) (stop bool)) {
+	stor := ctx.GasStore(ak.key)
+	iter := store.PrefixIterator(stor, GrantsByGranterKey(granter))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var grant Grant
+		amino.MustUnmarshal(iter.Value(), &grant)
+		if process(grant) {
+			return
+		}
+	}
+}
+
+// Authorize checks that grantee is authorized to execute msg on behalf of its
+// signer, and consumes the authorization.
+func (ak AuthzKeeper) Authorize(ctx sdk.Context, grantee crypto.Address, msg std.Msg) error {
+	return ak.authorize(ctx, grantee, msg, true)
+}
+
+// CheckExecs checks, without consuming them, that the messages executed by
+// the MsgExecs of tx are authorized. It is meant to be called by the ante
+// handler, so that unauthorized transactions do not enter the mempool.
+func (ak AuthzKeeper) CheckExecs(ctx sdk.Context, tx std.Tx) error {
+	for _, msg := range tx.GetMsgs() {
+		exec, ok := msg.(MsgExec)
+		if !ok {
+			continue
+		}
+		for _, inner := range exec.Msgs {
+			if err := ak.authorize(ctx, exec.Grantee, inner, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ak AuthzKeeper) authorize(ctx sdk.Context, grantee crypto.Address, msg std.Msg, consume bool) error {
+	// MsgExec.ValidateBasic ensures inner messages have a single signer.
+	granter := msg.GetSigners()[0]
+	if granter == grantee {
+		return nil // no authorization needed to act on one's own behalf.
+	}
+
+	msgType := auth.MsgTypeURL(msg)
+	grant := ak.GetGrant(ctx, granter, grantee, msgType)
+	if grant == nil {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("%s is not authorized to execute %q on behalf of %s", grantee, msgType, granter))
+	}
+	if grant.Expiration != 0 && ctx.BlockTime().Unix() > grant.Expiration {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("authorization of %s to execute %q on behalf of %s expired", grantee, msgType, granter))
+	}
+
+	left, remove, err := grant.Authorization.Accept(ctx, msg)
+	if err != nil || !consume {
+		return err
+	}
+	if remove {
+		ak.RemoveGrant(ctx, granter, grantee, msgType)
+	} else {
+		grant.Authorization = left
+		ak.SetGrant(ctx, *grant)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is the name of the authz module
+const RouterKey = ModuleName
+
+// MsgGrant - grants an authorization to a grantee, replacing any previous one
+// for the same message type
+type MsgGrant struct {
+	Granter       crypto.Address `json:"granter" yaml:"granter"`
+	Grantee       crypto.Address `json:"grantee" yaml:"grantee"`
+	Authorization Authorization  `json:"authorization" yaml:"authorization"`
+	Expiration    int64          `json:"expiration" yaml:"expiration"`
+}
+
+var _ std.Msg = MsgGrant{}
+
+// NewMsgGrant - construct an authorization grant msg.
+func NewMsgGrant(granter, grantee crypto.Address, authorization Authorization, expiration int64) MsgGrant {
+	return MsgGrant{Granter: granter, Grantee: grantee, Authorization: authorization, Expiration: expiration}
+}
+
+// Route Implements Msg.
+func (msg MsgGrant) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgGrant) Type() string { return "grant" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgGrant) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.Granter == msg.Grantee {
+		return std.ErrInvalidAddress("cannot grant an authorization to self")
+	}
+	if msg.Authorization == nil {
+		return std.ErrUnknownRequest("missing authorization")
+	}
+	if msg.Expiration < 0 {
+		return std.ErrUnknownRequest("authorization expiration must not be negative")
+	}
+	return msg.Authorization.ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgGrant) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgGrant) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+// MsgRevoke - revokes the authorization granted to a grantee for a message
+// type
+type MsgRevoke struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+	MsgType string         `json:"msg_type" yaml:"msg_type"`
+}
+
+var _ std.Msg = MsgRevoke{}
+
+// NewMsgRevoke - construct an authorization revocation msg.
+func NewMsgRevoke(granter, grantee crypto.Address, msgType string) MsgRevoke {
+	return MsgRevoke{Granter: granter, Grantee: grantee, MsgType: msgType}
+}
+
+// Route Implements Msg.
+func (msg MsgRevoke) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevoke) Type() string { return "revoke" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevoke) ValidateBasic() error {
+	if msg.Granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.MsgType == "" {
+		return std.ErrUnknownRequest("missing message type")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevoke) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevoke) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+// MsgExec - executes messages on behalf of their signers, who granted the
+// grantee an authorization to do so
+type MsgExec struct {
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+	Msgs    []std.Msg      `json:"msgs" yaml:"msgs"`
+}
+
+var _ std.ExecMsg = MsgExec{}
+
+// NewMsgExec - construct a delegated execution msg.
+func NewMsgExec(grantee crypto.Address, msgs []std.Msg) MsgExec {
+	return MsgExec{Grantee: grantee, Msgs: msgs}
+}
+
+// Route Implements Msg.
+func (msg MsgExec) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgExec) Type() string { return "exec" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgExec) ValidateBasic() error {
+	if msg.Grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if len(msg.Msgs) == 0 {
+		return std.ErrUnknownRequest("no messages to execute")
+	}
+	for i, inner := range msg.Msgs {
+		if _, ok := inner.(MsgExec); ok {
+			return std.ErrUnknownRequest("nested exec messages are not supported")
+		}
+		if n := len(inner.GetSigners()); n != 1 {
+			return std.ErrUnauthorized(
+				fmt.Sprintf("executed message %d must have a single signer, got %d", i, n))
+		}
+		if err := inner.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgExec) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgExec) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Grantee}
+}
+
+// GetMsgs Implements ExecMsg.
+func (msg MsgExec) GetMsgs() []std.Msg {
+	return msg.Msgs
+}
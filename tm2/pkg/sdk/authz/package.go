@@ -0,0 +1,18 @@
+package authz
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/sdk/authz",
+	"authz",
+	amino.GetCallersDirname(),
+).WithDependencies().WithTypes(
+	GenericAuthorization{}, "GenericAuthorization",
+	SendAuthorization{}, "SendAuthorization",
+	Grant{}, "Grant",
+	MsgGrant{}, "MsgGrant",
+	MsgRevoke{}, "MsgRevoke",
+	MsgExec{}, "MsgExec",
+))
@@ -2,4 +2,12 @@ package bank
 
 const (
 	ModuleName = "bank"
+
+	// SupplyStoreKeyPrefix prefix for the total supply per denom store
+	SupplyStoreKeyPrefix = "/s/"
 )
+
+// SupplyStoreKey turn a denom to key used to get its total supply from the store
+func SupplyStoreKey(denom string) []byte {
+	return append([]byte(SupplyStoreKeyPrefix), denom...)
+}
@@ -98,13 +98,21 @@ func (bh bankHandler) handleMsgMultiSend(ctx sdk.Context, msg MsgMultiSend) sdk.
 //----------------------------------------
 // Query
 
-// query balance path
-const QueryBalance = "balances"
+// query paths
+const (
+	QueryBalance = "balances"
+	QuerySupply  = "supply"
+	QueryVesting = "vesting"
+)
 
 func (bh bankHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	switch secondPart(req.Path) {
 	case QueryBalance:
 		return bh.queryBalance(ctx, req)
+	case QuerySupply:
+		return bh.querySupply(ctx, req)
+	case QueryVesting:
+		return bh.queryVesting(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown bank query endpoint"))
@@ -135,6 +143,69 @@ func (bh bankHandler) queryBalance(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// querySupply fetches the total supply of all denoms, or of the denom
+// passed as path component.
+func (bh bankHandler) querySupply(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	supply := bh.bank.TotalSupply(ctx)
+	if denom := thirdPart(req.Path); denom != "" {
+		supply = std.Coins{std.NewCoin(denom, bh.bank.TotalCoin(ctx, denom))}
+	}
+
+	bz, err := amino.MarshalJSONIndent(supply, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// VestingInfo is the vesting state of an account, as returned by the
+// bank/vesting query. Accounts without a vesting schedule have no original
+// vesting and all of their coins are spendable.
+type VestingInfo struct {
+	OriginalVesting std.Coins `json:"original_vesting"`
+	Vested          std.Coins `json:"vested"`
+	Unvested        std.Coins `json:"unvested"`
+	Spendable       std.Coins `json:"spendable"`
+}
+
+// queryVesting fetches the vested and unvested coins of an account at the
+// current block. Account address is passed as path component.
+func (bh bankHandler) queryVesting(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	b32addr := thirdPart(req.Path)
+	addr, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	info := VestingInfo{
+		OriginalVesting: std.NewCoins(),
+		Vested:          std.NewCoins(),
+		Unvested:        std.NewCoins(),
+		Spendable:       bh.bank.SpendableCoins(ctx, addr),
+	}
+	if vacc, ok := bh.bank.acck.GetAccount(ctx, addr).(std.VestingAccount); ok {
+		info.OriginalVesting = vacc.GetOriginalVesting()
+		info.Unvested = vacc.GetVestingCoins(ctx.BlockHeight(), ctx.BlockTime())
+		info.Vested = info.OriginalVesting.Sub(info.Unvested)
+	}
+
+	bz, err := amino.MarshalJSONIndent(info, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
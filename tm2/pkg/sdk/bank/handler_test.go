@@ -54,6 +54,27 @@ func TestBalances(t *testing.T) {
 	require.True(t, coins.AmountOf("foo") == 10)
 }
 
+func TestSupply(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.bankk)
+	_, _, addr := tu.KeyTestPubAddr()
+
+	require.NoError(t, env.bankk.SetCoins(env.ctx, addr, std.NewCoins(std.NewCoin("bar", 5), std.NewCoin("foo", 10))))
+
+	var coins std.Coins
+	res := h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QuerySupply})
+	require.Nil(t, res.Error)
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+	require.Equal(t, "5bar,10foo", coins.String())
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QuerySupply + "/foo"})
+	require.Nil(t, res.Error)
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+	require.Equal(t, "10foo", coins.String())
+}
+
 func TestQuerierRouteNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 // bank.Keeper defines a module interface that facilitates the transfer of
@@ -24,6 +26,9 @@ type BankKeeperI interface {
 	SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error
 	SendCoinsUnrestricted(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
 
+	TotalCoin(ctx sdk.Context, denom string) int64
+	TotalSupply(ctx sdk.Context) std.Coins
+
 	InitGenesis(ctx sdk.Context, data GenesisState)
 	GetParams(ctx sdk.Context) Params
 }
@@ -35,15 +40,18 @@ var _ BankKeeperI = &BankKeeper{}
 type BankKeeper struct {
 	ViewKeeper
 
+	// The (unexposed) key used to access the total supply store.
+	key  store.StoreKey
 	acck auth.AccountKeeper
 	// The keeper used to store parameters
 	prmk params.ParamsKeeperI
 }
 
 // NewBankKeeper returns a new BankKeeper.
-func NewBankKeeper(acck auth.AccountKeeper, pk params.ParamsKeeperI) BankKeeper {
+func NewBankKeeper(key store.StoreKey, acck auth.AccountKeeper, pk params.ParamsKeeperI) BankKeeper {
 	return BankKeeper{
 		ViewKeeper: NewViewKeeper(acck),
+		key:        key,
 		acck:       acck,
 		prmk:       pk,
 	}
@@ -90,7 +98,7 @@ func (bank BankKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs
 		if !bank.canSendCoins(ctx, in.Address, in.Coins) {
 			return std.RestrictedTransferError{}
 		}
-		_, err := bank.SubtractCoins(ctx, in.Address, in.Coins)
+		_, err := bank.subtractCoins(ctx, in.Address, in.Coins)
 		if err != nil {
 			return err
 		}
@@ -106,7 +114,7 @@ func (bank BankKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs
 	}
 
 	for _, out := range outputs {
-		_, err := bank.AddCoins(ctx, out.Address, out.Coins)
+		_, err := bank.addCoins(ctx, out.Address, out.Coins)
 		if err != nil {
 			return err
 		}
@@ -164,12 +172,12 @@ func (bank BankKeeper) sendCoins(
 	toAddr crypto.Address,
 	amt std.Coins,
 ) error {
-	_, err := bank.SubtractCoins(ctx, fromAddr, amt)
+	_, err := bank.subtractCoins(ctx, fromAddr, amt)
 	if err != nil {
 		return err
 	}
 
-	_, err = bank.AddCoins(ctx, toAddr, amt)
+	_, err = bank.addCoins(ctx, toAddr, amt)
 	if err != nil {
 		return err
 	}
@@ -191,10 +199,20 @@ func (bank BankKeeper) sendCoins(
 	return nil
 }
 
-// SubtractCoins subtracts amt from the coins at the addr.
+// SubtractCoins subtracts amt from the coins at the addr, and removes them from
+// the total supply.
 //
-// CONTRACT: If the account is a vesting account, the amount has to be spendable.
+// If the account is a vesting account, the amount has to be spendable.
 func (bank BankKeeper) SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error) {
+	newCoins, err := bank.subtractCoins(ctx, addr, amt)
+	if err != nil {
+		return nil, err
+	}
+	bank.updateSupply(ctx, amt, nil)
+	return newCoins, nil
+}
+
+func (bank BankKeeper) subtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error) {
 	if !amt.IsValid() {
 		return nil, std.ErrInvalidCoins(amt.String())
 	}
@@ -212,13 +230,31 @@ func (bank BankKeeper) SubtractCoins(ctx sdk.Context, addr crypto.Address, amt s
 		)
 		return nil, err
 	}
-	err := bank.SetCoins(ctx, addr, newCoins)
+	if vacc, ok := acc.(std.VestingAccount); ok {
+		locked := vacc.GetVestingCoins(ctx.BlockHeight(), ctx.BlockTime())
+		if !newCoins.IsAllGTE(locked) {
+			err := std.ErrInsufficientCoins(
+				fmt.Sprintf("insufficient unlocked funds; %s < %s", bank.SpendableCoins(ctx, addr), amt),
+			)
+			return nil, err
+		}
+	}
+	err := bank.setCoins(ctx, addr, newCoins)
 
 	return newCoins, err
 }
 
-// AddCoins adds amt to the coins at the addr.
+// AddCoins adds amt to the coins at the addr, and to the total supply.
 func (bank BankKeeper) AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error) {
+	newCoins, err := bank.addCoins(ctx, addr, amt)
+	if err != nil {
+		return newCoins, err
+	}
+	bank.updateSupply(ctx, nil, amt)
+	return newCoins, nil
+}
+
+func (bank BankKeeper) addCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error) {
 	if !amt.IsValid() {
 		return nil, std.ErrInvalidCoins(amt.String())
 	}
@@ -232,12 +268,22 @@ func (bank BankKeeper) AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 		)
 	}
 
-	err := bank.SetCoins(ctx, addr, newCoins)
+	err := bank.setCoins(ctx, addr, newCoins)
 	return newCoins, err
 }
 
 // SetCoins sets the coins at the addr.
+// The total supply is updated by the difference with the previous coins.
 func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error {
+	oldCoins := bank.GetCoins(ctx, addr)
+	if err := bank.setCoins(ctx, addr, amt); err != nil {
+		return err
+	}
+	bank.updateSupply(ctx, oldCoins, amt)
+	return nil
+}
+
+func (bank BankKeeper) setCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error {
 	if !amt.IsValid() {
 		return std.ErrInvalidCoins(amt.String())
 	}
@@ -256,6 +302,57 @@ func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 	return nil
 }
 
+// updateSupply adds newCoins and removes oldCoins from the total supply.
+func (bank BankKeeper) updateSupply(ctx sdk.Context, oldCoins, newCoins std.Coins) {
+	for _, coin := range oldCoins {
+		if delta := newCoins.AmountOf(coin.Denom) - coin.Amount; delta != 0 {
+			bank.setTotalCoin(ctx, coin.Denom, bank.TotalCoin(ctx, coin.Denom)+delta)
+		}
+	}
+	for _, coin := range newCoins {
+		if oldCoins.AmountOf(coin.Denom) == 0 {
+			bank.setTotalCoin(ctx, coin.Denom, bank.TotalCoin(ctx, coin.Denom)+coin.Amount)
+		}
+	}
+}
+
+// TotalCoin returns the total supply of denom.
+func (bank BankKeeper) TotalCoin(ctx sdk.Context, denom string) int64 {
+	stor := ctx.GasStore(bank.key)
+	bz := stor.Get(SupplyStoreKey(denom))
+	if bz == nil {
+		return 0
+	}
+	var amount int64
+	amino.MustUnmarshal(bz, &amount)
+	return amount
+}
+
+// TotalSupply returns the total supply of all denoms.
+func (bank BankKeeper) TotalSupply(ctx sdk.Context) std.Coins {
+	stor := ctx.GasStore(bank.key)
+	iter := store.PrefixIterator(stor, []byte(SupplyStoreKeyPrefix))
+	defer iter.Close()
+
+	supply := std.NewCoins()
+	for ; iter.Valid(); iter.Next() {
+		var amount int64
+		amino.MustUnmarshal(iter.Value(), &amount)
+		denom := string(iter.Key()[len(SupplyStoreKeyPrefix):])
+		supply = append(supply, std.NewCoin(denom, amount))
+	}
+	return supply
+}
+
+func (bank BankKeeper) setTotalCoin(ctx sdk.Context, denom string, amount int64) {
+	stor := ctx.GasStore(bank.key)
+	if amount == 0 {
+		stor.Delete(SupplyStoreKey(denom))
+		return
+	}
+	stor.Set(SupplyStoreKey(denom), amino.MustMarshal(amount))
+}
+
 // ----------------------------------------
 // ViewKeeper
 
@@ -263,6 +360,7 @@ func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 // account balances.
 type ViewKeeperI interface {
 	GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
+	SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins
 	HasCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) bool
 }
 
@@ -292,6 +390,33 @@ func (view ViewKeeper) GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
 	return acc.GetCoins()
 }
 
+// SpendableCoins returns the coins at the addr which are not locked by a
+// vesting schedule.
+func (view ViewKeeper) SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins {
+	acc := view.acck.GetAccount(ctx, addr)
+	if acc == nil {
+		return std.NewCoins()
+	}
+	coins := acc.GetCoins()
+	vacc, ok := acc.(std.VestingAccount)
+	if !ok {
+		return coins
+	}
+	return spendableCoins(coins, vacc.GetVestingCoins(ctx.BlockHeight(), ctx.BlockTime()))
+}
+
+// spendableCoins returns coins minus locked, ignoring the denoms for which
+// less than the locked amount is held.
+func spendableCoins(coins, locked std.Coins) std.Coins {
+	spendable := std.NewCoins()
+	for _, coin := range coins {
+		if amt := coin.Amount - locked.AmountOf(coin.Denom); amt > 0 {
+			spendable = append(spendable, std.NewCoin(coin.Denom, amt))
+		}
+	}
+	return spendable
+}
+
 // HasCoins returns whether or not an account has at least amt coins.
 func (view ViewKeeper) HasCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) bool {
 	return view.GetCoins(ctx, addr).IsAllGTE(amt)
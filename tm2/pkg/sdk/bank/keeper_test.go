@@ -184,3 +184,53 @@ func TestSetRestrictedDenoms(t *testing.T) {
 	params = bankk.GetParams(ctx)
 	require.Empty(t, params.RestrictedDenoms)
 }
+
+func TestTotalSupply(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+	bankk := env.bankk
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	invariant := TotalSupplyInvariant(env.acck, bankk)
+
+	require.True(t, bankk.TotalSupply(ctx).IsZero())
+
+	// Setting and adding coins issues them.
+	require.NoError(t, bankk.SetCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 10))))
+	_, err := bankk.AddCoins(ctx, addr2, std.NewCoins(std.NewCoin("barcoin", 5), std.NewCoin("foocoin", 5)))
+	require.NoError(t, err)
+	require.Equal(t, int64(15), bankk.TotalCoin(ctx, "foocoin"))
+	require.Equal(t, int64(5), bankk.TotalCoin(ctx, "barcoin"))
+
+	// Transfers leave the supply unchanged.
+	require.NoError(t, bankk.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 3))))
+	require.NoError(t, bankk.InputOutputCoins(ctx,
+		[]Input{NewInput(addr2, std.NewCoins(std.NewCoin("barcoin", 2)))},
+		[]Output{NewOutput(addr, std.NewCoins(std.NewCoin("barcoin", 2)))},
+	))
+	require.Equal(t, int64(15), bankk.TotalCoin(ctx, "foocoin"))
+	require.Equal(t, int64(5), bankk.TotalCoin(ctx, "barcoin"))
+
+	// Subtracting coins removes them.
+	_, err = bankk.SubtractCoins(ctx, addr2, std.NewCoins(std.NewCoin("barcoin", 3)))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), bankk.TotalCoin(ctx, "barcoin"))
+	require.NoError(t, bankk.SetCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 1))))
+	require.Equal(t, int64(9), bankk.TotalCoin(ctx, "foocoin"))
+	require.Equal(t, int64(0), bankk.TotalCoin(ctx, "barcoin"))
+	require.True(t, bankk.TotalSupply(ctx).IsEqual(std.NewCoins(std.NewCoin("foocoin", 9))))
+
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	// Coins set outside of the bank keeper break the invariant.
+	acc := env.acck.GetAccount(ctx, addr)
+	acc.SetCoins(std.NewCoins(std.NewCoin("foocoin", 100)))
+	env.acck.SetAccount(ctx, acc)
+	msg, broken := invariant(ctx)
+	require.True(t, broken)
+	require.Contains(t, msg, "total supply: 9foocoin")
+}
@@ -17,7 +17,7 @@ type MsgSend struct {
 	Amount      std.Coins      `json:"amount" yaml:"amount"`
 }
 
-var _ std.Msg = MsgSend{}
+var _ std.ReceiversMsg = MsgSend{}
 
 // NewMsgSend - construct arbitrary multi-in, multi-out send msg.
 func NewMsgSend(fromAddr, toAddr crypto.Address, amount std.Coins) MsgSend {
@@ -57,13 +57,18 @@ func (msg MsgSend) GetSigners() []crypto.Address {
 	return []crypto.Address{msg.FromAddress}
 }
 
+// GetReceivers Implements ReceiversMsg.
+func (msg MsgSend) GetReceivers() []crypto.Address {
+	return []crypto.Address{msg.ToAddress}
+}
+
 // MsgMultiSend - high level transaction of the coin module
 type MsgMultiSend struct {
 	Inputs  []Input  `json:"inputs" yaml:"inputs"`
 	Outputs []Output `json:"outputs" yaml:"outputs"`
 }
 
-var _ std.Msg = MsgMultiSend{}
+var _ std.ReceiversMsg = MsgMultiSend{}
 
 // NewMsgMultiSend - construct arbitrary multi-in, multi-out send msg.
 func NewMsgMultiSend(in []Input, out []Output) MsgMultiSend {
@@ -104,6 +109,15 @@ func (msg MsgMultiSend) GetSigners() []crypto.Address {
 	return addrs
 }
 
+// GetReceivers Implements ReceiversMsg.
+func (msg MsgMultiSend) GetReceivers() []crypto.Address {
+	addrs := make([]crypto.Address, len(msg.Outputs))
+	for i, out := range msg.Outputs {
+		addrs[i] = out.Address
+	}
+	return addrs
+}
+
 // Input models transaction input
 type Input struct {
 	Address crypto.Address `json:"address" yaml:"address"`
@@ -16,6 +16,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // Key to store the consensus params in the main store.
@@ -38,12 +39,18 @@ type BaseApp struct {
 	mainKey store.StoreKey // Main Store in cms (e.g. iavl, merkle-ized)
 
 	anteHandler  AnteHandler  // ante handler for fee and auth
+	postHandler  PostHandler  // post handler, eg. for fee refunds
 	initChainer  InitChainer  // initialize state with validators and state blob
 	beginBlocker BeginBlocker // logic to run before any txs
 	endBlocker   EndBlocker   // logic to run after all txs, and to determine valset changes
 
 	beginTxHook BeginTxHook // BaseApp-specific hook run before running transaction messages.
 	endTxHook   EndTxHook   // BaseApp-specific hook run after running transaction messages.
+	restoreHook RestoreHook // BaseApp-specific hook run after restoring a snapshot.
+
+	// state snapshots, nil if disabled
+	snapshots       *snapshots.Manager
+	snapshotAppHash []byte // app hash of the snapshot being restored
 
 	// --------------------
 	// Volatile state
@@ -71,6 +78,9 @@ type BaseApp struct {
 	// minimum block time (in Unix seconds) at which to halt the chain and gracefully shutdown
 	haltTime uint64
 
+	// number of the latest blocks the node is requested to keep, 0 for all
+	minRetainBlocks int64
+
 	// application's version string
 	appVersion string
 }
@@ -240,14 +250,21 @@ func (app *BaseApp) Seal() { app.sealed = true }
 // IsSealed returns true if the BaseApp is sealed and false otherwise.
 func (app *BaseApp) IsSealed() bool { return app.sealed }
 
+// CommittedMultiStoreContextKey is the context key of the multistore of the
+// last committed state, in the context of CheckTx: the changes of the check
+// state from it are those of the txs pending in the mempool.
+type CommittedMultiStoreContextKey struct{}
+
 // setCheckState sets checkState with the cached multistore and
 // the context wrapping it.
 // It is called by InitChain() and Commit()
 func (app *BaseApp) setCheckState(header abci.Header) {
 	ms := app.cms.MultiCacheWrap()
 	app.checkState = &state{
-		ms:  ms,
-		ctx: NewContext(RunTxModeCheck, ms, header, app.logger).WithMinGasPrices(app.minGasPrices),
+		ms: ms,
+		ctx: NewContext(RunTxModeCheck, ms, header, app.logger).
+			WithMinGasPrices(app.minGasPrices).
+			WithValue(CommittedMultiStoreContextKey{}, store.MultiStore(app.cms)),
 	}
 }
 
@@ -495,6 +512,15 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	}
 
 	cacheMS, err := app.cms.MultiImmutableCacheWrapWithVersion(req.Height)
+	if err != nil && req.Height < app.LastBlockHeight() {
+		res.Error = ABCIError(std.ErrInternal(
+			fmt.Sprintf(
+				"state at height %d is pruned or unavailable; %s (latest height: %d)",
+				req.Height, err, app.LastBlockHeight(),
+			),
+		))
+		return
+	}
 	if err != nil {
 		res.Error = ABCIError(std.ErrInternal(
 			fmt.Sprintf(
@@ -584,6 +610,12 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
+		res.TimeoutHeight = tx.TimeoutHeight
+		res.TimeoutTimestamp = tx.TimeoutTimestamp
+		res.Priority = result.Priority
+		res.Sender = result.Sender
+		res.Sequence = result.Sequence
+		res.Replacement = result.Replacement
 		return
 	}
 }
@@ -812,6 +844,7 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 		return
 	}
 
+	var anteResult Result
 	if app.anteHandler != nil {
 		var anteCtx Context
 		var msCache store.MultiStore
@@ -849,6 +882,7 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 			ctx = newCtx.WithMultiStore(ms)
 			msCache.MultiWrite()
 			gasWanted = result.GasWanted
+			anteResult = result
 		}
 	}
 
@@ -862,6 +896,10 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 
 	result = app.runMsgs(runMsgCtx, msgs, mode)
 	result.GasWanted = gasWanted
+	result.Priority = anteResult.Priority
+	result.Sender = anteResult.Sender
+	result.Sequence = anteResult.Sequence
+	result.Replacement = anteResult.Replacement
 
 	// Safety check: don't write the cache state unless we're in DeliverTx.
 	if mode != RunTxModeDeliver {
@@ -877,6 +915,20 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 		msCache.MultiWrite()
 	}
 
+	if app.postHandler != nil {
+		// The post handler runs on the state resulting from the ante handler and
+		// the messages, with its own gas meter so that it doesn't change the gas
+		// used by the tx. If it fails, its state changes are discarded.
+		postCtx, msCache := app.cacheTxContext(ctx.WithGasMeter(store.NewInfiniteGasMeter()))
+		postResult := app.postHandler(postCtx, tx, ctx.GasMeter().GasConsumed())
+		if postResult.IsOK() {
+			msCache.MultiWrite()
+			result.Events = append(result.Events, postResult.Events...)
+		} else {
+			app.logger.Error("post handler failed", "err", postResult.Error, "log", postResult.Log)
+		}
+	}
+
 	return result
 }
 
@@ -924,10 +976,9 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// The write to the DeliverTx state writes all state transitions to the root
 	// MultiStore (app.cms) so when Commit() is called is persists those values.
 	app.deliverState.ms.MultiWrite()
-	commitID := app.cms.Commit()
-	app.logger.Debug("Commit synced", "commit", fmt.Sprintf("%X", commitID))
 
-	// Save this header.
+	// Save this header, along with the state it is committed with, as the
+	// base store may commit to its entries.
 	baseStore := app.cms.GetStore(app.baseKey)
 	if baseStore == nil {
 		res.Error = ABCIError(errors.New("baseapp expects MultiStore with 'base' Store"))
@@ -936,6 +987,15 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	headerBz := amino.MustMarshal(header)
 	baseStore.Set(mainLastHeaderKey, headerBz)
 
+	commitID := app.cms.Commit()
+	app.logger.Debug("Commit synced", "commit", fmt.Sprintf("%X", commitID))
+
+	// Take a snapshot of the committed state, including the header. The
+	// snapshot is taken synchronously, as the base store is not versioned.
+	if app.snapshots != nil && app.snapshots.ShouldSnapshot(commitID.Version) {
+		app.createSnapshot(commitID.Version)
+	}
+
 	// Reset the Check state to the latest committed.
 	//
 	// NOTE: This is safe because Tendermint holds a lock on the mempool for
@@ -947,9 +1007,30 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 
 	// return.
 	res.Data = commitID.Hash
+	res.RetainHeight = app.retainHeight(header.GetHeight())
 	return
 }
 
+// retainHeight returns the height below which the node may prune the blocks,
+// or 0 to keep all the blocks. The blocks of the states kept by the store
+// pruning options are retained, so that they can still be queried together
+func (app *BaseApp) retainHeight(height int64) int64 {
+	if app.minRetainBlocks <= 0 {
+		return 0
+	}
+
+	retainHeight := height - app.minRetainBlocks + 1
+	if keepRecent := app.cms.GetStoreOptions().KeepRecent; keepRecent > 0 {
+		retainHeight = min(retainHeight, height-keepRecent)
+	}
+
+	if retainHeight <= 0 {
+		return 0
+	}
+
+	return retainHeight
+}
+
 // halt attempts to gracefully shutdown the node via SIGINT and SIGTERM falling
 // back on os.Exit if both fail.
 func (app *BaseApp) halt() {
@@ -20,8 +20,9 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/sdk/testutils"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
-	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 var (
@@ -65,7 +66,7 @@ func defaultLogger() *slog.Logger {
 func newBaseApp(name string, db dbm.DB, options ...func(*BaseApp)) *BaseApp {
 	logger := defaultLogger()
 	app := NewBaseApp(name, logger, db, baseKey, mainKey, options...)
-	app.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, nil)
+	app.MountStoreWithDB(baseKey, hashdb.StoreConstructor, nil)
 	app.MountStoreWithDB(mainKey, iavl.StoreConstructor, nil)
 	return app
 }
@@ -145,6 +146,112 @@ func TestLoadVersion(t *testing.T) {
 	testLoadVersionHelper(t, app, int64(2), commitID2)
 }
 
+func TestCommitRetainHeight(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		pruning         store.PruningOptions
+		minRetainBlocks int64
+		expected        []int64 // retain heights of the blocks 1 to 5
+	}{
+		{"disabled", store.PruneEverything, 0, []int64{0, 0, 0, 0, 0}},
+		{"min retain blocks", store.PruneEverything, 2, []int64{0, 1, 2, 3, 4}},
+		{"states kept", store.PruningOptions{KeepRecent: 3}, 2, []int64{0, 0, 0, 1, 2}},
+		{"states pruned", store.PruningOptions{KeepRecent: 1}, 3, []int64{0, 0, 1, 2, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := setupBaseApp(t, SetPruningOptions(tc.pruning), SetMinRetainBlocks(tc.minRetainBlocks))
+
+			for i, expected := range tc.expected {
+				header := &bft.Header{ChainID: "test-chain", Height: int64(i + 1)}
+				app.BeginBlock(abci.RequestBeginBlock{Header: header})
+				res := app.Commit()
+
+				assert.Equal(t, expected, res.RetainHeight, "height %d", header.Height)
+			}
+		})
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	app := setupBaseApp(t, SetSnapshots(memdb.NewMemDB(), snapshots.Options{Interval: 2, KeepRecent: 1}))
+
+	var appHash []byte
+	for height := int64(1); height <= 4; height++ {
+		header := &bft.Header{ChainID: "test-chain", Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.deliverState.ms.GetStore(mainKey).Set([]byte(fmt.Sprintf("key%d", height)), []byte("value"))
+		app.deliverState.ms.GetStore(baseKey).Set([]byte(fmt.Sprintf("base%d", height)), []byte("value"))
+		appHash = app.Commit().Data
+	}
+
+	// Only the latest snapshot is kept
+	list := app.ListSnapshots(abci.RequestListSnapshots{})
+	require.Len(t, list.Snapshots, 1)
+	snapshot := list.Snapshots[0]
+	require.Equal(t, int64(4), snapshot.Height)
+
+	var restored bool
+	target := setupBaseApp(t, SetSnapshots(nil, snapshots.Options{}))
+	target.restoreHook = func(ms store.MultiStore) { restored = true }
+
+	// The offered app hash is verified once the snapshot is restored
+	offer := target.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+	require.Equal(t, abci.OfferSnapshotAccept, offer.Result)
+
+	// A corrupted chunk is fetched again
+	chunk := app.LoadSnapshotChunk(abci.RequestLoadSnapshotChunk{Height: 4, Format: snapshot.Format, Chunk: 0})
+	res := target.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{Index: 0, Chunk: []byte("invalid"), Sender: "peer"})
+	assert.Equal(t, abci.ApplySnapshotChunkRetry, res.Result)
+	assert.Equal(t, []uint32{0}, res.RefetchChunks)
+	assert.Equal(t, []string{"peer"}, res.RejectSenders)
+
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk = app.LoadSnapshotChunk(abci.RequestLoadSnapshotChunk{Height: 4, Format: snapshot.Format, Chunk: i})
+		require.Nil(t, chunk.Error)
+
+		res := target.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{Index: i, Chunk: chunk.Chunk})
+		require.Equal(t, abci.ApplySnapshotChunkAccept, res.Result)
+	}
+
+	assert.True(t, restored)
+	assert.Equal(t, app.LastCommitID(), target.LastCommitID())
+	assert.Equal(t, []byte("value"), target.cms.GetStore(baseKey).Get([]byte("base4")))
+	assert.Equal(t, "test-chain", target.checkState.ctx.ChainID())
+
+	// A snapshot can only be restored into an empty state
+	offer = target.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+	assert.Equal(t, abci.OfferSnapshotAbort, offer.Result)
+}
+
+func TestSnapshotRestore_AppHashMismatch(t *testing.T) {
+	t.Parallel()
+
+	app := setupBaseApp(t, SetSnapshots(memdb.NewMemDB(), snapshots.Options{Interval: 1}))
+	app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: "test-chain", Height: 1}})
+	app.Commit()
+
+	snapshot := app.ListSnapshots(abci.RequestListSnapshots{}).Snapshots[0]
+
+	target := setupBaseApp(t, SetSnapshots(nil, snapshots.Options{}))
+	offer := target.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: snapshot, AppHash: []byte("invalid")})
+	require.Equal(t, abci.OfferSnapshotAccept, offer.Result)
+
+	var res abci.ResponseApplySnapshotChunk
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk := app.LoadSnapshotChunk(abci.RequestLoadSnapshotChunk{Height: 1, Format: snapshot.Format, Chunk: i})
+		res = target.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{Index: i, Chunk: chunk.Chunk})
+	}
+	assert.Equal(t, abci.ApplySnapshotChunkAbort, res.Result)
+}
+
 func TestAppVersionSetterGetter(t *testing.T) {
 	t.Parallel()
 
@@ -1108,6 +1215,71 @@ func TestBaseAppAnteHandler(t *testing.T) {
 	app.Commit()
 }
 
+func TestBaseAppPostHandler(t *testing.T) {
+	t.Parallel()
+
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx Context, tx std.Tx, simulate bool) (newCtx Context, res Result, abort bool) {
+			newCtx = ctx.WithGasMeter(store.NewGasMeter(100000))
+			res = incrementingCounter(t, newCtx.GasStore(mainKey), anteKey, getCounter(tx))
+			return
+		})
+	}
+
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, newMsgCounterHandler(t, mainKey, deliverKey))
+	}
+
+	postKey := []byte("post-key")
+	postOpt := func(bapp *BaseApp) {
+		bapp.SetPostHandler(func(ctx Context, tx std.Tx, gasUsed int64) Result {
+			// does not count in the gas used by the tx
+			ctx.GasMeter().ConsumeGas(1_000_000, "post")
+			if getCounter(tx) == 2 {
+				setIntOnStore(ctx.Store(mainKey), postKey, -1)
+				return Result{ResponseBase: abci.ResponseBase{Error: ABCIError(std.ErrInternal("post handler failure"))}}
+			}
+			setIntOnStore(ctx.Store(mainKey), postKey, gasUsed)
+			return Result{ResponseBase: abci.ResponseBase{Events: []abci.Event{abci.EventString("post")}}}
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt, postOpt)
+
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	header := &bft.Header{ChainID: "test-chain", Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	// the post handler runs after a successful tx
+	tx := newTxCounter(0, 0)
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, []abci.Event{abci.EventString("post")}, res.Events)
+	require.Positive(t, res.GasUsed)
+
+	store := app.getState(RunTxModeDeliver).ctx.Store(mainKey)
+	require.Equal(t, res.GasUsed, getIntFromStore(store, postKey))
+
+	// the post handler runs after a failed tx
+	tx = newTxCounter(1, 1)
+	setFailOnHandler(&tx, true)
+	res = app.Deliver(tx)
+	require.False(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, []abci.Event{abci.EventString("post")}, res.Events)
+	require.Equal(t, int64(1), getIntFromStore(store, deliverKey))
+
+	// the state changes of a failing post handler are discarded
+	tx = newTxCounter(2, 1)
+	res = app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Empty(t, res.Events)
+	require.Equal(t, int64(2), getIntFromStore(store, deliverKey))
+	require.Equal(t, res.GasUsed, getIntFromStore(store, postKey))
+}
+
 func TestGasConsumptionBadTx(t *testing.T) {
 	t.Parallel()
 
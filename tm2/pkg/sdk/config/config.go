@@ -12,18 +12,47 @@ import (
 type AppConfig struct {
 	// Lowest gas prices accepted by a validator in the form of "100tokenA/3gas;10tokenB/5gas" separated by semicolons
 	MinGasPrices string `json:"min_gas_prices" toml:"min_gas_prices" comment:"Lowest gas prices accepted by a validator"`
+
+	// Number of blocks between the state snapshots served to the nodes bootstrapping with state sync, 0 to disable
+	SnapshotInterval int64 `json:"snapshot_interval" toml:"snapshot_interval" comment:"Number of blocks between the state snapshots served to the nodes\n bootstrapping with state sync, 0 to disable the snapshots"`
+
+	// Number of the most recent snapshots to keep, 0 to keep all the snapshots
+	SnapshotKeepRecent int `json:"snapshot_keep_recent" toml:"snapshot_keep_recent" comment:"Number of the most recent snapshots to keep, 0 to keep all the snapshots"`
+
+	// Commit the entries of the base store to the app hash, as required to serve state snapshots
+	CommitBaseStore bool `json:"commit_base_store" toml:"commit_base_store" comment:"Commit the entries of the base store to the app hash, as required to serve\n state snapshots. Only applies to a new chain, as it changes the app hash:\n all the nodes of the chain must set it alike"`
+
+	// Number of the most recent application states to keep, in addition to the latest one
+	PruningKeepRecent int64 `json:"pruning_keep_recent" toml:"pruning_keep_recent" comment:"Number of the most recent application states to keep, in addition to the latest one.\n Historical and proven queries can only be served for the kept states"`
 }
 
 // DefaultAppConfig returns a default configuration for the application
 func DefaultAppConfig() *AppConfig {
 	return &AppConfig{
-		MinGasPrices: "",
+		MinGasPrices:       "",
+		SnapshotInterval:   0,
+		SnapshotKeepRecent: 2,
+		CommitBaseStore:    false,
+		PruningKeepRecent:  1,
 	}
 }
 
 // ValidateBasic performs basic validation, checking format and param bounds, etc., and
 // returns an error if any check fails.
 func (cfg *AppConfig) ValidateBasic() error {
+	if cfg.SnapshotInterval < 0 {
+		return errors.New("snapshot_interval can't be negative")
+	}
+	if cfg.SnapshotKeepRecent < 0 {
+		return errors.New("snapshot_keep_recent can't be negative")
+	}
+	if cfg.SnapshotInterval > 0 && !cfg.CommitBaseStore {
+		return errors.New("snapshot_interval requires commit_base_store")
+	}
+	if cfg.PruningKeepRecent < 0 {
+		return errors.New("pruning_keep_recent can't be negative")
+	}
+
 	if cfg.MinGasPrices == "" {
 		return nil
 	}
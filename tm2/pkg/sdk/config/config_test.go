@@ -34,3 +34,35 @@ func TestValidateAppConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAppConfigSnapshots(t *testing.T) {
+	cfg := DefaultAppConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.SnapshotInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultAppConfig()
+	cfg.SnapshotKeepRecent = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// The snapshots require the base store to be committed
+	cfg = DefaultAppConfig()
+	cfg.SnapshotInterval = 100
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.CommitBaseStore = true
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestValidateAppConfigPruning(t *testing.T) {
+	cfg := DefaultAppConfig()
+	// The previous state is kept, to be queried with a proof
+	assert.Equal(t, int64(1), cfg.PruningKeepRecent)
+
+	cfg.PruningKeepRecent = 100
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.PruningKeepRecent = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // File for storing in-package BaseApp optional functions,
@@ -29,6 +31,35 @@ func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.setMinGasPrices(gasPrices) }
 }
 
+// SetMinRetainBlocks returns an option that sets the number of the latest
+// blocks the app requests the node to keep on commit, see
+// ResponseCommit.RetainHeight. 0 keeps all the blocks.
+func SetMinRetainBlocks(minRetainBlocks int64) func(*BaseApp) {
+	if minRetainBlocks < 0 {
+		panic(fmt.Sprintf("invalid minimum retain blocks: %d", minRetainBlocks))
+	}
+
+	return func(bap *BaseApp) { bap.minRetainBlocks = minRetainBlocks }
+}
+
+// SetSnapshots returns an option that enables the state snapshots of the app,
+// saved in the given DB, and served to the nodes bootstrapping with state
+// sync. A nil DB only enables restoring snapshots.
+func SetSnapshots(db dbm.DB, opts snapshots.Options) func(*BaseApp) {
+	if opts.Interval < 0 || opts.KeepRecent < 0 {
+		panic(fmt.Sprintf("invalid snapshot options: %+v", opts))
+	}
+
+	return func(bap *BaseApp) {
+		if db == nil {
+			db = memdb.NewMemDB()
+			opts.Interval = 0
+		}
+
+		bap.snapshots = snapshots.NewManager(snapshots.NewStore(db), bap.cms, opts)
+	}
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -86,6 +117,20 @@ func (app *BaseApp) SetAnteHandler(ah AnteHandler) {
 	app.anteHandler = ah
 }
 
+func (app *BaseApp) SetPostHandler(ph PostHandler) {
+	if app.sealed {
+		panic("SetPostHandler() on sealed BaseApp")
+	}
+	app.postHandler = ph
+}
+
+func (app *BaseApp) SetRestoreHook(restoreHook RestoreHook) {
+	if app.sealed {
+		panic("SetRestoreHook() on sealed BaseApp")
+	}
+	app.restoreHook = restoreHook
+}
+
 func (app *BaseApp) SetBeginTxHook(beginTx BeginTxHook) {
 	if app.sealed {
 		panic("SetBeginTxHook() on sealed BaseApp")
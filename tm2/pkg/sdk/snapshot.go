@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
+)
+
+// createSnapshot takes a snapshot of the committed state at the given height.
+// Failures are logged, as they must not halt the chain.
+func (app *BaseApp) createSnapshot(height int64) {
+	snapshot, err := app.snapshots.Create(height)
+	if err != nil {
+		app.logger.Error("Unable to create state snapshot", "height", height, "err", err)
+		return
+	}
+
+	app.logger.Info("Created state snapshot", "height", height, "chunks", snapshot.Chunks)
+}
+
+// ListSnapshots implements the ABCI interface.
+func (app *BaseApp) ListSnapshots(req abci.RequestListSnapshots) (res abci.ResponseListSnapshots) {
+	if app.snapshots == nil {
+		return
+	}
+
+	snapshots, err := app.snapshots.List()
+	if err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+
+	res.Snapshots = snapshots
+	return
+}
+
+// LoadSnapshotChunk implements the ABCI interface.
+func (app *BaseApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) (res abci.ResponseLoadSnapshotChunk) {
+	if app.snapshots == nil {
+		return
+	}
+
+	chunk, err := app.snapshots.LoadChunk(req.Height, req.Format, req.Chunk)
+	if err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+
+	res.Chunk = chunk
+	return
+}
+
+// OfferSnapshot implements the ABCI interface. The app hash of the offered
+// snapshot, verified by the node, is checked once the snapshot is restored.
+func (app *BaseApp) OfferSnapshot(req abci.RequestOfferSnapshot) (res abci.ResponseOfferSnapshot) {
+	if app.snapshots == nil {
+		res.Result = abci.OfferSnapshotAbort
+		return
+	}
+	if req.Snapshot == nil || len(req.AppHash) == 0 {
+		res.Result = abci.OfferSnapshotReject
+		return
+	}
+	if app.cms.LastCommitID().Version != 0 {
+		// The state can only be restored into an empty store
+		app.logger.Error("Unable to restore a snapshot, the state is not empty")
+		res.Result = abci.OfferSnapshotAbort
+		return
+	}
+
+	err := app.snapshots.Restore(*req.Snapshot)
+	switch {
+	case err == nil:
+		app.snapshotAppHash = req.AppHash
+		res.Result = abci.OfferSnapshotAccept
+	case errors.Is(err, snapshots.ErrUnknownFormat):
+		res.Result = abci.OfferSnapshotRejectFormat
+	default:
+		app.logger.Error("Rejected state snapshot", "height", req.Snapshot.Height, "err", err)
+		res.Result = abci.OfferSnapshotReject
+	}
+
+	return
+}
+
+// ApplySnapshotChunk implements the ABCI interface. Once the last chunk is
+// applied, the app is loaded from the restored state.
+func (app *BaseApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) (res abci.ResponseApplySnapshotChunk) {
+	if app.snapshots == nil {
+		res.Result = abci.ApplySnapshotChunkAbort
+		return
+	}
+
+	done, err := app.snapshots.RestoreChunk(req.Index, req.Chunk)
+	switch {
+	case errors.Is(err, snapshots.ErrChunkHashMismatch):
+		// Fetch the chunk again, from another peer
+		res.Result = abci.ApplySnapshotChunkRetry
+		res.RefetchChunks = []uint32{req.Index}
+		res.RejectSenders = []string{req.Sender}
+		return
+	case errors.Is(err, snapshots.ErrNoRestore), errors.Is(err, snapshots.ErrUnexpectedChunk):
+		res.Result = abci.ApplySnapshotChunkAbort
+		res.Error = ABCIError(err)
+		return
+	case err != nil:
+		app.logger.Error("Unable to restore state snapshot", "err", err)
+		res.Result = abci.ApplySnapshotChunkRejectSnapshot
+		return
+	}
+
+	if done {
+		if err := app.loadRestoredState(); err != nil {
+			app.logger.Error("Unable to load restored state", "err", err)
+			res.Result = abci.ApplySnapshotChunkAbort
+			res.Error = ABCIError(err)
+			return
+		}
+	}
+
+	res.Result = abci.ApplySnapshotChunkAccept
+	return
+}
+
+// loadRestoredState verifies the app hash of the restored state, and
+// initializes the app from it.
+func (app *BaseApp) loadRestoredState() error {
+	appHash := app.snapshotAppHash
+	app.snapshotAppHash = nil
+
+	lastCommitID := app.cms.LastCommitID()
+	if !bytes.Equal(lastCommitID.Hash, appHash) {
+		return fmt.Errorf("restored app hash %X does not match the snapshot app hash %X",
+			lastCommitID.Hash, appHash)
+	}
+
+	if err := app.initFromMainStore(); err != nil {
+		return err
+	}
+
+	if app.restoreHook != nil {
+		ms := app.cms.MultiCacheWrap()
+		app.restoreHook(ms)
+		ms.MultiWrite()
+	}
+
+	app.logger.Info("Restored state snapshot", "height", lastCommitID.Version, "hash", fmt.Sprintf("%X", lastCommitID.Hash))
+
+	return nil
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -25,11 +26,22 @@ type Result struct {
 	abci.ResponseBase
 	GasWanted int64
 	GasUsed   int64
+
+	// Set by the AnteHandler in CheckTx, to order the tx in the mempool.
+	Priority    int64
+	Sender      crypto.Address
+	Sequence    uint64
+	Replacement bool
 }
 
 // AnteHandler authenticates transactions, before their internal messages are handled.
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, result Result, abort bool)
 
+// PostHandler is run after the messages of a delivered transaction are
+// handled, whether they succeeded or not, given the gas used by the
+// transaction. The events of its result are appended to the transaction's.
+type PostHandler func(ctx Context, tx Tx, gasUsed int64) Result
+
 // Exports from std.
 type Msg = std.Msg
 
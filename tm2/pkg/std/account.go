@@ -2,6 +2,7 @@ package std
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -10,6 +11,7 @@ import (
 	_ "github.com/gnolang/gno/tm2/pkg/crypto/mock"
 	_ "github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	_ "github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	_ "github.com/gnolang/gno/tm2/pkg/crypto/secp256r1"
 )
 
 // Account is an interface used to store coins at a given address within state.
@@ -42,6 +44,22 @@ type AccountUnrestricter interface {
 	IsUnrestricted() bool
 }
 
+// SessionKeyAccount is implemented by accounts which accept transactions
+// signed by session keys: secondary public keys, allowed to sign the
+// transactions of the account within a limited scope.
+type SessionKeyAccount interface {
+	// UseSessionKey checks that the session key pubKey is allowed to sign tx
+	// in the block at height, and records its use (ie. the coins it spends).
+	UseSessionKey(pubKey crypto.PubKey, tx Tx, height int64) error
+}
+
+// VestingAccount is implemented by accounts whose coins are released over
+// time or block height. Unvested coins cannot be spent.
+type VestingAccount interface {
+	GetOriginalVesting() Coins
+	GetVestingCoins(height int64, blockTime time.Time) Coins // unvested
+}
+
 //----------------------------------------
 // BaseAccount
 
@@ -19,6 +19,11 @@ type SignDoc struct {
 	Fee           Fee    `json:"fee" yaml:"fee"`
 	Msgs          []Msg  `json:"msgs" yaml:"msgs"`
 	Memo          string `json:"memo" yaml:"memo"`
+
+	// Omitted when zero, so that the sign bytes of transactions without
+	// timeout are unchanged.
+	TimeoutHeight    int64 `json:"timeout_height,omitempty" yaml:"timeout_height,omitempty"`
+	TimeoutTimestamp int64 `json:"timeout_timestamp,omitempty" yaml:"timeout_timestamp,omitempty"`
 }
 
 // GetSignaturePayload returns the sign payload for the SignDoc.
@@ -48,3 +48,21 @@ func TestSignDoc_GetSignaturePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestSignDoc_GetSignaturePayload_Timeout(t *testing.T) {
+	t.Parallel()
+
+	doc := SignDoc{ChainID: "dummy", Memo: "memo"}
+
+	// Zero timeouts are omitted, keeping the payload of txs without timeout.
+	signPayload, err := GetSignaturePayload(doc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(signPayload), "timeout")
+
+	doc.TimeoutHeight = 10
+	doc.TimeoutTimestamp = 20
+	signPayload, err = GetSignaturePayload(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(signPayload), `"timeout_height":"10"`)
+	assert.Contains(t, string(signPayload), `"timeout_timestamp":"20"`)
+}
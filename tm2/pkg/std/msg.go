@@ -26,3 +26,21 @@ type Msg interface {
 	// CONTRACT: Returns addrs in some deterministic order.
 	GetSigners() []crypto.Address
 }
+
+// ReceiversMsg is implemented by the messages sending coins to other
+// accounts than their signers.
+type ReceiversMsg interface {
+	Msg
+
+	// GetReceivers returns the addrs of the accounts receiving coins.
+	GetReceivers() []crypto.Address
+}
+
+// ExecMsg is implemented by the messages executing other messages, e.g. on
+// behalf of their signers.
+type ExecMsg interface {
+	Msg
+
+	// GetMsgs returns the messages executed.
+	GetMsgs() []Msg
+}
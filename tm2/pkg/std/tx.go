@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -20,11 +21,17 @@ var (
 
 // Tx is a standard way to wrap a Msg with Fee and Signatures.
 // NOTE: the first signature is the fee payer (Signatures must not be nil).
+//
+// TimeoutHeight and TimeoutTimestamp (unix seconds) optionally bound the
+// validity of the transaction: it is rejected once the block height, or block
+// time, is past them. Zero means no timeout.
 type Tx struct {
-	Msgs       []Msg       `json:"msg" yaml:"msg"`
-	Fee        Fee         `json:"fee" yaml:"fee"`
-	Signatures []Signature `json:"signatures" yaml:"signatures"`
-	Memo       string      `json:"memo" yaml:"memo"`
+	Msgs             []Msg       `json:"msg" yaml:"msg"`
+	Fee              Fee         `json:"fee" yaml:"fee"`
+	Signatures       []Signature `json:"signatures" yaml:"signatures"`
+	Memo             string      `json:"memo" yaml:"memo"`
+	TimeoutHeight    int64       `json:"timeout_height,omitempty" yaml:"timeout_height,omitempty"`
+	TimeoutTimestamp int64       `json:"timeout_timestamp,omitempty" yaml:"timeout_timestamp,omitempty"`
 }
 
 func NewTx(msgs []Msg, fee Fee, sigs []Signature, memo string) Tx {
@@ -50,6 +57,12 @@ func (tx Tx) ValidateBasic() error {
 	if !tx.Fee.GasFee.IsValid() {
 		return ErrInsufficientFee(fmt.Sprintf("invalid fee %s amount provided", tx.Fee.GasFee))
 	}
+	if _, ok := tx.Fee.Granter(); tx.Fee.FeeGranter != "" && !ok {
+		return ErrInvalidAddress(fmt.Sprintf("invalid fee granter address %q", tx.Fee.FeeGranter))
+	}
+	if tx.TimeoutHeight < 0 || tx.TimeoutTimestamp < 0 {
+		return ErrTxTimeout("negative timeout")
+	}
 	if len(stdSigs) == 0 {
 		return ErrNoSignatures("no signers")
 	}
@@ -107,6 +120,18 @@ func (tx Tx) GetMemo() string { return tx.Memo }
 // .Empty().
 func (tx Tx) GetSignatures() []Signature { return tx.Signatures }
 
+// IsExpired returns whether the timeout of the transaction is past, given the
+// height and time of the block it would be included in.
+func (tx Tx) IsExpired(height int64, blockTime time.Time) bool {
+	if tx.TimeoutHeight != 0 && height > tx.TimeoutHeight {
+		return true
+	}
+	if tx.TimeoutTimestamp != 0 && blockTime.Unix() > tx.TimeoutTimestamp {
+		return true
+	}
+	return false
+}
+
 func (tx Tx) GetSignBytes(chainID string, accountNumber uint64, sequence uint64) ([]byte, error) {
 	return GetSignaturePayload(SignDoc{
 		ChainID:       chainID,
@@ -115,6 +140,9 @@ func (tx Tx) GetSignBytes(chainID string, accountNumber uint64, sequence uint64)
 		Fee:           tx.Fee,
 		Msgs:          tx.Msgs,
 		Memo:          tx.Memo,
+
+		TimeoutHeight:    tx.TimeoutHeight,
+		TimeoutTimestamp: tx.TimeoutTimestamp,
 	})
 }
 
@@ -123,9 +151,15 @@ func (tx Tx) GetSignBytes(chainID string, accountNumber uint64, sequence uint64)
 // Fee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+//
+// If FeeGranter is set, the fee is paid by the granter instead of the first
+// signer, provided that the granter granted a fee allowance to the latter.
+// FeeGranter is a bech32 string so that it is omitted from the encoding of
+// fees without granter.
 type Fee struct {
-	GasWanted int64 `json:"gas_wanted" yaml:"gas_wanted"`
-	GasFee    Coin  `json:"gas_fee" yaml:"gas_fee"`
+	GasWanted  int64                `json:"gas_wanted" yaml:"gas_wanted"`
+	GasFee     Coin                 `json:"gas_fee" yaml:"gas_fee"`
+	FeeGranter crypto.Bech32Address `json:"fee_granter,omitempty" yaml:"fee_granter,omitempty"`
 }
 
 // NewFee returns a new instance of Fee
@@ -136,6 +170,19 @@ func NewFee(gasWanted int64, gasFee Coin) Fee {
 	}
 }
 
+// Granter returns the address of the fee granter, and whether it is set and
+// valid.
+func (fee Fee) Granter() (crypto.Address, bool) {
+	if fee.FeeGranter == "" {
+		return crypto.Address{}, false
+	}
+	addr, err := crypto.AddressFromBech32(string(fee.FeeGranter))
+	if err != nil || addr.IsZero() {
+		return crypto.Address{}, false
+	}
+	return addr, true
+}
+
 // Bytes for signing later
 func (fee Fee) Bytes() []byte {
 	bz, err := amino.MarshalJSON(fee) // TODO
@@ -0,0 +1,411 @@
+// Package hashdb implements a DB store committing to its entries.
+//
+// Like dbadapter.Store, the values are written to the DB as they are. The
+// hash of each value is also set in an IAVL tree, whose root hash is the
+// commit hash of the store, so that the entries of the store are part of the
+// app hash, and can be verified when restored from a snapshot.
+//
+// Only the latest entries are kept, along with the previous values of the
+// entries changed by the recent versions, so that the store can be rolled
+// back to one of them.
+package hashdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+
+	"github.com/gnolang/gno/tm2/pkg/store/cache"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var (
+	// hashesPrefix is the prefix of the IAVL tree of the hashes in the DB.
+	// It sets the tree apart from the entries, and from the other IAVL
+	// trees mounted on the same DB.
+	hashesPrefix = []byte("hash/")
+
+	// undoPrefix is the prefix of the previous values of the entries
+	// changed by each version, by version and key.
+	undoPrefix = []byte("undo/")
+)
+
+// IsHashed reports whether the store in db, as given to StoreConstructor,
+// holds the hashes of its entries, like the stores created by
+// StoreConstructor once committed.
+func IsHashed(db dbm.DB) bool {
+	itr := dbm.IteratePrefix(db, hashesPrefix)
+	defer itr.Close()
+
+	return itr.Valid()
+}
+
+// Implements CommitStoreConstructor.
+func StoreConstructor(db dbm.DB, opts types.StoreOptions) types.CommitStore {
+	hashes := iavl.StoreConstructor(dbm.NewPrefixDB(db, hashesPrefix), opts)
+
+	st := newDBStore(db, opts)
+	st.hashes = hashes.(*iavl.Store)
+
+	return st
+}
+
+// Implements CommitStoreConstructor.
+// The entries of the store are versioned like those of StoreConstructor, but
+// are not hashed: like dbadapter.Store, the store commits to an empty
+// CommitID, and is not part of the app hash.
+func UnhashedStoreConstructor(db dbm.DB, opts types.StoreOptions) types.CommitStore {
+	return newDBStore(db, opts)
+}
+
+func newDBStore(db dbm.DB, opts types.StoreOptions) *Store {
+	return &Store{
+		Store:   dbadapter.Store{DB: db},
+		undo:    dbm.NewPrefixDB(db, undoPrefix),
+		opts:    opts,
+		changed: make(map[string][]byte),
+	}
+}
+
+var (
+	_ types.Store       = (*Store)(nil)
+	_ types.CommitStore = (*Store)(nil)
+)
+
+// Store is a DB store, whose values are hashed in an IAVL tree.
+type Store struct {
+	dbadapter.Store
+
+	hashes  *iavl.Store // nil if the entries are not hashed
+	undo    dbm.DB
+	opts    types.StoreOptions
+	version int64
+
+	// changed holds the previous values of the entries changed since the
+	// last commit, encoded by undoValue.
+	changed map[string][]byte
+}
+
+// Hashes returns the IAVL store of the hashes of the values, by key, or nil
+// if the entries are not hashed.
+func (st *Store) Hashes() *iavl.Store {
+	return st.hashes
+}
+
+// Implements Store.
+func (st *Store) Set(key, value []byte) {
+	types.AssertValidValue(value)
+	st.recordChange(key)
+	st.Store.Set(key, value)
+	if st.hashes != nil {
+		st.hashes.Set(key, valueHash(value))
+	}
+}
+
+// Implements Store.
+func (st *Store) Delete(key []byte) {
+	st.recordChange(key)
+	st.Store.Delete(key)
+	if st.hashes != nil {
+		st.hashes.Delete(key)
+	}
+}
+
+// recordChange keeps the value of the entry before its first change since
+// the last commit.
+func (st *Store) recordChange(key []byte) {
+	if _, ok := st.changed[string(key)]; !ok {
+		st.changed[string(key)] = undoValue(st.Store.Get(key))
+	}
+}
+
+// Implements Store.
+func (st *Store) CacheWrap() types.Store {
+	return cache.New(st)
+}
+
+// Implements Store.
+func (st *Store) Write() {
+	panic("unexpected .Write() on hashdb.Store.")
+}
+
+// Implements Committer.
+// The previous values of the changed entries are saved under the new
+// version, and those of the versions which can no longer be rolled back to
+// are deleted.
+func (st *Store) Commit() types.CommitID {
+	var id types.CommitID
+	if st.hashes != nil {
+		id = st.hashes.Commit()
+		st.version = id.Version
+	} else {
+		st.version++
+	}
+
+	batch := st.undo.NewBatch()
+	defer batch.Close()
+
+	// The key of the version itself marks the changes of the version as
+	// available, even if there is none
+	prefix := versionKey(st.version)
+	batch.Set(prefix, []byte{})
+	for key, value := range st.changed {
+		batch.Set(append(prefix[:len(prefix):len(prefix)], key...), value)
+	}
+	st.pruneChanges(batch)
+	batch.Write()
+
+	st.changed = make(map[string][]byte)
+
+	return id
+}
+
+// pruneChanges deletes the changes of the versions up to the oldest version
+// kept by the pruning options, as the store can only be rolled back to a kept
+// version.
+func (st *Store) pruneChanges(batch dbm.Batch) {
+	oldest := st.version - st.opts.KeepRecent
+	if st.opts.KeepEvery == 1 || oldest <= 0 {
+		// Every version is kept
+		return
+	}
+
+	itr := st.undo.Iterator(nil, versionKey(oldest+1))
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		batch.Delete(itr.Key())
+	}
+}
+
+// Implements Committer.
+func (st *Store) LastCommitID() types.CommitID {
+	if st.hashes == nil {
+		return types.CommitID{}
+	}
+	return st.hashes.LastCommitID()
+}
+
+// Implements Committer.
+func (st *Store) GetStoreOptions() types.StoreOptions {
+	return st.opts
+}
+
+// Implements Committer.
+func (st *Store) SetStoreOptions(opts types.StoreOptions) {
+	st.opts = opts
+	if st.hashes != nil {
+		st.hashes.SetStoreOptions(opts)
+	}
+}
+
+// Implements Committer.
+func (st *Store) LoadLatestVersion() error {
+	if st.hashes != nil {
+		if err := st.hashes.LoadLatestVersion(); err != nil {
+			return err
+		}
+		return st.loadVersion(st.hashes.LastCommitID().Version)
+	}
+
+	// The latest version is the last one with saved changes
+	itr := st.undo.ReverseIterator(nil, nil)
+	defer itr.Close()
+
+	var ver int64
+	if itr.Valid() {
+		ver = int64(binary.BigEndian.Uint64(itr.Key()))
+	}
+	return st.loadVersion(ver)
+}
+
+// Implements Committer.
+// Only the hashes are loaded at the given version, the entries are always
+// the latest ones.
+func (st *Store) LoadVersion(ver int64) error {
+	if st.hashes != nil {
+		if ver > 0 && !IsHashed(st.Store.DB) {
+			return fmt.Errorf("unable to load version %d: the entries are not hashed", ver)
+		}
+		if err := st.hashes.LoadVersion(ver); err != nil {
+			return err
+		}
+	}
+	return st.loadVersion(ver)
+}
+
+func (st *Store) loadVersion(ver int64) error {
+	st.version = ver
+	st.changed = make(map[string][]byte)
+	return nil
+}
+
+// RollbackToVersion reverts the entries, and their hashes, from the latest
+// version to the given one, and deletes the newer versions. The changes of
+// all the reverted versions must still be saved.
+func (st *Store) RollbackToVersion(ver, latest int64) error {
+	if err := st.CheckRollback(ver, latest); err != nil {
+		return err
+	}
+	if st.hashes != nil {
+		if err := st.hashes.LoadVersionForOverwriting(ver); err != nil {
+			return err
+		}
+	}
+
+	// Revert the latest changes first, down to the given version
+	for v := latest; v > ver; v-- {
+		if err := st.revertVersion(v); err != nil {
+			return err
+		}
+	}
+
+	return st.loadVersion(ver)
+}
+
+// CheckRollback checks that the store can be rolled back from the latest
+// version to the given one.
+func (st *Store) CheckRollback(ver, latest int64) error {
+	for v := ver + 1; v <= latest; v++ {
+		if !st.undo.Has(versionKey(v)) {
+			return fmt.Errorf("the changes of version %d were pruned, or are not versioned", v)
+		}
+	}
+	if st.hashes != nil && !st.hashes.VersionExists(ver) {
+		return fmt.Errorf("version %d was pruned", ver)
+	}
+
+	return nil
+}
+
+// revertVersion restores the previous values of the entries changed by the
+// given version, and deletes its changes.
+func (st *Store) revertVersion(ver int64) error {
+	prefix := versionKey(ver)
+
+	batch := st.undo.NewBatch()
+	defer batch.Close()
+
+	itr := st.undo.Iterator(prefix, versionKey(ver+1))
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		batch.Delete(itr.Key())
+
+		key := itr.Key()[len(prefix):]
+		if len(key) == 0 {
+			continue // marker of the version
+		}
+
+		value := itr.Value()
+		switch {
+		case len(value) == 0:
+			return fmt.Errorf("invalid change of entry %X at version %d", key, ver)
+		case value[0] == 0:
+			st.Store.Delete(key)
+		default:
+			st.Store.Set(key, value[1:])
+		}
+	}
+
+	batch.WriteSync()
+
+	return nil
+}
+
+// ExportEntries calls fn for each entry of the store, in the order of the
+// keys. The entries are the latest ones.
+func (st *Store) ExportEntries(fn func(key, value []byte) error) error {
+	if st.hashes == nil {
+		return fmt.Errorf("the entries are not hashed")
+	}
+
+	itr := st.hashes.Iterator(nil, nil)
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		key := itr.Key()
+
+		value := st.Store.Get(key)
+		if value == nil {
+			return fmt.Errorf("missing entry %X", key)
+		}
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restorer writes the entries of a store restored from a snapshot, once its
+// hashes are imported and loaded. Each entry is verified against its hash,
+// and all the hashed entries must be restored, in the order of their keys.
+type Restorer struct {
+	st  *Store
+	itr types.Iterator
+}
+
+// NewRestorer returns a restorer of the entries of the store, whose entries
+// must be hashed.
+func (st *Store) NewRestorer() *Restorer {
+	return &Restorer{
+		st:  st,
+		itr: st.hashes.Iterator(nil, nil),
+	}
+}
+
+// Add verifies the next entry against its hash, and writes it.
+func (r *Restorer) Add(key, value []byte) error {
+	if !r.itr.Valid() || !bytes.Equal(r.itr.Key(), key) {
+		return fmt.Errorf("unexpected entry %X", key)
+	}
+	if !bytes.Equal(r.itr.Value(), valueHash(value)) {
+		return fmt.Errorf("invalid hash of entry %X", key)
+	}
+
+	r.st.Store.Set(key, value)
+	r.itr.Next()
+
+	return nil
+}
+
+// Commit checks that all the entries have been restored.
+func (r *Restorer) Commit() error {
+	defer r.Close()
+
+	if r.itr.Valid() {
+		return fmt.Errorf("missing entry %X", r.itr.Key())
+	}
+
+	return nil
+}
+
+// Close releases the restorer.
+func (r *Restorer) Close() {
+	r.itr.Close()
+}
+
+func valueHash(value []byte) []byte {
+	return tmhash.Sum(value)
+}
+
+// versionKey returns the prefix of the changes of the version.
+func versionKey(ver int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(ver))
+}
+
+// undoValue encodes the previous value of an entry, which is nil if the
+// entry didn't exist.
+func undoValue(value []byte) []byte {
+	if value == nil {
+		return []byte{0}
+	}
+	return append([]byte{1}, value...)
+}
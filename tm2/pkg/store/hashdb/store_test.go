@@ -0,0 +1,213 @@
+package hashdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/goleveldb"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/iavl"
+
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+func newStore(t *testing.T) *Store {
+	t.Helper()
+
+	st := StoreConstructor(memdb.NewMemDB(), types.StoreOptions{PruningOptions: types.PruneNothing}).(*Store)
+	require.NoError(t, st.LoadLatestVersion())
+
+	return st
+}
+
+func TestStore_Commit(t *testing.T) {
+	t.Parallel()
+
+	st := newStore(t)
+	st.Set([]byte("key1"), []byte("value1"))
+	st.Set([]byte("key2"), []byte("value2"))
+	commit1 := st.Commit()
+
+	assert.Equal(t, int64(1), commit1.Version)
+	assert.NotEmpty(t, commit1.Hash)
+	assert.Equal(t, []byte("value1"), st.Get([]byte("key1")))
+
+	// The commit hash depends on the entries
+	other := newStore(t)
+	other.Set([]byte("key2"), []byte("value2"))
+	other.Set([]byte("key1"), []byte("value1"))
+	assert.Equal(t, commit1, other.Commit())
+
+	st.Set([]byte("key1"), []byte("changed"))
+	commit2 := st.Commit()
+	assert.NotEqual(t, commit1.Hash, commit2.Hash)
+
+	st.Delete([]byte("key1"))
+	commit3 := st.Commit()
+	assert.NotEqual(t, commit2.Hash, commit3.Hash)
+	assert.Nil(t, st.Get([]byte("key1")))
+	assert.Equal(t, commit3, st.LastCommitID())
+
+	// Only the hashes are versioned
+	require.NoError(t, st.LoadVersion(1))
+	assert.Equal(t, commit1, st.LastCommitID())
+	assert.Nil(t, st.Get([]byte("key1")))
+}
+
+func TestStore_Unhashed(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	newUnhashedStore := func() *Store {
+		st := UnhashedStoreConstructor(db, types.StoreOptions{PruningOptions: types.PruneNothing}).(*Store)
+		require.NoError(t, st.LoadLatestVersion())
+		return st
+	}
+
+	// Like a dbadapter.Store, the store commits to an empty CommitID
+	st := newUnhashedStore()
+	st.Set([]byte("key1"), []byte("value1"))
+	assert.Equal(t, types.CommitID{}, st.Commit())
+	assert.Equal(t, types.CommitID{}, st.LastCommitID())
+	assert.Nil(t, st.Hashes())
+	assert.False(t, IsHashed(db))
+
+	// The changes of the versions are kept
+	st.Set([]byte("key1"), []byte("changed"))
+	st.Commit()
+
+	st = newUnhashedStore()
+	require.NoError(t, st.RollbackToVersion(1, 2))
+	assert.Equal(t, []byte("value1"), st.Get([]byte("key1")))
+
+	st = newUnhashedStore()
+	require.NoError(t, st.RollbackToVersion(0, 1))
+	assert.Nil(t, st.Get([]byte("key1")))
+	assert.Error(t, st.CheckRollback(0, 1))
+}
+
+func TestStore_ExportRestore(t *testing.T) {
+	t.Parallel()
+
+	st := newStore(t)
+	st.Set([]byte("key1"), []byte("value1"))
+	st.Set([]byte("key2"), []byte("value2"))
+	commit := st.Commit()
+
+	var nodes []iavl.ExportNode
+	require.NoError(t, st.Hashes().Export(1, func(node iavl.ExportNode) error {
+		nodes = append(nodes, node)
+		return nil
+	}))
+
+	var entries [][2][]byte
+	require.NoError(t, st.ExportEntries(func(key, value []byte) error {
+		entries = append(entries, [2][]byte{key, value})
+		return nil
+	}))
+	require.Len(t, entries, 2)
+
+	// restore imports the hashes, and returns a restorer of the entries
+	restore := func(t *testing.T) (*Store, *Restorer) {
+		t.Helper()
+
+		restored := newStore(t)
+		importer, err := restored.Hashes().Import(1)
+		require.NoError(t, err)
+		for _, node := range nodes {
+			require.NoError(t, importer.Add(node))
+		}
+		require.NoError(t, importer.Commit())
+
+		return restored, restored.NewRestorer()
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		restored, restorer := restore(t)
+		for _, entry := range entries {
+			require.NoError(t, restorer.Add(entry[0], entry[1]))
+		}
+		require.NoError(t, restorer.Commit())
+
+		assert.Equal(t, commit, restored.LastCommitID())
+		assert.Equal(t, []byte("value2"), restored.Get([]byte("key2")))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		_, restorer := restore(t)
+		defer restorer.Close()
+
+		assert.ErrorContains(t, restorer.Add([]byte("key1"), []byte("other")), "invalid hash")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		t.Parallel()
+
+		_, restorer := restore(t)
+		defer restorer.Close()
+
+		assert.ErrorContains(t, restorer.Add([]byte("key0"), []byte("value0")), "unexpected entry")
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		t.Parallel()
+
+		_, restorer := restore(t)
+		require.NoError(t, restorer.Add(entries[0][0], entries[0][1]))
+		assert.ErrorContains(t, restorer.Commit(), "missing entry")
+	})
+}
+
+// BenchmarkStore_Commit measures the overhead of hashing the entries, against
+// the dbadapter.Store the entries are otherwise written to.
+func BenchmarkStore_Commit(b *testing.B) {
+	const entriesPerCommit = 100
+
+	value := make([]byte, 256)
+	benchmark := func(b *testing.B, st types.CommitStore) {
+		b.Helper()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < entriesPerCommit; j++ {
+				st.Set(fmt.Appendf(nil, "key/%d", (i*entriesPerCommit+j)%10_000), value)
+			}
+			st.Commit()
+		}
+	}
+
+	// The default pruning of the nodes keeps only the latest version
+	opts := types.StoreOptions{PruningOptions: types.PruneEverything}
+	newDB := func(b *testing.B) dbm.DB {
+		b.Helper()
+
+		db, err := goleveldb.NewGoLevelDB("bench", b.TempDir())
+		require.NoError(b, err)
+		b.Cleanup(db.Close)
+
+		return db
+	}
+
+	b.Run("dbadapter", func(b *testing.B) {
+		benchmark(b, dbadapter.StoreConstructor(newDB(b), opts))
+	})
+	b.Run("unhashed", func(b *testing.B) {
+		st := UnhashedStoreConstructor(newDB(b), opts)
+		require.NoError(b, st.LoadLatestVersion())
+		benchmark(b, st)
+	})
+	b.Run("hashdb", func(b *testing.B) {
+		st := StoreConstructor(newDB(b), opts)
+		require.NoError(b, st.LoadLatestVersion())
+		benchmark(b, st)
+	})
+}
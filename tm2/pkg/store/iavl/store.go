@@ -147,6 +147,41 @@ func (st *Store) LoadVersion(ver int64) error {
 	}
 }
 
+// LoadVersionForOverwriting loads the given version, and deletes the
+// versions above it.
+func (st *Store) LoadVersionForOverwriting(ver int64) error {
+	tree, ok := st.tree.(*iavl.MutableTree)
+	if !ok {
+		return errors.New("unable to overwrite the versions of an immutable store")
+	}
+
+	_, err := tree.LoadVersionForOverwriting(ver)
+	return err
+}
+
+// Export calls fn for each node of the tree at the given version, in the
+// order expected by Import.
+func (st *Store) Export(version int64, fn func(iavl.ExportNode) error) error {
+	iTree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return err
+	}
+
+	return iTree.Export(fn)
+}
+
+// Import returns an importer of a tree version into the store, which must
+// not have any saved version. The store is loaded at the imported version
+// once the importer is committed.
+func (st *Store) Import(version int64) (*iavl.Importer, error) {
+	tree, ok := st.tree.(*iavl.MutableTree)
+	if !ok {
+		return nil, errors.New("unable to import into an immutable store")
+	}
+
+	return tree.Import(version)
+}
+
 // VersionExists returns whether or not a given version is stored.
 func (st *Store) VersionExists(version int64) bool {
 	return st.tree.VersionExists(version)
@@ -253,6 +288,9 @@ func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		res.Key = key
 		if !st.VersionExists(res.Height) {
 			res.Log = errors.Wrap(iavl.ErrVersionDoesNotExist, "").Error()
+			res.Error = serrors.ErrUnknownRequest(
+				fmt.Sprintf("state at height %d is pruned or does not exist (latest height: %d)", res.Height, tree.Version()),
+			)
 			break
 		}
 
@@ -589,6 +589,12 @@ func TestIAVLStoreQuery(t *testing.T) {
 	qres = iavlStore.Query(query0)
 	require.Nil(t, qres.Error)
 	require.Equal(t, v1, qres.Value)
+
+	// unknown heights are reported
+	queryUnknown := abci.RequestQuery{Path: "/key", Data: k1, Height: cid.Version + 1}
+	qres = iavlStore.Query(queryUnknown)
+	require.ErrorContains(t, qres.Error, "is pruned or does not exist")
+	require.Nil(t, qres.Value)
 }
 
 func BenchmarkIAVLIteratorNext(b *testing.B) {
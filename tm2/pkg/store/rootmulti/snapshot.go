@@ -0,0 +1,301 @@
+package rootmulti
+
+import (
+	"bufio"
+	"io"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/iavl"
+
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
+	iavlstore "github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// snapshotMaxItemSize is the maximum size of a single snapshot item
+const snapshotMaxItemSize = 64 << 20
+
+// snapshotItem is an item of a snapshot stream. An item with the store name
+// starts the items of the store: the nodes of its IAVL tree, followed by its
+// key/values for a hashdb store, whose tree holds the hashes of the values.
+type snapshotItem struct {
+	Store string
+	Node  *iavl.ExportNode
+	KV    *snapshotKV
+}
+
+type snapshotKV struct {
+	Key   []byte
+	Value []byte
+}
+
+// Implements CommitMultiStore.
+func (ms *multiStore) Snapshot(height int64, w io.Writer) error {
+	if height <= 0 {
+		return errors.New("invalid snapshot height %d", height)
+	}
+	if _, err := getCommitInfo(ms.db, height); err != nil {
+		return errors.New("unable to snapshot height %d: %v", height, err)
+	}
+
+	writeItem := func(item snapshotItem) error {
+		_, err := amino.MarshalSizedWriter(w, item)
+		return err
+	}
+
+	for _, key := range ms.sortedKeys() {
+		if err := writeItem(snapshotItem{Store: key.Name()}); err != nil {
+			return err
+		}
+
+		switch store := ms.stores[key].(type) {
+		case *iavlstore.Store:
+			err := store.Export(height, func(node iavl.ExportNode) error {
+				return writeItem(snapshotItem{Node: &node})
+			})
+			if err != nil {
+				return errors.New("unable to export store %s: %v", key.Name(), err)
+			}
+		case *hashdb.Store:
+			if store.Hashes() == nil {
+				return errors.New("store %s is not merkleized, and does not support snapshots", key.Name())
+			}
+
+			// Only the latest entries are available
+			if height != ms.lastCommitID.Version {
+				return errors.New("unable to snapshot store %s at height %d, only the latest height %d is available",
+					key.Name(), height, ms.lastCommitID.Version)
+			}
+
+			// The hashes come first, to verify the entries against them
+			err := store.Hashes().Export(height, func(node iavl.ExportNode) error {
+				return writeItem(snapshotItem{Node: &node})
+			})
+			if err == nil {
+				err = store.ExportEntries(func(key, value []byte) error {
+					return writeItem(snapshotItem{KV: &snapshotKV{Key: key, Value: value}})
+				})
+			}
+			if err != nil {
+				return errors.New("unable to export store %s: %v", key.Name(), err)
+			}
+		case dbadapter.Store:
+			// The entries are not part of the app hash, so the restored
+			// entries could not be verified
+			return errors.New("store %s is not merkleized, and does not support snapshots", key.Name())
+		default:
+			return errors.New("store %s of type %T does not support snapshots", key.Name(), store)
+		}
+	}
+
+	return nil
+}
+
+// Implements CommitMultiStore.
+// The entries written by a failed restore are deleted, so that another
+// snapshot can be restored.
+func (ms *multiStore) Restore(height int64, r io.Reader) (err error) {
+	if height <= 0 {
+		return errors.New("invalid restore height %d", height)
+	}
+	if getLatestVersion(ms.db) != 0 {
+		return errors.New("unable to restore into a non-empty store")
+	}
+
+	defer func() {
+		if err != nil {
+			ms.clearStores()
+		}
+	}()
+
+	return ms.restore(height, r)
+}
+
+func (ms *multiStore) restore(height int64, r io.Reader) error {
+	var (
+		br       = bufio.NewReader(r)
+		stores   = make(map[types.StoreKey]types.CommitStore, len(ms.storesParams))
+		current  types.CommitStore
+		importer *iavl.Importer
+		restorer *hashdb.Restorer
+	)
+
+	// Discard the nodes of an interrupted import
+	defer func() {
+		if importer != nil {
+			importer.Close()
+		}
+		if restorer != nil {
+			restorer.Close()
+		}
+	}()
+
+	commitImporter := func() error {
+		if importer == nil {
+			return nil
+		}
+
+		err := importer.Commit()
+		importer = nil
+
+		return err
+	}
+
+	// finishStore commits the import of the current store, and checks that
+	// the entries of a hashdb store are all restored
+	finishStore := func() error {
+		if err := commitImporter(); err != nil {
+			return err
+		}
+
+		store, ok := current.(*hashdb.Store)
+		if !ok {
+			return nil
+		}
+		if restorer == nil {
+			restorer = store.NewRestorer()
+		}
+
+		err := restorer.Commit()
+		restorer = nil
+
+		return err
+	}
+
+	for {
+		var item snapshotItem
+
+		n, err := amino.UnmarshalSizedReader(br, &item, snapshotMaxItemSize)
+		if err == io.EOF && n == 0 {
+			break
+		}
+		if err != nil {
+			return errors.New("unable to read snapshot item: %v", err)
+		}
+
+		switch {
+		case item.Store != "":
+			if err := finishStore(); err != nil {
+				return err
+			}
+
+			key, ok := ms.keysByName[item.Store]
+			if !ok {
+				return errors.New("unknown store %s in snapshot", item.Store)
+			}
+			if _, ok := stores[key]; ok {
+				return errors.New("duplicate store %s in snapshot", item.Store)
+			}
+
+			current, err = ms.constructStore(ms.storesParams[key])
+			if err != nil {
+				return err
+			}
+			stores[key] = current
+
+			switch store := current.(type) {
+			case *iavlstore.Store:
+				importer, err = store.Import(height)
+			case *hashdb.Store:
+				if store.Hashes() == nil {
+					return errors.New("store %s is not merkleized, and can't be restored", item.Store)
+				}
+				importer, err = store.Hashes().Import(height)
+			}
+			if err != nil {
+				return errors.New("unable to import store %s: %v", item.Store, err)
+			}
+		case item.Node != nil:
+			if importer == nil {
+				return errors.New("unexpected IAVL node in snapshot")
+			}
+			if err := importer.Add(*item.Node); err != nil {
+				return err
+			}
+		case item.KV != nil:
+			store, ok := current.(*hashdb.Store)
+			if !ok {
+				return errors.New("unexpected key/value in snapshot")
+			}
+			if restorer == nil {
+				// The entries follow the hashes they are verified against
+				if err := commitImporter(); err != nil {
+					return err
+				}
+				restorer = store.NewRestorer()
+			}
+			if err := restorer.Add(item.KV.Key, item.KV.Value); err != nil {
+				return errors.New("unable to restore snapshot entry: %v", err)
+			}
+		default:
+			return errors.New("empty snapshot item")
+		}
+	}
+
+	if err := finishStore(); err != nil {
+		return err
+	}
+
+	for key := range ms.storesParams {
+		if _, ok := stores[key]; !ok {
+			return errors.New("store %s is missing from snapshot", key.Name())
+		}
+	}
+
+	// Save the commit info of the restored stores
+	storeInfos := make([]storeInfo, 0, len(stores))
+	for key, store := range stores {
+		store.SetStoreOptions(ms.storeOpts)
+		if err := store.LoadVersion(height); err != nil {
+			return errors.New("unable to load restored store %s: %v", key.Name(), err)
+		}
+
+		storeInfos = append(storeInfos, storeInfo{
+			Name: key.Name(),
+			Core: storeCore{CommitID: store.LastCommitID()},
+		})
+	}
+
+	batch := ms.db.NewBatch()
+	defer batch.Close()
+	setCommitInfo(batch, height, commitInfo{Version: height, StoreInfos: storeInfos})
+	setLatestVersion(batch, height)
+	batch.WriteSync()
+
+	return ms.LoadVersion(height)
+}
+
+// clearStores deletes the entries of the mounted stores.
+func (ms *multiStore) clearStores() {
+	for _, params := range ms.storesParams {
+		db := ms.storeDB(params)
+
+		itr := db.Iterator(nil, nil)
+		var keys [][]byte
+		for ; itr.Valid(); itr.Next() {
+			keys = append(keys, itr.Key())
+		}
+		itr.Close()
+
+		for _, key := range keys {
+			db.Delete(key)
+		}
+	}
+}
+
+// sortedKeys returns the keys of the mounted stores, sorted by name.
+func (ms *multiStore) sortedKeys() []types.StoreKey {
+	keys := make([]types.StoreKey, 0, len(ms.storesParams))
+	for key := range ms.storesParams {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name() < keys[j].Name()
+	})
+
+	return keys
+}
@@ -0,0 +1,211 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	tmiavl "github.com/gnolang/gno/tm2/pkg/iavl"
+
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var (
+	snapshotMainKey  = types.NewStoreKey("main")
+	snapshotBaseKey  = types.NewStoreKey("base")
+	snapshotOtherKey = types.NewStoreKey("other")
+)
+
+// newSnapshotMultiStore returns a multistore mounted like the gno.land app,
+// with an IAVL and a hashdb store sharing the same DB, and another IAVL store.
+func newSnapshotMultiStore(t *testing.T, db dbm.DB) *multiStore {
+	t.Helper()
+
+	ms := NewMultiStore(db)
+	ms.MountStoreWithDB(snapshotMainKey, iavl.StoreConstructor, db)
+	ms.MountStoreWithDB(snapshotBaseKey, hashdb.StoreConstructor, db)
+	ms.MountStoreWithDB(snapshotOtherKey, iavl.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	return ms
+}
+
+func TestMultiStore_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	for h := 0; h < 5; h++ {
+		for i := 0; i < 20; i++ {
+			key := []byte(fmt.Sprintf("key%02d", (i+h*3)%30))
+			value := []byte(fmt.Sprintf("value%d", h))
+
+			ms.GetStore(snapshotMainKey).Set(key, value)
+			ms.GetStore(snapshotBaseKey).Set(key, []byte(fmt.Sprintf("base%d", h)))
+			ms.GetStore(snapshotOtherKey).Set(key, value)
+		}
+		ms.GetStore(snapshotMainKey).Delete([]byte(fmt.Sprintf("key%02d", h)))
+		ms.GetStore(snapshotBaseKey).Delete([]byte(fmt.Sprintf("key%02d", h+1)))
+		ms.Commit()
+	}
+
+	height := ms.LastCommitID().Version
+
+	var buf bytes.Buffer
+	require.NoError(t, ms.Snapshot(height, &buf))
+
+	restored := newSnapshotMultiStore(t, memdb.NewMemDB())
+	require.NoError(t, restored.Restore(height, &buf))
+
+	assert.Equal(t, ms.LastCommitID(), restored.LastCommitID())
+
+	for _, key := range []types.StoreKey{snapshotMainKey, snapshotBaseKey, snapshotOtherKey} {
+		assert.Equal(t, storeEntries(t, ms, key), storeEntries(t, restored, key), "store %s", key.Name())
+	}
+
+	// The restored stores keep committing the same hashes
+	for _, s := range []*multiStore{ms, restored} {
+		s.GetStore(snapshotMainKey).Set([]byte("new"), []byte("value"))
+	}
+	assert.Equal(t, ms.Commit(), restored.Commit())
+}
+
+func TestMultiStore_SnapshotErrors(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	for h := 0; h < 2; h++ {
+		ms.GetStore(snapshotMainKey).Set([]byte("key"), []byte{byte(h)})
+		ms.Commit()
+	}
+
+	var buf bytes.Buffer
+
+	// Missing height
+	assert.Error(t, ms.Snapshot(3, &buf))
+
+	// The DB store is only available at the latest height
+	assert.Error(t, ms.Snapshot(1, &buf))
+
+	// Restore into a non-empty store
+	require.NoError(t, ms.Snapshot(2, &buf))
+	assert.Error(t, ms.Restore(2, &buf))
+
+	// Restore a truncated snapshot
+	buf.Reset()
+	require.NoError(t, ms.Snapshot(2, &buf))
+	truncated := bytes.NewReader(buf.Bytes()[:buf.Len()-1])
+	restored := newSnapshotMultiStore(t, memdb.NewMemDB())
+	assert.Error(t, restored.Restore(2, truncated))
+
+	// The failed restore is cleared, the snapshot can be restored again
+	require.NoError(t, restored.Restore(2, &buf))
+	assert.Equal(t, ms.LastCommitID(), restored.LastCommitID())
+}
+
+func TestMultiStore_SnapshotVerifiesEntries(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	ms.GetStore(snapshotMainKey).Set([]byte("key"), []byte("value"))
+	ms.GetStore(snapshotBaseKey).Set([]byte("key1"), []byte("base1"))
+	ms.GetStore(snapshotBaseKey).Set([]byte("key2"), []byte("base2"))
+	ms.Commit()
+
+	var buf bytes.Buffer
+	require.NoError(t, ms.Snapshot(1, &buf))
+
+	// The entries of the hashdb store are committed
+	restored := newSnapshotMultiStore(t, memdb.NewMemDB())
+	tampered := bytes.ReplaceAll(buf.Bytes(), []byte("base2"), []byte("evil2"))
+	assert.ErrorContains(t, restored.Restore(1, bytes.NewReader(tampered)), "invalid hash")
+
+	// Nor can entries be left out
+	var missing bytes.Buffer
+	writeItem := func(item snapshotItem) {
+		_, err := amino.MarshalSizedWriter(&missing, item)
+		require.NoError(t, err)
+	}
+	for _, key := range ms.sortedKeys() {
+		writeItem(snapshotItem{Store: key.Name()})
+
+		switch store := ms.GetCommitStore(key).(type) {
+		case *iavl.Store:
+			require.NoError(t, store.Export(1, func(node tmiavl.ExportNode) error {
+				writeItem(snapshotItem{Node: &node})
+				return nil
+			}))
+		case *hashdb.Store:
+			require.NoError(t, store.Hashes().Export(1, func(node tmiavl.ExportNode) error {
+				writeItem(snapshotItem{Node: &node})
+				return nil
+			}))
+			writeItem(snapshotItem{KV: &snapshotKV{Key: []byte("key1"), Value: []byte("base1")}})
+		}
+	}
+	assert.ErrorContains(t, restored.Restore(1, &missing), "missing entry")
+
+	require.NoError(t, restored.Restore(1, &buf))
+	assert.Equal(t, ms.LastCommitID(), restored.LastCommitID())
+}
+
+func TestMultiStore_SnapshotUnverifiableStore(t *testing.T) {
+	t.Parallel()
+
+	for name, constructor := range map[string]types.CommitStoreConstructor{
+		"dbadapter":       dbadapter.StoreConstructor,
+		"unhashed hashdb": hashdb.UnhashedStoreConstructor,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := memdb.NewMemDB()
+			ms := NewMultiStore(db)
+			ms.MountStoreWithDB(snapshotMainKey, iavl.StoreConstructor, db)
+			ms.MountStoreWithDB(snapshotBaseKey, constructor, db)
+			require.NoError(t, ms.LoadLatestVersion())
+
+			ms.GetStore(snapshotBaseKey).Set([]byte("key"), []byte("value"))
+			ms.Commit()
+
+			// The entries of the DB store are not part of the app hash
+			var buf bytes.Buffer
+			assert.ErrorContains(t, ms.Snapshot(1, &buf), "not merkleized")
+		})
+	}
+}
+
+// storeEntries returns the entries of a store. The entries of the hashdb
+// store are the hashed ones, which leaves out the entries of the IAVL trees
+// sharing its DB.
+func storeEntries(t *testing.T, ms *multiStore, key types.StoreKey) map[string]string {
+	t.Helper()
+
+	entries := make(map[string]string)
+
+	if store, ok := ms.GetCommitStore(key).(*hashdb.Store); ok {
+		require.NoError(t, store.ExportEntries(func(key, value []byte) error {
+			entries[string(key)] = string(value)
+			return nil
+		}))
+
+		return entries
+	}
+
+	itr := ms.GetStore(key).Iterator(nil, nil)
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		entries[string(itr.Key())] = string(itr.Value())
+	}
+
+	return entries
+}
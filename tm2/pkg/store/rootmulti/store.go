@@ -12,7 +12,10 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 
 	"github.com/gnolang/gno/tm2/pkg/store/cachemulti"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	serrors "github.com/gnolang/gno/tm2/pkg/store/errors"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
+	iavlstore "github.com/gnolang/gno/tm2/pkg/store/iavl"
 	"github.com/gnolang/gno/tm2/pkg/store/immut"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
 )
@@ -162,6 +165,62 @@ func (ms *multiStore) LoadVersion(ver int64) error {
 	return nil
 }
 
+// Implements CommitMultiStore.
+func (ms *multiStore) RollbackToVersion(ver int64) error {
+	latest := getLatestVersion(ms.db)
+	if ver <= 0 || ver >= latest {
+		return errors.New("invalid rollback version %d, the latest version is %d", ver, latest)
+	}
+	// Check all the stores before reverting any of them
+	stores := make([]types.CommitStore, 0, len(ms.storesParams))
+	for _, key := range ms.sortedKeys() {
+		store, err := ms.constructStore(ms.storesParams[key])
+		if err != nil {
+			return errors.New("failed to load Store: %v", err)
+		}
+
+		switch store := store.(type) {
+		case *iavlstore.Store:
+			if !store.VersionExists(ver) {
+				return errors.New("unable to rollback store %s: version %d was pruned", key.Name(), ver)
+			}
+		case *hashdb.Store:
+			if err := store.CheckRollback(ver, latest); err != nil {
+				return errors.New("unable to rollback store %s: %v", key.Name(), err)
+			}
+		case dbadapter.Store:
+			// The store is not versioned, its entries are the latest ones
+		default:
+			return errors.New("store %s of type %T does not support rollbacks", key.Name(), store)
+		}
+		stores = append(stores, store)
+	}
+
+	for i, key := range ms.sortedKeys() {
+		var err error
+		switch store := stores[i].(type) {
+		case *iavlstore.Store:
+			err = store.LoadVersionForOverwriting(ver)
+		case *hashdb.Store:
+			err = store.RollbackToVersion(ver, latest)
+		}
+		if err != nil {
+			return errors.New("unable to rollback store %s: %v", key.Name(), err)
+		}
+	}
+
+	// Delete the commit infos of the reverted versions
+	batch := ms.db.NewBatch()
+	defer batch.Close()
+	for v := ver + 1; v <= latest; v++ {
+		batch.Delete(fmt.Appendf(nil, commitInfoKeyFmt, v))
+	}
+	setLatestVersion(batch, ver)
+	batch.WriteSync()
+
+	return ms.LoadVersion(ver)
+}
+
 // ----------------------------------------
 // +CommitStore
 
@@ -288,7 +347,7 @@ func (ms *multiStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	req.Path = subpath
 	res = queryable.Query(req)
 
-	if !req.Prove {
+	if !req.Prove || res.Error != nil {
 		return res
 	} else if res.Proof == nil || len(res.Proof.Ops) == 0 {
 		res.Error = serrors.ErrInternal("proof is unexpectedly empty; ensure height has not been pruned")
@@ -334,12 +393,7 @@ func parsePath(path string) (storeName string, subpath string, err serrors.Error
 // ----------------------------------------
 
 func (ms *multiStore) constructStore(params storeParams) (store types.CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(ms.db, []byte("s/k:"+params.key.Name()+"/"))
-	}
+	db := ms.storeDB(params)
 	opts := ms.storeOpts
 
 	// XXX: use these:
@@ -349,6 +403,14 @@ func (ms *multiStore) constructStore(params storeParams) (store types.CommitStor
 	return store, nil
 }
 
+// storeDB returns the DB of a store, prefixed within its mounted DB.
+func (ms *multiStore) storeDB(params storeParams) dbm.DB {
+	if params.db != nil {
+		return dbm.NewPrefixDB(params.db, []byte("s/_/"))
+	}
+	return dbm.NewPrefixDB(ms.db, []byte("s/k:"+params.key.Name()+"/"))
+}
+
 func (ms *multiStore) nameToKey(name string) types.StoreKey {
 	for key := range ms.storesParams {
 		if key.Name() == name {
@@ -433,6 +495,12 @@ func (si storeInfo) Hash() []byte {
 // ----------------------------------------
 // Misc.
 
+// GetLatestVersion returns the latest version committed by the multistore
+// of db, or 0 if it is empty.
+func GetLatestVersion(db dbm.DB) int64 {
+	return getLatestVersion(db)
+}
+
 func getLatestVersion(db dbm.DB) int64 {
 	var latest int64
 	latestBytes := db.Get([]byte(latestVersionKey))
@@ -1,6 +1,7 @@
 package rootmulti
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -11,6 +12,8 @@ import (
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/hashdb"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
 )
@@ -168,6 +171,125 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestMultistoreRollbackToVersion(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	newStore := func() *multiStore {
+		ms := NewMultiStore(db)
+		ms.SetStoreOptions(types.StoreOptions{PruningOptions: types.PruneNothing})
+		ms.MountStoreWithDB(snapshotMainKey, iavl.StoreConstructor, db)
+		ms.MountStoreWithDB(snapshotBaseKey, dbadapter.StoreConstructor, db)
+		require.NoError(t, ms.LoadLatestVersion())
+		return ms
+	}
+	ms := newStore()
+
+	commits := make([]types.CommitID, 0, 3)
+	for i := range 3 {
+		value := []byte(fmt.Sprintf("value%d", i))
+		ms.GetStore(snapshotMainKey).Set([]byte("key"), value)
+		ms.GetStore(snapshotBaseKey).Set([]byte("key"), value)
+		commits = append(commits, ms.Commit())
+	}
+
+	require.Error(t, ms.RollbackToVersion(0))
+	require.Error(t, ms.RollbackToVersion(3))
+
+	require.NoError(t, ms.RollbackToVersion(2))
+	require.Equal(t, commits[1], ms.LastCommitID())
+	require.Equal(t, []byte("value1"), ms.GetStore(snapshotMainKey).Get([]byte("key")))
+
+	// The store which is not versioned keeps its latest entries
+	require.Equal(t, []byte("value2"), ms.GetStore(snapshotBaseKey).Get([]byte("key")))
+
+	// The rollback is persisted, and the reverted version can be committed again
+	ms = newStore()
+	require.Equal(t, commits[1], ms.LastCommitID())
+
+	ms.GetStore(snapshotMainKey).Set([]byte("key"), []byte("value2"))
+	require.Equal(t, commits[2], ms.Commit())
+
+	// The pruned versions can't be rolled back to
+	pruned := newSnapshotMultiStore(t, memdb.NewMemDB())
+	pruned.Commit()
+	pruned.Commit()
+	require.ErrorContains(t, pruned.RollbackToVersion(1), "was pruned")
+}
+
+func TestMultistoreRollbackToVersion_HashDB(t *testing.T) {
+	t.Parallel()
+
+	for name, constructor := range map[string]types.CommitStoreConstructor{
+		"hashed":   hashdb.StoreConstructor,
+		"unhashed": hashdb.UnhashedStoreConstructor,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := memdb.NewMemDB()
+			newStore := func(opts types.PruningOptions) *multiStore {
+				ms := NewMultiStore(db)
+				ms.SetStoreOptions(types.StoreOptions{PruningOptions: opts})
+				ms.MountStoreWithDB(snapshotMainKey, iavl.StoreConstructor, db)
+				ms.MountStoreWithDB(snapshotBaseKey, constructor, db)
+				require.NoError(t, ms.LoadLatestVersion())
+				return ms
+			}
+			ms := newStore(types.PruneNothing)
+
+			base := func() types.Store { return ms.GetStore(snapshotBaseKey) }
+			base().Set([]byte("key"), []byte("value0"))
+			base().Set([]byte("deleted"), []byte("value0"))
+			commits := []types.CommitID{ms.Commit()}
+			for i := 1; i < 4; i++ {
+				value := []byte(fmt.Sprintf("value%d", i))
+				ms.GetStore(snapshotMainKey).Set([]byte("key"), value)
+				base().Set([]byte("key"), value)
+				base().Set([]byte("added"), value)
+				base().Delete([]byte("deleted"))
+				commits = append(commits, ms.Commit())
+			}
+
+			// The entries of the reverted versions are reverted, along
+			// with their hashes
+			require.NoError(t, ms.RollbackToVersion(3))
+			require.Equal(t, commits[2], ms.LastCommitID())
+			require.Equal(t, []byte("value2"), base().Get([]byte("key")))
+
+			require.NoError(t, ms.RollbackToVersion(1))
+			require.Equal(t, commits[0], ms.LastCommitID())
+			require.Equal(t, []byte("value0"), base().Get([]byte("key")))
+			require.Equal(t, []byte("value0"), base().Get([]byte("deleted")))
+			require.Nil(t, base().Get([]byte("added")))
+
+			// The rollback is persisted, and the reverted versions can be
+			// committed again
+			ms = newStore(types.PruneNothing)
+			require.Equal(t, commits[0], ms.LastCommitID())
+			ms.GetStore(snapshotMainKey).Set([]byte("key"), []byte("value1"))
+			base().Set([]byte("key"), []byte("value1"))
+			base().Set([]byte("added"), []byte("value1"))
+			base().Delete([]byte("deleted"))
+			require.Equal(t, commits[1], ms.Commit())
+
+			// The changes of the pruned versions are deleted, and the
+			// stores are left as they are
+			ms = newStore(types.NewPruningOptions(1, 0))
+			for i := 0; i < 3; i++ {
+				base().Set([]byte("key"), []byte("pruned"))
+				ms.Commit()
+			}
+			require.ErrorContains(t, ms.RollbackToVersion(3), "pruned")
+			ms = newStore(types.PruneNothing)
+			require.Equal(t, int64(5), ms.LastCommitID().Version)
+			require.Equal(t, []byte("pruned"), base().Get([]byte("key")))
+
+			require.NoError(t, ms.RollbackToVersion(4))
+		})
+	}
+}
+
 func TestParsePath(t *testing.T) {
 	t.Parallel()
 
@@ -257,6 +379,12 @@ func TestMultiStoreQuery(t *testing.T) {
 	qres = multi.Query(query)
 	require.Nil(t, qres.Error)
 	require.Equal(t, v2, qres.Value)
+
+	// Test proven query of a missing height.
+	query.Height = ver + 1
+	qres = multi.Query(query)
+	require.NotNil(t, qres.Error)
+	require.Contains(t, qres.Error.Error(), "is pruned or does not exist")
 }
 
 // -----------------------------------------------------------------------
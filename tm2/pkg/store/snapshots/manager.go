@@ -0,0 +1,319 @@
+// Package snapshots implements the snapshots of the application state,
+// used to bootstrap new nodes with state sync.
+//
+// A snapshot is the zlib-compressed state written by a Snapshotter, split
+// into chunks. The metadata of a snapshot are the hashes of its chunks,
+// and its hash is the hash of its metadata, so that each chunk is verified
+// while restoring the snapshot.
+package snapshots
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+)
+
+const (
+	// CurrentFormat is the format of the snapshots taken by the manager
+	CurrentFormat uint32 = 1
+
+	// DefaultChunkSize is the default maximum size of a chunk
+	DefaultChunkSize = 10 << 20
+)
+
+var (
+	ErrInvalidHeight     = errors.New("invalid snapshot height")
+	ErrUnknownFormat     = errors.New("unknown snapshot format")
+	ErrInvalidMetadata   = errors.New("invalid snapshot metadata")
+	ErrSnapshotExists    = errors.New("snapshot already exists")
+	ErrNoRestore         = errors.New("no snapshot restore in progress")
+	ErrUnexpectedChunk   = errors.New("unexpected snapshot chunk")
+	ErrChunkHashMismatch = errors.New("snapshot chunk hash mismatch")
+)
+
+// Snapshotter writes the state at a height, and restores it
+type Snapshotter interface {
+	Snapshot(height int64, w io.Writer) error
+	Restore(height int64, r io.Reader) error
+}
+
+// Options are the snapshot options of the application
+type Options struct {
+	Interval   int64 // snapshot every Interval heights, 0 to disable
+	KeepRecent int   // number of recent snapshots to keep, 0 to keep all
+}
+
+// metadata are the metadata of a snapshot
+type metadata struct {
+	ChunkHashes [][]byte
+}
+
+// Manager takes the snapshots of the application state, and restores them
+type Manager struct {
+	store       *Store
+	snapshotter Snapshotter
+	opts        Options
+	chunkSize   int
+
+	mux     sync.Mutex
+	restore *restore // snapshot being restored, if any
+}
+
+// NewManager returns a new snapshot manager
+func NewManager(store *Store, snapshotter Snapshotter, opts Options) *Manager {
+	return &Manager{
+		store:       store,
+		snapshotter: snapshotter,
+		opts:        opts,
+		chunkSize:   DefaultChunkSize,
+	}
+}
+
+// ShouldSnapshot returns whether a snapshot is due at the given height
+func (m *Manager) ShouldSnapshot(height int64) bool {
+	return m.opts.Interval > 0 && height > 0 && height%m.opts.Interval == 0
+}
+
+// Create takes a snapshot of the state at the given height, and prunes the
+// old snapshots
+func (m *Manager) Create(height int64) (*abci.Snapshot, error) {
+	if height <= 0 {
+		return nil, ErrInvalidHeight
+	}
+	if _, err := m.store.Get(height, CurrentFormat); err == nil {
+		return nil, ErrSnapshotExists
+	}
+
+	snapshot, err := m.create(height)
+	if err != nil {
+		// Delete the saved chunks
+		m.store.Delete(height, CurrentFormat)
+
+		return nil, err
+	}
+
+	if m.opts.KeepRecent > 0 {
+		if _, err := m.store.Prune(m.opts.KeepRecent); err != nil {
+			return nil, fmt.Errorf("unable to prune snapshots, %w", err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (m *Manager) create(height int64) (*abci.Snapshot, error) {
+	cw := &chunkWriter{
+		store:     m.store,
+		height:    height,
+		chunkSize: m.chunkSize,
+	}
+
+	zw := zlib.NewWriter(cw)
+	if err := m.snapshotter.Snapshot(height, zw); err != nil {
+		return nil, fmt.Errorf("unable to snapshot height %d, %w", height, err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	cw.flush()
+
+	bz, err := amino.Marshal(metadata{ChunkHashes: cw.hashes})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &abci.Snapshot{
+		Height:   height,
+		Format:   CurrentFormat,
+		Chunks:   uint32(len(cw.hashes)),
+		Hash:     tmhash.Sum(bz),
+		Metadata: bz,
+	}
+
+	if err := m.store.Save(snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// List returns the available snapshots, the most recent first
+func (m *Manager) List() ([]*abci.Snapshot, error) {
+	return m.store.List()
+}
+
+// LoadChunk returns a chunk of an available snapshot
+func (m *Manager) LoadChunk(height int64, format, index uint32) ([]byte, error) {
+	return m.store.LoadChunk(height, format, index)
+}
+
+// Restore starts restoring a snapshot, whose chunks are then applied in
+// order with RestoreChunk. A restore in progress is aborted
+func (m *Manager) Restore(snapshot abci.Snapshot) error {
+	if snapshot.Format != CurrentFormat {
+		return ErrUnknownFormat
+	}
+	if snapshot.Height <= 0 {
+		return ErrInvalidHeight
+	}
+
+	var md metadata
+	if err := amino.Unmarshal(snapshot.Metadata, &md); err != nil {
+		return fmt.Errorf("%w, %w", ErrInvalidMetadata, err)
+	}
+	if !bytes.Equal(tmhash.Sum(snapshot.Metadata), snapshot.Hash) {
+		return fmt.Errorf("%w, hash mismatch", ErrInvalidMetadata)
+	}
+	if snapshot.Chunks == 0 || int(snapshot.Chunks) != len(md.ChunkHashes) {
+		return fmt.Errorf("%w, %d chunks for %d hashes", ErrInvalidMetadata, snapshot.Chunks, len(md.ChunkHashes))
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.restore != nil {
+		m.restore.abort()
+	}
+
+	r := &restore{
+		snapshot:    snapshot,
+		chunkHashes: md.ChunkHashes,
+		chunks:      make(chan []byte, 1),
+		done:        make(chan error, 1),
+	}
+
+	go func() {
+		zr, err := zlib.NewReader(&chunkReader{chunks: r.chunks})
+		if err == nil {
+			err = m.snapshotter.Restore(snapshot.Height, zr)
+		}
+
+		r.done <- err
+	}()
+
+	m.restore = r
+
+	return nil
+}
+
+// RestoreChunk applies the next chunk of the snapshot being restored, and
+// returns whether the restore is completed
+func (m *Manager) RestoreChunk(index uint32, chunk []byte) (bool, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	r := m.restore
+	if r == nil {
+		return false, ErrNoRestore
+	}
+	if index != r.next {
+		return false, fmt.Errorf("%w, expected chunk %d, got %d", ErrUnexpectedChunk, r.next, index)
+	}
+	if !bytes.Equal(tmhash.Sum(chunk), r.chunkHashes[index]) {
+		return false, fmt.Errorf("%w, chunk %d", ErrChunkHashMismatch, index)
+	}
+
+	select {
+	case r.chunks <- chunk:
+	case err := <-r.done:
+		// The restore failed before reading all the chunks
+		m.restore = nil
+		if err == nil {
+			err = errors.New("snapshot restored before its last chunk")
+		}
+
+		return false, err
+	}
+
+	r.next++
+	if r.next < r.snapshot.Chunks {
+		return false, nil
+	}
+
+	close(r.chunks)
+	m.restore = nil
+
+	return true, <-r.done
+}
+
+// restore is a snapshot being restored
+type restore struct {
+	snapshot    abci.Snapshot
+	chunkHashes [][]byte
+	next        uint32      // index of the next chunk
+	chunks      chan []byte // chunks read by the snapshotter
+	done        chan error  // result of the snapshotter
+}
+
+// abort stops the restore, and waits for the snapshotter to return
+func (r *restore) abort() {
+	close(r.chunks)
+	<-r.done
+}
+
+// chunkWriter splits the written snapshot into chunks, saved in the store
+type chunkWriter struct {
+	store     *Store
+	height    int64
+	chunkSize int
+
+	buf    bytes.Buffer
+	hashes [][]byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for len(p) > 0 {
+		size := min(len(p), w.chunkSize-w.buf.Len())
+		w.buf.Write(p[:size])
+		p = p[size:]
+
+		if w.buf.Len() == w.chunkSize {
+			w.flush()
+		}
+	}
+
+	return n, nil
+}
+
+// flush saves the buffered chunk, if any
+func (w *chunkWriter) flush() {
+	if w.buf.Len() == 0 {
+		return
+	}
+
+	chunk := bytes.Clone(w.buf.Bytes())
+	w.store.SaveChunk(w.height, CurrentFormat, uint32(len(w.hashes)), chunk)
+	w.hashes = append(w.hashes, tmhash.Sum(chunk))
+	w.buf.Reset()
+}
+
+// chunkReader reads the chunks of a snapshot, in order
+type chunkReader struct {
+	chunks <-chan []byte
+	chunk  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		chunk, ok := <-r.chunks
+		if !ok {
+			return 0, io.EOF
+		}
+
+		r.chunk = chunk
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+
+	return n, nil
+}
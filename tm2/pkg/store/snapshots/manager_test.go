@@ -0,0 +1,230 @@
+package snapshots
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+// mockSnapshotter snapshots a fixed state, and keeps the restored one
+type mockSnapshotter struct {
+	state    []byte
+	restored []byte
+}
+
+func (s *mockSnapshotter) Snapshot(_ int64, w io.Writer) error {
+	_, err := w.Write(s.state)
+	return err
+}
+
+func (s *mockSnapshotter) Restore(_ int64, r io.Reader) error {
+	bz, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.restored = bz
+
+	return nil
+}
+
+// newTestManager returns a manager snapshotting random, incompressible
+// data, in chunks of 1KB
+func newTestManager(t *testing.T, size int, opts Options) (*Manager, *mockSnapshotter) {
+	t.Helper()
+
+	state := make([]byte, size)
+	rand.New(rand.NewSource(int64(size))).Read(state)
+
+	snapshotter := &mockSnapshotter{state: state}
+	m := NewManager(NewStore(memdb.NewMemDB()), snapshotter, opts)
+	m.chunkSize = 1024
+
+	return m, snapshotter
+}
+
+func loadChunks(t *testing.T, m *Manager, snapshot *abci.Snapshot) [][]byte {
+	t.Helper()
+
+	chunks := make([][]byte, 0, snapshot.Chunks)
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := m.LoadChunk(snapshot.Height, snapshot.Format, i)
+		require.NoError(t, err)
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+func TestManager_ShouldSnapshot(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestManager(t, 0, Options{Interval: 10})
+	assert.False(t, m.ShouldSnapshot(0))
+	assert.False(t, m.ShouldSnapshot(5))
+	assert.True(t, m.ShouldSnapshot(10))
+	assert.True(t, m.ShouldSnapshot(20))
+
+	m, _ = newTestManager(t, 0, Options{})
+	assert.False(t, m.ShouldSnapshot(10))
+}
+
+func TestManager_CreateRestore(t *testing.T) {
+	t.Parallel()
+
+	m, snapshotter := newTestManager(t, 10_000, Options{Interval: 1})
+
+	snapshot, err := m.Create(3)
+	require.NoError(t, err)
+	assert.Greater(t, snapshot.Chunks, uint32(1))
+
+	_, err = m.Create(3)
+	assert.ErrorIs(t, err, ErrSnapshotExists)
+
+	snapshots, err := m.List()
+	require.NoError(t, err)
+	assert.Equal(t, []*abci.Snapshot{snapshot}, snapshots)
+
+	chunks := loadChunks(t, m, snapshot)
+
+	target, restored := newTestManager(t, 0, Options{})
+	require.NoError(t, target.Restore(*snapshot))
+
+	for i, chunk := range chunks {
+		done, err := target.RestoreChunk(uint32(i), chunk)
+		require.NoError(t, err)
+		assert.Equal(t, i == len(chunks)-1, done)
+	}
+
+	assert.Equal(t, snapshotter.state, restored.restored)
+
+	_, err = target.RestoreChunk(0, chunks[0])
+	assert.ErrorIs(t, err, ErrNoRestore)
+}
+
+func TestManager_CreatePrune(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestManager(t, 100, Options{Interval: 1, KeepRecent: 2})
+
+	for _, height := range []int64{1, 2, 3} {
+		_, err := m.Create(height)
+		require.NoError(t, err)
+	}
+
+	snapshots, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, int64(3), snapshots[0].Height)
+	assert.Equal(t, int64(2), snapshots[1].Height)
+}
+
+func TestManager_CreateError(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestManager(t, 0, Options{})
+	m.snapshotter = &failingSnapshotter{}
+
+	_, err := m.Create(1)
+	assert.Error(t, err)
+
+	// The written chunks are deleted
+	itr := m.store.db.Iterator(nil, nil)
+	defer itr.Close()
+	assert.False(t, itr.Valid())
+}
+
+func TestManager_RestoreErrors(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestManager(t, 5000, Options{})
+	snapshot, err := m.Create(1)
+	require.NoError(t, err)
+
+	chunks := loadChunks(t, m, snapshot)
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		target, _ := newTestManager(t, 0, Options{})
+
+		invalid := *snapshot
+		invalid.Format = 2
+		assert.ErrorIs(t, target.Restore(invalid), ErrUnknownFormat)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		t.Parallel()
+
+		target, _ := newTestManager(t, 0, Options{})
+
+		invalid := *snapshot
+		invalid.Hash = bytes.Repeat([]byte{1}, 32)
+		assert.ErrorIs(t, target.Restore(invalid), ErrInvalidMetadata)
+
+		invalid = *snapshot
+		invalid.Chunks++
+		assert.ErrorIs(t, target.Restore(invalid), ErrInvalidMetadata)
+	})
+
+	t.Run("invalid chunks", func(t *testing.T) {
+		t.Parallel()
+
+		target, restored := newTestManager(t, 0, Options{})
+		require.NoError(t, target.Restore(*snapshot))
+
+		_, err := target.RestoreChunk(1, chunks[1])
+		assert.ErrorIs(t, err, ErrUnexpectedChunk)
+
+		_, err = target.RestoreChunk(0, []byte("invalid"))
+		assert.ErrorIs(t, err, ErrChunkHashMismatch)
+
+		// The restore goes on with the valid chunks
+		for i, chunk := range chunks {
+			_, err := target.RestoreChunk(uint32(i), chunk)
+			require.NoError(t, err)
+		}
+		assert.Len(t, restored.restored, 5000)
+	})
+
+	t.Run("aborted restore", func(t *testing.T) {
+		t.Parallel()
+
+		target, restored := newTestManager(t, 0, Options{})
+		require.NoError(t, target.Restore(*snapshot))
+
+		_, err := target.RestoreChunk(0, chunks[0])
+		require.NoError(t, err)
+
+		// Restarting the restore aborts the previous one
+		require.NoError(t, target.Restore(*snapshot))
+		for i, chunk := range chunks {
+			_, err := target.RestoreChunk(uint32(i), chunk)
+			require.NoError(t, err)
+		}
+		assert.Len(t, restored.restored, 5000)
+	})
+}
+
+type failingSnapshotter struct{}
+
+func (failingSnapshotter) Snapshot(_ int64, w io.Writer) error {
+	if _, err := w.Write(bytes.Repeat([]byte{1}, 4096)); err != nil {
+		return err
+	}
+
+	return errors.New("snapshot failed")
+}
+
+func (failingSnapshotter) Restore(int64, io.Reader) error {
+	return errors.New("restore failed")
+}
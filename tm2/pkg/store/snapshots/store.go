@@ -0,0 +1,154 @@
+package snapshots
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var (
+	snapshotKeyPrefix = []byte("s/") // s/<height><format>
+	chunkKeyPrefix    = []byte("c/") // c/<height><format><index>
+)
+
+// ErrNotFound is returned when a snapshot or a chunk does not exist.
+var ErrNotFound = errors.New("snapshot not found")
+
+// Store persists the snapshots, and their chunks, in a DB.
+type Store struct {
+	db dbm.DB
+}
+
+// NewStore returns a new snapshot store using the given DB
+func NewStore(db dbm.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// Save saves the snapshot. Its chunks must be saved beforehand
+func (s *Store) Save(snapshot *abci.Snapshot) error {
+	bz, err := amino.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("unable to marshal snapshot, %w", err)
+	}
+
+	s.db.SetSync(snapshotKey(snapshot.Height, snapshot.Format), bz)
+
+	return nil
+}
+
+// SaveChunk saves a chunk of a snapshot
+func (s *Store) SaveChunk(height int64, format, index uint32, chunk []byte) {
+	s.db.Set(chunkKey(height, format, index), chunk)
+}
+
+// Get returns the snapshot at the given height and format
+func (s *Store) Get(height int64, format uint32) (*abci.Snapshot, error) {
+	bz := s.db.Get(snapshotKey(height, format))
+	if bz == nil {
+		return nil, ErrNotFound
+	}
+
+	snapshot := new(abci.Snapshot)
+	if err := amino.Unmarshal(bz, snapshot); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal snapshot, %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// List returns the saved snapshots, the most recent first
+func (s *Store) List() ([]*abci.Snapshot, error) {
+	itr := dbm.IteratePrefix(s.db, snapshotKeyPrefix)
+	defer itr.Close()
+
+	var snapshots []*abci.Snapshot
+	for ; itr.Valid(); itr.Next() {
+		snapshot := new(abci.Snapshot)
+		if err := amino.Unmarshal(itr.Value(), snapshot); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal snapshot, %w", err)
+		}
+
+		snapshots = append([]*abci.Snapshot{snapshot}, snapshots...)
+	}
+
+	return snapshots, nil
+}
+
+// LoadChunk returns a chunk of a saved snapshot
+func (s *Store) LoadChunk(height int64, format, index uint32) ([]byte, error) {
+	if _, err := s.Get(height, format); err != nil {
+		return nil, err
+	}
+
+	chunk := s.db.Get(chunkKey(height, format, index))
+	if chunk == nil {
+		return nil, ErrNotFound
+	}
+
+	return chunk, nil
+}
+
+// Delete deletes the snapshot, and its chunks
+func (s *Store) Delete(height int64, format uint32) {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	itr := dbm.IteratePrefix(s.db, chunksPrefix(height, format))
+	for ; itr.Valid(); itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	itr.Close()
+
+	batch.Delete(snapshotKey(height, format))
+	batch.WriteSync()
+}
+
+// Prune deletes the snapshots except the keepRecent most recent ones, and
+// returns the number of deleted snapshots
+func (s *Store) Prune(keepRecent int) (int, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(snapshots) <= keepRecent {
+		return 0, nil
+	}
+
+	for _, snapshot := range snapshots[keepRecent:] {
+		s.Delete(snapshot.Height, snapshot.Format)
+	}
+
+	return len(snapshots) - keepRecent, nil
+}
+
+// snapshotKey returns the key of a snapshot, ordered by height
+func snapshotKey(height int64, format uint32) []byte {
+	key := make([]byte, 0, len(snapshotKeyPrefix)+12)
+	key = append(key, snapshotKeyPrefix...)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	key = binary.BigEndian.AppendUint32(key, format)
+
+	return key
+}
+
+// chunksPrefix returns the key prefix of the chunks of a snapshot
+func chunksPrefix(height int64, format uint32) []byte {
+	key := make([]byte, 0, len(chunkKeyPrefix)+16)
+	key = append(key, chunkKeyPrefix...)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	key = binary.BigEndian.AppendUint32(key, format)
+
+	return key
+}
+
+// chunkKey returns the key of a snapshot chunk
+func chunkKey(height int64, format, index uint32) []byte {
+	return binary.BigEndian.AppendUint32(chunksPrefix(height, format), index)
+}
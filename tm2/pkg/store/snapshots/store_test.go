@@ -0,0 +1,105 @@
+package snapshots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+func saveSnapshot(t *testing.T, s *Store, height int64, chunks ...string) *abci.Snapshot {
+	t.Helper()
+
+	for i, chunk := range chunks {
+		s.SaveChunk(height, CurrentFormat, uint32(i), []byte(chunk))
+	}
+
+	snapshot := &abci.Snapshot{
+		Height: height,
+		Format: CurrentFormat,
+		Chunks: uint32(len(chunks)),
+		Hash:   []byte{byte(height)},
+	}
+	require.NoError(t, s.Save(snapshot))
+
+	return snapshot
+}
+
+func TestStore_SaveList(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(memdb.NewMemDB())
+
+	snapshots, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	first := saveSnapshot(t, s, 5, "a", "b")
+	second := saveSnapshot(t, s, 300, "c")
+
+	snapshots, err = s.List()
+	require.NoError(t, err)
+	assert.Equal(t, []*abci.Snapshot{second, first}, snapshots)
+
+	snapshot, err := s.Get(5, CurrentFormat)
+	require.NoError(t, err)
+	assert.Equal(t, first, snapshot)
+
+	_, err = s.Get(6, CurrentFormat)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_LoadChunk(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(memdb.NewMemDB())
+	saveSnapshot(t, s, 5, "a", "b")
+
+	chunk, err := s.LoadChunk(5, CurrentFormat, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), chunk)
+
+	_, err = s.LoadChunk(5, CurrentFormat, 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// The chunks of an unsaved snapshot are not available
+	s.SaveChunk(6, CurrentFormat, 0, []byte("c"))
+	_, err = s.LoadChunk(6, CurrentFormat, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_DeletePrune(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(memdb.NewMemDB())
+	for _, height := range []int64{1, 2, 3, 4} {
+		saveSnapshot(t, s, height, "a", "b")
+	}
+
+	s.Delete(2, CurrentFormat)
+	_, err := s.Get(2, CurrentFormat)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, s.db.Get(chunkKey(2, CurrentFormat, 0)))
+
+	pruned, err := s.Prune(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+
+	snapshots, err := s.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, int64(4), snapshots[0].Height)
+
+	// Only the chunks of the kept snapshot remain
+	itr := s.db.Iterator(nil, nil)
+	defer itr.Close()
+
+	count := 0
+	for ; itr.Valid(); itr.Next() {
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
@@ -11,6 +11,12 @@ func NewCommitMultiStore(db dbm.DB) types.CommitMultiStore {
 	return rootmulti.NewMultiStore(db)
 }
 
+// GetLatestVersion returns the latest version committed by the multistore
+// of db, or 0 if it is empty.
+func GetLatestVersion(db dbm.DB) int64 {
+	return rootmulti.GetLatestVersion(db)
+}
+
 func NewPruningOptionsFromString(strategy string) (opt PruningOptions) {
 	switch strategy {
 	case "nothing":
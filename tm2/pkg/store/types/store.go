@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -132,6 +133,21 @@ type CommitMultiStore interface {
 	// (height). An error is returned if any store cannot be loaded. This
 	// should only be used for querying and iterating at past heights.
 	MultiImmutableCacheWrapWithVersion(version int64) (MultiStore, error)
+
+	// Snapshot writes the state of the stores at the given height.
+	// The stores which are not versioned can only be written at the
+	// latest height.
+	Snapshot(height int64, w io.Writer) error
+
+	// Restore restores the stores at the given height from a snapshot.
+	// The stores must be empty.
+	Restore(height int64, r io.Reader) error
+
+	// RollbackToVersion reverts the stores to the given version, deleting
+	// the versions above it. The stores which are not versioned are kept
+	// as they are, and the stores committing to unversioned entries can't
+	// be reverted once their entries changed.
+	RollbackToVersion(ver int64) error
 }
 
 // CommitID contains the tree version number and its merkle root.